@@ -8,7 +8,7 @@ use cw2::set_contract_version;
 use cw20::{Cw20ExecuteMsg, Cw20ReceiveMsg};
 
 use crate::error::ContractError;
-use crate::msg::{ExecuteMsg, InstantiateMsg, QueryMsg, ReceiveMsg, EscrowResponse};
+use crate::msg::{ExecuteMsg, InstantiateMsg, QueryMsg, ReceiveMsg, EscrowResponse, StatusResponse};
 use crate::state::{EscrowInfo, EscrowStatus, ESCROW_INFO};
 
 // version info for migration info
@@ -294,6 +294,7 @@ pub fn execute_confirm_source_escrow(
 pub fn query(deps: Deps, _env: Env, msg: QueryMsg) -> StdResult<Binary> {
     match msg {
         QueryMsg::Escrow {} => to_binary(&query_escrow(deps)?),
+        QueryMsg::Status {} => to_binary(&query_status(deps)?),
     }
 }
 
@@ -318,3 +319,10 @@ fn query_escrow(deps: Deps) -> StdResult<EscrowResponse> {
     })
 }
 
+fn query_status(deps: Deps) -> StdResult<StatusResponse> {
+    let escrow_info = ESCROW_INFO.load(deps.storage)?;
+    Ok(StatusResponse {
+        status: escrow_info.status,
+    })
+}
+
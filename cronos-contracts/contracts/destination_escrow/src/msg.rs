@@ -42,6 +42,9 @@ pub enum QueryMsg {
     /// Get escrow details
     #[returns(EscrowResponse)]
     Escrow {},
+    /// Get escrow status
+    #[returns(StatusResponse)]
+    Status {},
 }
 
 #[cw_serde]
@@ -63,6 +66,11 @@ pub struct EscrowResponse {
     pub src_block_height: Option<u64>,
 }
 
+#[cw_serde]
+pub struct StatusResponse {
+    pub status: EscrowStatus,
+}
+
 #[cw_serde]
 pub enum EscrowStatus {
     Active,
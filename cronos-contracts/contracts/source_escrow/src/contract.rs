@@ -1,20 +1,24 @@
 #[cfg(not(feature = "library"))]
 use cosmwasm_std::entry_point;
 use cosmwasm_std::{
-    to_binary, Binary, Deps, DepsMut, Env, MessageInfo, Response, StdResult, Uint128,
+    to_binary, Binary, Deps, DepsMut, Env, MessageInfo, Response, StdError, StdResult, Uint128,
     CosmosMsg, BankMsg, WasmMsg, from_binary, Addr
 };
 use cw2::set_contract_version;
 use cw20::{Cw20ExecuteMsg, Cw20ReceiveMsg};
 
 use crate::error::ContractError;
-use crate::msg::{ExecuteMsg, InstantiateMsg, QueryMsg, ReceiveMsg, EscrowResponse, PriceResponse, FillStatusResponse};
+use crate::msg::{ExecuteMsg, InstantiateMsg, QueryMsg, ReceiveMsg, EscrowResponse, PriceResponse, FillStatusResponse, StatusResponse, SimulateFillResponse};
 use crate::state::{EscrowInfo, EscrowStatus, ESCROW_INFO};
 
 // version info for migration info
 const CONTRACT_NAME: &str = "crates.io:source_escrow";
 const CONTRACT_VERSION: &str = env!("CARGO_PKG_VERSION");
 
+// Dutch auction prices are quoted in destination units per whole deposited
+// unit of 18 decimals
+const PRICE_PRECISION: u128 = 1_000_000_000_000_000_000;
+
 #[cfg_attr(not(feature = "library"), entry_point)]
 pub fn instantiate(
     deps: DepsMut,
@@ -370,6 +374,8 @@ pub fn query(deps: Deps, env: Env, msg: QueryMsg) -> StdResult<Binary> {
         QueryMsg::Escrow {} => to_binary(&query_escrow(deps)?),
         QueryMsg::CurrentPrice {} => to_binary(&query_current_price(deps, env)?),
         QueryMsg::FillStatus {} => to_binary(&query_fill_status(deps)?),
+        QueryMsg::Status {} => to_binary(&query_status(deps)?),
+        QueryMsg::SimulateFill { input_amount } => to_binary(&query_simulate_fill(deps, env, input_amount)?),
     }
 }
 
@@ -421,6 +427,50 @@ fn query_fill_status(deps: Deps) -> StdResult<FillStatusResponse> {
     })
 }
 
+fn query_status(deps: Deps) -> StdResult<StatusResponse> {
+    let escrow_info = ESCROW_INFO.load(deps.storage)?;
+    Ok(StatusResponse {
+        status: escrow_info.status,
+    })
+}
+
+fn query_simulate_fill(deps: Deps, env: Env, input_amount: Uint128) -> StdResult<SimulateFillResponse> {
+    let escrow_info = ESCROW_INFO.load(deps.storage)?;
+    if input_amount.is_zero() {
+        return Err(StdError::generic_err("input amount must be positive"));
+    }
+
+    let current_price = calculate_current_price(&escrow_info, env.block.time.seconds())
+        .map_err(|e| StdError::generic_err(e.to_string()))?;
+
+    // Dutch auctions fill at the current price, other escrows pro rata to
+    // the amounts they were created with
+    let output_amount = if escrow_info.initial_price.is_some() {
+        if current_price.is_zero() {
+            return Err(StdError::generic_err("current price is zero"));
+        }
+        input_amount.checked_multiply_ratio(PRICE_PRECISION, current_price)
+    } else {
+        if escrow_info.dst_amount.is_zero() {
+            return Err(StdError::generic_err("destination amount is zero"));
+        }
+        input_amount.checked_multiply_ratio(escrow_info.deposited_amount, escrow_info.dst_amount)
+    }
+    .map_err(|e| StdError::generic_err(e.to_string()))?;
+
+    if output_amount > escrow_info.remaining_amount {
+        return Err(StdError::generic_err(format!(
+            "fill of {} exceeds remaining amount {}",
+            output_amount, escrow_info.remaining_amount
+        )));
+    }
+
+    Ok(SimulateFillResponse {
+        output_amount,
+        current_price,
+    })
+}
+
 fn calculate_current_price(escrow_info: &EscrowInfo, current_time: u64) -> Result<Uint128, ContractError> {
     if let (Some(initial_price), Some(decay_rate), Some(min_price)) = (
         &escrow_info.initial_price,
@@ -474,5 +524,39 @@ mod tests {
         let res = instantiate(deps.as_mut(), mock_env(), info, msg).unwrap();
         assert_eq!(0, res.messages.len());
     }
+
+    #[test]
+    fn simulate_fill() {
+        let mut deps = mock_dependencies();
+        let env = mock_env();
+
+        let msg = InstantiateMsg {
+            maker: "maker".to_string(),
+            taker: None,
+            secret_hash: "hash123".to_string(),
+            timelock: env.block.time.seconds() + 1000,
+            dst_chain_id: "ethereum-1".to_string(),
+            dst_asset: "USDC".to_string(),
+            dst_amount: Uint128::from(2000u128),
+            initial_price: Some(Uint128::from(2000u128)),
+            price_decay_rate: Some(Uint128::from(1u128)),
+            minimum_price: Some(Uint128::from(1000u128)),
+            allow_partial_fill: true,
+            minimum_fill_amount: None,
+        };
+        instantiate(deps.as_mut(), env.clone(), mock_info("creator", &[]), msg).unwrap();
+        let info = mock_info("maker", &coins(PRICE_PRECISION, "earth"));
+        execute(deps.as_mut(), env.clone(), info, ExecuteMsg::Deposit {}).unwrap();
+
+        // Half the destination amount at the initial price buys half the deposit
+        let res = query(deps.as_ref(), env.clone(), QueryMsg::SimulateFill { input_amount: Uint128::from(1000u128) }).unwrap();
+        let response: SimulateFillResponse = from_binary(&res).unwrap();
+        assert_eq!(Uint128::from(2000u128), response.current_price);
+        assert_eq!(Uint128::from(PRICE_PRECISION / 2), response.output_amount);
+
+        // More than the deposit can't be filled
+        let err = query(deps.as_ref(), env, QueryMsg::SimulateFill { input_amount: Uint128::from(3000u128) });
+        assert!(err.is_err());
+    }
 }
 
@@ -57,6 +57,12 @@ pub enum QueryMsg {
     /// Get fill status
     #[returns(FillStatusResponse)]
     FillStatus {},
+    /// Get escrow status
+    #[returns(StatusResponse)]
+    Status {},
+    /// Simulate a fill paying input_amount of the destination asset
+    #[returns(SimulateFillResponse)]
+    SimulateFill { input_amount: Uint128 },
 }
 
 #[cw_serde]
@@ -96,6 +102,18 @@ pub struct FillStatusResponse {
     pub allow_partial_fill: bool,
 }
 
+#[cw_serde]
+pub struct StatusResponse {
+    pub status: EscrowStatus,
+}
+
+#[cw_serde]
+pub struct SimulateFillResponse {
+    /// Amount of the deposited asset the fill would release
+    pub output_amount: Uint128,
+    pub current_price: Uint128,
+}
+
 #[cw_serde]
 pub enum EscrowStatus {
     Active,
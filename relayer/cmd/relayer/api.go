@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+)
+
+// maxOrderRequestBytes bounds the body of an order submission
+const maxOrderRequestBytes = 1 << 20
+
+// apiReadHeaderTimeout bounds how long a client may take to send request
+// headers
+const apiReadHeaderTimeout = 10 * time.Second
+
+// orderService queues orders submitted through the API and cancels or
+// resolves tracked ones, normally the order manager
+type orderService interface {
+	SubmitOrder(order *order_manager.Order, sig order_manager.OrderSignature) error
+	CancelOrder(ctx context.Context, orderID string) (string, error)
+	ForceCompleteOrder(orderID, reason, operator string) error
+	ForceFailOrder(orderID, reason, operator string) error
+}
+
+// submitOrderRequest is the body of POST /orders: the order and its maker's
+// signature
+type submitOrderRequest struct {
+	Order order_manager.Order `json:"order"`
+	order_manager.OrderSignature
+}
+
+// apiHandler serves the relayer's HTTP API
+type apiHandler struct {
+	orders orderService
+	// Bearer token of operator endpoints; empty leaves cancelling open and
+	// disables resolving orders by hand
+	authToken string
+	logger    *zap.Logger
+}
+
+// cancelOrderResponse is the body of a successful DELETE /orders/{id}. TxHash
+// is empty when the order was dropped without an on-chain cancel
+type cancelOrderResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	TxHash string `json:"tx_hash,omitempty"`
+}
+
+// resolveOrderRequest is the body of POST /orders/{id}/complete and
+// POST /orders/{id}/fail. Operator names who resolved the order for its
+// history, and defaults to the client's address
+type resolveOrderRequest struct {
+	Reason   string `json:"reason"`
+	Operator string `json:"operator"`
+}
+
+// resolveOrderResponse is the body of a successful order resolution
+type resolveOrderResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// newAPIHandler returns the handler of the relayer's HTTP API
+func newAPIHandler(orders orderService, authToken string, logger *zap.Logger) http.Handler {
+	h := &apiHandler{orders: orders, authToken: authToken, logger: logger}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orders", h.handleOrders)
+	mux.HandleFunc("/orders/", h.handleOrder)
+	return mux
+}
+
+// handleOrders queues an order whose signature proves its maker submitted it
+func (h *apiHandler) handleOrders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req submitOrderRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxOrderRequestBytes)).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid order: %v", err))
+		return
+	}
+
+	order := &req.Order
+	if err := h.orders.SubmitOrder(order, req.OrderSignature); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, order_manager.ErrInvalidSignature) {
+			status = http.StatusUnauthorized
+		}
+		h.logger.Warn("Rejected submitted order",
+			zap.String("maker", order.Maker),
+			zap.String("source_chain", order.SourceChain),
+			zap.Error(err))
+		writeAPIError(w, status, err.Error())
+		return
+	}
+
+	// The order is being processed concurrently; only its ID is fixed
+	writeAPIJSON(w, http.StatusAccepted, map[string]string{"id": order.ID})
+}
+
+// handleOrder serves the operator endpoints of the order named by the path:
+// DELETE /orders/{id} cancels it, POST /orders/{id}/complete and
+// POST /orders/{id}/fail resolve it by hand
+func (h *apiHandler) handleOrder(w http.ResponseWriter, r *http.Request) {
+	id, action, hasAction := strings.Cut(strings.TrimPrefix(r.URL.Path, "/orders/"), "/")
+	if id == "" {
+		writeAPIError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch {
+	case !hasAction:
+		h.cancelOrder(w, r, id)
+	case action == "complete" || action == "fail":
+		h.resolveOrder(w, r, id, action)
+	default:
+		writeAPIError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// cancelOrder cancels an order, DELETE /orders/{id}
+func (h *apiHandler) cancelOrder(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodDelete {
+		w.Header().Set("Allow", http.MethodDelete)
+		writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !h.authorize(w, r, true) {
+		return
+	}
+
+	txHash, err := h.orders.CancelOrder(r.Context(), id)
+	if err != nil {
+		status := http.StatusBadGateway
+		switch {
+		case errors.Is(err, order_manager.ErrOrderNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, order_manager.ErrOrderNotCancellable):
+			status = http.StatusConflict
+		}
+		h.logger.Warn("Failed to cancel order", zap.String("order_id", id), zap.Error(err))
+		writeAPIError(w, status, err.Error())
+		return
+	}
+
+	// Orders with a cancel transaction stay cancelling until it is confirmed
+	status := order_manager.OrderStatusCancelled
+	if txHash != "" {
+		status = order_manager.OrderStatusCancelling
+	}
+
+	h.logger.Info("Cancelled order", zap.String("order_id", id), zap.String("status", string(status)), zap.String("tx_hash", txHash))
+	writeAPIJSON(w, http.StatusOK, cancelOrderResponse{
+		ID:     id,
+		Status: string(status),
+		TxHash: txHash,
+	})
+}
+
+// resolveOrder completes or fails an order the automation can't settle,
+// POST /orders/{id}/complete or POST /orders/{id}/fail
+func (h *apiHandler) resolveOrder(w http.ResponseWriter, r *http.Request, id, action string) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if !h.authorize(w, r, false) {
+		return
+	}
+
+	var req resolveOrderRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxOrderRequestBytes)).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	operator := req.Operator
+	if operator == "" {
+		operator = r.RemoteAddr
+	}
+
+	resolve, status := h.orders.ForceCompleteOrder, order_manager.OrderStatusCompleted
+	if action == "fail" {
+		resolve, status = h.orders.ForceFailOrder, order_manager.OrderStatusFailed
+	}
+	if err := resolve(id, req.Reason, operator); err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, order_manager.ErrOrderNotFound):
+			code = http.StatusNotFound
+		case errors.Is(err, order_manager.ErrOrderNotResolvable):
+			code = http.StatusConflict
+		}
+		h.logger.Warn("Failed to resolve order", zap.String("order_id", id), zap.String("action", action), zap.Error(err))
+		writeAPIError(w, code, err.Error())
+		return
+	}
+
+	h.logger.Warn("Resolved order by hand",
+		zap.String("order_id", id),
+		zap.String("status", string(status)),
+		zap.String("operator", operator),
+		zap.String("remote_addr", r.RemoteAddr),
+		zap.String("reason", req.Reason))
+	writeAPIJSON(w, http.StatusOK, resolveOrderResponse{ID: id, Status: string(status)})
+}
+
+// authorize checks the bearer token of an operator request, writing the
+// error response when it is rejected. Without a configured token requests
+// are only let through when open is set
+func (h *apiHandler) authorize(w http.ResponseWriter, r *http.Request, open bool) bool {
+	if h.authToken == "" {
+		if open {
+			return true
+		}
+		writeAPIError(w, http.StatusForbidden, "endpoint disabled: relayer.api.auth_token is not set")
+		return false
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.authToken)) != 1 {
+		h.logger.Warn("Rejected unauthorized request",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.String("remote_addr", r.RemoteAddr))
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeAPIError(w, http.StatusUnauthorized, "unauthorized")
+		return false
+	}
+	return true
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeAPIJSON(w, status, map[string]string{"error": message})
+}
+
+// startAPI listens on the configured API address and serves the API in the
+// background until Stop shuts it down
+func (rs *RelayerService) startAPI() error {
+	addr := net.JoinHostPort(rs.config.Relayer.API.Host, strconv.Itoa(rs.config.Relayer.API.Port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	rs.api = &http.Server{
+		Handler:           newAPIHandler(rs.orderManager, rs.config.Relayer.API.AuthToken, rs.logger.Named("api")),
+		ReadHeaderTimeout: apiReadHeaderTimeout,
+	}
+	go func() {
+		if err := rs.api.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			rs.logger.Error("API server failed", zap.Error(err))
+		}
+	}()
+
+	rs.logger.Info("API server started", zap.String("addr", listener.Addr().String()))
+	return nil
+}
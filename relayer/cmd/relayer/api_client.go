@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+// operatorAPI returns the base URL and bearer token of the relayer API an
+// operator command calls: apiURL when set, otherwise relayer.api of the
+// config, and authToken when set, otherwise relayer.api.auth_token. action
+// names what the command does for the error when the API is disabled
+func operatorAPI(apiURL, authToken, action string) (string, string, error) {
+	if apiURL != "" {
+		return apiURL, authToken, nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", "", err
+	}
+	defer logger.Sync()
+
+	if !cfg.Relayer.API.Enabled {
+		return "", "", fmt.Errorf("relayer.api.enabled must be set to %s, or pass --api-url", action)
+	}
+	if authToken == "" {
+		authToken = cfg.Relayer.API.AuthToken
+	}
+	return "http://" + net.JoinHostPort(cfg.Relayer.API.Host, strconv.Itoa(cfg.Relayer.API.Port)), authToken, nil
+}
+
+// callOrderAPI sends a request with an optional JSON body to an operator
+// endpoint of the relayer API and decodes a successful response into out.
+// When the API refuses the request its reason is returned as the error
+func callOrderAPI(ctx context.Context, method, endpoint, authToken string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		bz, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(bz)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach relayer API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Error == "" {
+			return errors.New(resp.Status)
+		}
+		return errors.New(apiErr.Error)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// cancelOrderTimeout bounds a cancel-order request, which may wait for an
+// on-chain cancel transaction to be sent
+const cancelOrderTimeout = 2 * time.Minute
+
+var (
+	cancelOrderAPIURL    string
+	cancelOrderAuthToken string
+)
+
+var cancelOrderCmd = &cobra.Command{
+	Use:   "cancel-order [order-id]",
+	Short: "Cancel an in-flight order on the running relayer",
+	Long: `Ask the running relayer, through its HTTP API, to cancel an order. An order the
+relayer hasn't funded an escrow for is dropped from tracking. An order whose
+destination escrow the relayer funded has the escrow cancelled on chain, which is
+only possible once the escrow's timelock has passed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCancelOrder,
+}
+
+func init() {
+	cancelOrderCmd.Flags().StringVar(&cancelOrderAPIURL, "api-url", "", "Base URL of the relayer API (default from relayer.api)")
+	cancelOrderCmd.Flags().StringVar(&cancelOrderAuthToken, "auth-token", "", "Bearer token of the relayer API (default from relayer.api.auth_token)")
+}
+
+func runCancelOrder(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	baseURL, authToken, err := operatorAPI(cancelOrderAPIURL, cancelOrderAuthToken, "cancel orders")
+	if err != nil {
+		return err
+	}
+
+	res, err := cancelOrder(ctx, baseURL, authToken, args[0])
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Order %s %s\n", res.ID, res.Status)
+	if res.TxHash != "" {
+		fmt.Fprintf(out, "Cancel tx: %s\n", res.TxHash)
+	}
+	return nil
+}
+
+// cancelOrder asks the relayer API at baseURL to cancel an order, returning
+// the API's reason as the error when it can't be cancelled
+func cancelOrder(ctx context.Context, baseURL, authToken, orderID string) (*cancelOrderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, cancelOrderTimeout)
+	defer cancel()
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/orders/" + url.PathEscape(orderID)
+	var res cancelOrderResponse
+	if err := callOrderAPI(ctx, http.MethodDelete, endpoint, authToken, nil, &res); err != nil {
+		return nil, fmt.Errorf("failed to cancel order %s: %w", orderID, err)
+	}
+	return &res, nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/ethereum_client"
+)
+
+// catchupProgressInterval is how often a catch-up scan logs its progress
+const catchupProgressInterval = 10 * time.Second
+
+// blockRange is an inclusive range of block numbers
+type blockRange struct {
+	from, to uint64
+}
+
+func (r blockRange) blocks() uint64 {
+	return r.to - r.from + 1
+}
+
+// splitBlockRange splits [from, to] into consecutive ranges of at most size
+// blocks, oldest first
+func splitBlockRange(from, to, size uint64) []blockRange {
+	if size == 0 {
+		size = 1
+	}
+
+	var ranges []blockRange
+	for start := from; start <= to; {
+		end := to
+		if to-start >= size {
+			end = start + size - 1
+		}
+		ranges = append(ranges, blockRange{from: start, to: end})
+		start = end + 1
+	}
+	return ranges
+}
+
+// fetchEthereumOrders lists the escrows the Ethereum factory created in
+// [from, to], in block order. A gap of more than one log scan batch, as after
+// downtime, is caught up in batches fetched by Relayer.CatchupWorkers workers
+// in parallel; the batches are still returned oldest first so older escrows
+// are queued before newer ones. Cronos escrows are listed by a paginated
+// factory query rather than by block, so only Ethereum scans catch up this
+// way
+func (rs *RelayerService) fetchEthereumOrders(ctx context.Context, from, to uint64) ([]ethereum_client.EscrowOrder, error) {
+	factory := rs.config.Contracts.Ethereum.EscrowFactory
+	batches := splitBlockRange(from, to, rs.config.Relayer.LogScanBatchSize)
+	workers := rs.config.Relayer.CatchupWorkers
+	if workers <= 1 || len(batches) == 1 {
+		return rs.ethereumOrders.GetEscrowOrders(ctx, factory, from, to)
+	}
+	if workers > len(batches) {
+		workers = len(batches)
+	}
+
+	rs.logger.Info("Catching up on Ethereum escrows",
+		zap.Uint64("from_block", from),
+		zap.Uint64("to_block", to),
+		zap.Int("batches", len(batches)),
+		zap.Int("workers", workers))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]ethereum_client.EscrowOrder, len(batches))
+	var (
+		scanned  atomic.Uint64
+		failOnce sync.Once
+		failErr  error
+	)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				batch := batches[i]
+				orders, err := rs.ethereumOrders.GetEscrowOrders(ctx, factory, batch.from, batch.to)
+				if err != nil {
+					// The range is scanned again from the start on the next poll
+					failOnce.Do(func() {
+						failErr = err
+						cancel()
+					})
+					continue
+				}
+				results[i] = orders
+				scanned.Add(batch.blocks())
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go rs.logCatchupProgress(done, "ethereum", &scanned, to-from+1)
+
+feed:
+	for i := range batches {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(done)
+
+	if failErr != nil {
+		return nil, failErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var orders []ethereum_client.EscrowOrder
+	for _, batch := range results {
+		orders = append(orders, batch...)
+	}
+
+	rs.logger.Info("Caught up on Ethereum escrows",
+		zap.Uint64("to_block", to),
+		zap.Int("escrows", len(orders)))
+	return orders, nil
+}
+
+// logCatchupProgress logs how many of total blocks have been scanned every
+// catchupProgressInterval until done is closed
+func (rs *RelayerService) logCatchupProgress(done <-chan struct{}, chain string, scanned *atomic.Uint64, total uint64) {
+	ticker := time.NewTicker(catchupProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			n := scanned.Load()
+			rs.logger.Info("Catch-up scan progress",
+				zap.String("chain", chain),
+				zap.Uint64("blocks_scanned", n),
+				zap.Uint64("blocks_remaining", total-n))
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// scanCheckpoint is the last block of each chain the order scanners finished
+// with, saved so a restarted relayer doesn't rescan from genesis
+type scanCheckpoint struct {
+	Cronos   int64  `json:"cronos"`
+	Ethereum uint64 `json:"ethereum"`
+}
+
+// saveCheckpoint writes checkpoint to path as JSON, replacing any previous one
+func saveCheckpoint(path string, checkpoint scanCheckpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated checkpoint
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// loadCheckpoint reads a checkpoint written by saveCheckpoint, or
+// found=false if there is none
+func loadCheckpoint(path string) (checkpoint scanCheckpoint, found bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return scanCheckpoint{}, false, nil
+	}
+	if err != nil {
+		return scanCheckpoint{}, false, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return scanCheckpoint{}, false, fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+	return checkpoint, true, nil
+}
+
+// restoreCheckpoint resumes the order scanners after the blocks saved by the
+// previous run, or before relayer.start_block when there is no checkpoint
+func (rs *RelayerService) restoreCheckpoint() error {
+	var checkpoint scanCheckpoint
+	found := false
+	if path := rs.config.Relayer.CheckpointPath; path != "" {
+		var err error
+		if checkpoint, found, err = loadCheckpoint(path); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		start := rs.config.Relayer.StartBlock
+		if start.Cronos > 0 {
+			checkpoint.Cronos = int64(start.Cronos) - 1
+		}
+		if start.Ethereum > 0 {
+			checkpoint.Ethereum = start.Ethereum - 1
+		}
+	}
+
+	atomic.StoreInt64(&rs.lastCronosBlock, checkpoint.Cronos)
+	atomic.StoreUint64(&rs.lastEthereumBlock, checkpoint.Ethereum)
+	atomic.StoreUint64(&rs.ethereumCheckpoint, checkpoint.Ethereum)
+
+	rs.logger.Info("Resuming order scans",
+		zap.Bool("from_checkpoint", found),
+		zap.Int64("cronos_block", checkpoint.Cronos),
+		zap.Uint64("ethereum_block", checkpoint.Ethereum))
+	return nil
+}
+
+// persistCheckpoint saves the blocks the order scanners have finished with.
+// It runs on shutdown alongside the order store, so a crash resumes from the
+// last clean shutdown and rescans the orders the store never saw
+func (rs *RelayerService) persistCheckpoint() error {
+	path := rs.config.Relayer.CheckpointPath
+	if path == "" {
+		return nil
+	}
+
+	checkpoint := scanCheckpoint{
+		Cronos:   atomic.LoadInt64(&rs.lastCronosBlock),
+		Ethereum: atomic.LoadUint64(&rs.ethereumCheckpoint),
+	}
+	if err := saveCheckpoint(path, checkpoint); err != nil {
+		return err
+	}
+
+	rs.logger.Info("Saved scan checkpoint",
+		zap.Int64("cronos_block", checkpoint.Cronos),
+		zap.Uint64("ethereum_block", checkpoint.Ethereum))
+	return nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+)
+
+var configShowOutput string
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the relayer configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration",
+	Long: `Load the configuration the way the relayer does, merging defaults, the config
+file and BRIDGE_ environment variables, and print the result with secrets
+redacted. In YAML each value is annotated with where it came from: env, file or
+default. JSON output lists the sources separately.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigShow,
+}
+
+func init() {
+	configShowCmd.Flags().StringVarP(&configShowOutput, "output", "o", "yaml", "Output format: yaml or json")
+	configCmd.AddCommand(configShowCmd)
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	if configShowOutput != "yaml" && configShowOutput != "json" {
+		return fmt.Errorf("--output must be yaml or json, got %q", configShowOutput)
+	}
+
+	// A config that fails validation is still shown, since that is when it
+	// is most needed, and the failure is returned after it
+	_, loadErr := loadConfig()
+	defer logger.Sync()
+
+	settings := config.EffectiveSettings()
+
+	var out []byte
+	var err error
+	if configShowOutput == "json" {
+		out, err = settingsJSON(settings)
+	} else {
+		out, err = settingsYAML(settings)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := cmd.OutOrStdout().Write(out); err != nil {
+		return err
+	}
+	return loadErr
+}
+
+// settingsYAML renders settings as nested YAML, commenting each value with
+// its source
+func settingsYAML(settings []config.Setting) ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, setting := range settings {
+		parent, name := root, setting.Key
+		if i := strings.LastIndex(setting.Key, "."); i >= 0 {
+			parent = yamlMapping(root, strings.Split(setting.Key[:i], "."))
+			name = setting.Key[i+1:]
+		}
+
+		value := &yaml.Node{}
+		if err := value.Encode(setting.Value); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", setting.Key, err)
+		}
+		value.LineComment = setting.Source
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, value)
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// yamlMapping returns the mapping at path below node, creating it if needed
+func yamlMapping(node *yaml.Node, path []string) *yaml.Node {
+	for _, name := range path {
+		var child *yaml.Node
+		for i := 0; i < len(node.Content); i += 2 {
+			if node.Content[i].Value == name {
+				child = node.Content[i+1]
+				break
+			}
+		}
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, child)
+		}
+		node = child
+	}
+	return node
+}
+
+// settingsJSON renders settings as nested JSON next to a map of each key's
+// source
+func settingsJSON(settings []config.Setting) ([]byte, error) {
+	values := make(map[string]interface{})
+	sources := make(map[string]string, len(settings))
+	for _, setting := range settings {
+		parent := values
+		path := strings.Split(setting.Key, ".")
+		for _, name := range path[:len(path)-1] {
+			child, ok := parent[name].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				parent[name] = child
+			}
+			parent = child
+		}
+		parent[path[len(path)-1]] = setting.Value
+		sources[setting.Key] = setting.Source
+	}
+
+	// Don't escape the angle brackets of redacted values
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(map[string]interface{}{"config": values, "sources": sources}); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/rpc_retry"
+)
+
+// lagEscalationChecks is the number of consecutive health checks the block
+// lag must grow over the limit before it is logged as an error
+const lagEscalationChecks = 3
+
+// startupProbeTimeout bounds the check of both chains' nodes at startup
+const startupProbeTimeout = 30 * time.Second
+
+// blockLagMetrics publishes how many blocks each chain's scanner trails the
+// chain tip, keyed by chain name
+var blockLagMetrics = expvar.NewMap("relayer_block_lag")
+
+// chainHealthMetrics publishes 1 for each chain whose node is in use and 0
+// while its RPC circuit breaker is open, keyed by chain name
+var chainHealthMetrics = expvar.NewMap("relayer_chain_healthy")
+
+// scanTimeoutMetrics counts the order scans abandoned for taking longer than
+// the scan timeout, keyed by chain name
+var scanTimeoutMetrics = expvar.NewMap("relayer_scan_timeouts")
+
+// cronosTipSource reports the latest Cronos block height
+type cronosTipSource interface {
+	GetLatestBlock(ctx context.Context) (int64, error)
+}
+
+// ethereumTipSource reports the latest Ethereum block number
+type ethereumTipSource interface {
+	GetLatestBlock(ctx context.Context) (uint64, error)
+}
+
+// blockLag tracks how far a chain's scanner trails the chain tip across
+// health checks
+type blockLag struct {
+	chain string
+	// last is the lag seen by the previous check
+	last uint64
+	// growing counts consecutive checks where the lag was over the limit and
+	// larger than before
+	growing int
+}
+
+// observe records the lag between tip and scanned and returns the level it
+// should be logged at: warn once it exceeds maxLag and error once it has kept
+// growing for lagEscalationChecks checks. A zero maxLag disables alerting
+func (l *blockLag) observe(tip, scanned, maxLag uint64) (uint64, zapcore.Level) {
+	var lag uint64
+	if tip > scanned {
+		lag = tip - scanned
+	}
+
+	level := zapcore.DebugLevel
+	if maxLag > 0 && lag > maxLag {
+		if lag > l.last {
+			l.growing++
+		} else {
+			l.growing = 0
+		}
+
+		level = zapcore.WarnLevel
+		if l.growing >= lagEscalationChecks {
+			level = zapcore.ErrorLevel
+		}
+	} else {
+		l.growing = 0
+	}
+
+	l.last = lag
+	return lag, level
+}
+
+// checkBlockLags compares each chain's tip with the last block its scanner
+// processed, logging and publishing the lag. Chains whose RPC circuit
+// breaker is open are reported unhealthy and skipped
+func (rs *RelayerService) checkBlockLags(ctx context.Context) {
+	maxLag := rs.config.Relayer.MaxBlockLag
+
+	if rs.checkBreaker("cronos", rs.cronosBreaker) {
+		cronosTip, err := rs.cronosTip.GetLatestBlock(ctx)
+		if err != nil {
+			setChainHealth("cronos", false)
+			rs.logger.Error("Cronos health check failed", zap.Error(err))
+		} else if scanned := atomic.LoadInt64(&rs.lastCronosBlock); scanned > 0 {
+			rs.reportBlockLag(&rs.cronosLag, uint64(cronosTip), uint64(scanned), maxLag)
+		}
+	}
+
+	if rs.checkBreaker("ethereum", rs.ethereumBreaker) {
+		ethereumTip, err := rs.ethereumTip.GetLatestBlock(ctx)
+		if err != nil {
+			setChainHealth("ethereum", false)
+			rs.logger.Error("Ethereum health check failed", zap.Error(err))
+		} else if scanned := atomic.LoadUint64(&rs.lastEthereumBlock); scanned > 0 {
+			rs.reportBlockLag(&rs.ethereumLag, ethereumTip, scanned, maxLag)
+		}
+	}
+
+	// Other EVM chains aren't scanned yet, so only their nodes are checked
+	for name, client := range rs.chains {
+		if name == config.PrimaryEVMChain || !rs.checkBreaker(name, client.Breaker()) {
+			continue
+		}
+		if _, err := client.GetLatestBlock(ctx); err != nil {
+			rs.logger.Error("Chain health check failed", zap.String("chain", name), zap.Error(err))
+		}
+	}
+}
+
+// checkBreaker reports whether a chain's node is in use, marking the chain
+// unhealthy while its RPC circuit breaker is open
+func (rs *RelayerService) checkBreaker(chain string, breaker *rpc_retry.Breaker) bool {
+	state := breaker.State()
+	healthy := state != rpc_retry.StateOpen
+	setChainHealth(chain, healthy)
+
+	if !healthy {
+		rs.logger.Error("Chain is unhealthy, RPC circuit breaker is open",
+			zap.String("chain", chain),
+			zap.Stringer("breaker", state))
+	}
+	return healthy
+}
+
+// setChainHealth publishes whether a chain's node is in use
+func setChainHealth(chain string, healthy bool) {
+	metric := new(expvar.Int)
+	if healthy {
+		metric.Set(1)
+	}
+	chainHealthMetrics.Set(chain, metric)
+}
+
+// checkStartupHealth asks both chains' nodes for their tip before the relayer
+// starts. A chain whose node is down is marked unhealthy and the relayer
+// starts degraded: the other chain is monitored as usual while the down
+// chain's scanner keeps polling, and the health check reports it, until the
+// node is back. Startup only fails if both chains are down
+func checkStartupHealth(ctx context.Context, cronos cronosTipSource, ethereum ethereumTipSource, logger *zap.Logger) error {
+	_, cronosErr := cronos.GetLatestBlock(ctx)
+	_, ethereumErr := ethereum.GetLatestBlock(ctx)
+
+	if cronosErr != nil && ethereumErr != nil {
+		return fmt.Errorf("both chains are unreachable: cronos: %v; ethereum: %v", cronosErr, ethereumErr)
+	}
+
+	for _, chain := range []struct {
+		name string
+		err  error
+	}{
+		{"cronos", cronosErr},
+		{"ethereum", ethereumErr},
+	} {
+		setChainHealth(chain.name, chain.err == nil)
+		if chain.err != nil {
+			logger.Warn("Chain is unreachable, starting degraded until it recovers",
+				zap.String("chain", chain.name),
+				zap.Error(chain.err))
+		}
+	}
+	return nil
+}
+
+// reportBlockLag records a chain's lag and logs it at the level it warrants
+func (rs *RelayerService) reportBlockLag(l *blockLag, tip, scanned, maxLag uint64) {
+	lag, level := l.observe(tip, scanned, maxLag)
+
+	metric := new(expvar.Int)
+	metric.Set(int64(lag))
+	blockLagMetrics.Set(l.chain, metric)
+
+	msg := "Block scanner is keeping up"
+	switch level {
+	case zapcore.WarnLevel:
+		msg = "Block scanner is falling behind the chain tip"
+	case zapcore.ErrorLevel:
+		msg = "Block scanner lag keeps growing"
+	}
+
+	if ce := rs.logger.Check(level, msg); ce != nil {
+		ce.Write(
+			zap.String("chain", l.chain),
+			zap.Uint64("tip", tip),
+			zap.Uint64("scanned", scanned),
+			zap.Uint64("lag", lag),
+			zap.Uint64("max_lag", maxLag),
+			zap.Int("growing_checks", l.growing))
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"math/rand"
+	"time"
+)
+
+// jitterTicker delivers ticks like time.Ticker, but draws every interval
+// afresh from interval ± jitter*interval so that replicas started together
+// drift apart instead of polling in lockstep
+type jitterTicker struct {
+	C <-chan time.Time
+
+	stop chan struct{}
+}
+
+// newJitterTicker starts a ticker around interval. A jitter of 0 keeps the
+// interval fixed
+func newJitterTicker(interval time.Duration, jitter float64) *jitterTicker {
+	c := make(chan time.Time, 1)
+	t := &jitterTicker{C: c, stop: make(chan struct{})}
+	go t.run(c, interval, jitter)
+	return t
+}
+
+func (t *jitterTicker) run(c chan<- time.Time, interval time.Duration, jitter float64) {
+	timer := time.NewTimer(jitteredInterval(interval, jitter, rand.Float64))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case now := <-timer.C:
+			// Like time.Ticker, drop the tick if the last one wasn't read
+			select {
+			case c <- now:
+			default:
+			}
+			timer.Reset(jitteredInterval(interval, jitter, rand.Float64))
+		}
+	}
+}
+
+// Stop turns the ticker off
+func (t *jitterTicker) Stop() {
+	close(t.stop)
+}
+
+// jitteredInterval scales interval by a factor drawn uniformly from
+// [1-jitter, 1+jitter] using random, which returns values in [0, 1)
+func jitteredInterval(interval time.Duration, jitter float64, random func() float64) time.Duration {
+	if jitter <= 0 {
+		return interval
+	}
+	factor := 1 + jitter*(2*random()-1)
+	return time.Duration(float64(interval) * factor)
+}
@@ -2,19 +2,28 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
 	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
 	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
 	"github.com/manus-ai/cronos-eth-bridge/pkg/ethereum_client"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/logging"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/matching"
 	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/price_oracle"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/rpc_retry"
 )
 
 var (
@@ -59,8 +68,15 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Path to configuration file")
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(reconcileCmd)
+	rootCmd.AddCommand(orderCmd)
+	rootCmd.AddCommand(cancelOrderCmd)
+	rootCmd.AddCommand(completeOrderCmd)
+	rootCmd.AddCommand(failOrderCmd)
+	rootCmd.AddCommand(configCmd)
 }
 
+// initLogger sets up the bootstrap logger used until the configuration is loaded
 func initLogger() error {
 	var err error
 	logger, err = zap.NewProduction()
@@ -70,29 +86,70 @@ func initLogger() error {
 	return nil
 }
 
+// loadConfig loads the configuration and replaces the bootstrap logger with
+// one built from it
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	configuredLogger, err := logging.NewLogger(cfg.Logging)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	logger = configuredLogger
+
+	return cfg, nil
+}
+
 func runRelayer(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Load configuration
-	cfg, err := config.LoadConfig(configPath)
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return err
 	}
+	defer logger.Sync()
 
 	logger.Info("Starting Cronos-Ethereum Bridge Relayer",
 		zap.String("cronos_chain_id", cfg.Cronos.ChainID),
 		zap.String("ethereum_chain_id", cfg.Ethereum.ChainID))
 
 	// Initialize blockchain clients
-	cronosClient, err := cronos_client.NewClient(&cfg.Cronos, logger.Named("cronos"))
+	cronosClient, err := cronos_client.NewClient(&cfg.Cronos, &cfg.Relayer, logger.Named("cronos"))
 	if err != nil {
 		return fmt.Errorf("failed to initialize Cronos client: %w", err)
 	}
 
-	ethereumClient, err := ethereum_client.NewClient(&cfg.Ethereum, &cfg.Contracts.Ethereum, logger.Named("ethereum"))
+	chains, err := newChainClients(cfg, logger)
+	if err != nil {
+		return err
+	}
+	ethereumClient, ok := chains[config.PrimaryEVMChain]
+	if !ok {
+		return fmt.Errorf("no %s chain configured", config.PrimaryEVMChain)
+	}
+
+	// Start degraded if one chain's node is down, but not without both
+	probeCtx, probeCancel := context.WithTimeout(ctx, startupProbeTimeout)
+	err = checkStartupHealth(probeCtx, cronosClient, ethereumClient, logger)
+	probeCancel()
+	if err != nil {
+		return err
+	}
+
+	matcher, err := matching.New(cfg.Relayer.MatchingStrategy)
 	if err != nil {
-		return fmt.Errorf("failed to initialize Ethereum client: %w", err)
+		return fmt.Errorf("failed to initialize order matcher: %w", err)
+	}
+	if cfg.DutchAuction.PriceOracleURL != "" {
+		matcher = matching.OracleGuard{
+			Matcher:             matcher,
+			Oracle:              price_oracle.NewHTTPOracle(cfg.DutchAuction.PriceOracleURL, cfg.DutchAuction.PriceOracleTimeout),
+			MaxDeviationPercent: cfg.DutchAuction.MaxOracleDeviation,
+		}
 	}
 
 	// Initialize order manager
@@ -103,8 +160,24 @@ func runRelayer(cmd *cobra.Command, args []string) error {
 		config:         cfg,
 		cronosClient:   cronosClient,
 		ethereumClient: ethereumClient,
+		chains:         chains,
 		orderManager:   orderManager,
+		matcher:        matcher,
 		logger:         logger,
+		tokens:         ethereumClient,
+		cronosOrders:   cronosClient,
+		ethereumOrders: ethereumClient,
+		cronosTip:      cronosClient,
+		ethereumTip:    ethereumClient,
+
+		cronosBreaker:   cronosClient.Breaker(),
+		ethereumBreaker: ethereumClient.Breaker(),
+
+		cronosLag:   blockLag{chain: "cronos"},
+		ethereumLag: blockLag{chain: "ethereum"},
+
+		cronosPending:   order_manager.NewPendingOrders(cfg.Relayer.ConfirmationDepth.Cronos),
+		ethereumPending: order_manager.NewPendingOrders(cfg.Relayer.ConfirmationDepth.Ethereum),
 	}
 
 	if err := relayerService.Start(ctx); err != nil {
@@ -136,6 +209,39 @@ func runRelayer(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// newChainClients connects to every configured EVM chain, keyed by chain name
+func newChainClients(cfg *config.Config, logger *zap.Logger) (map[string]*ethereum_client.Client, error) {
+	clients := make(map[string]*ethereum_client.Client, len(cfg.Chains))
+	for _, name := range config.ChainNames(cfg.Chains) {
+		chain := cfg.Chains[name]
+		client, err := ethereum_client.NewClient(&chain.ChainConfig, &chain.Contracts, &cfg.Relayer, logger.Named(name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize %s client: %w", name, err)
+		}
+		clients[name] = client
+	}
+	return clients, nil
+}
+
+// tokenMetadataSource looks up the symbol and decimals of ERC20 tokens
+type tokenMetadataSource interface {
+	GetTokenMetadata(ctx context.Context, tokenAddr string) (ethereum_client.TokenMetadata, error)
+}
+
+// cronosOrderSource lists the escrows deployed by the Cronos factory
+type cronosOrderSource interface {
+	cronosTipSource
+	GetEscrowOrdersPage(ctx context.Context, factoryAddr string, startAfter string, limit uint32) ([]cronos_client.EscrowOrder, string, error)
+}
+
+// ethereumOrderSource finds the escrows created through the Ethereum factory
+// and the blocks their creation transactions landed in
+type ethereumOrderSource interface {
+	ethereumTipSource
+	GetEscrowOrders(ctx context.Context, factoryAddr string, fromBlock, toBlock uint64) ([]ethereum_client.EscrowOrder, error)
+	GetTransactionBlock(ctx context.Context, txHash string) (blockNumber uint64, found bool, err error)
+}
+
 // RelayerService represents the main relayer service
 type RelayerService struct {
 	config         *config.Config
@@ -144,9 +250,47 @@ type RelayerService struct {
 	orderManager   *order_manager.OrderManager
 	logger         *zap.Logger
 
-	// Monitoring
+	// Clients of every configured EVM chain keyed by chain name, including
+	// ethereumClient as config.PrimaryEVMChain
+	chains map[string]*ethereum_client.Client
+
+	// Strategy pairing complementary orders; price-time priority if unset
+	matcher matching.Matcher
+
+	// ERC20 metadata for Ethereum deposits, normally the Ethereum client
+	tokens tokenMetadataSource
+
+	// Escrows the order scanners read, normally the chain clients
+	cronosOrders   cronosOrderSource
+	ethereumOrders ethereumOrderSource
+
+	// Chain tips the health check compares scan progress against, normally
+	// the chain clients
+	cronosTip   cronosTipSource
+	ethereumTip ethereumTipSource
+
+	// RPC circuit breakers of the chain clients; a chain is unhealthy while
+	// its breaker is open
+	cronosBreaker   *rpc_retry.Breaker
+	ethereumBreaker *rpc_retry.Breaker
+
+	// Monitoring. The last scanned blocks are written by the scanners and
+	// read atomically by the health check
 	lastCronosBlock   int64
 	lastEthereumBlock uint64
+	cronosLag         blockLag
+	ethereumLag       blockLag
+
+	// Last Ethereum block a restart can resume after without missing an
+	// order still waiting for confirmations, written by the Ethereum scanner
+	ethereumCheckpoint uint64
+
+	// Orders waiting for enough confirmations, owned by each chain's scanner
+	cronosPending   *order_manager.PendingOrders
+	ethereumPending *order_manager.PendingOrders
+
+	// HTTP API server; nil unless relayer.api.enabled is set
+	api *http.Server
 
 	// Stop channel
 	stopChan chan struct{}
@@ -161,11 +305,22 @@ func (rs *RelayerService) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to start order manager: %w", err)
 	}
 
+	if rs.config.Relayer.API.Enabled {
+		if err := rs.startAPI(); err != nil {
+			return fmt.Errorf("failed to start API server: %w", err)
+		}
+	}
+
+	if err := rs.restoreCheckpoint(); err != nil {
+		return fmt.Errorf("failed to restore scan checkpoint: %w", err)
+	}
+
 	// Start monitoring goroutines
 	go rs.monitorCronosOrders(ctx)
 	go rs.monitorEthereumOrders(ctx)
 	go rs.processOrderMatching(ctx)
 	go rs.healthCheck(ctx)
+	go rs.monitorStuckTransactions(ctx)
 
 	rs.logger.Info("Relayer service started successfully")
 	return nil
@@ -175,21 +330,45 @@ func (rs *RelayerService) Start(ctx context.Context) error {
 func (rs *RelayerService) Stop(ctx context.Context) error {
 	close(rs.stopChan)
 
-	// Stop order manager
-	if err := rs.orderManager.Stop(); err != nil {
+	// Stop taking orders before draining the ones in flight
+	if rs.api != nil {
+		if err := rs.api.Shutdown(ctx); err != nil {
+			rs.logger.Error("Failed to stop API server", zap.Error(err))
+		}
+	}
+
+	// Let in-flight withdrawals and cancellations finish before stopping
+	for _, order := range rs.orderManager.Drain(ctx) {
+		rs.logger.Warn("Order still in flight at shutdown",
+			zap.String("order_id", order.ID),
+			zap.String("status", string(order.Status)))
+	}
+
+	// Stop order manager, giving up on goroutines stuck past the deadline
+	if err := rs.orderManager.Stop(ctx); err != nil {
 		rs.logger.Error("Failed to stop order manager", zap.Error(err))
 	}
 
+	if err := rs.orderManager.FlushOrders(); err != nil {
+		rs.logger.Error("Failed to flush unfinished orders", zap.Error(err))
+	}
+	if err := rs.persistCheckpoint(); err != nil {
+		rs.logger.Error("Failed to save scan checkpoint", zap.Error(err))
+	}
+
 	rs.logger.Info("Relayer service stopped")
 	return nil
 }
 
-// monitorCronosOrders monitors for new orders on Cronos
+// monitorCronosOrders monitors for new orders on Cronos. Blocks are polled on
+// an interval, and when a websocket endpoint is configured the factory's
+// events trigger a scan as soon as an escrow is created
 func (rs *RelayerService) monitorCronosOrders(ctx context.Context) {
-	ticker := time.NewTicker(rs.config.Relayer.BlockPollInterval)
+	ticker := newJitterTicker(rs.config.Relayer.BlockPollInterval, rs.config.Relayer.PollJitter)
 	defer ticker.Stop()
 
 	rs.logger.Info("Starting Cronos order monitoring")
+	events := rs.subscribeCronosFactoryEvents(ctx)
 
 	for {
 		select {
@@ -198,16 +377,45 @@ func (rs *RelayerService) monitorCronosOrders(ctx context.Context) {
 		case <-rs.stopChan:
 			return
 		case <-ticker.C:
-			if err := rs.scanCronosOrders(ctx); err != nil {
-				rs.logger.Error("Failed to scan Cronos orders", zap.Error(err))
+			rs.runScan(ctx, "cronos", rs.scanCronosOrders)
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if event.Type != wasmEventType {
+				continue
 			}
+			rs.runScan(ctx, "cronos", rs.scanCronosOrders)
 		}
 	}
 }
 
+// wasmEventType is the type of the events CosmWasm contracts emit
+const wasmEventType = "wasm"
+
+// subscribeCronosFactoryEvents subscribes to the transactions of the Cronos
+// escrow factory. It returns nil, leaving block polling alone, if no
+// websocket endpoint is configured or the subscription fails
+func (rs *RelayerService) subscribeCronosFactoryEvents(ctx context.Context) <-chan abci.Event {
+	if rs.config.Cronos.WSEndpoint == "" {
+		return nil
+	}
+
+	query := fmt.Sprintf("tm.event='Tx' AND wasm._contract_address='%s'", rs.config.Contracts.Cronos.EscrowFactory)
+	events, err := rs.cronosClient.SubscribeContractEvents(ctx, query)
+	if err != nil {
+		rs.logger.Warn("Failed to subscribe to Cronos factory events, polling blocks only", zap.Error(err))
+		return nil
+	}
+
+	rs.logger.Info("Subscribed to Cronos factory events", zap.String("query", query))
+	return events
+}
+
 // monitorEthereumOrders monitors for new orders on Ethereum
 func (rs *RelayerService) monitorEthereumOrders(ctx context.Context) {
-	ticker := time.NewTicker(rs.config.Relayer.BlockPollInterval)
+	ticker := newJitterTicker(rs.config.Relayer.BlockPollInterval, rs.config.Relayer.PollJitter)
 	defer ticker.Stop()
 
 	rs.logger.Info("Starting Ethereum order monitoring")
@@ -219,17 +427,42 @@ func (rs *RelayerService) monitorEthereumOrders(ctx context.Context) {
 		case <-rs.stopChan:
 			return
 		case <-ticker.C:
-			if err := rs.scanEthereumOrders(ctx); err != nil {
-				rs.logger.Error("Failed to scan Ethereum orders", zap.Error(err))
-			}
+			rs.runScan(ctx, "ethereum", rs.scanEthereumOrders)
 		}
 	}
 }
 
+// runScan runs one order scan of chain, bounded by Relayer.ScanTimeout so a
+// hung node can't stall the monitor. Timeouts are logged as errors and
+// counted per chain
+func (rs *RelayerService) runScan(ctx context.Context, chain string, scan func(ctx context.Context) error) {
+	timeout := rs.config.Relayer.ScanTimeout
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := scan(ctx)
+	if err == nil {
+		return
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		scanTimeoutMetrics.Add(chain, 1)
+		rs.logger.Error("Order scan timed out",
+			zap.String("chain", chain),
+			zap.Duration("timeout", timeout),
+			zap.Error(err))
+		return
+	}
+	rs.logger.Error("Failed to scan orders", zap.String("chain", chain), zap.Error(err))
+}
+
 // scanCronosOrders scans for new orders on Cronos
 func (rs *RelayerService) scanCronosOrders(ctx context.Context) error {
 	// Get latest block
-	latestBlock, err := rs.cronosClient.GetLatestBlock(ctx)
+	latestBlock, err := rs.cronosOrders.GetLatestBlock(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get latest Cronos block: %w", err)
 	}
@@ -239,34 +472,91 @@ func (rs *RelayerService) scanCronosOrders(ctx context.Context) error {
 	}
 
 	// Get new orders from the factory
-	orders, err := rs.cronosClient.GetEscrowOrders(
-		ctx,
-		rs.config.Contracts.Cronos.EscrowFactory,
-		"", // start_after
-		50, // limit
-	)
+	orders, err := rs.fetchCronosOrders(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get Cronos orders: %w", err)
+		return err
+	}
+
+	// Queue new orders until they are confirmed. Escrow listings carry no
+	// creation height, so the height they were first seen at stands in for it
+	for i := range orders {
+		rs.queueCronosOrder(&orders[i], uint64(latestBlock))
 	}
 
-	// Process new orders
-	for _, cronosOrder := range orders {
-		order := rs.convertCronosOrderToOrder(&cronosOrder)
+	// Cronos has instant finality, so confirmed orders are never reorged out
+	confirmed := rs.cronosPending.Promote(uint64(latestBlock))
+	for _, order := range confirmed {
 		rs.orderManager.AddOrder(order)
 	}
 
-	rs.lastCronosBlock = latestBlock
+	atomic.StoreInt64(&rs.lastCronosBlock, latestBlock)
 	rs.logger.Debug("Scanned Cronos orders",
 		zap.Int64("latest_block", latestBlock),
-		zap.Int("new_orders", len(orders)))
+		zap.Int("new_orders", len(confirmed)),
+		zap.Int("pending_orders", rs.cronosPending.Len()))
 
 	return nil
 }
 
+// cronosScanPageSize is the number of escrows listed per factory query
+const cronosScanPageSize = 50
+
+// fetchCronosOrders lists every source escrow of the Cronos factory, page by
+// page, each page starting after the salt the previous one ended with
+func (rs *RelayerService) fetchCronosOrders(ctx context.Context) ([]cronos_client.EscrowOrder, error) {
+	var orders []cronos_client.EscrowOrder
+	startAfter := ""
+	for {
+		page, next, err := rs.cronosOrders.GetEscrowOrdersPage(ctx, rs.config.Contracts.Cronos.EscrowFactory, startAfter, cronosScanPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Cronos orders after %q: %w", startAfter, err)
+		}
+		orders = append(orders, page...)
+
+		if next == "" {
+			return orders, nil
+		}
+		startAfter = next
+	}
+}
+
+// queueCronosOrder converts an escrow listed by the Cronos factory and queues
+// it until it is confirmed. An escrow whose amounts can't be parsed is
+// tracked as a failed order so it is reported once and never acted on
+func (rs *RelayerService) queueCronosOrder(cronosOrder *cronos_client.EscrowOrder, height uint64) {
+	order, err := rs.convertCronosOrderToOrder(cronosOrder)
+	if err != nil {
+		order = &order_manager.Order{
+			ID:               order_manager.OrderID(cronosOrder.SecretHash, "cronos", "ethereum"),
+			Type:             order_manager.OrderTypeCronosToEthereum,
+			Status:           order_manager.OrderStatusPending,
+			SourceChain:      "cronos",
+			DestinationChain: cronosOrder.DstChainID,
+			Maker:            cronosOrder.Maker,
+			SecretHash:       cronosOrder.SecretHash,
+			SourceEscrowAddr: cronosOrder.Address,
+			CreatedAt:        time.Unix(int64(cronosOrder.CreatedAt), 0),
+			UpdatedAt:        time.Now(),
+		}
+		if rs.orderManager.QuarantineOrder(order, fmt.Sprintf("malformed escrow: %v", err)) {
+			rs.logger.Warn("Quarantined Cronos order with malformed escrow",
+				zap.String("order_id", order.ID),
+				zap.String("escrow", cronosOrder.Address),
+				zap.Error(err))
+		}
+		return
+	}
+
+	if rs.orderManager.MergeOrder(order) {
+		return
+	}
+	rs.cronosPending.Add(order, "", height)
+}
+
 // scanEthereumOrders scans for new orders on Ethereum
 func (rs *RelayerService) scanEthereumOrders(ctx context.Context) error {
 	// Get latest block
-	latestBlock, err := rs.ethereumClient.GetLatestBlock(ctx)
+	latestBlock, err := rs.ethereumOrders.GetLatestBlock(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get latest Ethereum block: %w", err)
 	}
@@ -276,32 +566,62 @@ func (rs *RelayerService) scanEthereumOrders(ctx context.Context) error {
 	}
 
 	// Get new orders from the factory
-	orders, err := rs.ethereumClient.GetEscrowOrders(
-		ctx,
-		rs.config.Contracts.Ethereum.EscrowFactory,
-		rs.lastEthereumBlock,
-	)
+	orders, err := rs.fetchEthereumOrders(ctx, rs.lastEthereumBlock+1, latestBlock)
 	if err != nil {
 		return fmt.Errorf("failed to get Ethereum orders: %w", err)
 	}
 
-	// Process new orders
+	// Queue new orders until they are confirmed. A failed token lookup leaves
+	// the block range to be scanned again
 	for _, ethOrder := range orders {
-		order := rs.convertEthereumOrderToOrder(&ethOrder)
+		order, err := rs.convertEthereumOrderToOrder(ctx, &ethOrder)
+		if err != nil {
+			return fmt.Errorf("failed to convert Ethereum order %s: %w", ethOrder.ID, err)
+		}
+		rs.ethereumPending.Add(order, ethOrder.ID, ethOrder.BlockNumber)
+	}
+	atomic.StoreUint64(&rs.lastEthereumBlock, latestBlock)
+
+	// Drop orders whose creation tx was reorged out before promoting the rest
+	dropped, err := rs.ethereumPending.Refresh(ctx, rs.ethereumOrders.GetTransactionBlock)
+	if err != nil {
+		return fmt.Errorf("failed to refresh pending Ethereum orders: %w", err)
+	}
+	for _, order := range dropped {
+		rs.logger.Warn("Dropped Ethereum order removed by reorg", zap.String("order_id", order.ID))
+	}
+
+	// An escrow for a swap already being tracked is the destination escrow the
+	// relayer deployed for it
+	confirmed := rs.ethereumPending.Promote(latestBlock)
+	for _, order := range confirmed {
+		if rs.orderManager.MergeOrder(order) {
+			continue
+		}
 		rs.orderManager.AddOrder(order)
 	}
 
-	rs.lastEthereumBlock = latestBlock
+	// Pending orders only live in memory, so a restart must scan their blocks again
+	checkpoint := latestBlock
+	if oldest, found := rs.ethereumPending.OldestBlock(); found && oldest <= checkpoint {
+		checkpoint = 0
+		if oldest > 0 {
+			checkpoint = oldest - 1
+		}
+	}
+	atomic.StoreUint64(&rs.ethereumCheckpoint, checkpoint)
+
 	rs.logger.Debug("Scanned Ethereum orders",
 		zap.Uint64("latest_block", latestBlock),
-		zap.Int("new_orders", len(orders)))
+		zap.Int("new_orders", len(confirmed)),
+		zap.Int("pending_orders", rs.ethereumPending.Len()))
 
 	return nil
 }
 
 // processOrderMatching processes order matching logic
 func (rs *RelayerService) processOrderMatching(ctx context.Context) {
-	ticker := time.NewTicker(rs.config.Relayer.OrderUpdateInterval)
+	ticker := newJitterTicker(rs.config.Relayer.OrderUpdateInterval, rs.config.Relayer.PollJitter)
 	defer ticker.Stop()
 
 	rs.logger.Info("Starting order matching processor")
@@ -318,32 +638,56 @@ func (rs *RelayerService) processOrderMatching(ctx context.Context) {
 	}
 }
 
-// matchOrders attempts to match orders
+// matchOrders matches complementary orders whose prices cross and queues them
+// for execution
 func (rs *RelayerService) matchOrders(ctx context.Context) {
-	activeOrders := rs.orderManager.GetActiveOrders()
-	
-	// Simple matching logic - in practice, this would be more sophisticated
-	for _, order := range activeOrders {
-		if order.Status == order_manager.OrderStatusActive {
-			// Check if order conditions are met for execution
-			if rs.canExecuteOrder(order) {
-				order.Status = order_manager.OrderStatusMatched
-				rs.logger.Info("Order matched for execution", zap.String("order_id", order.ID))
+	var orders []*order_manager.Order
+	for _, order := range rs.orderManager.GetOrdersByStatus(order_manager.OrderStatusActive) {
+		if rs.canExecuteOrder(order) {
+			orders = append(orders, order)
+		}
+	}
+
+	matcher := rs.matcher
+	if matcher == nil {
+		matcher = matching.PriceTimeMatcher{}
+	}
+
+	for _, match := range matcher.Match(orders) {
+		fills := map[string]*big.Int{
+			match.MakerOrderID: match.MakerAmount,
+			match.TakerOrderID: match.TakerAmount,
+		}
+		for _, orderID := range []string{match.MakerOrderID, match.TakerOrderID} {
+			if order, exists := rs.orderManager.GetOrder(orderID); exists {
+				// Partially fillable orders only withdraw the matched amount
+				if order.PartialFill != nil && order.PartialFill.AllowPartialFill {
+					rs.orderManager.SetPendingFill(order, fills[orderID])
+				}
+				rs.orderManager.SetStatus(order, order_manager.OrderStatusMatched,
+					fmt.Sprintf("matched %s with %s", match.MakerOrderID, match.TakerOrderID), "")
+				rs.orderManager.QueueOrder(order)
 			}
 		}
+
+		rs.logger.Info("Orders matched for execution",
+			zap.String("maker_order_id", match.MakerOrderID),
+			zap.String("taker_order_id", match.TakerOrderID),
+			zap.String("maker_amount", match.MakerAmount.String()),
+			zap.String("taker_amount", match.TakerAmount.String()),
+			zap.String("price", match.Price.FloatString(18)))
 	}
 }
 
-// canExecuteOrder checks if an order can be executed
+// canExecuteOrder checks if an order can be offered for matching
 func (rs *RelayerService) canExecuteOrder(order *order_manager.Order) bool {
-	// Simplified logic - check if both escrows are funded
-	// In practice, you would query both chains to verify the state
-	return order.SourceEscrowAddr != "" && order.DestEscrowAddr != ""
+	// The maker's funds must already be locked in the source escrow
+	return order.SourceEscrowAddr != ""
 }
 
 // healthCheck performs periodic health checks
 func (rs *RelayerService) healthCheck(ctx context.Context) {
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := newJitterTicker(1*time.Minute, rs.config.Relayer.PollJitter)
 	defer ticker.Stop()
 
 	for {
@@ -360,27 +704,73 @@ func (rs *RelayerService) healthCheck(ctx context.Context) {
 
 // performHealthCheck performs a health check
 func (rs *RelayerService) performHealthCheck(ctx context.Context) {
-	// Check Cronos connection
-	_, err := rs.cronosClient.GetLatestBlock(ctx)
-	if err != nil {
-		rs.logger.Error("Cronos health check failed", zap.Error(err))
-	}
+	// Check both connections and how far the scanners trail the chain tips
+	rs.checkBlockLags(ctx)
 
-	// Check Ethereum connection
-	_, err = rs.ethereumClient.GetLatestBlock(ctx)
-	if err != nil {
-		rs.logger.Error("Ethereum health check failed", zap.Error(err))
-	}
+	// Resync nonces left behind by transactions dropped from the mempool
+	rs.reconcileNonces(ctx)
 
 	// Log order statistics
 	stats := rs.orderManager.GetOrderStats()
 	rs.logger.Info("Order manager statistics", zap.Any("stats", stats))
 }
 
-// convertCronosOrderToOrder converts a Cronos order to the internal Order format
-func (rs *RelayerService) convertCronosOrderToOrder(cronosOrder *cronos_client.EscrowOrder) *order_manager.Order {
+// reconcileNonces resyncs the relayer accounts' nonces on every EVM chain
+// that drifted from the chain's. Cronos needs no reconciliation, since its
+// account sequence is reloaded before every transaction
+func (rs *RelayerService) reconcileNonces(ctx context.Context) {
+	for name, chain := range rs.chains {
+		resynced, err := chain.ReconcileNonces(ctx)
+		if err != nil {
+			rs.logger.Warn("Failed to reconcile nonces", zap.String("chain", name), zap.Error(err))
+			continue
+		}
+		if resynced > 0 {
+			rs.logger.Info("Resynced account nonces", zap.String("chain", name), zap.Int("accounts", resynced))
+		}
+	}
+}
+
+// monitorStuckTransactions periodically replaces the relayer's EVM
+// transactions that stayed unmined past their chain's gas_bump.stuck_timeout
+func (rs *RelayerService) monitorStuckTransactions(ctx context.Context) {
+	ticker := newJitterTicker(rs.config.Relayer.BlockPollInterval, rs.config.Relayer.PollJitter)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-rs.stopChan:
+			return
+		case <-ticker.C:
+			rs.bumpStuckTransactions(ctx)
+		}
+	}
+}
+
+// bumpStuckTransactions resends the stuck transactions of every EVM chain
+// with a higher fee. Cronos transactions are not bumped
+func (rs *RelayerService) bumpStuckTransactions(ctx context.Context) {
+	for name, chain := range rs.chains {
+		replaced, err := chain.BumpStuckTransactions(ctx)
+		if err != nil {
+			rs.logger.Warn("Failed to check for stuck transactions", zap.String("chain", name), zap.Error(err))
+			continue
+		}
+		if replaced > 0 {
+			rs.logger.Info("Replaced stuck transactions", zap.String("chain", name), zap.Int("transactions", replaced))
+		}
+	}
+}
+
+// convertCronosOrderToOrder converts a Cronos order to the internal Order format.
+// Orders are keyed by the swap's canonical ID so both escrows of a swap map
+// to the same order. Amounts that don't parse are rejected rather than left
+// unset on the order
+func (rs *RelayerService) convertCronosOrderToOrder(cronosOrder *cronos_client.EscrowOrder) (*order_manager.Order, error) {
 	order := &order_manager.Order{
-		ID:               cronosOrder.ID,
+		ID:               order_manager.OrderID(cronosOrder.SecretHash, "cronos", "ethereum"),
 		Type:             order_manager.OrderTypeCronosToEthereum,
 		Status:           order_manager.OrderStatus(cronosOrder.Status),
 		SourceChain:      "cronos",
@@ -389,47 +779,58 @@ func (rs *RelayerService) convertCronosOrderToOrder(cronosOrder *cronos_client.E
 		Taker:            cronosOrder.Taker,
 		SecretHash:       cronosOrder.SecretHash,
 		Timelock:         cronosOrder.Timelock,
+		SourceEscrowAddr: cronosOrder.Address,
 		CreatedAt:        time.Unix(int64(cronosOrder.CreatedAt), 0),
 		UpdatedAt:        time.Now(),
 		ExpiresAt:        time.Unix(int64(cronosOrder.Timelock), 0),
 	}
 
 	// Set source asset info
-	if amount, ok := new(big.Int).SetString(cronosOrder.DepositedAmount, 10); ok {
-		order.SourceAsset = order_manager.AssetInfo{
-			Symbol:  cronosOrder.DepositedDenom,
-			Amount:  amount,
-			Decimals: 18, // Default to 18 decimals
-		}
+	depositedAmount, err := parseAmount("deposited_amount", cronosOrder.DepositedAmount)
+	if err != nil {
+		return nil, err
+	}
+	order.SourceAsset = order_manager.AssetInfo{
+		Symbol:  rs.config.CanonicalSymbol(cronosOrder.DepositedDenom),
+		Amount:  depositedAmount,
+		Decimals: rs.assetDecimals(cronosOrder.DepositedDenom),
 	}
 
 	// Set destination asset info
-	if amount, ok := new(big.Int).SetString(cronosOrder.DstAmount, 10); ok {
-		order.DestinationAsset = order_manager.AssetInfo{
-			Symbol:  cronosOrder.DstAsset,
-			Amount:  amount,
-			Decimals: 18, // Default to 18 decimals
-		}
+	dstAmount, err := parseAmount("dst_amount", cronosOrder.DstAmount)
+	if err != nil {
+		return nil, err
+	}
+	order.DestinationAsset = order_manager.AssetInfo{
+		Symbol:  rs.config.CanonicalSymbol(cronosOrder.DstAsset),
+		Amount:  dstAmount,
+		Decimals: rs.assetDecimals(cronosOrder.DstAsset),
 	}
 
 	// Set Dutch auction parameters if present
 	if cronosOrder.InitialPrice != "" {
-		if initialPrice, ok := new(big.Int).SetString(cronosOrder.InitialPrice, 10); ok {
-			order.DutchAuction = &order_manager.DutchAuctionParams{
-				InitialPrice: initialPrice,
-				StartTime:    time.Unix(int64(cronosOrder.CreatedAt), 0),
-				Duration:     rs.config.DutchAuction.MaxAuctionDuration,
-			}
+		initialPrice, err := parseAmount("initial_price", cronosOrder.InitialPrice)
+		if err != nil {
+			return nil, err
+		}
+		order.DutchAuction = &order_manager.DutchAuctionParams{
+			InitialPrice: initialPrice,
+			StartTime:    time.Unix(int64(cronosOrder.CreatedAt), 0),
+			Duration:     rs.config.DutchAuction.MaxAuctionDuration,
+			CurveType:    rs.config.DutchAuction.DefaultCurveType,
+		}
 
-			if minPrice, ok := new(big.Int).SetString(cronosOrder.MinimumPrice, 10); ok {
-				order.DutchAuction.MinimumPrice = minPrice
-			}
+		if order.DutchAuction.MinimumPrice, err = parseOptionalAmount("minimum_price", cronosOrder.MinimumPrice); err != nil {
+			return nil, err
+		}
+		if order.DutchAuction.DecayRate, err = parseOptionalAmount("price_decay_rate", cronosOrder.PriceDecayRate); err != nil {
+			return nil, err
+		}
 
-			if decayRate, ok := new(big.Int).SetString(cronosOrder.PriceDecayRate, 10); ok {
-				order.DutchAuction.DecayRate = decayRate
-			}
+		order.CurrentPrice = initialPrice
 
-			order.CurrentPrice = initialPrice
+		if err := rs.enforceAuctionDuration(order.DutchAuction); err != nil {
+			return nil, err
 		}
 	}
 
@@ -439,26 +840,87 @@ func (rs *RelayerService) convertCronosOrderToOrder(cronosOrder *cronos_client.E
 			AllowPartialFill: true,
 		}
 
-		if filledAmount, ok := new(big.Int).SetString(cronosOrder.FilledAmount, 10); ok {
-			order.PartialFill.FilledAmount = filledAmount
+		if order.PartialFill.FilledAmount, err = parseAmount("filled_amount", cronosOrder.FilledAmount); err != nil {
+			return nil, err
 		}
-
-		if remainingAmount, ok := new(big.Int).SetString(cronosOrder.RemainingAmount, 10); ok {
-			order.PartialFill.RemainingAmount = remainingAmount
+		if order.PartialFill.RemainingAmount, err = parseAmount("remaining_amount", cronosOrder.RemainingAmount); err != nil {
+			return nil, err
 		}
-
-		if minFillAmount, ok := new(big.Int).SetString(cronosOrder.MinimumFillAmount, 10); ok {
-			order.PartialFill.MinimumFillAmount = minFillAmount
+		if order.PartialFill.MinimumFillAmount, err = parseOptionalAmount("minimum_fill_amount", cronosOrder.MinimumFillAmount); err != nil {
+			return nil, err
 		}
 	}
 
-	return order
+	return order, nil
+}
+
+// enforceAuctionDuration checks that an auction's price decays to its minimum
+// within dutch_auction.max_auction_duration. Longer auctions are rejected, or
+// cut off at the limit when dutch_auction.clamp_auction_duration is set. A
+// zero limit accepts any auction
+func (rs *RelayerService) enforceAuctionDuration(params *order_manager.DutchAuctionParams) error {
+	limit := rs.config.DutchAuction.MaxAuctionDuration
+	seconds := auctionDecaySeconds(params)
+	if limit <= 0 || seconds == nil || seconds.Cmp(big.NewInt(int64(limit/time.Second))) <= 0 {
+		return nil
+	}
+
+	if !rs.config.DutchAuction.ClampAuctionDuration {
+		return fmt.Errorf("auction decays for %ss, longer than max_auction_duration %s", seconds, limit)
+	}
+	params.Duration = limit
+	return nil
+}
+
+// auctionDecaySeconds returns how many seconds an auction's price takes to
+// fall from its initial to its minimum price, or nil if it never falls
+func auctionDecaySeconds(params *order_manager.DutchAuctionParams) *big.Int {
+	if params.DecayRate == nil || params.DecayRate.Sign() <= 0 {
+		return nil
+	}
+
+	spread := new(big.Int).Set(params.InitialPrice)
+	if params.MinimumPrice != nil {
+		spread.Sub(spread, params.MinimumPrice)
+	}
+	if spread.Sign() <= 0 {
+		return new(big.Int)
+	}
+	if params.CurveType == config.AuctionCurveExponential {
+		return order_manager.ExponentialDecaySeconds(spread, params.DecayRate)
+	}
+
+	// A final partial step still takes a second
+	seconds, rem := new(big.Int).QuoRem(spread, params.DecayRate, new(big.Int))
+	if rem.Sign() > 0 {
+		seconds.Add(seconds, big.NewInt(1))
+	}
+	return seconds
+}
+
+// parseAmount parses a non-negative base 10 amount reported by a contract
+func parseAmount(field, value string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s %q", field, value)
+	}
+	return amount, nil
+}
+
+// parseOptionalAmount parses an amount that may be left empty, returning nil
+// if it is
+func parseOptionalAmount(field, value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	return parseAmount(field, value)
 }
 
-// convertEthereumOrderToOrder converts an Ethereum order to the internal Order format
-func (rs *RelayerService) convertEthereumOrderToOrder(ethOrder *ethereum_client.EscrowOrder) *order_manager.Order {
+// convertEthereumOrderToOrder converts an Ethereum order to the internal Order
+// format, reading the symbol and decimals of ERC20 deposits from the token
+func (rs *RelayerService) convertEthereumOrderToOrder(ctx context.Context, ethOrder *ethereum_client.EscrowOrder) (*order_manager.Order, error) {
 	order := &order_manager.Order{
-		ID:               ethOrder.ID,
+		ID:               order_manager.OrderID(ethOrder.SecretHash, "ethereum", "cronos"),
 		Type:             order_manager.OrderTypeEthereumToCronos,
 		Status:           order_manager.OrderStatus(ethOrder.Status),
 		SourceChain:      "ethereum",
@@ -468,26 +930,64 @@ func (rs *RelayerService) convertEthereumOrderToOrder(ethOrder *ethereum_client.
 		SecretHash:       ethOrder.SecretHash,
 		Timelock:         ethOrder.Timelock,
 		SourceEscrowAddr: ethOrder.EscrowAddress,
+		SourceImmutables: ethOrder.SrcImmutables,
+		SourceBlock:      ethOrder.BlockNumber,
 		CreatedAt:        time.Unix(int64(ethOrder.CreatedAt), 0),
 		UpdatedAt:        time.Now(),
 		ExpiresAt:        time.Unix(int64(ethOrder.Timelock), 0),
 	}
 
-	// Set source asset info
+	// Set source asset info. Fee-on-transfer tokens leave the escrow with
+	// less than the deposited amount, and only what it holds can be withdrawn
+	sourceAmount := ethOrder.DepositedAmount
+	if ethOrder.ReceivedAmount != nil {
+		sourceAmount = ethOrder.ReceivedAmount
+	}
 	order.SourceAsset = order_manager.AssetInfo{
-		Symbol:   "ETH", // Default to ETH, could be ERC20 token
+		Symbol:   rs.config.CanonicalSymbol("ETH"),
 		Address:  ethOrder.TokenAddress,
-		Amount:   ethOrder.DepositedAmount,
-		Decimals: 18,
+		Amount:   sourceAmount,
+		Decimals: rs.assetDecimals("ETH"),
+	}
+	if !ethereum_client.IsNativeToken(ethOrder.TokenAddress) {
+		token, err := rs.tokens.GetTokenMetadata(ctx, ethOrder.TokenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metadata of token %s: %w", ethOrder.TokenAddress, err)
+		}
+		order.SourceAsset.Symbol = rs.config.CanonicalSymbol(token.Symbol)
+		order.SourceAsset.Decimals = int(token.Decimals)
+
+		// Tokens are only matched to configured assets by contract, since
+		// anyone can deploy a token with a well-known symbol
+		if asset, ok := rs.config.AssetByContract(ethOrder.TokenAddress); ok {
+			order.SourceAsset.Decimals = asset.Decimals
+		}
 	}
 
 	// Set destination asset info
 	order.DestinationAsset = order_manager.AssetInfo{
-		Symbol:   ethOrder.SrcAsset,
+		Symbol:   rs.config.CanonicalSymbol(ethOrder.SrcAsset),
 		Amount:   ethOrder.SrcAmount,
-		Decimals: 18,
+		Decimals: rs.assetDecimals(ethOrder.SrcAsset),
 	}
 
-	return order
+	return order, nil
+}
+
+// defaultAssetDecimals is assumed for assets that aren't configured and
+// whose token can't be asked
+const defaultAssetDecimals = 18
+
+// assetDecimals returns the configured decimals of an asset, configured
+// under either its raw denom or its canonical symbol, or
+// defaultAssetDecimals for assets that aren't configured
+func (rs *RelayerService) assetDecimals(denom string) int {
+	if asset, ok := rs.config.Asset(denom); ok {
+		return asset.Decimals
+	}
+	if asset, ok := rs.config.Asset(rs.config.CanonicalSymbol(denom)); ok {
+		return asset.Decimals
+	}
+	return defaultAssetDecimals
 }
 
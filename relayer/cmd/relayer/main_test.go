@@ -0,0 +1,1572 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/ethereum_client"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/matching"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/rpc_retry"
+)
+
+// fakeTokens serves token metadata from a map keyed by address
+type fakeTokens map[string]ethereum_client.TokenMetadata
+
+func (f fakeTokens) GetTokenMetadata(ctx context.Context, tokenAddr string) (ethereum_client.TokenMetadata, error) {
+	metadata, ok := f[tokenAddr]
+	if !ok {
+		return ethereum_client.TokenMetadata{}, errors.New("unknown token")
+	}
+	return metadata, nil
+}
+
+const testTokenAddress = "0x2222222222222222222222222222222222222222"
+
+func newTestRelayerService() *RelayerService {
+	return &RelayerService{
+		config: &config.Config{},
+		tokens: fakeTokens{testTokenAddress: {Symbol: "USDC", Decimals: 6}},
+	}
+}
+
+func TestConvertEthereumOrderToOrder(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		tokenAddress string
+		wantSymbol   string
+		wantDecimals int
+	}{
+		{name: "native ETH", tokenAddress: "0x0000000000000000000000000000000000000000", wantSymbol: "ETH", wantDecimals: 18},
+		{name: "no token address", tokenAddress: "", wantSymbol: "ETH", wantDecimals: 18},
+		{name: "ERC20 token", tokenAddress: testTokenAddress, wantSymbol: "USDC", wantDecimals: 6},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			order, err := newTestRelayerService().convertEthereumOrderToOrder(context.Background(), &ethereum_client.EscrowOrder{
+				ID:              "0xorder",
+				DepositedAmount: big.NewInt(1000000),
+				TokenAddress:    tc.tokenAddress,
+				SrcAmount:       big.NewInt(5),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if order.SourceAsset.Symbol != tc.wantSymbol || order.SourceAsset.Decimals != tc.wantDecimals {
+				t.Fatalf("expected %s with %d decimals, got %s with %d",
+					tc.wantSymbol, tc.wantDecimals, order.SourceAsset.Symbol, order.SourceAsset.Decimals)
+			}
+			if order.SourceAsset.Address != tc.tokenAddress || order.SourceAsset.Amount.Int64() != 1000000 {
+				t.Fatalf("unexpected source asset: %+v", order.SourceAsset)
+			}
+		})
+	}
+}
+
+func TestConvertOrdersConfiguredDecimals(t *testing.T) {
+	rs := newTestRelayerService()
+	rs.config.Assets = map[string]config.AssetMeta{
+		"usdc":    {Decimals: 6},
+		"weth":    {Decimals: 18},
+		"basecro": {Decimals: 8},
+		"usdt":    {Decimals: 6, ContractAddress: "0x4444444444444444444444444444444444444444"},
+	}
+	rs.tokens = fakeTokens{
+		testTokenAddress: {Symbol: "WETH", Decimals: 18},
+		// The token reports 18 decimals, but the configured 6 win
+		"0x4444444444444444444444444444444444444444": {Symbol: "USDT", Decimals: 18},
+	}
+
+	cronosOrder, err := rs.convertCronosOrderToOrder(&cronos_client.EscrowOrder{
+		ID:              "salt-1",
+		SecretHash:      "0x01",
+		DepositedAmount: "100000000",
+		DepositedDenom:  "basecro",
+		DstAsset:        "USDC",
+		DstAmount:       "5000000",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cronosOrder.SourceAsset.Decimals != 8 || cronosOrder.DestinationAsset.Decimals != 6 {
+		t.Fatalf("expected basecro with 8 decimals for USDC with 6, got %d and %d",
+			cronosOrder.SourceAsset.Decimals, cronosOrder.DestinationAsset.Decimals)
+	}
+
+	for _, tc := range []struct {
+		name         string
+		tokenAddress string
+		srcAsset     string
+		wantSource   int
+		wantDest     int
+	}{
+		{name: "18 decimal token for 6 decimal asset", tokenAddress: testTokenAddress, srcAsset: "USDC", wantSource: 18, wantDest: 6},
+		{name: "configured token contract", tokenAddress: "0x4444444444444444444444444444444444444444", srcAsset: "WETH", wantSource: 6, wantDest: 18},
+		{name: "unconfigured asset", tokenAddress: testTokenAddress, srcAsset: "DAI", wantSource: 18, wantDest: 18},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			order, err := rs.convertEthereumOrderToOrder(context.Background(), &ethereum_client.EscrowOrder{
+				ID:              "0xorder",
+				SecretHash:      "0x02",
+				DepositedAmount: big.NewInt(1000000),
+				TokenAddress:    tc.tokenAddress,
+				SrcAsset:        tc.srcAsset,
+				SrcAmount:       big.NewInt(5),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if order.SourceAsset.Decimals != tc.wantSource || order.DestinationAsset.Decimals != tc.wantDest {
+				t.Fatalf("expected %d and %d decimals, got %d and %d",
+					tc.wantSource, tc.wantDest, order.SourceAsset.Decimals, order.DestinationAsset.Decimals)
+			}
+		})
+	}
+}
+
+func TestConvertOrdersCanonicalSymbols(t *testing.T) {
+	rs := newTestRelayerService()
+	rs.config.AssetAliases = map[string]string{"basecro": "CRO", "wcro": "CRO", "weth": "ETH"}
+	rs.config.Assets = map[string]config.AssetMeta{"basecro": {Decimals: 8}}
+	rs.tokens = fakeTokens{testTokenAddress: {Symbol: "WETH", Decimals: 18}}
+
+	cronosOrder, err := rs.convertCronosOrderToOrder(&cronos_client.EscrowOrder{
+		SecretHash:      "0x01",
+		DepositedAmount: "100000000",
+		DepositedDenom:  "basecro",
+		DstAsset:        "weth",
+		DstAmount:       "5000000",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cronosOrder.SourceAsset.Symbol != "CRO" || cronosOrder.DestinationAsset.Symbol != "ETH" {
+		t.Fatalf("expected CRO for ETH, got %s for %s", cronosOrder.SourceAsset.Symbol, cronosOrder.DestinationAsset.Symbol)
+	}
+	// Decimals configured under the raw denom still apply
+	if cronosOrder.SourceAsset.Decimals != 8 {
+		t.Fatalf("expected basecro's 8 decimals, got %d", cronosOrder.SourceAsset.Decimals)
+	}
+
+	// The complementary orders, funded in native ETH or WETH, sit on the
+	// reverse pair of the Cronos order
+	for _, tokenAddress := range []string{"", testTokenAddress} {
+		ethOrder, err := rs.convertEthereumOrderToOrder(context.Background(), &ethereum_client.EscrowOrder{
+			SecretHash:      "0x02",
+			DepositedAmount: big.NewInt(5000000),
+			TokenAddress:    tokenAddress,
+			SrcAsset:        "wcro",
+			SrcAmount:       big.NewInt(100000000),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ethOrder.SourceAsset.Symbol != "ETH" || ethOrder.DestinationAsset.Symbol != "CRO" {
+			t.Fatalf("expected ETH for CRO from token %q, got %s for %s",
+				tokenAddress, ethOrder.SourceAsset.Symbol, ethOrder.DestinationAsset.Symbol)
+		}
+	}
+}
+
+func TestConvertEthereumOrderToOrderReceivedAmount(t *testing.T) {
+	order, err := newTestRelayerService().convertEthereumOrderToOrder(context.Background(), &ethereum_client.EscrowOrder{
+		ID:              "0xorder",
+		SecretHash:      "0x03",
+		DepositedAmount: big.NewInt(1000),
+		ReceivedAmount:  big.NewInt(990),
+		TokenAddress:    testTokenAddress,
+		SrcAsset:        "USDC",
+		SrcAmount:       big.NewInt(5),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.SourceAsset.Amount.Cmp(big.NewInt(990)) != 0 {
+		t.Fatalf("expected the received amount as source amount, got %s", order.SourceAsset.Amount)
+	}
+}
+
+func TestConvertEthereumOrderToOrderUnknownToken(t *testing.T) {
+	_, err := newTestRelayerService().convertEthereumOrderToOrder(context.Background(), &ethereum_client.EscrowOrder{
+		ID:              "0xorder",
+		DepositedAmount: big.NewInt(1),
+		TokenAddress:    "0x3333333333333333333333333333333333333333",
+	})
+	if err == nil {
+		t.Fatal("expected an error for a token without metadata")
+	}
+}
+
+func TestConvertedOrdersShareCanonicalID(t *testing.T) {
+	rs := newTestRelayerService()
+	hashLock := "0x9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658"
+
+	cronosOrder, err := rs.convertCronosOrderToOrder(&cronos_client.EscrowOrder{
+		ID:              "salt-1",
+		Address:         "crc1escrow",
+		SecretHash:      hashLock,
+		DepositedAmount: "1000",
+		DstAmount:       "5",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ethOrder, err := rs.convertEthereumOrderToOrder(context.Background(), &ethereum_client.EscrowOrder{
+		ID:              "0xtxhash",
+		SecretHash:      hashLock,
+		EscrowAddress:   "0xescrow",
+		DepositedAmount: big.NewInt(5),
+		SrcAmount:       big.NewInt(1000),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cronosOrder.ID != ethOrder.ID {
+		t.Fatalf("expected both halves of the swap to share an ID, got %s and %s", cronosOrder.ID, ethOrder.ID)
+	}
+	if cronosOrder.SourceEscrowAddr != "crc1escrow" {
+		t.Fatalf("expected the Cronos escrow address to be kept, got %q", cronosOrder.SourceEscrowAddr)
+	}
+
+	other, err := rs.convertEthereumOrderToOrder(context.Background(), &ethereum_client.EscrowOrder{
+		ID:              "0xtxhash",
+		SecretHash:      "0x01",
+		DepositedAmount: big.NewInt(5),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other.ID == cronosOrder.ID {
+		t.Fatal("expected a different swap to get a different ID")
+	}
+}
+
+// newValidCronosOrder returns a Dutch auction, partially fillable Cronos order
+func newValidCronosOrder() *cronos_client.EscrowOrder {
+	return &cronos_client.EscrowOrder{
+		ID:                "salt-1",
+		Address:           "crc1escrow",
+		SecretHash:        "0x01",
+		DepositedAmount:   "1000",
+		DstAmount:         "5",
+		InitialPrice:      "100",
+		MinimumPrice:      "50",
+		PriceDecayRate:    "1",
+		AllowPartialFill:  true,
+		FilledAmount:      "0",
+		RemainingAmount:   "1000",
+		MinimumFillAmount: "10",
+	}
+}
+
+func TestConvertCronosOrderToOrderRejectsMalformedAmounts(t *testing.T) {
+	rs := newTestRelayerService()
+	if _, err := rs.convertCronosOrderToOrder(newValidCronosOrder()); err != nil {
+		t.Fatalf("expected valid order, got %v", err)
+	}
+
+	for _, tc := range []struct {
+		field  string
+		mutate func(o *cronos_client.EscrowOrder)
+	}{
+		{"deposited_amount", func(o *cronos_client.EscrowOrder) { o.DepositedAmount = "1e18" }},
+		{"deposited_amount", func(o *cronos_client.EscrowOrder) { o.DepositedAmount = "" }},
+		{"dst_amount", func(o *cronos_client.EscrowOrder) { o.DstAmount = "-5" }},
+		{"initial_price", func(o *cronos_client.EscrowOrder) { o.InitialPrice = "abc" }},
+		{"minimum_price", func(o *cronos_client.EscrowOrder) { o.MinimumPrice = "1.5" }},
+		{"price_decay_rate", func(o *cronos_client.EscrowOrder) { o.PriceDecayRate = "0x10" }},
+		{"filled_amount", func(o *cronos_client.EscrowOrder) { o.FilledAmount = "" }},
+		{"remaining_amount", func(o *cronos_client.EscrowOrder) { o.RemainingAmount = "lots" }},
+		{"minimum_fill_amount", func(o *cronos_client.EscrowOrder) { o.MinimumFillAmount = " 10" }},
+	} {
+		t.Run(tc.field, func(t *testing.T) {
+			cronosOrder := newValidCronosOrder()
+			tc.mutate(cronosOrder)
+
+			order, err := rs.convertCronosOrderToOrder(cronosOrder)
+			if err == nil || !strings.Contains(err.Error(), tc.field) {
+				t.Fatalf("expected an error naming %s, got %v", tc.field, err)
+			}
+			if order != nil {
+				t.Fatal("expected no order to be returned")
+			}
+		})
+	}
+
+	// Optional amounts may be left empty
+	cronosOrder := newValidCronosOrder()
+	cronosOrder.MinimumPrice = ""
+	cronosOrder.MinimumFillAmount = ""
+	order, err := rs.convertCronosOrderToOrder(cronosOrder)
+	if err != nil {
+		t.Fatalf("expected empty optional amounts to be accepted, got %v", err)
+	}
+	if order.DutchAuction.MinimumPrice != nil || order.PartialFill.MinimumFillAmount != nil {
+		t.Fatal("expected empty optional amounts to stay unset")
+	}
+}
+
+func TestConvertCronosOrderToOrderMaxAuctionDuration(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		limit        time.Duration
+		clamp        bool
+		wantErr      bool
+		wantDuration time.Duration
+	}{
+		// The valid order decays from 100 to 50, taking 50s at 1 per second
+		{name: "within limit", limit: time.Minute, wantDuration: time.Minute},
+		{name: "no limit", limit: 0},
+		{name: "over limit rejected", limit: 30 * time.Second, wantErr: true},
+		{name: "over limit clamped", limit: 30 * time.Second, clamp: true, wantDuration: 30 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := newTestRelayerService()
+			rs.config.DutchAuction.MaxAuctionDuration = tc.limit
+			rs.config.DutchAuction.ClampAuctionDuration = tc.clamp
+
+			order, err := rs.convertCronosOrderToOrder(newValidCronosOrder())
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "max_auction_duration") {
+					t.Fatalf("expected max_auction_duration error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if order.DutchAuction.Duration != tc.wantDuration {
+				t.Fatalf("expected duration %s, got %s", tc.wantDuration, order.DutchAuction.Duration)
+			}
+		})
+	}
+}
+
+func TestAuctionDecaySeconds(t *testing.T) {
+	for _, tc := range []struct {
+		initial, minimum, rate int64
+		noMinimum              bool
+		want                   int64
+		never                  bool
+	}{
+		{initial: 100, minimum: 50, rate: 1, want: 50},
+		{initial: 100, minimum: 50, rate: 3, want: 17},
+		{initial: 100, rate: 10, noMinimum: true, want: 10},
+		{initial: 50, minimum: 100, rate: 1, want: 0},
+		{initial: 100, minimum: 50, rate: 0, never: true},
+	} {
+		params := &order_manager.DutchAuctionParams{
+			InitialPrice: big.NewInt(tc.initial),
+			DecayRate:    big.NewInt(tc.rate),
+		}
+		if !tc.noMinimum {
+			params.MinimumPrice = big.NewInt(tc.minimum)
+		}
+
+		got := auctionDecaySeconds(params)
+		if tc.never {
+			if got != nil {
+				t.Fatalf("expected %+v to never decay, got %s", tc, got)
+			}
+			continue
+		}
+		if got == nil || got.Int64() != tc.want {
+			t.Fatalf("expected %+v to decay in %ds, got %v", tc, tc.want, got)
+		}
+	}
+}
+
+func TestAuctionDecaySecondsExponential(t *testing.T) {
+	params := &order_manager.DutchAuctionParams{
+		InitialPrice: big.NewInt(1000),
+		MinimumPrice: big.NewInt(100),
+		DecayRate:    big.NewInt(10),
+		CurveType:    config.AuctionCurveExponential,
+	}
+	// A linear auction would reach its minimum after 90s
+	if got := auctionDecaySeconds(params); got == nil || got.Int64() != 613 {
+		t.Fatalf("expected the exponential auction to decay in 613s, got %v", got)
+	}
+}
+
+func TestQueueCronosOrderQuarantinesMalformedAmount(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	rs := newTestRelayerService()
+	rs.logger = zap.New(core)
+	rs.orderManager = order_manager.NewOrderManager(rs.config, nil, nil, zap.NewNop())
+	rs.cronosPending = order_manager.NewPendingOrders(1)
+
+	cronosOrder := newValidCronosOrder()
+	cronosOrder.DepositedAmount = "12abc"
+
+	// Rescans of the factory keep listing the escrow
+	rs.queueCronosOrder(cronosOrder, 10)
+	rs.queueCronosOrder(cronosOrder, 11)
+
+	if rs.cronosPending.Len() != 0 {
+		t.Fatalf("expected the malformed order not to be queued, got %d pending", rs.cronosPending.Len())
+	}
+	order, ok := rs.orderManager.GetOrder(order_manager.OrderID("0x01", "cronos", "ethereum"))
+	if !ok {
+		t.Fatal("expected the malformed order to be tracked")
+	}
+	if order.Status != order_manager.OrderStatusFailed || !strings.Contains(order.LastError, "12abc") {
+		t.Fatalf("expected the order to be failed with the offending value, got %s: %q", order.Status, order.LastError)
+	}
+	if entries := logs.FilterMessage("Quarantined Cronos order with malformed escrow").Len(); entries != 1 {
+		t.Fatalf("expected the order to be reported once, got %d", entries)
+	}
+}
+
+// fakeTip reports a fixed chain tip, or err when the node is down
+type fakeTip[T int64 | uint64] struct {
+	tip T
+	err error
+}
+
+func (f *fakeTip[T]) GetLatestBlock(ctx context.Context) (T, error) {
+	return f.tip, f.err
+}
+
+func TestCheckBlockLagsEscalates(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	ethereumTip := &fakeTip[uint64]{tip: 1000}
+
+	rs := newTestRelayerService()
+	rs.logger = zap.New(core)
+	rs.config.Relayer.MaxBlockLag = 100
+	rs.cronosTip = &fakeTip[int64]{tip: 510}
+	rs.ethereumTip = ethereumTip
+	rs.cronosLag = blockLag{chain: "cronos"}
+	rs.ethereumLag = blockLag{chain: "ethereum"}
+	rs.lastCronosBlock = 500
+	rs.lastEthereumBlock = 500
+
+	var levels []zapcore.Level
+	for i := 0; i < 4; i++ {
+		rs.checkBlockLags(context.Background())
+		// The Ethereum tip keeps running ahead of the stalled scanner
+		ethereumTip.tip += 50
+
+		var ethereumEntries []observer.LoggedEntry
+		for _, entry := range logs.TakeAll() {
+			if entry.ContextMap()["chain"] == "ethereum" {
+				ethereumEntries = append(ethereumEntries, entry)
+			}
+		}
+		if len(ethereumEntries) != 1 {
+			t.Fatalf("expected one Ethereum lag entry, got %d", len(ethereumEntries))
+		}
+		levels = append(levels, ethereumEntries[0].Level)
+	}
+
+	expected := []zapcore.Level{zapcore.WarnLevel, zapcore.WarnLevel, zapcore.ErrorLevel, zapcore.ErrorLevel}
+	for i := range expected {
+		if levels[i] != expected[i] {
+			t.Fatalf("expected levels %v, got %v", expected, levels)
+		}
+	}
+
+	if rs.cronosLag.last != 10 || rs.cronosLag.growing != 0 {
+		t.Fatalf("expected Cronos to be within the limit, got %+v", rs.cronosLag)
+	}
+	if got := blockLagMetrics.Get("ethereum").String(); got != "650" {
+		t.Fatalf("expected published Ethereum lag 650, got %s", got)
+	}
+}
+
+func TestBlockLagObserve(t *testing.T) {
+	l := blockLag{chain: "ethereum"}
+
+	if lag, level := l.observe(1000, 500, 0); lag != 500 || level != zapcore.DebugLevel {
+		t.Fatalf("expected disabled alert to log at debug, got lag %d at %v", lag, level)
+	}
+	// A shrinking lag resets the escalation
+	l.observe(1000, 500, 100)
+	l.observe(1100, 500, 100)
+	if _, level := l.observe(1100, 550, 100); level != zapcore.WarnLevel || l.growing != 0 {
+		t.Fatalf("expected shrinking lag to warn without escalating, got %v after %d growing checks", level, l.growing)
+	}
+	// A scanner ahead of a lagging node reports no lag
+	if lag, _ := l.observe(900, 1000, 100); lag != 0 {
+		t.Fatalf("expected no lag, got %d", lag)
+	}
+}
+
+func TestCheckBlockLagsSkipsChainWithOpenBreaker(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	rs := newTestRelayerService()
+	rs.logger = zap.New(core)
+	rs.config.Relayer.MaxBlockLag = 100
+	rs.cronosTip = &fakeTip[int64]{tip: 510}
+	rs.ethereumTip = &fakeTip[uint64]{tip: 1000}
+	rs.cronosLag = blockLag{chain: "cronos"}
+	rs.ethereumLag = blockLag{chain: "ethereum"}
+	rs.lastCronosBlock = 500
+	rs.lastEthereumBlock = 500
+
+	rs.ethereumBreaker = rpc_retry.NewBreaker("ethereum-health-test", 1, time.Hour, nil)
+	rs.ethereumBreaker.Record(chain_errors.ErrRPCUnavailable)
+
+	rs.checkBlockLags(context.Background())
+
+	entries := logs.FilterMessage("Chain is unhealthy, RPC circuit breaker is open").All()
+	if len(entries) != 1 || entries[0].ContextMap()["chain"] != "ethereum" {
+		t.Fatalf("expected Ethereum to be reported unhealthy, got %+v", entries)
+	}
+	if rs.ethereumLag.last != 0 {
+		t.Fatalf("expected the Ethereum lag check to be skipped, got %+v", rs.ethereumLag)
+	}
+	if rs.cronosLag.last != 10 {
+		t.Fatalf("expected the Cronos lag to be checked, got %+v", rs.cronosLag)
+	}
+	if chainHealthMetrics.Get("ethereum").String() != "0" || chainHealthMetrics.Get("cronos").String() != "1" {
+		t.Fatalf("unexpected chain health metrics %s", chainHealthMetrics.String())
+	}
+}
+
+func TestCheckStartupHealth(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	cronosDown := &fakeTip[int64]{err: chain_errors.ErrRPCUnavailable}
+	ethereumUp := &fakeTip[uint64]{tip: 1000}
+
+	// One chain down starts degraded with that chain marked unhealthy
+	if err := checkStartupHealth(context.Background(), cronosDown, ethereumUp, zap.New(core)); err != nil {
+		t.Fatalf("expected startup to continue with one chain down, got %v", err)
+	}
+	if chainHealthMetrics.Get("cronos").String() != "0" || chainHealthMetrics.Get("ethereum").String() != "1" {
+		t.Fatalf("unexpected chain health metrics %s", chainHealthMetrics.String())
+	}
+	if logs.FilterField(zap.String("chain", "cronos")).Len() != 1 {
+		t.Fatalf("expected a warning for the unreachable chain, got %v", logs.All())
+	}
+
+	ethereumDown := &fakeTip[uint64]{err: chain_errors.ErrRPCUnavailable}
+	if err := checkStartupHealth(context.Background(), cronosDown, ethereumDown, zap.NewNop()); err == nil {
+		t.Fatal("expected startup to fail with both chains down")
+	}
+}
+
+// hangingCronosNode never answers, returning only once ctx is done
+type hangingCronosNode struct{}
+
+func (hangingCronosNode) GetLatestBlock(ctx context.Context) (int64, error) {
+	<-ctx.Done()
+	return 0, ctx.Err()
+}
+
+func (hangingCronosNode) GetEscrowOrdersPage(ctx context.Context, factoryAddr string, startAfter string, limit uint32) ([]cronos_client.EscrowOrder, string, error) {
+	<-ctx.Done()
+	return nil, "", ctx.Err()
+}
+
+// hangingEthereumNode never answers, returning only once ctx is done
+type hangingEthereumNode struct{}
+
+func (hangingEthereumNode) GetLatestBlock(ctx context.Context) (uint64, error) {
+	<-ctx.Done()
+	return 0, ctx.Err()
+}
+
+func (hangingEthereumNode) GetEscrowOrders(ctx context.Context, factoryAddr string, fromBlock, toBlock uint64) ([]ethereum_client.EscrowOrder, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (hangingEthereumNode) GetTransactionBlock(ctx context.Context, txHash string) (uint64, bool, error) {
+	<-ctx.Done()
+	return 0, false, ctx.Err()
+}
+
+// pagingCronosNode lists source escrows named by their salts, sorted, in
+// pages like the factory, counting destination escrows towards each page
+// without returning them
+type pagingCronosNode struct {
+	hangingCronosNode
+	salts        []string
+	destinations map[string]bool
+	startAfters  []string
+}
+
+func (n *pagingCronosNode) GetEscrowOrdersPage(ctx context.Context, factoryAddr string, startAfter string, limit uint32) ([]cronos_client.EscrowOrder, string, error) {
+	n.startAfters = append(n.startAfters, startAfter)
+
+	var listed []string
+	for _, salt := range n.salts {
+		if salt > startAfter && uint32(len(listed)) < limit {
+			listed = append(listed, salt)
+		}
+	}
+
+	var orders []cronos_client.EscrowOrder
+	for _, salt := range listed {
+		if !n.destinations[salt] {
+			orders = append(orders, cronos_client.EscrowOrder{ID: salt})
+		}
+	}
+
+	next := ""
+	if uint32(len(listed)) == limit {
+		next = listed[len(listed)-1]
+	}
+	return orders, next, nil
+}
+
+func TestFetchCronosOrdersPaginates(t *testing.T) {
+	node := &pagingCronosNode{destinations: map[string]bool{}}
+	for i := 0; i < 2*cronosScanPageSize+7; i++ {
+		salt := fmt.Sprintf("salt-%03d", i)
+		node.salts = append(node.salts, salt)
+		// A page ending with a destination escrow still continues after it
+		if i == cronosScanPageSize-1 {
+			node.destinations[salt] = true
+		}
+	}
+
+	rs := newTestRelayerService()
+	rs.cronosOrders = node
+
+	orders, err := rs.fetchCronosOrders(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"", "salt-049", "salt-099"}; strings.Join(node.startAfters, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected pages after %q, got %q", want, node.startAfters)
+	}
+	if len(orders) != len(node.salts)-1 {
+		t.Fatalf("expected %d source escrows, got %d", len(node.salts)-1, len(orders))
+	}
+	seen := make(map[string]bool)
+	for _, order := range orders {
+		if seen[order.ID] || node.destinations[order.ID] {
+			t.Fatalf("unexpected escrow %s", order.ID)
+		}
+		seen[order.ID] = true
+	}
+}
+
+func TestFetchCronosOrdersExactPages(t *testing.T) {
+	node := &pagingCronosNode{}
+	for i := 0; i < cronosScanPageSize; i++ {
+		node.salts = append(node.salts, fmt.Sprintf("salt-%03d", i))
+	}
+
+	rs := newTestRelayerService()
+	rs.cronosOrders = node
+
+	// A full last page takes one more, empty, query to tell it was the last
+	orders, err := rs.fetchCronosOrders(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(orders) != cronosScanPageSize || len(node.startAfters) != 2 {
+		t.Fatalf("expected %d escrows in 2 queries, got %d in %d", cronosScanPageSize, len(orders), len(node.startAfters))
+	}
+}
+
+// saltKeyedFactory lists escrows by salt like the Cronos factory, with
+// addresses in a different order than their salts
+type saltKeyedFactory struct {
+	escrows     []cronos_client.EscrowInfo
+	startAfters []string
+}
+
+func (f *saltKeyedFactory) GetBlockTime(ctx context.Context, height int64) (time.Time, error) {
+	return time.Unix(0, 0), nil
+}
+
+func (f *saltKeyedFactory) ListEscrows(ctx context.Context, factoryAddr string, startAfter string, limit uint32) ([]cronos_client.EscrowInfo, error) {
+	f.startAfters = append(f.startAfters, startAfter)
+
+	var listed []cronos_client.EscrowInfo
+	for _, info := range f.escrows {
+		if info.Salt > startAfter && uint32(len(listed)) < limit {
+			listed = append(listed, info)
+		}
+	}
+	return listed, nil
+}
+
+func (f *saltKeyedFactory) GetEscrow(ctx context.Context, escrowAddr string) (*cronos_client.EscrowOrder, error) {
+	return &cronos_client.EscrowOrder{
+		Address:         escrowAddr,
+		SecretHash:      "0x" + strings.Repeat("ab", 32),
+		DepositedAmount: "1",
+		DstAmount:       "1",
+	}, nil
+}
+
+func TestScanCronosEscrowsPagesBySalt(t *testing.T) {
+	factory := &saltKeyedFactory{}
+	for i := 0; i < 2*reconcilePageSize+3; i++ {
+		factory.escrows = append(factory.escrows, cronos_client.EscrowInfo{
+			Address:    fmt.Sprintf("cro1escrow%03d", 2*reconcilePageSize+3-i),
+			EscrowType: cronos_client.EscrowTypeSource,
+			Salt:       fmt.Sprintf("salt-%03d", i),
+		})
+	}
+
+	sources, _, err := newTestRelayerService().scanCronosEscrows(context.Background(), factory, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"", "salt-049", "salt-099"}; strings.Join(factory.startAfters, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected pages after %q, got %q", want, factory.startAfters)
+	}
+	if len(sources) != len(factory.escrows) {
+		t.Fatalf("expected %d source escrows, got %d", len(factory.escrows), len(sources))
+	}
+	seen := make(map[string]bool)
+	for _, order := range sources {
+		if seen[order.SourceEscrowAddr] {
+			t.Fatalf("escrow %s scanned twice", order.SourceEscrowAddr)
+		}
+		seen[order.SourceEscrowAddr] = true
+	}
+}
+
+func TestSplitBlockRange(t *testing.T) {
+	for _, tc := range []struct {
+		from, to, size uint64
+		want           []blockRange
+	}{
+		{from: 1, to: 10, size: 5, want: []blockRange{{1, 5}, {6, 10}}},
+		{from: 1, to: 11, size: 5, want: []blockRange{{1, 5}, {6, 10}, {11, 11}}},
+		{from: 7, to: 7, size: 5, want: []blockRange{{7, 7}}},
+		{from: 1, to: 3, size: 0, want: []blockRange{{1, 1}, {2, 2}, {3, 3}}},
+	} {
+		got := splitBlockRange(tc.from, tc.to, tc.size)
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Fatalf("splitBlockRange(%d, %d, %d) = %v, want %v", tc.from, tc.to, tc.size, got, tc.want)
+		}
+	}
+}
+
+// countingEthereumNode lists one escrow per queried range, taking delay per
+// query, and records how many queries ran at once
+type countingEthereumNode struct {
+	hangingEthereumNode
+	delay  time.Duration
+	failAt uint64
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	queries     int
+}
+
+func (n *countingEthereumNode) GetEscrowOrders(ctx context.Context, factoryAddr string, fromBlock, toBlock uint64) ([]ethereum_client.EscrowOrder, error) {
+	n.mu.Lock()
+	n.inFlight++
+	n.queries++
+	if n.inFlight > n.maxInFlight {
+		n.maxInFlight = n.inFlight
+	}
+	n.mu.Unlock()
+
+	defer func() {
+		n.mu.Lock()
+		n.inFlight--
+		n.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(n.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if n.failAt != 0 && fromBlock <= n.failAt && n.failAt <= toBlock {
+		return nil, chain_errors.ErrRPCUnavailable
+	}
+	return []ethereum_client.EscrowOrder{{ID: fmt.Sprintf("0x%d", fromBlock), BlockNumber: fromBlock}}, nil
+}
+
+func TestFetchEthereumOrdersCatchesUpInParallel(t *testing.T) {
+	const workers = 4
+	node := &countingEthereumNode{delay: 20 * time.Millisecond}
+
+	rs := newTestRelayerService()
+	rs.logger = zap.NewNop()
+	rs.config.Relayer.LogScanBatchSize = 100
+	rs.config.Relayer.CatchupWorkers = workers
+	rs.ethereumOrders = node
+
+	orders, err := rs.fetchEthereumOrders(context.Background(), 1, 2000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if node.queries != 20 {
+		t.Fatalf("expected 20 batch queries, got %d", node.queries)
+	}
+	if node.maxInFlight != workers {
+		t.Fatalf("expected %d concurrent queries, got %d", workers, node.maxInFlight)
+	}
+
+	// Older escrows come first whatever order the batches finished in
+	if len(orders) != 20 {
+		t.Fatalf("expected 20 escrows, got %d", len(orders))
+	}
+	for i, order := range orders {
+		if want := uint64(i*100 + 1); order.BlockNumber != want {
+			t.Fatalf("escrow %d is from block %d, want %d", i, order.BlockNumber, want)
+		}
+	}
+}
+
+func TestFetchEthereumOrdersSerial(t *testing.T) {
+	node := &countingEthereumNode{}
+
+	rs := newTestRelayerService()
+	rs.config.Relayer.LogScanBatchSize = 100
+	rs.config.Relayer.CatchupWorkers = 1
+	rs.ethereumOrders = node
+
+	if _, err := rs.fetchEthereumOrders(context.Background(), 1, 2000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The client splits the range into log queries itself
+	if node.queries != 1 {
+		t.Fatalf("expected a single query, got %d", node.queries)
+	}
+}
+
+func TestFetchEthereumOrdersBatchFails(t *testing.T) {
+	node := &countingEthereumNode{delay: time.Millisecond, failAt: 1050}
+
+	rs := newTestRelayerService()
+	rs.logger = zap.NewNop()
+	rs.config.Relayer.LogScanBatchSize = 100
+	rs.config.Relayer.CatchupWorkers = 4
+	rs.ethereumOrders = node
+
+	orders, err := rs.fetchEthereumOrders(context.Background(), 1, 2000)
+	if !errors.Is(err, chain_errors.ErrRPCUnavailable) {
+		t.Fatalf("expected the batch error, got %v", err)
+	}
+	if orders != nil {
+		t.Fatalf("expected no escrows from a failed catch-up, got %d", len(orders))
+	}
+}
+
+func TestRunScanTimesOut(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	rs := newTestRelayerService()
+	rs.logger = zap.New(core)
+	rs.config.Relayer.ScanTimeout = 50 * time.Millisecond
+	rs.cronosOrders = hangingCronosNode{}
+	rs.ethereumOrders = hangingEthereumNode{}
+
+	for _, tc := range []struct {
+		chain string
+		scan  func(ctx context.Context) error
+	}{
+		{chain: "cronos", scan: rs.scanCronosOrders},
+		{chain: "ethereum", scan: rs.scanEthereumOrders},
+	} {
+		t.Run(tc.chain, func(t *testing.T) {
+			before := int64(0)
+			if metric := scanTimeoutMetrics.Get(tc.chain); metric != nil {
+				before = metric.(*expvar.Int).Value()
+			}
+
+			done := make(chan struct{})
+			go func() {
+				rs.runScan(context.Background(), tc.chain, tc.scan)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("scan did not return within the timeout")
+			}
+
+			if got := scanTimeoutMetrics.Get(tc.chain).(*expvar.Int).Value(); got != before+1 {
+				t.Fatalf("expected %d timeouts, got %d", before+1, got)
+			}
+			entries := logs.FilterMessage("Order scan timed out").FilterField(zap.String("chain", tc.chain)).All()
+			if len(entries) != 1 || entries[0].Level != zapcore.ErrorLevel {
+				t.Fatalf("expected one timeout error, got %+v", entries)
+			}
+		})
+	}
+}
+
+func TestRunScanWithoutTimeout(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	rs := newTestRelayerService()
+	rs.logger = zap.New(core)
+
+	// Without a timeout the scan's context is the service's own
+	ctx, cancel := context.WithCancel(context.Background())
+	rs.runScan(ctx, "cronos", func(scanCtx context.Context) error {
+		if _, ok := scanCtx.Deadline(); ok {
+			t.Fatal("expected no scan deadline")
+		}
+		cancel()
+		return scanCtx.Err()
+	})
+
+	if entries := logs.FilterMessage("Failed to scan orders").All(); len(entries) != 1 {
+		t.Fatalf("expected a scan failure, got %+v", logs.All())
+	}
+}
+
+func TestJitteredInterval(t *testing.T) {
+	interval := 10 * time.Second
+	for _, tc := range []struct {
+		random   float64
+		expected time.Duration
+	}{
+		{random: 0, expected: 8 * time.Second},
+		{random: 0.5, expected: 10 * time.Second},
+		{random: 0.75, expected: 11 * time.Second},
+	} {
+		got := jitteredInterval(interval, 0.2, func() float64 { return tc.random })
+		if got != tc.expected {
+			t.Fatalf("expected %s for %g, got %s", tc.expected, tc.random, got)
+		}
+	}
+
+	if got := jitteredInterval(interval, 0, func() float64 { return 0 }); got != interval {
+		t.Fatalf("expected no jitter to keep the interval, got %s", got)
+	}
+}
+
+func TestJitterTickerVariesIntervals(t *testing.T) {
+	const (
+		interval = 20 * time.Millisecond
+		jitter   = 0.5
+	)
+	ticker := newJitterTicker(interval, jitter)
+	defer ticker.Stop()
+
+	last := time.Now()
+	intervals := make(map[time.Duration]bool)
+	for i := 0; i < 10; i++ {
+		select {
+		case now := <-ticker.C:
+			elapsed := now.Sub(last)
+			last = now
+			// Allow for scheduling delays past the top of the band
+			if elapsed < time.Duration(float64(interval)*(1-jitter)) || elapsed > time.Duration(float64(interval)*(1+jitter))+15*time.Millisecond {
+				t.Fatalf("tick %d after %s, outside the ±%g band around %s", i, elapsed, jitter, interval)
+			}
+			intervals[elapsed.Round(time.Millisecond)] = true
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a tick")
+		}
+	}
+
+	if len(intervals) < 2 {
+		t.Fatalf("expected the intervals to vary, got %v", intervals)
+	}
+}
+
+// fakeSubmitter accepts orders signed "valid" and records them, and records
+// the orders it resolves
+type fakeSubmitter struct {
+	submitted []*order_manager.Order
+	resolved  []string
+}
+
+func (f *fakeSubmitter) ForceCompleteOrder(orderID, reason, operator string) error {
+	return f.resolve(orderID, "completed", reason, operator)
+}
+
+func (f *fakeSubmitter) ForceFailOrder(orderID, reason, operator string) error {
+	if reason == "" {
+		return fmt.Errorf("%w: a reason is required to fail an order", order_manager.ErrOrderNotResolvable)
+	}
+	return f.resolve(orderID, "failed", reason, operator)
+}
+
+func (f *fakeSubmitter) resolve(orderID, status, reason, operator string) error {
+	switch orderID {
+	case "stuck":
+		f.resolved = append(f.resolved, fmt.Sprintf("%s %s by %s: %s", orderID, status, operator, reason))
+		return nil
+	case "completed":
+		return fmt.Errorf("%w: order is already completed", order_manager.ErrOrderNotResolvable)
+	default:
+		return order_manager.ErrOrderNotFound
+	}
+}
+
+func (f *fakeSubmitter) CancelOrder(ctx context.Context, orderID string) (string, error) {
+	switch orderID {
+	case "unfunded":
+		return "", nil
+	case "funded":
+		return "0xcancel", nil
+	case "active":
+		return "", fmt.Errorf("%w: destination escrow can't be cancelled until later", order_manager.ErrOrderNotCancellable)
+	case "rpc-down":
+		return "", chain_errors.ErrRPCUnavailable
+	default:
+		return "", order_manager.ErrOrderNotFound
+	}
+}
+
+func (f *fakeSubmitter) SubmitOrder(order *order_manager.Order, sig order_manager.OrderSignature) error {
+	switch sig.Signature {
+	case "valid":
+		order.ID = "order-1"
+		f.submitted = append(f.submitted, order)
+		return nil
+	case "bad-order":
+		return errors.New("order rejected")
+	default:
+		return fmt.Errorf("%w: wrong signer", order_manager.ErrInvalidSignature)
+	}
+}
+
+func TestAPISubmitOrder(t *testing.T) {
+	submitter := &fakeSubmitter{}
+	server := httptest.NewServer(newAPIHandler(submitter, "", zap.NewNop()))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+	}{
+		{"valid signature", http.MethodPost, `{"order":{"maker":"0xmaker","source_asset":{"amount":1000}},"signature":"valid"}`, http.StatusAccepted},
+		{"invalid signature", http.MethodPost, `{"order":{"maker":"0xmaker"},"signature":"forged"}`, http.StatusUnauthorized},
+		{"rejected order", http.MethodPost, `{"order":{"maker":"0xmaker"},"signature":"bad-order"}`, http.StatusBadRequest},
+		{"malformed body", http.MethodPost, `{"order":`, http.StatusBadRequest},
+		{"wrong method", http.MethodGet, "", http.StatusMethodNotAllowed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, server.URL+"/orders", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("failed to build request: %v", err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+		})
+	}
+
+	if len(submitter.submitted) != 1 {
+		t.Fatalf("expected 1 submitted order, got %d", len(submitter.submitted))
+	}
+	order := submitter.submitted[0]
+	if order.Maker != "0xmaker" || order.SourceAsset.Amount.Int64() != 1000 {
+		t.Fatalf("unexpected submitted order: %+v", order)
+	}
+}
+
+func TestAPICancelOrder(t *testing.T) {
+	server := httptest.NewServer(newAPIHandler(&fakeSubmitter{}, "", zap.NewNop()))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"unfunded order", http.MethodDelete, "/orders/unfunded", http.StatusOK},
+		{"funded order", http.MethodDelete, "/orders/funded", http.StatusOK},
+		{"not cancellable", http.MethodDelete, "/orders/active", http.StatusConflict},
+		{"chain unavailable", http.MethodDelete, "/orders/rpc-down", http.StatusBadGateway},
+		{"unknown order", http.MethodDelete, "/orders/missing", http.StatusNotFound},
+		{"no order id", http.MethodDelete, "/orders/", http.StatusNotFound},
+		{"wrong method", http.MethodGet, "/orders/funded", http.StatusMethodNotAllowed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, server.URL+tc.path, nil)
+			if err != nil {
+				t.Fatalf("failed to build request: %v", err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCancelOrderCommand(t *testing.T) {
+	server := httptest.NewServer(newAPIHandler(&fakeSubmitter{}, "", zap.NewNop()))
+	defer server.Close()
+
+	res, err := cancelOrder(context.Background(), server.URL, "", "funded")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.ID != "funded" || res.Status != "cancelling" || res.TxHash != "0xcancel" {
+		t.Fatalf("unexpected response: %+v", res)
+	}
+
+	// The API's reason is surfaced to the operator
+	_, err = cancelOrder(context.Background(), server.URL, "", "active")
+	if err == nil || !strings.Contains(err.Error(), "can't be cancelled until later") {
+		t.Fatalf("expected the API's reason, got %v", err)
+	}
+}
+
+func TestAPIResolveOrder(t *testing.T) {
+	const token = "operator-token"
+
+	submitter := &fakeSubmitter{}
+	server := httptest.NewServer(newAPIHandler(submitter, token, zap.NewNop()))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name       string
+		method     string
+		path       string
+		auth       string
+		body       string
+		wantStatus int
+	}{
+		{"complete", http.MethodPost, "/orders/stuck/complete", "Bearer " + token, `{"reason":"settled by hand","operator":"alice"}`, http.StatusOK},
+		{"fail", http.MethodPost, "/orders/stuck/fail", "Bearer " + token, `{"reason":"escrow drained","operator":"bob"}`, http.StatusOK},
+		{"fail without reason", http.MethodPost, "/orders/stuck/fail", "Bearer " + token, "", http.StatusConflict},
+		{"already finished", http.MethodPost, "/orders/completed/complete", "Bearer " + token, "", http.StatusConflict},
+		{"unknown order", http.MethodPost, "/orders/missing/complete", "Bearer " + token, "", http.StatusNotFound},
+		{"unknown action", http.MethodPost, "/orders/stuck/retry", "Bearer " + token, "", http.StatusNotFound},
+		{"malformed body", http.MethodPost, "/orders/stuck/fail", "Bearer " + token, `{"reason":`, http.StatusBadRequest},
+		{"wrong method", http.MethodGet, "/orders/stuck/complete", "Bearer " + token, "", http.StatusMethodNotAllowed},
+		{"no token", http.MethodPost, "/orders/stuck/complete", "", "", http.StatusUnauthorized},
+		{"wrong token", http.MethodPost, "/orders/stuck/fail", "Bearer wrong", `{"reason":"forged"}`, http.StatusUnauthorized},
+		{"not a bearer token", http.MethodPost, "/orders/stuck/complete", token, "", http.StatusUnauthorized},
+		{"cancel without token", http.MethodDelete, "/orders/unfunded", "", "", http.StatusUnauthorized},
+		{"cancel with token", http.MethodDelete, "/orders/unfunded", "Bearer " + token, "", http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, server.URL+tc.path, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("failed to build request: %v", err)
+			}
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+		})
+	}
+
+	// Rejected requests never reach the order manager
+	want := []string{"stuck completed by alice: settled by hand", "stuck failed by bob: escrow drained"}
+	if strings.Join(submitter.resolved, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("expected %q to be resolved, got %q", want, submitter.resolved)
+	}
+}
+
+func TestAPIResolveOrderWithoutToken(t *testing.T) {
+	submitter := &fakeSubmitter{}
+	server := httptest.NewServer(newAPIHandler(submitter, "", zap.NewNop()))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/orders/stuck/complete", "application/json", strings.NewReader(`{"reason":"settled"}`))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected resolving to be disabled, got status %d", resp.StatusCode)
+	}
+	if len(submitter.resolved) != 0 {
+		t.Fatalf("expected no order to be resolved, got %q", submitter.resolved)
+	}
+}
+
+func TestResolveOrderCommand(t *testing.T) {
+	const token = "operator-token"
+
+	submitter := &fakeSubmitter{}
+	server := httptest.NewServer(newAPIHandler(submitter, token, zap.NewNop()))
+	defer server.Close()
+
+	res, err := resolveOrder(context.Background(), server.URL, token, "stuck", "fail", resolveOrderRequest{Reason: "escrow drained", Operator: "alice"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.ID != "stuck" || res.Status != "failed" {
+		t.Fatalf("unexpected response: %+v", res)
+	}
+	if len(submitter.resolved) != 1 || submitter.resolved[0] != "stuck failed by alice: escrow drained" {
+		t.Fatalf("expected the operator and reason to be sent, got %q", submitter.resolved)
+	}
+
+	// The API's reason is surfaced to the operator
+	_, err = resolveOrder(context.Background(), server.URL, "wrong", "stuck", "complete", resolveOrderRequest{})
+	if err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Fatalf("expected the token to be rejected, got %v", err)
+	}
+	_, err = resolveOrder(context.Background(), server.URL, token, "completed", "complete", resolveOrderRequest{})
+	if err == nil || !strings.Contains(err.Error(), "already completed") {
+		t.Fatalf("expected the API's reason, got %v", err)
+	}
+}
+
+func TestConfigShowRedactsSecrets(t *testing.T) {
+	const privateKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(`
+cronos:
+  chain_id: "cronos_777-1"
+  rpc_endpoint: "http://localhost:26657"
+  private_key: "`+privateKey+`"
+ethereum:
+  rpc_endpoint: "http://localhost:8545"
+  private_keys: ["`+privateKey+`"]
+`), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	previousPath, previousLogger := configPath, logger
+	t.Cleanup(func() {
+		configPath, logger = previousPath, previousLogger
+		configShowOutput = "yaml"
+		viper.Reset()
+	})
+	configPath, logger = path, zap.NewNop()
+
+	for _, output := range []string{"yaml", "json"} {
+		viper.Reset()
+		configShowOutput = output
+
+		var out strings.Builder
+		cmd := &cobra.Command{}
+		cmd.SetOut(&out)
+
+		// The config lacks contracts, which is reported after it is shown
+		err := runConfigShow(cmd, nil)
+		if err == nil || !strings.Contains(err.Error(), "contracts") {
+			t.Fatalf("expected a validation error, got %v", err)
+		}
+
+		if strings.Contains(out.String(), privateKey) {
+			t.Fatalf("%s output leaks the private key:\n%s", output, out.String())
+		}
+		if strings.Count(out.String(), config.RedactedValue) != 2 {
+			t.Fatalf("expected both keys to be redacted in %s output:\n%s", output, out.String())
+		}
+		if !strings.Contains(out.String(), "http://localhost:26657") {
+			t.Fatalf("expected other values to be shown in %s output:\n%s", output, out.String())
+		}
+	}
+}
+
+// scanningEthereumNode serves a fixed tip and set of escrows, recording the
+// first block of each queried range
+type scanningEthereumNode struct {
+	tip     uint64
+	escrows []ethereum_client.EscrowOrder
+	from    []uint64
+}
+
+func (n *scanningEthereumNode) GetLatestBlock(ctx context.Context) (uint64, error) {
+	return n.tip, nil
+}
+
+func (n *scanningEthereumNode) GetEscrowOrders(ctx context.Context, factoryAddr string, fromBlock, toBlock uint64) ([]ethereum_client.EscrowOrder, error) {
+	n.from = append(n.from, fromBlock)
+
+	var escrows []ethereum_client.EscrowOrder
+	for _, escrow := range n.escrows {
+		if fromBlock <= escrow.BlockNumber && escrow.BlockNumber <= toBlock {
+			escrows = append(escrows, escrow)
+		}
+	}
+	return escrows, nil
+}
+
+func (n *scanningEthereumNode) GetTransactionBlock(ctx context.Context, txHash string) (uint64, bool, error) {
+	for _, escrow := range n.escrows {
+		if escrow.ID == txHash {
+			return escrow.BlockNumber, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func newCheckpointTestService(path string, node *scanningEthereumNode) *RelayerService {
+	rs := newTestRelayerService()
+	rs.logger = zap.NewNop()
+	rs.config.Relayer.CheckpointPath = path
+	rs.config.Relayer.ConfirmationDepth.Ethereum = 10
+	rs.ethereumOrders = node
+	rs.ethereumPending = order_manager.NewPendingOrders(rs.config.Relayer.ConfirmationDepth.Ethereum)
+	rs.orderManager = order_manager.NewOrderManager(rs.config, nil, nil, zap.NewNop())
+	return rs
+}
+
+func TestCheckpointResumesAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	node := &scanningEthereumNode{
+		tip: 100,
+		escrows: []ethereum_client.EscrowOrder{{
+			ID:              "0xescrow",
+			BlockNumber:     95,
+			DepositedAmount: big.NewInt(1000000),
+			TokenAddress:    testTokenAddress,
+			SrcAmount:       big.NewInt(5),
+		}},
+	}
+
+	rs := newCheckpointTestService(path, node)
+	if err := rs.restoreCheckpoint(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rs.scanEthereumOrders(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rs.lastCronosBlock = 42
+	if err := rs.persistCheckpoint(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The escrow is still waiting for confirmations, so the checkpoint stays
+	// before its block
+	checkpoint, found, err := loadCheckpoint(path)
+	if err != nil || !found {
+		t.Fatalf("expected a saved checkpoint, got found=%v err=%v", found, err)
+	}
+	if checkpoint != (scanCheckpoint{Cronos: 42, Ethereum: 94}) {
+		t.Fatalf("unexpected checkpoint: %+v", checkpoint)
+	}
+
+	// A restarted relayer picks up the escrow again from the checkpoint
+	node.tip = 110
+	node.from = nil
+	restarted := newCheckpointTestService(path, node)
+	if err := restarted.restoreCheckpoint(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restarted.lastCronosBlock != 42 || restarted.lastEthereumBlock != 94 {
+		t.Fatalf("expected to resume after blocks 42 and 94, got %d and %d",
+			restarted.lastCronosBlock, restarted.lastEthereumBlock)
+	}
+	if err := restarted.scanEthereumOrders(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(node.from) != 1 || node.from[0] != 95 {
+		t.Fatalf("expected the scan to resume from block 95, got %v", node.from)
+	}
+	if restarted.ethereumPending.Len() != 0 {
+		t.Fatal("expected the resumed escrow to be confirmed")
+	}
+	if restarted.ethereumCheckpoint != 110 {
+		t.Fatalf("expected the checkpoint to advance to 110, got %d", restarted.ethereumCheckpoint)
+	}
+}
+
+func TestRestoreCheckpointStartBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	rs := newCheckpointTestService(path, &scanningEthereumNode{})
+	rs.config.Relayer.StartBlock = config.StartBlockConfig{Cronos: 500, Ethereum: 1000}
+	if err := rs.restoreCheckpoint(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rs.lastCronosBlock != 499 || rs.lastEthereumBlock != 999 {
+		t.Fatalf("expected to start at blocks 500 and 1000, got %d and %d",
+			rs.lastCronosBlock+1, rs.lastEthereumBlock+1)
+	}
+
+	// Once a checkpoint exists the start blocks no longer apply
+	if err := saveCheckpoint(path, scanCheckpoint{Cronos: 700, Ethereum: 1200}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rs.restoreCheckpoint(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rs.lastCronosBlock != 700 || rs.lastEthereumBlock != 1200 {
+		t.Fatalf("expected to resume after blocks 700 and 1200, got %d and %d",
+			rs.lastCronosBlock, rs.lastEthereumBlock)
+	}
+}
+
+// swapCronosClient withdraws from every Cronos escrow, recording the escrows
+type swapCronosClient struct {
+	mu          sync.Mutex
+	withdrawals []string
+}
+
+func (c *swapCronosClient) CreateDestinationEscrow(ctx context.Context, factoryAddr string, params cronos_client.CreateDestEscrowParams) (string, error) {
+	return "0xcreate", nil
+}
+
+func (c *swapCronosClient) WithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.withdrawals = append(c.withdrawals, escrowAddr)
+	return "0xwithdraw", nil
+}
+
+func (c *swapCronosClient) PartialWithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string, amount string) (string, error) {
+	return c.WithdrawFromEscrow(ctx, escrowAddr, secret)
+}
+
+func (c *swapCronosClient) CancelEscrow(ctx context.Context, escrowAddr string) (string, error) {
+	return "0xcancel", nil
+}
+
+func (c *swapCronosClient) GetEscrowStatus(ctx context.Context, escrowAddr string) (string, error) {
+	return "active", nil
+}
+
+func (c *swapCronosClient) GetEscrowDeposit(ctx context.Context, escrowAddr string) (*big.Int, error) {
+	return big.NewInt(1000), nil
+}
+
+func (c *swapCronosClient) CheckTransaction(ctx context.Context, txHash string) (bool, error) {
+	return true, nil
+}
+
+func (c *swapCronosClient) SimulateFill(ctx context.Context, escrowAddr string, inputAmount string) (string, error) {
+	return inputAmount, nil
+}
+
+// swapEthereumClient reports every Ethereum escrow funded with 1000
+type swapEthereumClient struct{}
+
+func (swapEthereumClient) CreateDestinationEscrow(ctx context.Context, resolverAddr string, params ethereum_client.CreateDestEscrowParams) (string, error) {
+	return "0xcreate", nil
+}
+
+func (swapEthereumClient) WithdrawFromEscrow(ctx context.Context, resolverAddr string, escrowAddr string, secret string, immutables ethereum_client.Immutables) (string, error) {
+	return "0xwithdraw", nil
+}
+
+func (swapEthereumClient) CancelEscrow(ctx context.Context, resolverAddr string, escrowAddr string, immutables ethereum_client.Immutables) (string, error) {
+	return "0xcancel", nil
+}
+
+func (swapEthereumClient) GetLatestBlock(ctx context.Context) (uint64, error) {
+	return 1, nil
+}
+
+func (swapEthereumClient) GetRevealedSecrets(ctx context.Context, escrowAddr string, fromBlock uint64) ([]ethereum_client.RevealedSecret, error) {
+	return nil, nil
+}
+
+func (swapEthereumClient) GetEscrowDeposit(ctx context.Context, escrowAddr string) (*big.Int, error) {
+	return big.NewInt(1000), nil
+}
+
+func (swapEthereumClient) CheckTransaction(ctx context.Context, txHash string) (bool, error) {
+	return true, nil
+}
+
+// fixedMatcher matches the orders of its results whatever the book holds
+type fixedMatcher []matching.MatchResult
+
+func (m fixedMatcher) Match(orders []*order_manager.Order) []matching.MatchResult {
+	return m
+}
+
+func TestMatchOrdersExecutesMatchedOrders(t *testing.T) {
+	rs := newTestRelayerService()
+	rs.logger = zap.NewNop()
+	rs.config.Relayer.OrderUpdateInterval = time.Hour
+	rs.config.Relayer.OrderStorePath = filepath.Join(t.TempDir(), "orders.json")
+	rs.config.DutchAuction.PriceUpdateInterval = time.Hour
+
+	var orders []*order_manager.Order
+	for _, id := range []string{"maker", "taker"} {
+		orders = append(orders, &order_manager.Order{
+			ID:               id,
+			Type:             order_manager.OrderTypeCronosToEthereum,
+			Status:           order_manager.OrderStatusActive,
+			Secret:           "secret-" + id,
+			SourceEscrowAddr: "crc1" + id,
+			DestEscrowAddr:   "0x" + id,
+			SourceAsset:      order_manager.AssetInfo{Symbol: "CRO", Amount: big.NewInt(1000)},
+			DestinationAsset: order_manager.AssetInfo{Symbol: "ETH", Amount: big.NewInt(1000)},
+			ExpiresAt:        time.Now().Add(time.Hour),
+		})
+	}
+	if err := order_manager.SaveOrders(rs.config.Relayer.OrderStorePath, orders); err != nil {
+		t.Fatalf("failed to save orders: %v", err)
+	}
+
+	cronos := &swapCronosClient{}
+	rs.orderManager = order_manager.NewOrderManager(rs.config, cronos, swapEthereumClient{}, zap.NewNop())
+	if err := rs.orderManager.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start order manager: %v", err)
+	}
+	defer rs.orderManager.Stop(context.Background())
+
+	rs.matcher = fixedMatcher{{
+		MakerOrderID: "maker",
+		TakerOrderID: "taker",
+		MakerAmount:  big.NewInt(1000),
+		TakerAmount:  big.NewInt(1000),
+		Price:        big.NewRat(1, 1),
+	}}
+	rs.matchOrders(context.Background())
+
+	// Matched orders are executed and retired without another trigger
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, makerTracked := rs.orderManager.GetOrder("maker")
+		_, takerTracked := rs.orderManager.GetOrder("taker")
+		if !makerTracked && !takerTracked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("matched orders were never executed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cronos.mu.Lock()
+	defer cronos.mu.Unlock()
+	if len(cronos.withdrawals) != 2 {
+		t.Fatalf("expected both source escrows withdrawn, got %v", cronos.withdrawals)
+	}
+}
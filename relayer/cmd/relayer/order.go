@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+)
+
+var orderCmd = &cobra.Command{
+	Use:   "order [order-id]",
+	Short: "Show a stored order and its status history",
+	Long: `Print the status of an order in the order store together with every status
+transition the relayer recorded for it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOrder,
+}
+
+func runOrder(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	if cfg.Relayer.OrderStorePath == "" {
+		return fmt.Errorf("relayer.order_store_path must be set to look up orders")
+	}
+
+	orders, err := order_manager.LoadOrders(cfg.Relayer.OrderStorePath)
+	if err != nil {
+		return fmt.Errorf("failed to load order store: %w", err)
+	}
+
+	for _, order := range orders {
+		if order.ID == args[0] {
+			printOrderHistory(cmd, order)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("order %s not found in %s", args[0], cfg.Relayer.OrderStorePath)
+}
+
+// printOrderHistory writes an order's current state followed by its status
+// transitions, oldest first
+func printOrderHistory(cmd *cobra.Command, order *order_manager.Order) {
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "Order %s\t%s\t%s\n", order.ID, order.Type, order.Status)
+	if order.LastError != "" {
+		fmt.Fprintf(out, "Last error: %s\n", order.LastError)
+	}
+
+	fmt.Fprintf(out, "History (%d transitions)\n", len(order.History))
+	for _, transition := range order.History {
+		fmt.Fprintf(out, "  %s\t%s -> %s", transition.Timestamp.Format(time.RFC3339), transition.From, transition.To)
+		if transition.TxHash != "" {
+			fmt.Fprintf(out, "\ttx=%s", transition.TxHash)
+		}
+		if transition.Note != "" {
+			fmt.Fprintf(out, "\t%s", transition.Note)
+		}
+		fmt.Fprintln(out)
+	}
+}
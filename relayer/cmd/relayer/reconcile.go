@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/ethereum_client"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+)
+
+// reconcilePageSize is the number of Cronos escrows listed per factory query
+const reconcilePageSize = 50
+
+// cronosEscrowLister lists the Cronos factory's escrows and looks up each one
+type cronosEscrowLister interface {
+	GetBlockTime(ctx context.Context, height int64) (time.Time, error)
+	ListEscrows(ctx context.Context, factoryAddr string, startAfter string, limit uint32) ([]cronos_client.EscrowInfo, error)
+	GetEscrow(ctx context.Context, escrowAddr string) (*cronos_client.EscrowOrder, error)
+}
+
+var (
+	reconcileCronosHeight  int64
+	reconcileEthereumBlock uint64
+	reconcileDryRun        bool
+)
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Recover in-flight orders from on-chain escrows",
+	Long: `Scan both chains for escrows created since the given heights, pair source and
+destination escrows by hashlock and add the unfinished orders to the order store,
+where the relayer picks them up on its next start.`,
+	RunE: runReconcile,
+}
+
+func init() {
+	reconcileCmd.Flags().Int64Var(&reconcileCronosHeight, "cronos-from-height", 1, "Cronos block height to scan escrows from")
+	reconcileCmd.Flags().Uint64Var(&reconcileEthereumBlock, "ethereum-from-block", 0, "Ethereum block number to scan escrows from")
+	reconcileCmd.Flags().BoolVar(&reconcileDryRun, "dry-run", false, "Print the summary without updating the order store")
+	reconcileCmd.MarkFlagRequired("ethereum-from-block")
+}
+
+func runReconcile(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	if cfg.Relayer.OrderStorePath == "" && !reconcileDryRun {
+		return fmt.Errorf("relayer.order_store_path must be set to store reconciled orders")
+	}
+
+	cronosClient, err := cronos_client.NewClient(&cfg.Cronos, &cfg.Relayer, logger.Named("cronos"))
+	if err != nil {
+		return fmt.Errorf("failed to initialize Cronos client: %w", err)
+	}
+
+	ethereumClient, err := ethereum_client.NewClient(&cfg.Ethereum, &cfg.Contracts.Ethereum, &cfg.Relayer, logger.Named("ethereum"))
+	if err != nil {
+		return fmt.Errorf("failed to initialize Ethereum client: %w", err)
+	}
+
+	// Only the order conversions are needed from the service
+	rs := &RelayerService{config: cfg, logger: logger, tokens: ethereumClient}
+
+	cronosSources, cronosDestinations, err := rs.scanCronosEscrows(ctx, cronosClient, reconcileCronosHeight)
+	if err != nil {
+		return err
+	}
+
+	ethereumEscrows, err := rs.scanEthereumEscrows(ctx, ethereumClient, reconcileEthereumBlock)
+	if err != nil {
+		return err
+	}
+
+	result := order_manager.Reconcile(cronosSources, ethereumEscrows, cronosDestinations)
+
+	added := 0
+	if !reconcileDryRun {
+		added, err = order_manager.MergeOrders(cfg.Relayer.OrderStorePath, result.Recovered)
+		if err != nil {
+			return fmt.Errorf("failed to store reconciled orders: %w", err)
+		}
+	}
+
+	printReconcileResult(cmd, result, added)
+	return nil
+}
+
+// scanCronosEscrows lists the factory's escrows created at or after
+// fromHeight, returning source escrows as orders and destination escrows
+// separately. Escrow listings carry creation times rather than heights, so
+// the height is resolved to its block time first
+func (rs *RelayerService) scanCronosEscrows(ctx context.Context, client cronosEscrowLister, fromHeight int64) ([]*order_manager.Order, []order_manager.Escrow, error) {
+	var since time.Time
+	if fromHeight > 1 {
+		blockTime, err := client.GetBlockTime(ctx, fromHeight)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve Cronos height %d: %w", fromHeight, err)
+		}
+		since = blockTime
+	}
+
+	var sources []*order_manager.Order
+	var destinations []order_manager.Escrow
+
+	startAfter := ""
+	for {
+		escrows, err := client.ListEscrows(ctx, rs.config.Contracts.Cronos.EscrowFactory, startAfter, reconcilePageSize)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list Cronos escrows: %w", err)
+		}
+
+		for _, info := range escrows {
+			if time.Unix(int64(info.CreatedAt), 0).Before(since) {
+				continue
+			}
+
+			escrow, err := client.GetEscrow(ctx, info.Address)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get Cronos escrow %s: %w", info.Address, err)
+			}
+
+			switch info.EscrowType {
+			case cronos_client.EscrowTypeSource:
+				escrow.ID = info.Salt
+				order, err := rs.convertCronosOrderToOrder(escrow)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to convert Cronos escrow %s: %w", info.Address, err)
+				}
+				order.SourceEscrowAddr = info.Address
+				sources = append(sources, order)
+			case cronos_client.EscrowTypeDestination:
+				destinations = append(destinations, order_manager.Escrow{
+					Chain:      "cronos",
+					Address:    info.Address,
+					SecretHash: escrow.SecretHash,
+					Status:     escrow.Status,
+					Timelock:   escrow.Timelock,
+				})
+			}
+		}
+
+		if len(escrows) < reconcilePageSize {
+			break
+		}
+		// The factory keys its escrows by salt, not address
+		startAfter = escrows[len(escrows)-1].Salt
+	}
+
+	return sources, destinations, nil
+}
+
+// scanEthereumEscrows returns every escrow the factory created from fromBlock
+// up to the latest block
+func (rs *RelayerService) scanEthereumEscrows(ctx context.Context, client *ethereum_client.Client, fromBlock uint64) ([]*order_manager.Order, error) {
+	latestBlock, err := client.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest Ethereum block: %w", err)
+	}
+
+	ethOrders, err := client.GetEscrowOrders(ctx, rs.config.Contracts.Ethereum.EscrowFactory, fromBlock, latestBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Ethereum escrows: %w", err)
+	}
+
+	orders := make([]*order_manager.Order, 0, len(ethOrders))
+	for i := range ethOrders {
+		order, err := rs.convertEthereumOrderToOrder(ctx, &ethOrders[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert Ethereum escrow %s: %w", ethOrders[i].ID, err)
+		}
+		orders = append(orders, order)
+	}
+
+	rs.logger.Info("Scanned Ethereum escrows",
+		zap.Uint64("from_block", fromBlock),
+		zap.Uint64("to_block", latestBlock),
+		zap.Int("escrows", len(orders)))
+
+	return orders, nil
+}
+
+// printReconcileResult writes a summary of the reconciled escrows
+func printReconcileResult(cmd *cobra.Command, result *order_manager.ReconcileResult, added int) {
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "Recovered %d orders (%d added to the order store)\n", len(result.Recovered), added)
+	for _, order := range result.Recovered {
+		fmt.Fprintf(out, "  %s\t%s\t%s\tsource=%s\tdestination=%s\n",
+			order.ID, order.Type, order.Status, order.SourceEscrowAddr, order.DestEscrowAddr)
+	}
+
+	fmt.Fprintf(out, "Completed %d orders\n", len(result.Completed))
+	for _, order := range result.Completed {
+		fmt.Fprintf(out, "  %s\t%s\t%s\n", order.ID, order.Type, order.Status)
+	}
+
+	fmt.Fprintf(out, "Orphaned %d escrows\n", len(result.Orphaned))
+	for _, escrow := range result.Orphaned {
+		fmt.Fprintf(out, "  %s\t%s\t%s\thashlock=%s\n", escrow.Chain, escrow.Address, escrow.Status, escrow.SecretHash)
+	}
+}
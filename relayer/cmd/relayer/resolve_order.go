@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// resolveOrderTimeout bounds a complete-order or fail-order request
+const resolveOrderTimeout = 30 * time.Second
+
+var (
+	resolveOrderAPIURL    string
+	resolveOrderAuthToken string
+	resolveOrderReason    string
+	resolveOrderOperator  string
+)
+
+var completeOrderCmd = &cobra.Command{
+	Use:   "complete-order [order-id]",
+	Short: "Mark a stuck order completed on the running relayer",
+	Long: `Ask the running relayer, through its HTTP API, to mark an order completed after its
+swap was settled by hand. The relayer stops tracking the order and records the
+intervention in its history. Requires relayer.api.auth_token.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runResolveOrder(cmd, args[0], "complete")
+	},
+}
+
+var failOrderCmd = &cobra.Command{
+	Use:   "fail-order [order-id]",
+	Short: "Mark a stuck order failed on the running relayer",
+	Long: `Ask the running relayer, through its HTTP API, to mark an order failed so it stops
+acting on it. The reason is recorded in the order's history along with who
+failed it. Requires relayer.api.auth_token.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if resolveOrderReason == "" {
+			return fmt.Errorf("--reason is required to fail an order")
+		}
+		return runResolveOrder(cmd, args[0], "fail")
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{completeOrderCmd, failOrderCmd} {
+		cmd.Flags().StringVar(&resolveOrderAPIURL, "api-url", "", "Base URL of the relayer API (default from relayer.api)")
+		cmd.Flags().StringVar(&resolveOrderAuthToken, "auth-token", "", "Bearer token of the relayer API (default from relayer.api.auth_token)")
+		cmd.Flags().StringVar(&resolveOrderReason, "reason", "", "Why the order is resolved by hand, recorded in its history")
+		cmd.Flags().StringVar(&resolveOrderOperator, "operator", os.Getenv("USER"), "Who resolved the order, recorded in its history")
+	}
+}
+
+func runResolveOrder(cmd *cobra.Command, orderID, action string) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	baseURL, authToken, err := operatorAPI(resolveOrderAPIURL, resolveOrderAuthToken, action+" orders")
+	if err != nil {
+		return err
+	}
+
+	res, err := resolveOrder(ctx, baseURL, authToken, orderID, action, resolveOrderRequest{
+		Reason:   resolveOrderReason,
+		Operator: resolveOrderOperator,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Order %s %s\n", res.ID, res.Status)
+	return nil
+}
+
+// resolveOrder asks the relayer API at baseURL to complete or fail an order,
+// action being "complete" or "fail", returning the API's reason as the error
+// when it can't be resolved
+func resolveOrder(ctx context.Context, baseURL, authToken, orderID, action string, req resolveOrderRequest) (*resolveOrderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, resolveOrderTimeout)
+	defer cancel()
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/orders/" + url.PathEscape(orderID) + "/" + action
+	var res resolveOrderResponse
+	if err := callOrderAPI(ctx, http.MethodPost, endpoint, authToken, req, &res); err != nil {
+		return nil, fmt.Errorf("failed to %s order %s: %w", action, orderID, err)
+	}
+	return &res, nil
+}
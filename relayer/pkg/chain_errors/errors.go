@@ -0,0 +1,125 @@
+package chain_errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// Classes of errors returned by the chain clients. Client errors wrap one of
+// these when the cause is recognised, so callers can test them with errors.Is
+var (
+	// ErrInsufficientFunds means the sending account can't pay for the
+	// transaction or its value
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrNonceTooLow means the account nonce or sequence was already used
+	ErrNonceTooLow = errors.New("nonce too low")
+	// ErrReverted means the transaction or call was executed and failed
+	ErrReverted = errors.New("execution reverted")
+	// ErrRPCUnavailable means the node couldn't be reached or refused to serve
+	// the request
+	ErrRPCUnavailable = errors.New("rpc unavailable")
+)
+
+// patterns maps lowercase fragments of node error messages to their class.
+// More specific fragments come first, since a message may contain several
+var patterns = []struct {
+	fragment string
+	class    error
+}{
+	// Ethereum
+	{"insufficient funds", ErrInsufficientFunds},
+	{"nonce too low", ErrNonceTooLow},
+	{"replacement transaction underpriced", ErrNonceTooLow},
+	{"execution reverted", ErrReverted},
+	{"transaction reverted", ErrReverted},
+	// Cosmos SDK
+	{"account sequence mismatch", ErrNonceTooLow},
+	{"incorrect account sequence", ErrNonceTooLow},
+	{"execute wasm contract failed", ErrReverted},
+	{"out of gas", ErrReverted},
+	// Transport
+	{"connection refused", ErrRPCUnavailable},
+	{"connection reset", ErrRPCUnavailable},
+	{"no such host", ErrRPCUnavailable},
+	{"i/o timeout", ErrRPCUnavailable},
+	{"too many requests", ErrRPCUnavailable},
+	{"502 bad gateway", ErrRPCUnavailable},
+	{"503 service unavailable", ErrRPCUnavailable},
+	{"504 gateway timeout", ErrRPCUnavailable},
+}
+
+// Classify wraps err with the class its message or type matches, keeping err
+// itself in the chain. Errors that are already classified or unrecognised
+// are returned unchanged
+func Classify(err error) error {
+	if err == nil || classOf(err) != nil {
+		return err
+	}
+
+	if class := match(err); class != nil {
+		return fmt.Errorf("%w: %w", class, err)
+	}
+	return err
+}
+
+// Reverted wraps err as an execution failure, for failures reported through
+// receipts or result codes rather than node errors
+func Reverted(err error) error {
+	if errors.Is(err, ErrReverted) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrReverted, err)
+}
+
+// IsTransient reports whether err may succeed if retried unchanged: the node
+// was unreachable, the request timed out, or the nonce was stale and has
+// since been resynced
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrRPCUnavailable) ||
+		errors.Is(err, ErrNonceTooLow) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+// RetryStaleNonce runs send and, if the node rejects it because the account
+// nonce or sequence was stale, e.g. after a transaction was dropped from the
+// mempool, calls resync to reload it from the chain and sends once more
+func RetryStaleNonce(send func() error, resync func() error) error {
+	err := send()
+	if !errors.Is(err, ErrNonceTooLow) {
+		return err
+	}
+	if resyncErr := resync(); resyncErr != nil {
+		return fmt.Errorf("failed to resync nonce after %v: %w", err, resyncErr)
+	}
+	return send()
+}
+
+// classOf returns the class err is already wrapped with, if any
+func classOf(err error) error {
+	for _, class := range []error{ErrInsufficientFunds, ErrNonceTooLow, ErrReverted, ErrRPCUnavailable} {
+		if errors.Is(err, class) {
+			return class
+		}
+	}
+	return nil
+}
+
+// match finds the class of an unclassified error
+func match(err error) error {
+	msg := strings.ToLower(err.Error())
+	for _, p := range patterns {
+		if strings.Contains(msg, p.fragment) {
+			return p.class
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrRPCUnavailable
+	}
+	return nil
+}
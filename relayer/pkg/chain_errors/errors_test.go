@@ -0,0 +1,110 @@
+package chain_errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		err   error
+		class error
+	}{
+		{name: "geth insufficient funds", err: errors.New("insufficient funds for gas * price + value: balance 0, tx cost 21000"), class: ErrInsufficientFunds},
+		{name: "geth nonce too low", err: errors.New("nonce too low: next nonce 5, tx nonce 4"), class: ErrNonceTooLow},
+		{name: "geth underpriced replacement", err: errors.New("replacement transaction underpriced"), class: ErrNonceTooLow},
+		{name: "geth revert", err: errors.New("execution reverted: Escrow: invalid secret"), class: ErrReverted},
+		{name: "cosmos sequence mismatch", err: errors.New("account sequence mismatch, expected 12, got 11: incorrect account sequence"), class: ErrNonceTooLow},
+		{name: "cosmos insufficient funds", err: errors.New("spendable balance 10basecro is smaller than 100basecro: insufficient funds"), class: ErrInsufficientFunds},
+		{name: "wasm execution failure", err: errors.New("failed to execute message; message index: 0: Timelock not expired: execute wasm contract failed"), class: ErrReverted},
+		{name: "cosmos out of gas", err: errors.New("out of gas in location: WriteFlat; gasWanted: 200000, gasUsed: 201234: out of gas"), class: ErrReverted},
+		{name: "connection refused", err: errors.New("post \"http://localhost:8545\": dial tcp 127.0.0.1:8545: connect: connection refused"), class: ErrRPCUnavailable},
+		{name: "rate limited", err: errors.New("429 Too Many Requests"), class: ErrRPCUnavailable},
+		{name: "bad gateway", err: errors.New("502 Bad Gateway: "), class: ErrRPCUnavailable},
+		{name: "unexpected eof", err: fmt.Errorf("failed to read response: %w", io.ErrUnexpectedEOF), class: ErrRPCUnavailable},
+		{name: "unrecognised", err: errors.New("abi: cannot unmarshal"), class: nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Classify(fmt.Errorf("failed to send transaction: %w", tc.err))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("classified error %v no longer wraps the original", err)
+			}
+			if got := classOf(err); got != tc.class {
+				t.Fatalf("expected class %v, got %v", tc.class, got)
+			}
+		})
+	}
+}
+
+func TestClassifyKeepsExistingClass(t *testing.T) {
+	err := Reverted(errors.New("transaction 0xabc failed: connection reset by peer"))
+	if got := Classify(err); got != err {
+		t.Fatalf("expected already classified error to be returned unchanged, got %v", got)
+	}
+	if Classify(nil) != nil {
+		t.Fatal("expected nil error to stay nil")
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	for _, tc := range []struct {
+		err       error
+		transient bool
+	}{
+		{err: Classify(errors.New("i/o timeout")), transient: true},
+		{err: Classify(errors.New("nonce too low")), transient: true},
+		{err: fmt.Errorf("failed to get block: %w", context.DeadlineExceeded), transient: true},
+		{err: Classify(errors.New("execution reverted")), transient: false},
+		{err: Classify(errors.New("insufficient funds")), transient: false},
+		{err: errors.New("unknown order type"), transient: false},
+	} {
+		if got := IsTransient(tc.err); got != tc.transient {
+			t.Fatalf("IsTransient(%v) = %v, expected %v", tc.err, got, tc.transient)
+		}
+	}
+}
+
+func TestRetryStaleNonce(t *testing.T) {
+	stale := Classify(errors.New("nonce too low: next nonce 5, tx nonce 4"))
+	reverted := Classify(errors.New("execution reverted"))
+
+	for _, tc := range []struct {
+		name      string
+		results   []error
+		resyncErr error
+		sends     int
+		resyncs   int
+		want      error
+	}{
+		{name: "success", results: []error{nil}, sends: 1},
+		{name: "other failure", results: []error{reverted}, sends: 1, want: ErrReverted},
+		{name: "recovers after resync", results: []error{stale, nil}, sends: 2, resyncs: 1},
+		{name: "still stale after resync", results: []error{stale, stale}, sends: 2, resyncs: 1, want: ErrNonceTooLow},
+		{name: "resync fails", results: []error{stale}, resyncErr: ErrRPCUnavailable, sends: 1, resyncs: 1, want: ErrRPCUnavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var sends, resyncs int
+			err := RetryStaleNonce(func() error {
+				sends++
+				return tc.results[sends-1]
+			}, func() error {
+				resyncs++
+				return tc.resyncErr
+			})
+
+			if tc.want == nil && err != nil {
+				t.Fatalf("expected success, got %v", err)
+			}
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+			if sends != tc.sends || resyncs != tc.resyncs {
+				t.Fatalf("expected %d sends and %d resyncs, got %d and %d", tc.sends, tc.resyncs, sends, resyncs)
+			}
+		})
+	}
+}
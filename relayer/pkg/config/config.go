@@ -2,9 +2,15 @@ package config
 
 import (
 	"fmt"
+	"math/big"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/viper"
 )
 
@@ -14,9 +20,25 @@ type Config struct {
 	Cronos   ChainConfig `mapstructure:"cronos"`
 	Ethereum ChainConfig `mapstructure:"ethereum"`
 
+	// EVM chains served, keyed by logical chain name. The ethereum and
+	// contracts.ethereum keys configure the PrimaryEVMChain entry unless it
+	// is listed here
+	Chains map[string]EVMChainConfig `mapstructure:"chains"`
+
 	// Contract addresses
 	Contracts ContractConfig `mapstructure:"contracts"`
 
+	// Assets keyed by symbol or Cosmos denom, e.g. USDC or basecro. Keys are
+	// matched case-insensitively. Orders take an asset's decimals from here,
+	// falling back to the token contract for ERC20 deposits and to 18
+	// otherwise
+	Assets map[string]AssetMeta `mapstructure:"assets"`
+
+	// Canonical symbols keyed by the raw denoms and symbols chains report
+	// for them, e.g. basecro: CRO or weth: ETH. Keys are matched
+	// case-insensitively, and assets without an alias are upper-cased
+	AssetAliases map[string]string `mapstructure:"asset_aliases"`
+
 	// Relayer configuration
 	Relayer RelayerConfig `mapstructure:"relayer"`
 
@@ -34,17 +56,104 @@ type Config struct {
 type ChainConfig struct {
 	ChainID     string `mapstructure:"chain_id"`
 	RPCEndpoint string `mapstructure:"rpc_endpoint"`
+	// Backup RPC endpoints for EVM chains. Reads are spread across the
+	// healthy endpoints and fail over when one goes down
+	RPCEndpoints []string `mapstructure:"rpc_endpoints"`
 	WSEndpoint  string `mapstructure:"ws_endpoint"`
 	GasPrice    string `mapstructure:"gas_price"`
 	GasLimit    uint64 `mapstructure:"gas_limit"`
 	// Private key for the relayer account
 	PrivateKey string `mapstructure:"private_key"`
+	// Additional relayer private keys; transactions are spread across all keys
+	// so they don't queue behind a single account's nonce
+	PrivateKeys []string `mapstructure:"private_keys"`
+	// How transactions pick a relayer account: "round_robin" or "least_busy"
+	KeySelection string `mapstructure:"key_selection"`
 	// Mnemonic as alternative to private key
 	Mnemonic string `mapstructure:"mnemonic"`
 	// HD derivation path
 	HDPath string `mapstructure:"hd_path"`
+	// Bech32 account address prefix on Cosmos chains, e.g. "crc" on Cronos
+	Bech32Prefix string `mapstructure:"bech32_prefix"`
+	// Transaction type for EVM chains: "legacy" or "dynamic" (EIP-1559)
+	TxType string `mapstructure:"tx_type"`
+	// Wait for Cosmos transactions to be included in a block and fail on
+	// DeliverTx errors instead of trusting the CheckTx result
+	WaitForTx     bool          `mapstructure:"wait_for_tx"`
+	TxWaitTimeout time.Duration `mapstructure:"tx_wait_timeout"`
+	// Retries and circuit breaker for calls to the node
+	RPCRetry RPCRetryConfig `mapstructure:"rpc_retry"`
+	// Resending EVM transactions that stay unmined with a higher fee
+	GasBump GasBumpConfig `mapstructure:"gas_bump"`
+}
+
+// PrimaryEVMChain names the EVM chain the order manager settles swaps on
+const PrimaryEVMChain = "ethereum"
+
+// EVMChainConfig holds an EVM chain and the contracts deployed on it
+type EVMChainConfig struct {
+	ChainConfig `mapstructure:",squash"`
+	Contracts   EthereumContracts `mapstructure:"contracts"`
 }
 
+// RPCRetryConfig controls how a chain client retries transient RPC failures
+// and when it stops calling an unreachable node
+type RPCRetryConfig struct {
+	// Attempts per call including the first; 0 or 1 disables retries
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// Delay before the first retry, doubled for each further retry up to
+	// MaxBackoff
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	// Consecutive failed calls that open the circuit breaker; 0 disables it
+	BreakerThreshold int `mapstructure:"breaker_threshold"`
+	// How long an open breaker rejects calls before letting a trial through
+	BreakerCooldown time.Duration `mapstructure:"breaker_cooldown"`
+}
+
+// GasBumpConfig controls how an EVM chain client replaces its transactions
+// that stay unmined, e.g. after gas prices spiked, by resending them with the
+// same nonce and a higher fee
+type GasBumpConfig struct {
+	// How long a transaction may stay unmined before it is replaced; 0
+	// disables bumping
+	StuckTimeout time.Duration `mapstructure:"stuck_timeout"`
+	// Fee increase of each replacement in percent. Nodes refuse replacements
+	// raising the fee by less than 10%
+	Percent int `mapstructure:"percent"`
+	// Highest gas price in wei, or fee cap of dynamic fee transactions, a
+	// replacement may pay
+	MaxGasPrice string `mapstructure:"max_gas_price"`
+}
+
+// MinGasBumpPercent is the smallest fee increase nodes accept for a
+// replacement transaction
+const MinGasBumpPercent = 10
+
+// Supported EVM transaction types
+const (
+	TxTypeLegacy  = "legacy"
+	TxTypeDynamic = "dynamic"
+)
+
+// Relayer account selection strategies
+const (
+	KeySelectionRoundRobin = "round_robin"
+	KeySelectionLeastBusy  = "least_busy"
+)
+
+// Order matching strategies
+const (
+	MatchingStrategyPriceTime    = "price_time"
+	MatchingStrategyDutchAuction = "dutch_auction"
+)
+
+// Dutch auction price curves
+const (
+	AuctionCurveLinear      = "linear"
+	AuctionCurveExponential = "exponential"
+)
+
 // ContractConfig holds contract addresses for both chains
 type ContractConfig struct {
 	Cronos   CronosContracts   `mapstructure:"cronos"`
@@ -61,6 +170,8 @@ type CronosContracts struct {
 	// Code IDs for contract instantiation
 	SourceEscrowCodeID      uint64 `mapstructure:"source_escrow_code_id"`
 	DestinationEscrowCodeID uint64 `mapstructure:"destination_escrow_code_id"`
+	// Instantiate escrows from the code IDs instead of through the factory
+	InstantiateEscrows bool `mapstructure:"instantiate_escrows"`
 }
 
 // EthereumContracts holds Solidity contract addresses on Ethereum
@@ -78,6 +189,11 @@ type RelayerConfig struct {
 	BlockPollInterval    time.Duration `mapstructure:"block_poll_interval"`
 	EventPollInterval    time.Duration `mapstructure:"event_poll_interval"`
 	OrderUpdateInterval  time.Duration `mapstructure:"order_update_interval"`
+
+	// Fraction the polling intervals are randomly stretched or shrunk by on
+	// every cycle, e.g. 0.2 for ±20%, so replicas sharing an RPC node don't
+	// poll it in lockstep; 0 disables the jitter
+	PollJitter float64 `mapstructure:"poll_jitter"`
 	
 	// Retry configuration
 	MaxRetries    int           `mapstructure:"max_retries"`
@@ -85,12 +201,111 @@ type RelayerConfig struct {
 	
 	// Timeouts
 	TransactionTimeout time.Duration `mapstructure:"transaction_timeout"`
+
+	// Blocks an escrow must be buried under before it is acted on
+	ConfirmationDepth ConfirmationDepthConfig `mapstructure:"confirmation_depth"`
+
+	// Outbound RPC calls allowed per second to each chain's node; 0 disables
+	// the limit
+	MaxRPCPerSecond RPCRateLimitConfig `mapstructure:"max_rpc_per_second"`
+
+	// Minimum gap between destination and source escrow timelocks
+	TimelockSafetyMargin time.Duration `mapstructure:"timelock_safety_margin"`
 	
 	// Batch processing
 	BatchSize int `mapstructure:"batch_size"`
+
+	// Maximum number of blocks per log query when scanning for events
+	LogScanBatchSize uint64 `mapstructure:"log_scan_batch_size"`
+
+	// Log scan batches fetched in parallel when a scanner catches up on more
+	// than one batch of blocks, e.g. after downtime; 0 or 1 scans serially
+	CatchupWorkers int `mapstructure:"catchup_workers"`
+
+	// Longest a single order scan of a chain may take before it is abandoned
+	// until the next poll; 0 disables the limit
+	ScanTimeout time.Duration `mapstructure:"scan_timeout"`
+
+	// Blocks a chain scanner may trail the chain tip before the health check
+	// warns; 0 disables the alert
+	MaxBlockLag uint64 `mapstructure:"max_block_lag"`
+
+	// File unfinished orders are saved to on shutdown and restored from on
+	// startup; empty disables persistence
+	OrderStorePath string `mapstructure:"order_store_path"`
+
+	// File the last scanned block of each chain is saved to on shutdown and
+	// resumed from on startup; empty rescans from StartBlock every time
+	CheckpointPath string `mapstructure:"checkpoint_path"`
+
+	// Blocks the order scanners start from when there is no checkpoint yet;
+	// 0 scans from genesis
+	StartBlock StartBlockConfig `mapstructure:"start_block"`
+
+	// Maximum number of status transitions kept per order; 0 disables the
+	// history
+	MaxOrderHistory int `mapstructure:"max_order_history"`
+
+	// Longest an order may go unmatched after it was created before it is
+	// expired and its escrow cancelled, however far out its timelock is; 0
+	// disables the limit
+	MaxOrderLifetime time.Duration `mapstructure:"max_order_lifetime"`
+
+	// Completed, failed, expired and cancelled orders are POSTed to
+	// WebhookURL; empty disables the webhook. Requests are signed with an
+	// HMAC-SHA256 of the body keyed with WebhookSecret, and failed deliveries
+	// are retried with backoff doubling from WebhookRetryInterval
+	WebhookURL           string        `mapstructure:"webhook_url"`
+	WebhookSecret        string        `mapstructure:"webhook_secret"`
+	WebhookMaxAttempts   int           `mapstructure:"webhook_max_attempts"`
+	WebhookRetryInterval time.Duration `mapstructure:"webhook_retry_interval"`
 	
-	// Fee configuration
+	// Fee configuration. The relayer charges RelayerFeePercentage percent of
+	// the source amount it withdraws; orders too small to pay a fee of at
+	// least one unit are rejected
 	RelayerFeePercentage float64 `mapstructure:"relayer_fee_percentage"`
+
+	// Strategy used to pair complementary orders, MatchingStrategyPriceTime
+	// or MatchingStrategyDutchAuction
+	MatchingStrategy string `mapstructure:"matching_strategy"`
+
+	// Ethereum escrows whose ERC20 token delivered less than the deposited
+	// amount, like fee-on-transfer tokens, are ignored rather than relayed
+	// for the amount actually received
+	RejectFeeOnTransferTokens bool `mapstructure:"reject_fee_on_transfer_tokens"`
+
+	// HTTP API makers submit signed orders through
+	API APIConfig `mapstructure:"api"`
+}
+
+// APIConfig holds the relayer's HTTP API configuration
+type APIConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Host    string `mapstructure:"host"`
+	Port    int    `mapstructure:"port"`
+
+	// Bearer token operator endpoints require, like cancelling or resolving
+	// an order by hand. Empty leaves cancelling open and disables the
+	// endpoints that force an order's status
+	AuthToken string `mapstructure:"auth_token"`
+}
+
+// ConfirmationDepthConfig holds per-chain confirmation requirements
+type ConfirmationDepthConfig struct {
+	Cronos   uint64 `mapstructure:"cronos"`
+	Ethereum uint64 `mapstructure:"ethereum"`
+}
+
+// StartBlockConfig holds the per-chain blocks scanning starts from
+type StartBlockConfig struct {
+	Cronos   uint64 `mapstructure:"cronos"`
+	Ethereum uint64 `mapstructure:"ethereum"`
+}
+
+// RPCRateLimitConfig holds per-chain RPC rate limits
+type RPCRateLimitConfig struct {
+	Cronos   float64 `mapstructure:"cronos"`
+	Ethereum float64 `mapstructure:"ethereum"`
 }
 
 // IBCConfig holds IBC-related configuration
@@ -109,12 +324,34 @@ type IBCConfig struct {
 	RelayerEndpoint string `mapstructure:"relayer_endpoint"`
 }
 
+// AssetMeta describes an asset the relayer handles
+type AssetMeta struct {
+	// Decimal places of the asset's base unit
+	Decimals int `mapstructure:"decimals"`
+	// ERC20 contract of the asset on Ethereum, if it is a token there
+	ContractAddress string `mapstructure:"contract_address"`
+}
+
 // DutchAuctionConfig holds Dutch auction parameters
 type DutchAuctionConfig struct {
 	// Default parameters for new orders
 	DefaultDecayRate    string        `mapstructure:"default_decay_rate"`
 	DefaultMinimumPrice string        `mapstructure:"default_minimum_price"`
 	MaxAuctionDuration  time.Duration `mapstructure:"max_auction_duration"`
+	// Orders whose price takes longer than MaxAuctionDuration to decay to its
+	// minimum are rejected, or clamped to end at MaxAuctionDuration when set
+	ClampAuctionDuration bool `mapstructure:"clamp_auction_duration"`
+	// Curve new auctions decay along, AuctionCurveLinear or
+	// AuctionCurveExponential. An exponential auction starts falling at its
+	// decay rate and slows down as it nears its minimum price
+	DefaultCurveType string `mapstructure:"default_curve_type"`
+
+	// Market price endpoint auction fills are checked against; empty
+	// disables the check. Auctions whose price is more than
+	// MaxOracleDeviation percent away from the oracle price aren't filled
+	PriceOracleURL     string        `mapstructure:"price_oracle_url"`
+	PriceOracleTimeout time.Duration `mapstructure:"price_oracle_timeout"`
+	MaxOracleDeviation float64       `mapstructure:"max_oracle_deviation"`
 	
 	// Price update frequency
 	PriceUpdateInterval time.Duration `mapstructure:"price_update_interval"`
@@ -147,7 +384,7 @@ func LoadConfig(configPath string) (*Config, error) {
 
 	// Read environment variables
 	viper.AutomaticEnv()
-	viper.SetEnvPrefix("BRIDGE")
+	viper.SetEnvPrefix(envPrefix)
 
 	// Read config file
 	if err := viper.ReadInConfig(); err != nil {
@@ -160,6 +397,7 @@ func LoadConfig(configPath string) (*Config, error) {
 	if err := viper.Unmarshal(config); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
+	resolveChains(config)
 
 	// Validate config
 	if err := validateConfig(config); err != nil {
@@ -176,21 +414,53 @@ func setDefaults() {
 	viper.SetDefault("cronos.gas_price", "5000000000000basecro")
 	viper.SetDefault("cronos.gas_limit", 300000)
 	viper.SetDefault("cronos.hd_path", "m/44'/60'/0'/0/0")
+	viper.SetDefault("cronos.bech32_prefix", "crc")
+	viper.SetDefault("cronos.wait_for_tx", true)
+	viper.SetDefault("cronos.tx_wait_timeout", "60s")
+	setRPCRetryDefaults("cronos")
 
 	// Ethereum defaults
 	viper.SetDefault("ethereum.chain_id", "1")
 	viper.SetDefault("ethereum.gas_price", "20000000000")
 	viper.SetDefault("ethereum.gas_limit", 300000)
+	viper.SetDefault("ethereum.tx_type", TxTypeLegacy)
+	viper.SetDefault("ethereum.key_selection", KeySelectionRoundRobin)
+	setRPCRetryDefaults("ethereum")
+	setGasBumpDefaults("ethereum")
 
 	// Relayer defaults
 	viper.SetDefault("relayer.block_poll_interval", "5s")
 	viper.SetDefault("relayer.event_poll_interval", "10s")
 	viper.SetDefault("relayer.order_update_interval", "30s")
+	viper.SetDefault("relayer.poll_jitter", 0)
 	viper.SetDefault("relayer.max_retries", 3)
 	viper.SetDefault("relayer.retry_interval", "10s")
 	viper.SetDefault("relayer.transaction_timeout", "60s")
+	viper.SetDefault("relayer.timelock_safety_margin", "1h")
+	viper.SetDefault("relayer.confirmation_depth.cronos", 1)
+	viper.SetDefault("relayer.confirmation_depth.ethereum", 12)
+	viper.SetDefault("relayer.max_rpc_per_second.cronos", 0)
+	viper.SetDefault("relayer.max_rpc_per_second.ethereum", 0)
 	viper.SetDefault("relayer.batch_size", 10)
+	viper.SetDefault("relayer.log_scan_batch_size", 5000)
+	viper.SetDefault("relayer.catchup_workers", 4)
+	viper.SetDefault("relayer.scan_timeout", "2m")
+	viper.SetDefault("relayer.max_block_lag", 100)
+	viper.SetDefault("relayer.order_store_path", "data/orders.json")
+	viper.SetDefault("relayer.checkpoint_path", "data/checkpoint.json")
+	viper.SetDefault("relayer.start_block.cronos", 0)
+	viper.SetDefault("relayer.start_block.ethereum", 0)
+	viper.SetDefault("relayer.max_order_history", 50)
+	viper.SetDefault("relayer.max_order_lifetime", "24h")
+	viper.SetDefault("relayer.reject_fee_on_transfer_tokens", false)
+	viper.SetDefault("relayer.webhook_max_attempts", 5)
+	viper.SetDefault("relayer.webhook_retry_interval", "2s")
 	viper.SetDefault("relayer.relayer_fee_percentage", 0.1)
+	viper.SetDefault("relayer.matching_strategy", MatchingStrategyPriceTime)
+	viper.SetDefault("relayer.api.enabled", false)
+	viper.SetDefault("relayer.api.host", "127.0.0.1")
+	viper.SetDefault("relayer.api.port", 8080)
+	viper.SetDefault("relayer.api.auth_token", "")
 
 	// IBC defaults
 	viper.SetDefault("ibc.transfer_port", "transfer")
@@ -200,6 +470,11 @@ func setDefaults() {
 	viper.SetDefault("dutch_auction.default_decay_rate", "1000000000000000000")
 	viper.SetDefault("dutch_auction.default_minimum_price", "1000000000000000000")
 	viper.SetDefault("dutch_auction.max_auction_duration", "24h")
+	viper.SetDefault("dutch_auction.clamp_auction_duration", false)
+	viper.SetDefault("dutch_auction.default_curve_type", AuctionCurveLinear)
+	viper.SetDefault("dutch_auction.price_oracle_url", "")
+	viper.SetDefault("dutch_auction.price_oracle_timeout", "5s")
+	viper.SetDefault("dutch_auction.max_oracle_deviation", 5)
 	viper.SetDefault("dutch_auction.price_update_interval", "60s")
 
 	// Logging defaults
@@ -208,6 +483,92 @@ func setDefaults() {
 	viper.SetDefault("logging.output_path", "stdout")
 }
 
+// defaultRPCRetryConfig returns the RPC retry settings used by both chains
+// unless configured
+func defaultRPCRetryConfig() RPCRetryConfig {
+	return RPCRetryConfig{
+		MaxAttempts:      3,
+		InitialBackoff:   500 * time.Millisecond,
+		MaxBackoff:       5 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+	}
+}
+
+// setRPCRetryDefaults sets the RPC retry defaults of a chain
+func setRPCRetryDefaults(chain string) {
+	defaults := defaultRPCRetryConfig()
+	viper.SetDefault(chain+".rpc_retry.max_attempts", defaults.MaxAttempts)
+	viper.SetDefault(chain+".rpc_retry.initial_backoff", defaults.InitialBackoff)
+	viper.SetDefault(chain+".rpc_retry.max_backoff", defaults.MaxBackoff)
+	viper.SetDefault(chain+".rpc_retry.breaker_threshold", defaults.BreakerThreshold)
+	viper.SetDefault(chain+".rpc_retry.breaker_cooldown", defaults.BreakerCooldown)
+}
+
+// defaultGasBumpConfig returns the gas bumping settings of EVM chains unless
+// configured
+func defaultGasBumpConfig() GasBumpConfig {
+	return GasBumpConfig{
+		StuckTimeout: 3 * time.Minute,
+		Percent:      20,
+		MaxGasPrice:  "500000000000",
+	}
+}
+
+// setGasBumpDefaults sets the gas bumping defaults of an EVM chain
+func setGasBumpDefaults(chain string) {
+	defaults := defaultGasBumpConfig()
+	viper.SetDefault(chain+".gas_bump.stuck_timeout", defaults.StuckTimeout)
+	viper.SetDefault(chain+".gas_bump.percent", defaults.Percent)
+	viper.SetDefault(chain+".gas_bump.max_gas_price", defaults.MaxGasPrice)
+}
+
+// resolveChains reconciles the chains map with the legacy ethereum keys. The
+// ethereum and contracts.ethereum keys become the PrimaryEVMChain entry when
+// it isn't listed, and a listed entry is copied back to them so code reading
+// the legacy fields sees the primary chain. Listed chains get the same
+// defaults the legacy keys do
+func resolveChains(config *Config) {
+	if config.Chains == nil {
+		config.Chains = make(map[string]EVMChainConfig)
+	}
+
+	for name, chain := range config.Chains {
+		applyChainDefaults(&chain.ChainConfig)
+		config.Chains[name] = chain
+	}
+
+	if primary, ok := config.Chains[PrimaryEVMChain]; ok {
+		config.Ethereum = primary.ChainConfig
+		config.Contracts.Ethereum = primary.Contracts
+	} else if config.Ethereum.RPCEndpoint != "" || len(config.Ethereum.RPCEndpoints) > 0 {
+		config.Chains[PrimaryEVMChain] = EVMChainConfig{
+			ChainConfig: config.Ethereum,
+			Contracts:   config.Contracts.Ethereum,
+		}
+	}
+}
+
+// applyChainDefaults fills the unset settings of an EVM chain listed under
+// chains with the defaults of the ethereum key
+func applyChainDefaults(chain *ChainConfig) {
+	if chain.GasLimit == 0 {
+		chain.GasLimit = 300000
+	}
+	if chain.TxType == "" {
+		chain.TxType = TxTypeLegacy
+	}
+	if chain.KeySelection == "" {
+		chain.KeySelection = KeySelectionRoundRobin
+	}
+	if chain.RPCRetry == (RPCRetryConfig{}) {
+		chain.RPCRetry = defaultRPCRetryConfig()
+	}
+	if chain.GasBump == (GasBumpConfig{}) {
+		chain.GasBump = defaultGasBumpConfig()
+	}
+}
+
 // validateConfig validates the loaded configuration
 func validateConfig(config *Config) error {
 	// Validate chain configurations
@@ -217,21 +578,97 @@ func validateConfig(config *Config) error {
 	if config.Cronos.RPCEndpoint == "" {
 		return fmt.Errorf("cronos.rpc_endpoint is required")
 	}
+	if !validBech32Prefix(config.Cronos.Bech32Prefix) {
+		return fmt.Errorf("cronos.bech32_prefix must be lowercase letters and digits, got %q", config.Cronos.Bech32Prefix)
+	}
+	if config.Cronos.WaitForTx && config.Cronos.TxWaitTimeout <= 0 {
+		return fmt.Errorf("cronos.tx_wait_timeout must be positive when wait_for_tx is set")
+	}
 	if config.Ethereum.ChainID == "" {
 		return fmt.Errorf("ethereum.chain_id is required")
 	}
-	if config.Ethereum.RPCEndpoint == "" {
-		return fmt.Errorf("ethereum.rpc_endpoint is required")
+	if config.Ethereum.RPCEndpoint == "" && len(config.Ethereum.RPCEndpoints) == 0 {
+		return fmt.Errorf("ethereum.rpc_endpoint or rpc_endpoints is required")
+	}
+	if config.Ethereum.TxType != TxTypeLegacy && config.Ethereum.TxType != TxTypeDynamic {
+		return fmt.Errorf("ethereum.tx_type must be %q or %q", TxTypeLegacy, TxTypeDynamic)
+	}
+	if config.Ethereum.KeySelection != KeySelectionRoundRobin && config.Ethereum.KeySelection != KeySelectionLeastBusy {
+		return fmt.Errorf("ethereum.key_selection must be %q or %q", KeySelectionRoundRobin, KeySelectionLeastBusy)
 	}
 
 	// Validate private keys or mnemonics
 	if config.Cronos.PrivateKey == "" && config.Cronos.Mnemonic == "" {
 		return fmt.Errorf("cronos private_key or mnemonic is required")
 	}
-	if config.Ethereum.PrivateKey == "" && config.Ethereum.Mnemonic == "" {
+	if config.Ethereum.PrivateKey == "" && len(config.Ethereum.PrivateKeys) == 0 && config.Ethereum.Mnemonic == "" {
 		return fmt.Errorf("ethereum private_key or mnemonic is required")
 	}
 
+	if err := validateRPCRetry("cronos", config.Cronos.RPCRetry); err != nil {
+		return err
+	}
+	if err := validateRPCRetry("ethereum", config.Ethereum.RPCRetry); err != nil {
+		return err
+	}
+	if err := validateGasBump("ethereum", config.Ethereum.GasBump); err != nil {
+		return err
+	}
+	if err := validateChains(config.Chains); err != nil {
+		return err
+	}
+
+	if config.Relayer.MaxRPCPerSecond.Cronos < 0 || config.Relayer.MaxRPCPerSecond.Ethereum < 0 {
+		return fmt.Errorf("relayer.max_rpc_per_second must not be negative")
+	}
+	if config.Relayer.RelayerFeePercentage < 0 || config.Relayer.RelayerFeePercentage >= 100 {
+		return fmt.Errorf("relayer.relayer_fee_percentage must be at least 0 and below 100")
+	}
+	if config.Relayer.PollJitter < 0 || config.Relayer.PollJitter >= 1 {
+		return fmt.Errorf("relayer.poll_jitter must be at least 0 and below 1")
+	}
+	switch config.Relayer.MatchingStrategy {
+	case "", MatchingStrategyPriceTime, MatchingStrategyDutchAuction:
+	default:
+		return fmt.Errorf("relayer.matching_strategy must be %q or %q", MatchingStrategyPriceTime, MatchingStrategyDutchAuction)
+	}
+	if config.Relayer.ScanTimeout < 0 {
+		return fmt.Errorf("relayer.scan_timeout must not be negative")
+	}
+	if config.Relayer.CatchupWorkers < 0 {
+		return fmt.Errorf("relayer.catchup_workers must not be negative")
+	}
+	if config.Relayer.MaxOrderHistory < 0 {
+		return fmt.Errorf("relayer.max_order_history must not be negative")
+	}
+	if config.Relayer.MaxOrderLifetime < 0 {
+		return fmt.Errorf("relayer.max_order_lifetime must not be negative")
+	}
+	if err := validateWebhook(&config.Relayer); err != nil {
+		return err
+	}
+	if config.Relayer.API.Enabled && (config.Relayer.API.Port <= 0 || config.Relayer.API.Port > 65535) {
+		return fmt.Errorf("relayer.api.port must be between 1 and 65535")
+	}
+	if config.DutchAuction.MaxAuctionDuration < 0 {
+		return fmt.Errorf("dutch_auction.max_auction_duration must not be negative")
+	}
+	switch config.DutchAuction.DefaultCurveType {
+	case "", AuctionCurveLinear, AuctionCurveExponential:
+	default:
+		return fmt.Errorf("dutch_auction.default_curve_type must be %q or %q", AuctionCurveLinear, AuctionCurveExponential)
+	}
+	if config.DutchAuction.PriceOracleURL != "" && config.DutchAuction.MaxOracleDeviation <= 0 {
+		return fmt.Errorf("dutch_auction.max_oracle_deviation must be positive when price_oracle_url is set")
+	}
+
+	if err := validateAssets(config.Assets); err != nil {
+		return err
+	}
+	if err := validateAssetAliases(config.AssetAliases); err != nil {
+		return err
+	}
+
 	// Validate contract addresses
 	if config.Contracts.Cronos.EscrowFactory == "" {
 		return fmt.Errorf("contracts.cronos.escrow_factory is required")
@@ -239,6 +676,260 @@ func validateConfig(config *Config) error {
 	if config.Contracts.Ethereum.EscrowFactory == "" {
 		return fmt.Errorf("contracts.ethereum.escrow_factory is required")
 	}
+	if err := validateContracts(&config.Contracts); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateChains checks every listed EVM chain like the ethereum key and
+// that no two of them share a chain ID. The PrimaryEVMChain entry mirrors the
+// ethereum keys, which are validated as such
+func validateChains(chains map[string]EVMChainConfig) error {
+	chainIDs := make(map[string]string, len(chains))
+	for _, name := range ChainNames(chains) {
+		chain := chains[name]
+		key := "chains." + name
+
+		if chain.ChainID == "" {
+			return fmt.Errorf("%s.chain_id is required", key)
+		}
+		if other, dup := chainIDs[chain.ChainID]; dup {
+			return fmt.Errorf("%s.chain_id %s is already used by chains.%s", key, chain.ChainID, other)
+		}
+		chainIDs[chain.ChainID] = name
+		if name == PrimaryEVMChain {
+			continue
+		}
+
+		if chain.RPCEndpoint == "" && len(chain.RPCEndpoints) == 0 {
+			return fmt.Errorf("%s.rpc_endpoint or rpc_endpoints is required", key)
+		}
+		if chain.TxType != TxTypeLegacy && chain.TxType != TxTypeDynamic {
+			return fmt.Errorf("%s.tx_type must be %q or %q", key, TxTypeLegacy, TxTypeDynamic)
+		}
+		if chain.KeySelection != KeySelectionRoundRobin && chain.KeySelection != KeySelectionLeastBusy {
+			return fmt.Errorf("%s.key_selection must be %q or %q", key, KeySelectionRoundRobin, KeySelectionLeastBusy)
+		}
+		if chain.PrivateKey == "" && len(chain.PrivateKeys) == 0 && chain.Mnemonic == "" {
+			return fmt.Errorf("%s private_key or mnemonic is required", key)
+		}
+		if err := validateRPCRetry(key, chain.RPCRetry); err != nil {
+			return err
+		}
+		if err := validateGasBump(key, chain.GasBump); err != nil {
+			return err
+		}
+		if !common.IsHexAddress(chain.Contracts.EscrowFactory) {
+			return fmt.Errorf("%s.contracts.escrow_factory %q must be a hex address", key, chain.Contracts.EscrowFactory)
+		}
+	}
+	return nil
+}
+
+// validateAssets checks the decimals and contract address of every
+// configured asset
+func validateAssets(assets map[string]AssetMeta) error {
+	names := make([]string, 0, len(assets))
+	for name := range assets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		asset := assets[name]
+		if asset.Decimals < 0 || asset.Decimals > 255 {
+			return fmt.Errorf("assets.%s.decimals must be between 0 and 255", name)
+		}
+		if asset.ContractAddress != "" && !common.IsHexAddress(asset.ContractAddress) {
+			return fmt.Errorf("assets.%s.contract_address %q must be a hex address", name, asset.ContractAddress)
+		}
+	}
+	return nil
+}
+
+// validateAssetAliases checks that every alias names a canonical symbol that
+// isn't itself aliased, so a denom normalizes the same in one step
+func validateAssetAliases(aliases map[string]string) error {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		symbol := strings.TrimSpace(aliases[name])
+		if symbol == "" {
+			return fmt.Errorf("asset_aliases.%s must not be empty", name)
+		}
+		for alias, target := range aliases {
+			if strings.EqualFold(alias, symbol) && !strings.EqualFold(target, symbol) {
+				return fmt.Errorf("asset_aliases.%s maps to %s, which is itself an alias of %s", name, symbol, target)
+			}
+		}
+	}
+	return nil
+}
+
+// CanonicalSymbol returns the symbol orders use for a raw denom or symbol:
+// its configured alias, or the upper-cased denom when it has none
+func (c *Config) CanonicalSymbol(denom string) string {
+	denom = strings.TrimSpace(denom)
+	for alias, symbol := range c.AssetAliases {
+		if strings.EqualFold(alias, denom) {
+			return strings.ToUpper(strings.TrimSpace(symbol))
+		}
+	}
+	return strings.ToUpper(denom)
+}
+
+// Asset returns the configured asset with the given symbol or denom
+func (c *Config) Asset(symbol string) (AssetMeta, bool) {
+	for name, asset := range c.Assets {
+		if strings.EqualFold(name, symbol) {
+			return asset, true
+		}
+	}
+	return AssetMeta{}, false
+}
+
+// AssetByContract returns the configured asset with the given ERC20 contract
+// address
+func (c *Config) AssetByContract(address string) (AssetMeta, bool) {
+	for _, asset := range c.Assets {
+		if asset.ContractAddress != "" && strings.EqualFold(asset.ContractAddress, address) {
+			return asset, true
+		}
+	}
+	return AssetMeta{}, false
+}
+
+// ChainNames returns the names of chains in sorted order
+func ChainNames(chains map[string]EVMChainConfig) []string {
+	names := make([]string, 0, len(chains))
+	for name := range chains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateRPCRetry checks that a chain's RPC retry settings are not negative
+func validateRPCRetry(chain string, cfg RPCRetryConfig) error {
+	if cfg.MaxAttempts < 0 {
+		return fmt.Errorf("%s.rpc_retry.max_attempts must not be negative", chain)
+	}
+	if cfg.InitialBackoff < 0 || cfg.MaxBackoff < 0 {
+		return fmt.Errorf("%s.rpc_retry backoff must not be negative", chain)
+	}
+	if cfg.BreakerThreshold < 0 {
+		return fmt.Errorf("%s.rpc_retry.breaker_threshold must not be negative", chain)
+	}
+	if cfg.BreakerThreshold > 0 && cfg.BreakerCooldown <= 0 {
+		return fmt.Errorf("%s.rpc_retry.breaker_cooldown must be positive when the breaker is enabled", chain)
+	}
+	return nil
+}
+
+// validateGasBump checks that an EVM chain's gas bumping settings can produce
+// replacements nodes accept, when bumping is enabled
+func validateGasBump(chain string, cfg GasBumpConfig) error {
+	if cfg.StuckTimeout < 0 {
+		return fmt.Errorf("%s.gas_bump.stuck_timeout must not be negative", chain)
+	}
+	if cfg.StuckTimeout == 0 {
+		return nil
+	}
+	if cfg.Percent < MinGasBumpPercent {
+		return fmt.Errorf("%s.gas_bump.percent must be at least %d", chain, MinGasBumpPercent)
+	}
+	if maxGasPrice, ok := new(big.Int).SetString(cfg.MaxGasPrice, 10); !ok || maxGasPrice.Sign() <= 0 {
+		return fmt.Errorf("%s.gas_bump.max_gas_price must be a positive amount of wei, got %q", chain, cfg.MaxGasPrice)
+	}
+	return nil
+}
+
+// validateWebhook checks the webhook URL and delivery settings when a webhook
+// is configured
+func validateWebhook(relayer *RelayerConfig) error {
+	if relayer.WebhookURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(relayer.WebhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("relayer.webhook_url %q must be an absolute http or https URL", relayer.WebhookURL)
+	}
+	if relayer.WebhookSecret == "" {
+		return fmt.Errorf("relayer.webhook_secret is required when webhook_url is set")
+	}
+	if relayer.WebhookMaxAttempts < 0 {
+		return fmt.Errorf("relayer.webhook_max_attempts must not be negative")
+	}
+	if relayer.WebhookRetryInterval < 0 {
+		return fmt.Errorf("relayer.webhook_retry_interval must not be negative")
+	}
+	return nil
+}
+
+// validBech32Prefix reports whether prefix can be used as a bech32 address
+// prefix. An empty prefix leaves the SDK's default in place
+func validBech32Prefix(prefix string) bool {
+	for _, r := range prefix {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// validateContracts checks that configured contract addresses are well formed
+// and that code IDs are set when escrows are instantiated directly
+func validateContracts(contracts *ContractConfig) error {
+	for _, c := range []struct {
+		key     string
+		address string
+	}{
+		{"contracts.cronos.escrow_factory", contracts.Cronos.EscrowFactory},
+		{"contracts.cronos.escrow_resolver", contracts.Cronos.EscrowResolver},
+		{"contracts.cronos.dutch_auction", contracts.Cronos.DutchAuction},
+		{"contracts.cronos.partial_fill", contracts.Cronos.PartialFill},
+		{"contracts.cronos.ibc_bridge_adapter", contracts.Cronos.IBCBridgeAdapter},
+	} {
+		if c.address == "" {
+			continue
+		}
+		if _, _, err := bech32.DecodeAndConvert(c.address); err != nil {
+			return fmt.Errorf("%s %q is not a valid bech32 address: %w", c.key, c.address, err)
+		}
+	}
+
+	for _, c := range []struct {
+		key     string
+		address string
+	}{
+		{"contracts.ethereum.escrow_factory", contracts.Ethereum.EscrowFactory},
+		{"contracts.ethereum.resolver", contracts.Ethereum.Resolver},
+		{"contracts.ethereum.ibc_handler", contracts.Ethereum.IBCHandler},
+		{"contracts.ethereum.limit_order_protocol", contracts.Ethereum.LimitOrderProtocol},
+	} {
+		if c.address == "" {
+			continue
+		}
+		if !common.IsHexAddress(c.address) {
+			return fmt.Errorf("%s %q is not a valid hex address", c.key, c.address)
+		}
+	}
+
+	if contracts.Cronos.InstantiateEscrows {
+		if contracts.Cronos.SourceEscrowCodeID == 0 {
+			return fmt.Errorf("contracts.cronos.source_escrow_code_id is required when instantiate_escrows is set")
+		}
+		if contracts.Cronos.DestinationEscrowCodeID == 0 {
+			return fmt.Errorf("contracts.cronos.destination_escrow_code_id is required when instantiate_escrows is set")
+		}
+	}
 
 	return nil
 }
@@ -247,23 +938,31 @@ func validateConfig(config *Config) error {
 func GetConfigFromEnv() (*Config, error) {
 	config := &Config{
 		Cronos: ChainConfig{
-			ChainID:     getEnvOrDefault("BRIDGE_CRONOS_CHAIN_ID", "cronos_777-1"),
-			RPCEndpoint: getEnvOrDefault("BRIDGE_CRONOS_RPC_ENDPOINT", ""),
-			WSEndpoint:  getEnvOrDefault("BRIDGE_CRONOS_WS_ENDPOINT", ""),
-			GasPrice:    getEnvOrDefault("BRIDGE_CRONOS_GAS_PRICE", "5000000000000basecro"),
-			GasLimit:    300000,
-			PrivateKey:  getEnvOrDefault("BRIDGE_CRONOS_PRIVATE_KEY", ""),
-			Mnemonic:    getEnvOrDefault("BRIDGE_CRONOS_MNEMONIC", ""),
-			HDPath:      getEnvOrDefault("BRIDGE_CRONOS_HD_PATH", "m/44'/60'/0'/0/0"),
+			ChainID:       getEnvOrDefault("BRIDGE_CRONOS_CHAIN_ID", "cronos_777-1"),
+			RPCEndpoint:   getEnvOrDefault("BRIDGE_CRONOS_RPC_ENDPOINT", ""),
+			WSEndpoint:    getEnvOrDefault("BRIDGE_CRONOS_WS_ENDPOINT", ""),
+			GasPrice:      getEnvOrDefault("BRIDGE_CRONOS_GAS_PRICE", "5000000000000basecro"),
+			GasLimit:      300000,
+			PrivateKey:    getEnvOrDefault("BRIDGE_CRONOS_PRIVATE_KEY", ""),
+			Mnemonic:      getEnvOrDefault("BRIDGE_CRONOS_MNEMONIC", ""),
+			HDPath:        getEnvOrDefault("BRIDGE_CRONOS_HD_PATH", "m/44'/60'/0'/0/0"),
+			WaitForTx:     true,
+			TxWaitTimeout: 60 * time.Second,
+			RPCRetry:      defaultRPCRetryConfig(),
 		},
 		Ethereum: ChainConfig{
-			ChainID:     getEnvOrDefault("BRIDGE_ETHEREUM_CHAIN_ID", "1"),
-			RPCEndpoint: getEnvOrDefault("BRIDGE_ETHEREUM_RPC_ENDPOINT", ""),
-			WSEndpoint:  getEnvOrDefault("BRIDGE_ETHEREUM_WS_ENDPOINT", ""),
-			GasPrice:    getEnvOrDefault("BRIDGE_ETHEREUM_GAS_PRICE", "20000000000"),
-			GasLimit:    300000,
-			PrivateKey:  getEnvOrDefault("BRIDGE_ETHEREUM_PRIVATE_KEY", ""),
-			Mnemonic:    getEnvOrDefault("BRIDGE_ETHEREUM_MNEMONIC", ""),
+			ChainID:      getEnvOrDefault("BRIDGE_ETHEREUM_CHAIN_ID", "1"),
+			RPCEndpoint:  getEnvOrDefault("BRIDGE_ETHEREUM_RPC_ENDPOINT", ""),
+			RPCEndpoints: splitEnvList(getEnvOrDefault("BRIDGE_ETHEREUM_RPC_ENDPOINTS", "")),
+			WSEndpoint:   getEnvOrDefault("BRIDGE_ETHEREUM_WS_ENDPOINT", ""),
+			GasPrice:     getEnvOrDefault("BRIDGE_ETHEREUM_GAS_PRICE", "20000000000"),
+			GasLimit:     300000,
+			PrivateKey:   getEnvOrDefault("BRIDGE_ETHEREUM_PRIVATE_KEY", ""),
+			PrivateKeys:  splitEnvList(getEnvOrDefault("BRIDGE_ETHEREUM_PRIVATE_KEYS", "")),
+			Mnemonic:     getEnvOrDefault("BRIDGE_ETHEREUM_MNEMONIC", ""),
+			TxType:       getEnvOrDefault("BRIDGE_ETHEREUM_TX_TYPE", TxTypeLegacy),
+			KeySelection: getEnvOrDefault("BRIDGE_ETHEREUM_KEY_SELECTION", KeySelectionRoundRobin),
+			RPCRetry:     defaultRPCRetryConfig(),
 		},
 		Contracts: ContractConfig{
 			Cronos: CronosContracts{
@@ -278,6 +977,7 @@ func GetConfigFromEnv() (*Config, error) {
 		},
 	}
 
+	resolveChains(config)
 	if err := validateConfig(config); err != nil {
 		return nil, err
 	}
@@ -293,3 +993,14 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+
+// splitEnvList splits a comma-separated environment value, dropping empty entries
+func splitEnvList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,749 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	testCronosContract = "crc1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnfvger"
+	testCronosAccount  = "crc1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5gukg5z"
+	testEthContract    = "0x1111111111111111111111111111111111111111"
+)
+
+func newValidConfig() *Config {
+	return &Config{
+		Cronos: ChainConfig{
+			ChainID:     "cronos_777-1",
+			RPCEndpoint: "http://localhost:26657",
+			PrivateKey:  "01",
+		},
+		Ethereum: ChainConfig{
+			ChainID:      "1",
+			RPCEndpoint:  "http://localhost:8545",
+			PrivateKey:   "01",
+			TxType:       TxTypeLegacy,
+			KeySelection: KeySelectionRoundRobin,
+		},
+		Contracts: ContractConfig{
+			Cronos: CronosContracts{
+				EscrowFactory:  testCronosContract,
+				EscrowResolver: testCronosAccount,
+			},
+			Ethereum: EthereumContracts{
+				EscrowFactory: testEthContract,
+				Resolver:      testEthContract,
+			},
+		},
+	}
+}
+
+func TestValidateConfigContracts(t *testing.T) {
+	if err := validateConfig(newValidConfig()); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		modify  func(*Config)
+		wantKey string
+	}{
+		{
+			name:    "cronos factory not bech32",
+			modify:  func(c *Config) { c.Contracts.Cronos.EscrowFactory = "CRONOS_ESCROW_FACTORY_ADDRESS" },
+			wantKey: "contracts.cronos.escrow_factory",
+		},
+		{
+			name:    "cronos factory as hex",
+			modify:  func(c *Config) { c.Contracts.Cronos.EscrowFactory = testEthContract },
+			wantKey: "contracts.cronos.escrow_factory",
+		},
+		{
+			name: "cronos resolver bad checksum",
+			modify: func(c *Config) {
+				c.Contracts.Cronos.EscrowResolver = testCronosAccount[:len(testCronosAccount)-1] + "x"
+			},
+			wantKey: "contracts.cronos.escrow_resolver",
+		},
+		{
+			name:    "cronos dutch auction typo",
+			modify:  func(c *Config) { c.Contracts.Cronos.DutchAuction = "crc1" },
+			wantKey: "contracts.cronos.dutch_auction",
+		},
+		{
+			name:    "ethereum factory as bech32",
+			modify:  func(c *Config) { c.Contracts.Ethereum.EscrowFactory = testCronosAccount },
+			wantKey: "contracts.ethereum.escrow_factory",
+		},
+		{
+			name:    "ethereum resolver too short",
+			modify:  func(c *Config) { c.Contracts.Ethereum.Resolver = testEthContract[:40] },
+			wantKey: "contracts.ethereum.resolver",
+		},
+		{
+			name:    "ethereum lop not hex",
+			modify:  func(c *Config) { c.Contracts.Ethereum.LimitOrderProtocol = "0x" + strings.Repeat("g", 40) },
+			wantKey: "contracts.ethereum.limit_order_protocol",
+		},
+		{
+			name: "missing source code id",
+			modify: func(c *Config) {
+				c.Contracts.Cronos.InstantiateEscrows = true
+				c.Contracts.Cronos.DestinationEscrowCodeID = 2
+			},
+			wantKey: "contracts.cronos.source_escrow_code_id",
+		},
+		{
+			name: "missing destination code id",
+			modify: func(c *Config) {
+				c.Contracts.Cronos.InstantiateEscrows = true
+				c.Contracts.Cronos.SourceEscrowCodeID = 1
+			},
+			wantKey: "contracts.cronos.destination_escrow_code_id",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			tc.modify(cfg)
+
+			err := validateConfig(cfg)
+			if err == nil {
+				t.Fatal("expected validation error")
+			}
+			if !strings.Contains(err.Error(), tc.wantKey) {
+				t.Fatalf("expected error about %s, got %v", tc.wantKey, err)
+			}
+		})
+	}
+}
+
+func TestValidateConfigCodeIDsOnlyWhenInstantiating(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Contracts.Cronos.SourceEscrowCodeID = 0
+	cfg.Contracts.Cronos.DestinationEscrowCodeID = 0
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("code IDs should be optional without instantiate_escrows, got %v", err)
+	}
+
+	cfg.Contracts.Cronos.InstantiateEscrows = true
+	cfg.Contracts.Cronos.SourceEscrowCodeID = 1
+	cfg.Contracts.Cronos.DestinationEscrowCodeID = 2
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
+
+func TestValidateConfigTxWaitTimeout(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Cronos.WaitForTx = true
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "cronos.tx_wait_timeout") {
+		t.Fatalf("expected tx_wait_timeout error, got %v", err)
+	}
+
+	cfg.Cronos.TxWaitTimeout = time.Minute
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
+
+func TestValidateConfigRPCEndpoints(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Ethereum.RPCEndpoint = ""
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "ethereum.rpc_endpoint") {
+		t.Fatalf("expected rpc_endpoint error, got %v", err)
+	}
+
+	cfg.Ethereum.RPCEndpoints = []string{"http://localhost:8545", "http://localhost:8546"}
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected rpc_endpoints alone to be valid, got %v", err)
+	}
+}
+
+func TestValidateConfigBech32Prefix(t *testing.T) {
+	for _, prefix := range []string{"", "crc", "cro", "wasm", "osmo1"} {
+		cfg := newValidConfig()
+		cfg.Cronos.Bech32Prefix = prefix
+		if err := validateConfig(cfg); err != nil {
+			t.Fatalf("expected prefix %q to be valid, got %v", prefix, err)
+		}
+	}
+
+	for _, prefix := range []string{"CRC", "crc-", "crc 1"} {
+		cfg := newValidConfig()
+		cfg.Cronos.Bech32Prefix = prefix
+		if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "cronos.bech32_prefix") {
+			t.Fatalf("expected bech32_prefix error for %q, got %v", prefix, err)
+		}
+	}
+}
+
+func TestValidateConfigRPCRetry(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Ethereum.RPCRetry = defaultRPCRetryConfig()
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	cfg.Ethereum.RPCRetry.BreakerCooldown = 0
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "ethereum.rpc_retry.breaker_cooldown") {
+		t.Fatalf("expected breaker_cooldown error, got %v", err)
+	}
+
+	cfg.Ethereum.RPCRetry.BreakerThreshold = 0
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected a disabled breaker to need no cooldown, got %v", err)
+	}
+
+	cfg.Cronos.RPCRetry.MaxAttempts = -1
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "cronos.rpc_retry.max_attempts") {
+		t.Fatalf("expected max_attempts error, got %v", err)
+	}
+}
+
+func TestValidateConfigGasBump(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Ethereum.GasBump = defaultGasBumpConfig()
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	cfg.Ethereum.GasBump.Percent = 5
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "ethereum.gas_bump.percent") {
+		t.Fatalf("expected percent error, got %v", err)
+	}
+
+	cfg.Ethereum.GasBump.Percent = 20
+	cfg.Ethereum.GasBump.MaxGasPrice = "50 gwei"
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "ethereum.gas_bump.max_gas_price") {
+		t.Fatalf("expected max_gas_price error, got %v", err)
+	}
+
+	// Disabled bumping needs no other settings
+	cfg.Ethereum.GasBump = GasBumpConfig{}
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected disabled bumping to be valid, got %v", err)
+	}
+
+	cfg.Ethereum.GasBump.StuckTimeout = -time.Second
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "ethereum.gas_bump.stuck_timeout") {
+		t.Fatalf("expected stuck_timeout error, got %v", err)
+	}
+}
+
+func TestValidateConfigMaxRPCPerSecond(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Relayer.MaxRPCPerSecond = RPCRateLimitConfig{Cronos: 10, Ethereum: 2.5}
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	cfg.Relayer.MaxRPCPerSecond.Ethereum = -1
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "relayer.max_rpc_per_second") {
+		t.Fatalf("expected max_rpc_per_second error, got %v", err)
+	}
+}
+
+func TestValidateConfigScanTimeout(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Relayer.ScanTimeout = time.Minute
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	cfg.Relayer.ScanTimeout = -time.Second
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "relayer.scan_timeout") {
+		t.Fatalf("expected scan_timeout error, got %v", err)
+	}
+}
+
+func TestValidateConfigMaxOrderLifetime(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Relayer.MaxOrderLifetime = 0
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected a disabled lifetime to be valid, got %v", err)
+	}
+
+	cfg.Relayer.MaxOrderLifetime = -time.Hour
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "relayer.max_order_lifetime") {
+		t.Fatalf("expected max_order_lifetime error, got %v", err)
+	}
+}
+
+func TestValidateConfigAPIPort(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Relayer.API.Port = 0
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected a disabled API to be valid, got %v", err)
+	}
+
+	cfg.Relayer.API.Enabled = true
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "relayer.api.port") {
+		t.Fatalf("expected api.port error, got %v", err)
+	}
+
+	cfg.Relayer.API.Port = 8080
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
+
+func TestValidateConfigCatchupWorkers(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Relayer.CatchupWorkers = -1
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "relayer.catchup_workers") {
+		t.Fatalf("expected catchup_workers error, got %v", err)
+	}
+
+	cfg.Relayer.CatchupWorkers = 8
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
+
+func TestValidateConfigMaxOracleDeviation(t *testing.T) {
+	cfg := newValidConfig()
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected a disabled oracle to be valid, got %v", err)
+	}
+
+	cfg.DutchAuction.PriceOracleURL = "http://localhost:9000/price"
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "dutch_auction.max_oracle_deviation") {
+		t.Fatalf("expected max_oracle_deviation error, got %v", err)
+	}
+
+	cfg.DutchAuction.MaxOracleDeviation = 5
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
+
+func TestValidateConfigAssets(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Assets = map[string]AssetMeta{
+		"usdc":    {Decimals: 6, ContractAddress: "0x2222222222222222222222222222222222222222"},
+		"basecro": {Decimals: 8},
+	}
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	cfg.Assets["basecro"] = AssetMeta{Decimals: -1}
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "assets.basecro.decimals") {
+		t.Fatalf("expected decimals error, got %v", err)
+	}
+
+	cfg.Assets["basecro"] = AssetMeta{Decimals: 8}
+	cfg.Assets["usdc"] = AssetMeta{Decimals: 6, ContractAddress: "crc1usdc"}
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "assets.usdc.contract_address") {
+		t.Fatalf("expected contract_address error, got %v", err)
+	}
+}
+
+func TestValidateConfigAssetAliases(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.AssetAliases = map[string]string{"basecro": "CRO", "wcro": "CRO", "weth": "ETH"}
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	cfg.AssetAliases["weth"] = " "
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "asset_aliases.weth must not be empty") {
+		t.Fatalf("expected empty alias error, got %v", err)
+	}
+
+	cfg.AssetAliases["weth"] = "ETH"
+	cfg.AssetAliases["cro"] = "CRONOS"
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "itself an alias") {
+		t.Fatalf("expected chained alias error, got %v", err)
+	}
+}
+
+func TestCanonicalSymbol(t *testing.T) {
+	cfg := &Config{AssetAliases: map[string]string{
+		"basecro": "CRO",
+		"wcro":    "cro",
+		"weth":    "ETH",
+		"uatom":   "ATOM",
+	}}
+
+	for denom, want := range map[string]string{
+		"basecro":   "CRO",
+		"BaseCRO":   "CRO",
+		"WCRO":      "CRO",
+		"weth":      "ETH",
+		"WETH":      "ETH",
+		"ETH":       "ETH",
+		"uatom":     "ATOM",
+		" usdc ":    "USDC",
+		"USDC":      "USDC",
+		"":          "",
+		"ibc/27394": "IBC/27394",
+	} {
+		if got := cfg.CanonicalSymbol(denom); got != want {
+			t.Errorf("CanonicalSymbol(%q) = %q, want %q", denom, got, want)
+		}
+	}
+}
+
+func TestValidateConfigMaxAuctionDuration(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.DutchAuction.MaxAuctionDuration = 24 * time.Hour
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	cfg.DutchAuction.MaxAuctionDuration = -time.Hour
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "dutch_auction.max_auction_duration") {
+		t.Fatalf("expected max_auction_duration error, got %v", err)
+	}
+}
+
+func TestValidateConfigAuctionCurve(t *testing.T) {
+	for _, curve := range []string{"", AuctionCurveLinear, AuctionCurveExponential} {
+		cfg := newValidConfig()
+		cfg.DutchAuction.DefaultCurveType = curve
+		if err := validateConfig(cfg); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", curve, err)
+		}
+	}
+
+	cfg := newValidConfig()
+	cfg.DutchAuction.DefaultCurveType = "logarithmic"
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "dutch_auction.default_curve_type") {
+		t.Fatalf("expected default_curve_type error, got %v", err)
+	}
+}
+
+func TestValidateConfigRelayerFeePercentage(t *testing.T) {
+	for _, tc := range []struct {
+		percentage float64
+		valid      bool
+	}{
+		{percentage: 0, valid: true},
+		{percentage: 0.1, valid: true},
+		{percentage: 99.9, valid: true},
+		{percentage: -0.1, valid: false},
+		{percentage: 100, valid: false},
+	} {
+		cfg := newValidConfig()
+		cfg.Relayer.RelayerFeePercentage = tc.percentage
+
+		err := validateConfig(cfg)
+		if tc.valid && err != nil {
+			t.Fatalf("expected %g%% to be valid, got %v", tc.percentage, err)
+		}
+		if !tc.valid && (err == nil || !strings.Contains(err.Error(), "relayer.relayer_fee_percentage")) {
+			t.Fatalf("expected relayer_fee_percentage error for %g%%, got %v", tc.percentage, err)
+		}
+	}
+}
+
+func TestValidateConfigPollJitter(t *testing.T) {
+	for _, tc := range []struct {
+		jitter float64
+		valid  bool
+	}{
+		{jitter: 0, valid: true},
+		{jitter: 0.2, valid: true},
+		{jitter: -0.1, valid: false},
+		{jitter: 1, valid: false},
+	} {
+		cfg := newValidConfig()
+		cfg.Relayer.PollJitter = tc.jitter
+
+		err := validateConfig(cfg)
+		if tc.valid && err != nil {
+			t.Fatalf("expected %g to be valid, got %v", tc.jitter, err)
+		}
+		if !tc.valid && (err == nil || !strings.Contains(err.Error(), "relayer.poll_jitter")) {
+			t.Fatalf("expected poll_jitter error for %g, got %v", tc.jitter, err)
+		}
+	}
+}
+
+func TestValidateConfigMatchingStrategy(t *testing.T) {
+	for _, strategy := range []string{"", MatchingStrategyPriceTime, MatchingStrategyDutchAuction} {
+		cfg := newValidConfig()
+		cfg.Relayer.MatchingStrategy = strategy
+		if err := validateConfig(cfg); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", strategy, err)
+		}
+	}
+
+	cfg := newValidConfig()
+	cfg.Relayer.MatchingStrategy = "pro_rata"
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "relayer.matching_strategy") {
+		t.Fatalf("expected matching_strategy error, got %v", err)
+	}
+}
+
+func TestValidateConfigWebhook(t *testing.T) {
+	cfg := newValidConfig()
+	cfg.Relayer.WebhookURL = "hooks.example.com/relayer"
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "relayer.webhook_url") {
+		t.Fatalf("expected webhook_url error, got %v", err)
+	}
+
+	cfg.Relayer.WebhookURL = "https://hooks.example.com/relayer"
+	if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), "relayer.webhook_secret") {
+		t.Fatalf("expected webhook_secret error, got %v", err)
+	}
+
+	cfg.Relayer.WebhookSecret = "secret"
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
+
+const testChainsCronosYAML = `
+cronos:
+  chain_id: "cronos_777-1"
+  rpc_endpoint: "http://localhost:26657"
+  private_key: "01"
+`
+
+const testChainsCronosContractsYAML = `
+contracts:
+  cronos:
+    escrow_factory: "` + testCronosContract + `"
+    escrow_resolver: "` + testCronosAccount + `"
+`
+
+// loadTestConfig writes yaml to a config file and loads it
+func loadTestConfig(t *testing.T, yaml string) (*Config, error) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	return LoadConfig(path)
+}
+
+func TestLoadConfigSingleChain(t *testing.T) {
+	cfg, err := loadTestConfig(t, testChainsCronosYAML+`
+ethereum:
+  chain_id: "11155111"
+  rpc_endpoint: "http://localhost:8545"
+  private_key: "01"
+  tx_type: "dynamic"
+`+testChainsCronosContractsYAML+`  ethereum:
+    escrow_factory: "`+testEthContract+`"
+    resolver: "`+testEthContract+`"
+`)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	// The legacy keys become the primary chain
+	if len(cfg.Chains) != 1 {
+		t.Fatalf("expected 1 chain, got %v", ChainNames(cfg.Chains))
+	}
+	primary, ok := cfg.Chains[PrimaryEVMChain]
+	if !ok {
+		t.Fatalf("expected %s chain, got %v", PrimaryEVMChain, ChainNames(cfg.Chains))
+	}
+	if primary.ChainConfig.ChainID != "11155111" || primary.TxType != TxTypeDynamic {
+		t.Fatalf("unexpected primary chain %+v", primary.ChainConfig)
+	}
+	if primary.Contracts.EscrowFactory != testEthContract {
+		t.Fatalf("expected escrow factory %s, got %s", testEthContract, primary.Contracts.EscrowFactory)
+	}
+	if cfg.Cronos.Bech32Prefix != "crc" {
+		t.Fatalf("expected default bech32 prefix crc, got %q", cfg.Cronos.Bech32Prefix)
+	}
+}
+
+func TestLoadConfigMultiChain(t *testing.T) {
+	cfg, err := loadTestConfig(t, testChainsCronosYAML+testChainsCronosContractsYAML+`
+chains:
+  ethereum:
+    chain_id: "11155111"
+    rpc_endpoint: "http://localhost:8545"
+    private_key: "01"
+    contracts:
+      escrow_factory: "`+testEthContract+`"
+      resolver: "`+testEthContract+`"
+  arbitrum:
+    chain_id: "421614"
+    rpc_endpoint: "http://localhost:8547"
+    private_key: "02"
+    gas_limit: 800000
+    tx_type: "dynamic"
+    contracts:
+      escrow_factory: "0x2222222222222222222222222222222222222222"
+`)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if names := ChainNames(cfg.Chains); strings.Join(names, ",") != "arbitrum,ethereum" {
+		t.Fatalf("expected arbitrum and ethereum, got %v", names)
+	}
+
+	// The listed primary chain backs the legacy keys
+	if cfg.Ethereum.ChainID != "11155111" || cfg.Contracts.Ethereum.EscrowFactory != testEthContract {
+		t.Fatalf("expected ethereum keys from chains.ethereum, got %+v %+v", cfg.Ethereum, cfg.Contracts.Ethereum)
+	}
+
+	arbitrum := cfg.Chains["arbitrum"]
+	if arbitrum.ChainConfig.ChainID != "421614" || arbitrum.GasLimit != 800000 || arbitrum.TxType != TxTypeDynamic {
+		t.Fatalf("unexpected arbitrum chain %+v", arbitrum.ChainConfig)
+	}
+	if arbitrum.Contracts.EscrowFactory != "0x2222222222222222222222222222222222222222" {
+		t.Fatalf("unexpected arbitrum contracts %+v", arbitrum.Contracts)
+	}
+
+	// Unset settings get the ethereum defaults
+	if arbitrum.KeySelection != KeySelectionRoundRobin || arbitrum.RPCRetry != defaultRPCRetryConfig() || arbitrum.GasBump != defaultGasBumpConfig() {
+		t.Fatalf("expected defaults on arbitrum, got %+v", arbitrum.ChainConfig)
+	}
+	if cfg.Ethereum.GasLimit != 300000 {
+		t.Fatalf("expected default gas limit on ethereum, got %d", cfg.Ethereum.GasLimit)
+	}
+}
+
+func TestValidateConfigChains(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		modify  func(*EVMChainConfig)
+		wantErr string
+	}{
+		{
+			name:    "missing chain id",
+			modify:  func(c *EVMChainConfig) { c.ChainConfig.ChainID = "" },
+			wantErr: "chains.arbitrum.chain_id",
+		},
+		{
+			name:    "duplicate chain id",
+			modify:  func(c *EVMChainConfig) { c.ChainConfig.ChainID = "1" },
+			wantErr: "chain_id 1 is already used",
+		},
+		{
+			name:    "missing key",
+			modify:  func(c *EVMChainConfig) { c.PrivateKey = "" },
+			wantErr: "chains.arbitrum private_key",
+		},
+		{
+			name:    "bad tx type",
+			modify:  func(c *EVMChainConfig) { c.TxType = "blob" },
+			wantErr: "chains.arbitrum.tx_type",
+		},
+		{
+			name:    "factory not hex",
+			modify:  func(c *EVMChainConfig) { c.Contracts.EscrowFactory = "ARBITRUM_ESCROW_FACTORY_ADDRESS" },
+			wantErr: "chains.arbitrum.contracts.escrow_factory",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newValidConfig()
+			arbitrum := EVMChainConfig{
+				ChainConfig: ChainConfig{
+					ChainID:     "421614",
+					RPCEndpoint: "http://localhost:8547",
+					PrivateKey:  "02",
+				},
+				Contracts: EthereumContracts{EscrowFactory: testEthContract},
+			}
+			cfg.Chains = map[string]EVMChainConfig{"arbitrum": arbitrum}
+			resolveChains(cfg)
+			if err := validateConfig(cfg); err != nil {
+				t.Fatalf("expected valid config, got %v", err)
+			}
+
+			tc.modify(&arbitrum)
+			cfg.Chains["arbitrum"] = arbitrum
+			resolveChains(cfg)
+			if err := validateConfig(cfg); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected %s error, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigAssets(t *testing.T) {
+	cfg, err := loadTestConfig(t, testChainsCronosYAML+`
+ethereum:
+  chain_id: "11155111"
+  rpc_endpoint: "http://localhost:8545"
+  private_key: "01"
+`+testChainsCronosContractsYAML+`  ethereum:
+    escrow_factory: "`+testEthContract+`"
+    resolver: "`+testEthContract+`"
+assets:
+  USDC:
+    decimals: 6
+    contract_address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+  WETH:
+    decimals: 18
+`)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if usdc, ok := cfg.Asset("USDC"); !ok || usdc.Decimals != 6 {
+		t.Fatalf("expected USDC with 6 decimals, got %+v, %v", usdc, ok)
+	}
+	if weth, ok := cfg.Asset("weth"); !ok || weth.Decimals != 18 {
+		t.Fatalf("expected WETH with 18 decimals, got %+v, %v", weth, ok)
+	}
+	if _, ok := cfg.Asset("DAI"); ok {
+		t.Fatal("expected an unconfigured asset not to be found")
+	}
+	if usdc, ok := cfg.AssetByContract("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"); !ok || usdc.Decimals != 6 {
+		t.Fatalf("expected USDC by contract address, got %+v, %v", usdc, ok)
+	}
+}
+
+func TestEffectiveSettings(t *testing.T) {
+	t.Setenv(EnvVar("cronos.gas_limit"), "500000")
+
+	_, err := loadTestConfig(t, `
+cronos:
+  chain_id: "cronos_777-1"
+  rpc_endpoint: "http://localhost:26657"
+  mnemonic: "test test test test test test test test test test test junk"
+ethereum:
+  rpc_endpoint: "http://localhost:8545"
+  private_key: "01"
+relayer:
+  webhook_secret: "hunter2"
+`+testChainsCronosContractsYAML+`  ethereum:
+    escrow_factory: "`+testEthContract+`"
+    resolver: "`+testEthContract+`"
+`)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	settings := make(map[string]Setting)
+	for _, setting := range EffectiveSettings() {
+		settings[setting.Key] = setting
+	}
+
+	for _, key := range []string{"cronos.mnemonic", "ethereum.private_key", "relayer.webhook_secret"} {
+		if value := settings[key].Value; value != RedactedValue {
+			t.Fatalf("expected %s to be redacted, got %v", key, value)
+		}
+	}
+	for key, want := range map[string]string{
+		"cronos.gas_limit":     SourceEnv,
+		"cronos.chain_id":      SourceFile,
+		"cronos.bech32_prefix": SourceDefault,
+	} {
+		if got := settings[key].Source; got != want {
+			t.Fatalf("expected %s to come from %s, got %q", key, want, got)
+		}
+	}
+	if value := settings["cronos.gas_limit"].Value; value != "500000" {
+		t.Fatalf("expected the env override of cronos.gas_limit, got %v", value)
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Sources of a configuration value, from highest to lowest precedence
+const (
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// RedactedValue stands in for secrets in the effective configuration
+const RedactedValue = "<redacted>"
+
+// envPrefix prefixes the environment variables that override config keys
+const envPrefix = "BRIDGE"
+
+// Setting is a configuration value as resolved by LoadConfig
+type Setting struct {
+	// Dotted key, e.g. cronos.rpc_endpoint
+	Key    string
+	Value  interface{}
+	Source string
+}
+
+// EffectiveSettings returns every key LoadConfig resolved, sorted, with the
+// source its value came from. Secrets are redacted. It must be called after
+// LoadConfig
+func EffectiveSettings() []Setting {
+	keys := viper.AllKeys()
+	sort.Strings(keys)
+
+	settings := make([]Setting, 0, len(keys))
+	for _, key := range keys {
+		value := viper.Get(key)
+		if isSecretKey(key) && !isEmptyValue(value) {
+			value = RedactedValue
+		}
+		settings = append(settings, Setting{Key: key, Value: value, Source: settingSource(key)})
+	}
+	return settings
+}
+
+// EnvVar returns the environment variable that overrides key. Keys keep
+// their dots, e.g. BRIDGE_CRONOS.RPC_ENDPOINT
+func EnvVar(key string) string {
+	return envPrefix + "_" + strings.ToUpper(key)
+}
+
+// settingSource reports where viper took key's value from, following its
+// precedence of environment over file over defaults
+func settingSource(key string) string {
+	if _, ok := os.LookupEnv(EnvVar(key)); ok {
+		return SourceEnv
+	}
+	if viper.InConfig(key) {
+		return SourceFile
+	}
+	return SourceDefault
+}
+
+// isSecretKey reports whether key holds a credential that mustn't be printed
+func isSecretKey(key string) bool {
+	name := key[strings.LastIndex(key, ".")+1:]
+	switch name {
+	case "private_key", "private_keys", "mnemonic":
+		return true
+	}
+	for _, suffix := range []string{"secret", "token", "password"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue reports whether an unset secret can be shown as is, so it is
+// clear the secret wasn't configured
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case []string:
+		return len(v) == 0
+	}
+	return false
+}
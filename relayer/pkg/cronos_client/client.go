@@ -2,25 +2,43 @@ package cronos_client
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/tx"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
 	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/rpc_retry"
 	"go.uber.org/zap"
 )
 
+// txSearchPageSize is the number of transactions fetched per search page
+const txSearchPageSize = 100
+
+// importPassphrase encrypts private keys only for the duration of an import
+const importPassphrase = "relayer-import"
+
 // Client represents a Cronos blockchain client
 type Client struct {
 	config     *config.ChainConfig
@@ -31,11 +49,14 @@ type Client struct {
 	account    sdk.AccAddress
 	accountNum uint64
 	sequence   uint64
+	// rpc retries node queries and guards the node with a circuit breaker
+	rpc *rpc_retry.Retrier
 }
 
 // EscrowOrder represents an escrow order from the blockchain
 type EscrowOrder struct {
 	ID              string    `json:"id"`
+	Address         string    `json:"address,omitempty"`
 	Maker           string    `json:"maker"`
 	Taker           string    `json:"taker,omitempty"`
 	SecretHash      string    `json:"secret_hash"`
@@ -58,6 +79,21 @@ type EscrowOrder struct {
 	MinimumFillAmount  string `json:"minimum_fill_amount,omitempty"`
 }
 
+// Escrow types listed by the factory contract
+const (
+	EscrowTypeSource      = "Source"
+	EscrowTypeDestination = "Destination"
+)
+
+// EscrowInfo is an escrow deployed by the factory contract
+type EscrowInfo struct {
+	Address    string `json:"address"`
+	EscrowType string `json:"escrow_type"`
+	Creator    string `json:"creator"`
+	CreatedAt  uint64 `json:"created_at"`
+	Salt       string `json:"salt"`
+}
+
 // ContractExecuteMsg represents a CosmWasm contract execute message
 type ContractExecuteMsg struct {
 	Contract string      `json:"contract"`
@@ -66,7 +102,7 @@ type ContractExecuteMsg struct {
 }
 
 // NewClient creates a new Cronos client
-func NewClient(cfg *config.ChainConfig, logger *zap.Logger) (*Client, error) {
+func NewClient(cfg *config.ChainConfig, relayerCfg *config.RelayerConfig, logger *zap.Logger) (*Client, error) {
 	// Initialize codec
 	encodingConfig := makeEncodingConfig()
 	
@@ -84,28 +120,45 @@ func NewClient(cfg *config.ChainConfig, logger *zap.Logger) (*Client, error) {
 		WithNodeURI(cfg.RPCEndpoint).
 		WithChainID(cfg.ChainID)
 
+	// Addresses are encoded with the chain's prefix from here on
+	setBech32Prefix(cfg.Bech32Prefix)
+
 	// Initialize account from private key or mnemonic
 	var account sdk.AccAddress
 	if cfg.PrivateKey != "" {
-		// TODO: Implement private key loading
 		logger.Info("Loading account from private key")
+		kb := keyring.NewInMemory(encodingConfig.Marshaler)
+		
+		var err error
+		account, err = importPrivateKey(kb, "relayer", cfg.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		clientCtx = clientCtx.WithKeyring(kb).WithFromAddress(account).WithFromName("relayer")
 	} else if cfg.Mnemonic != "" {
 		// Create keyring from mnemonic
 		kb := keyring.NewInMemory(encodingConfig.Marshaler)
 		
 		// Derive key from mnemonic
-		hdPath := hd.CreateHDPath(44, 60, 0, 0, 0) // Ethereum-compatible path for Cronos
+		hdPath := hd.CreateHDPath(60, 0, 0) // Ethereum-compatible path for Cronos
 		if cfg.HDPath != "" {
 			// Parse custom HD path if provided
-			// TODO: Implement HD path parsing
+			customPath, err := parseHDPath(cfg.HDPath)
+			if err != nil {
+				return nil, err
+			}
+			hdPath = customPath
 		}
 		
-		keyInfo, err := kb.NewAccount("relayer", cfg.Mnemonic, "", hdPath, hd.Secp256k1)
+		keyInfo, err := kb.NewAccount("relayer", cfg.Mnemonic, "", hdPath.String(), hd.Secp256k1)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create account from mnemonic: %w", err)
 		}
 		
-		account = keyInfo.GetAddress()
+		account, err = keyInfo.GetAddress()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account address: %w", err)
+		}
 		clientCtx = clientCtx.WithKeyring(kb).WithFromAddress(account).WithFromName("relayer")
 	} else {
 		return nil, fmt.Errorf("either private_key or mnemonic must be provided")
@@ -118,16 +171,79 @@ func NewClient(cfg *config.ChainConfig, logger *zap.Logger) (*Client, error) {
 		logger:    logger,
 		chainID:   cfg.ChainID,
 		account:   account,
+		rpc:       rpc_retry.New("cronos", cfg.RPCRetry, relayerCfg.MaxRPCPerSecond.Cronos, logger),
 	}
 
-	// Initialize account number and sequence
+	// Initialize account number and sequence. An unreachable node doesn't
+	// stop the client from being created, since the account is fetched again
+	// before every transaction
 	if err := client.updateAccountInfo(); err != nil {
-		return nil, fmt.Errorf("failed to update account info: %w", err)
+		if !chain_errors.IsTransient(err) {
+			return nil, fmt.Errorf("failed to update account info: %w", err)
+		}
+		logger.Warn("Cronos node unreachable, starting disconnected", zap.Error(err))
 	}
 
 	return client, nil
 }
 
+// setBech32Prefix points the SDK's address config at prefix. The config is
+// process wide and read by AccAddress.String and message validation, so it
+// must be set before any address is encoded. An empty prefix leaves it as is
+func setBech32Prefix(prefix string) {
+	if prefix == "" {
+		return
+	}
+
+	sdkConfig := sdk.GetConfig()
+	sdkConfig.SetBech32PrefixForAccount(prefix, prefix+sdk.PrefixPublic)
+	sdkConfig.SetBech32PrefixForValidator(
+		prefix+sdk.PrefixValidator+sdk.PrefixOperator,
+		prefix+sdk.PrefixValidator+sdk.PrefixOperator+sdk.PrefixPublic,
+	)
+	sdkConfig.SetBech32PrefixForConsensusNode(
+		prefix+sdk.PrefixValidator+sdk.PrefixConsensus,
+		prefix+sdk.PrefixValidator+sdk.PrefixConsensus+sdk.PrefixPublic,
+	)
+}
+
+// importPrivateKey imports a hex-encoded secp256k1 private key, with or
+// without a 0x prefix, into the keyring and returns its account address
+func importPrivateKey(kb keyring.Keyring, uid string, hexKey string) (sdk.AccAddress, error) {
+	hexKey = strings.TrimSpace(hexKey)
+	hexKey = strings.TrimPrefix(strings.TrimPrefix(hexKey, "0x"), "0X")
+
+	keyBytes, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode private key: %w", err)
+	}
+	if len(keyBytes) != secp256k1.PrivKeySize {
+		return nil, fmt.Errorf("invalid private key length: expected %d bytes, got %d", secp256k1.PrivKeySize, len(keyBytes))
+	}
+
+	// The keyring only imports armored keys, so armor it with a throwaway passphrase
+	privKey := &secp256k1.PrivKey{Key: keyBytes}
+	armor := crypto.EncryptArmorPrivKey(privKey, importPassphrase, string(hd.Secp256k1Type))
+	if err := kb.ImportPrivKey(uid, armor, importPassphrase); err != nil {
+		return nil, fmt.Errorf("failed to import private key: %w", err)
+	}
+
+	return sdk.AccAddress(privKey.PubKey().Address()), nil
+}
+
+// parseHDPath parses a BIP44 derivation path such as m/44'/60'/0'/0/0 into
+// its coin type, account, change and address index components
+func parseHDPath(path string) (*hd.BIP44Params, error) {
+	params, err := hd.NewParamsFromPath(strings.TrimSpace(path))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hd_path %q: %w", path, err)
+	}
+
+	hdPath := hd.CreateHDPath(params.CoinType, params.Account, params.AddressIndex)
+	hdPath.Change = params.Change
+	return hdPath, nil
+}
+
 // GetLatestBlock returns the latest block height
 func (c *Client) GetLatestBlock(ctx context.Context) (int64, error) {
 	node, err := c.clientCtx.GetNode()
@@ -135,9 +251,9 @@ func (c *Client) GetLatestBlock(ctx context.Context) (int64, error) {
 		return 0, fmt.Errorf("failed to get node: %w", err)
 	}
 
-	status, err := node.Status(ctx)
+	status, err := rpc_retry.Call(ctx, c.rpc, node.Status)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get node status: %w", err)
+		return 0, chain_errors.Classify(fmt.Errorf("failed to get node status: %w", err))
 	}
 
 	return status.SyncInfo.LatestBlockHeight, nil
@@ -159,9 +275,11 @@ func (c *Client) QueryContract(ctx context.Context, contractAddr string, queryMs
 
 	// Query the contract state
 	queryPath := fmt.Sprintf("store/wasm/key")
-	result, err := node.ABCIQuery(ctx, queryPath, queryBytes)
+	result, err := rpc_retry.Call(ctx, c.rpc, func(ctx context.Context) (*coretypes.ResultABCIQuery, error) {
+		return node.ABCIQuery(ctx, queryPath, queryBytes)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query contract: %w", err)
+		return nil, chain_errors.Classify(fmt.Errorf("failed to query contract: %w", err))
 	}
 
 	return result.Response.Value, nil
@@ -197,6 +315,45 @@ func (c *Client) ExecuteContract(ctx context.Context, contractAddr string, execu
 
 // GetEscrowOrders retrieves escrow orders from the factory contract
 func (c *Client) GetEscrowOrders(ctx context.Context, factoryAddr string, startAfter string, limit uint32) ([]EscrowOrder, error) {
+	orders, _, err := c.GetEscrowOrdersPage(ctx, factoryAddr, startAfter, limit)
+	return orders, err
+}
+
+// GetEscrowOrdersPage retrieves the source escrow orders among a page of
+// limit escrows listed by the factory after the startAfter salt. next is the
+// salt to list the following page after, or empty once the factory returned
+// fewer than limit escrows. Destination escrows and escrows whose details
+// can't be read still count towards the page
+func (c *Client) GetEscrowOrdersPage(ctx context.Context, factoryAddr string, startAfter string, limit uint32) (orders []EscrowOrder, next string, err error) {
+	escrows, err := c.ListEscrows(ctx, factoryAddr, startAfter, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(escrows) > 0 && uint32(len(escrows)) >= limit {
+		next = escrows[len(escrows)-1].Salt
+	}
+
+	// Query each escrow for detailed information
+	for _, escrowInfo := range escrows {
+		if escrowInfo.EscrowType == EscrowTypeSource {
+			order, err := c.GetEscrow(ctx, escrowInfo.Address)
+			if err != nil {
+				c.logger.Warn("Failed to get escrow details",
+					zap.String("address", escrowInfo.Address),
+					zap.Error(err))
+				continue
+			}
+			order.ID = escrowInfo.Salt
+			order.Address = escrowInfo.Address
+			orders = append(orders, *order)
+		}
+	}
+
+	return orders, next, nil
+}
+
+// ListEscrows returns a page of the escrows deployed by the factory contract
+func (c *Client) ListEscrows(ctx context.Context, factoryAddr string, startAfter string, limit uint32) ([]EscrowInfo, error) {
 	queryMsg := map[string]interface{}{
 		"escrow_list": map[string]interface{}{
 			"start_after": startAfter,
@@ -209,41 +366,68 @@ func (c *Client) GetEscrowOrders(ctx context.Context, factoryAddr string, startA
 		return nil, fmt.Errorf("failed to query escrow orders: %w", err)
 	}
 
-	var response struct {
-		Escrows []struct {
-			Address     string `json:"address"`
-			EscrowType  string `json:"escrow_type"`
-			Creator     string `json:"creator"`
-			CreatedAt   uint64 `json:"created_at"`
-			Salt        string `json:"salt"`
-		} `json:"escrows"`
+	return parseEscrowList(result)
+}
+
+// parseEscrowList decodes a factory's escrow_list response. A null response
+// or escrows field means the factory has no escrows and yields an empty
+// list; a response without an escrows field is an error, reporting the
+// contract's message if it returned one
+func parseEscrowList(result []byte) ([]EscrowInfo, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(result, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal escrow list response: %w", err)
 	}
 
-	if err := json.Unmarshal(result, &response); err != nil {
+	raw, ok := fields["escrows"]
+	if !ok {
+		if fields == nil {
+			return []EscrowInfo{}, nil
+		}
+		if msg := contractErrorMessage(fields); msg != "" {
+			return nil, fmt.Errorf("escrow list query failed: %s", msg)
+		}
+		return nil, fmt.Errorf("escrow list response has no escrows field: %s", result)
+	}
+
+	escrows := []EscrowInfo{}
+	if err := json.Unmarshal(raw, &escrows); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal escrow list response: %w", err)
 	}
+	if escrows == nil {
+		escrows = []EscrowInfo{}
+	}
+	return escrows, nil
+}
 
-	// Query each escrow for detailed information
-	var orders []EscrowOrder
-	for _, escrowInfo := range response.Escrows {
-		if escrowInfo.EscrowType == "Source" {
-			order, err := c.getEscrowDetails(ctx, escrowInfo.Address)
-			if err != nil {
-				c.logger.Warn("Failed to get escrow details",
-					zap.String("address", escrowInfo.Address),
-					zap.Error(err))
-				continue
-			}
-			order.ID = escrowInfo.Salt
-			orders = append(orders, *order)
+// contractErrorMessage returns the message of a contract error response,
+// either {"error": "msg"} or a serialized StdError such as
+// {"generic_err": {"msg": "msg"}}, or "" if fields isn't one
+func contractErrorMessage(fields map[string]json.RawMessage) string {
+	if raw, ok := fields["error"]; ok {
+		var msg string
+		if json.Unmarshal(raw, &msg) == nil && msg != "" {
+			return msg
 		}
+		return string(raw)
 	}
 
-	return orders, nil
+	if len(fields) != 1 {
+		return ""
+	}
+	for kind, raw := range fields {
+		var stdErr struct {
+			Msg string `json:"msg"`
+		}
+		if json.Unmarshal(raw, &stdErr) == nil && stdErr.Msg != "" {
+			return fmt.Sprintf("%s: %s", kind, stdErr.Msg)
+		}
+	}
+	return ""
 }
 
-// getEscrowDetails retrieves detailed information about a specific escrow
-func (c *Client) getEscrowDetails(ctx context.Context, escrowAddr string) (*EscrowOrder, error) {
+// GetEscrow retrieves detailed information about a specific escrow
+func (c *Client) GetEscrow(ctx context.Context, escrowAddr string) (*EscrowOrder, error) {
 	queryMsg := map[string]interface{}{
 		"escrow": map[string]interface{}{},
 	}
@@ -261,6 +445,40 @@ func (c *Client) getEscrowDetails(ctx context.Context, escrowAddr string) (*Escr
 	return &order, nil
 }
 
+// GetEscrowDeposit returns the amount deposited into an escrow
+func (c *Client) GetEscrowDeposit(ctx context.Context, escrowAddr string) (*big.Int, error) {
+	escrow, err := c.GetEscrow(ctx, escrowAddr)
+	if err != nil {
+		return nil, err
+	}
+	if escrow.DepositedAmount == "" {
+		return new(big.Int), nil
+	}
+
+	amount, ok := new(big.Int).SetString(escrow.DepositedAmount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid deposited amount %q in escrow %s", escrow.DepositedAmount, escrowAddr)
+	}
+	return amount, nil
+}
+
+// GetBlockTime returns the time of the block at height
+func (c *Client) GetBlockTime(ctx context.Context, height int64) (time.Time, error) {
+	node, err := c.clientCtx.GetNode()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get node: %w", err)
+	}
+
+	block, err := rpc_retry.Call(ctx, c.rpc, func(ctx context.Context) (*coretypes.ResultBlock, error) {
+		return node.Block(ctx, &height)
+	})
+	if err != nil {
+		return time.Time{}, chain_errors.Classify(fmt.Errorf("failed to get block %d: %w", height, err))
+	}
+
+	return block.Block.Time, nil
+}
+
 // GetCurrentPrice retrieves the current price for a Dutch auction order
 func (c *Client) GetCurrentPrice(ctx context.Context, escrowAddr string) (string, error) {
 	queryMsg := map[string]interface{}{
@@ -283,77 +501,165 @@ func (c *Client) GetCurrentPrice(ctx context.Context, escrowAddr string) (string
 	return response.CurrentPrice, nil
 }
 
-// CreateSourceEscrow creates a new source escrow through the factory
-func (c *Client) CreateSourceEscrow(ctx context.Context, factoryAddr string, params CreateEscrowParams) (string, error) {
-	executeMsg := map[string]interface{}{
-		"create_source_escrow": map[string]interface{}{
-			"maker":                 params.Maker,
-			"taker":                 params.Taker,
-			"secret_hash":           params.SecretHash,
-			"timelock":              params.Timelock,
-			"dst_chain_id":          params.DstChainID,
-			"dst_asset":             params.DstAsset,
-			"dst_amount":            params.DstAmount,
-			"initial_price":         params.InitialPrice,
-			"price_decay_rate":      params.PriceDecayRate,
-			"minimum_price":         params.MinimumPrice,
-			"allow_partial_fill":    params.AllowPartialFill,
-			"minimum_fill_amount":   params.MinimumFillAmount,
-			"label":                 params.Label,
-		},
+// GetEscrowStatus returns the status of an escrow as an order status:
+// "active" while it holds funds, "completed" once withdrawn and "cancelled"
+// once cancelled. A partially filled escrow is still active
+func (c *Client) GetEscrowStatus(ctx context.Context, escrowAddr string) (string, error) {
+	queryMsg := map[string]interface{}{
+		"status": map[string]interface{}{},
 	}
 
-	return c.ExecuteContract(ctx, factoryAddr, executeMsg, nil)
+	result, err := c.QueryContract(ctx, escrowAddr, queryMsg)
+	if err != nil {
+		return "", fmt.Errorf("failed to query escrow status: %w", err)
+	}
+
+	return parseEscrowStatus(result)
 }
 
-// CreateDestinationEscrow creates a new destination escrow through the factory
-func (c *Client) CreateDestinationEscrow(ctx context.Context, factoryAddr string, params CreateDestEscrowParams) (string, error) {
-	executeMsg := map[string]interface{}{
-		"create_destination_escrow": map[string]interface{}{
-			"taker":               params.Taker,
-			"maker":               params.Maker,
-			"secret_hash":         params.SecretHash,
-			"timelock":            params.Timelock,
-			"src_chain_id":        params.SrcChainID,
-			"src_escrow_address":  params.SrcEscrowAddress,
-			"expected_amount":     params.ExpectedAmount,
-			"label":               params.Label,
-		},
+// parseEscrowStatus maps an escrow contract's status response to an order
+// status
+func parseEscrowStatus(result []byte) (string, error) {
+	var response struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return "", fmt.Errorf("failed to unmarshal escrow status response: %w", err)
 	}
 
-	return c.ExecuteContract(ctx, factoryAddr, executeMsg, nil)
+	switch response.Status {
+	case "active", "partially_filled":
+		return "active", nil
+	case "withdrawn":
+		return "completed", nil
+	case "cancelled":
+		return "cancelled", nil
+	default:
+		return "", fmt.Errorf("unknown escrow status %q", response.Status)
+	}
 }
 
-// WithdrawFromEscrow withdraws funds from an escrow using the secret
-func (c *Client) WithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string) (string, error) {
-	executeMsg := map[string]interface{}{
-		"withdraw": map[string]interface{}{
-			"secret": secret,
+// SimulateFill asks a source escrow how much of its deposit a taker paying
+// inputAmount of the destination asset would receive at the current price
+func (c *Client) SimulateFill(ctx context.Context, escrowAddr string, inputAmount string) (string, error) {
+	queryMsg := map[string]interface{}{
+		"simulate_fill": map[string]interface{}{
+			"input_amount": inputAmount,
 		},
 	}
 
-	return c.ExecuteContract(ctx, escrowAddr, executeMsg, nil)
+	result, err := c.QueryContract(ctx, escrowAddr, queryMsg)
+	if err != nil {
+		return "", fmt.Errorf("failed to simulate fill: %w", err)
+	}
+
+	return parseSimulateFill(result)
 }
 
-// PartialWithdrawFromEscrow performs a partial withdrawal from an escrow
-func (c *Client) PartialWithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string, amount string) (string, error) {
-	executeMsg := map[string]interface{}{
-		"partial_withdraw": map[string]interface{}{
-			"secret": secret,
-			"amount": amount,
-		},
+// parseSimulateFill returns the output amount of a simulate_fill response
+func parseSimulateFill(result []byte) (string, error) {
+	var response struct {
+		OutputAmount string `json:"output_amount"`
 	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return "", fmt.Errorf("failed to unmarshal simulate fill response: %w", err)
+	}
+	if response.OutputAmount == "" {
+		return "", fmt.Errorf("simulate fill response has no output amount")
+	}
+
+	return response.OutputAmount, nil
+}
+
+// CreateSourceEscrow creates a new source escrow through the factory
+func (c *Client) CreateSourceEscrow(ctx context.Context, factoryAddr string, params CreateEscrowParams) (string, error) {
+	return c.ExecuteContract(ctx, factoryAddr, newCreateSourceEscrowMsg(params), nil)
+}
+
+// CreateDestinationEscrow creates a new destination escrow through the factory
+func (c *Client) CreateDestinationEscrow(ctx context.Context, factoryAddr string, params CreateDestEscrowParams) (string, error) {
+	return c.ExecuteContract(ctx, factoryAddr, newCreateDestinationEscrowMsg(params), nil)
+}
+
+// WithdrawFromEscrow withdraws funds from an escrow using the secret
+func (c *Client) WithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string) (string, error) {
+	return c.ExecuteContract(ctx, escrowAddr, withdrawMsg{Withdraw: withdraw{Secret: secret}}, nil)
+}
 
+// PartialWithdrawFromEscrow performs a partial withdrawal from an escrow
+func (c *Client) PartialWithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string, amount string) (string, error) {
+	executeMsg := partialWithdrawMsg{PartialWithdraw: partialWithdraw{Secret: secret, Amount: amount}}
 	return c.ExecuteContract(ctx, escrowAddr, executeMsg, nil)
 }
 
 // CancelEscrow cancels an escrow after the timelock expires
 func (c *Client) CancelEscrow(ctx context.Context, escrowAddr string) (string, error) {
-	executeMsg := map[string]interface{}{
-		"cancel": map[string]interface{}{},
+	return c.ExecuteContract(ctx, escrowAddr, cancelMsg{}, nil)
+}
+
+// Breaker returns the circuit breaker guarding the node
+func (c *Client) Breaker() *rpc_retry.Breaker {
+	return c.rpc.Breaker()
+}
+
+// Address returns the relayer's account address
+func (c *Client) Address() sdk.AccAddress {
+	return c.account
+}
+
+// BroadcastTx signs and broadcasts msgs from the relayer account
+func (c *Client) BroadcastTx(ctx context.Context, msgs ...sdk.Msg) (string, error) {
+	return c.broadcastTx(ctx, msgs...)
+}
+
+// TxEvent is an event emitted by a committed transaction
+type TxEvent struct {
+	Height     int64             `json:"height"`
+	TxHash     string            `json:"tx_hash"`
+	Type       string            `json:"type"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// SearchTxEvents returns the events of eventType emitted by transactions
+// matching query, oldest first
+func (c *Client) SearchTxEvents(ctx context.Context, query string, eventType string) ([]TxEvent, error) {
+	node, err := c.clientCtx.GetNode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node: %w", err)
 	}
 
-	return c.ExecuteContract(ctx, escrowAddr, executeMsg, nil)
+	var events []TxEvent
+	perPage := txSearchPageSize
+	for page := 1; ; page++ {
+		result, err := rpc_retry.Call(ctx, c.rpc, func(ctx context.Context) (*coretypes.ResultTxSearch, error) {
+			return node.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		})
+		if err != nil {
+			return nil, chain_errors.Classify(fmt.Errorf("failed to search transactions: %w", err))
+		}
+
+		for _, tx := range result.Txs {
+			for _, event := range tx.TxResult.Events {
+				if event.Type != eventType {
+					continue
+				}
+				attributes := make(map[string]string, len(event.Attributes))
+				for _, attr := range event.Attributes {
+					attributes[attr.Key] = attr.Value
+				}
+				events = append(events, TxEvent{
+					Height:     tx.Height,
+					TxHash:     fmt.Sprintf("%X", tx.Hash),
+					Type:       event.Type,
+					Attributes: attributes,
+				})
+			}
+		}
+
+		if len(result.Txs) == 0 || page*perPage >= result.TotalCount {
+			return events, nil
+		}
+	}
 }
 
 // broadcastTx builds and broadcasts a transaction
@@ -363,6 +669,36 @@ func (c *Client) broadcastTx(ctx context.Context, msgs ...sdk.Msg) (string, erro
 		return "", fmt.Errorf("failed to update account info: %w", err)
 	}
 
+	// A sequence the node rejects as stale, e.g. because an earlier
+	// transaction was dropped from the mempool, is resynced and the
+	// transaction signed again once
+	var txHash string
+	err := chain_errors.RetryStaleNonce(func() (err error) {
+		txHash, err = c.signAndBroadcast(ctx, msgs)
+		return err
+	}, func() error {
+		c.logger.Warn("Account sequence rejected as stale, resyncing from node",
+			zap.Uint64("sequence", c.sequence))
+		return c.updateAccountInfo()
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// CheckTx passing doesn't mean the transaction succeeds in a block
+	if c.config.WaitForTx {
+		if _, err := c.WaitForTx(ctx, txHash, c.config.TxWaitTimeout); err != nil {
+			return "", err
+		}
+	}
+
+	return txHash, nil
+}
+
+// signAndBroadcast signs msgs with the current account sequence and
+// broadcasts them, advancing the sequence once the node accepts the
+// transaction
+func (c *Client) signAndBroadcast(ctx context.Context, msgs []sdk.Msg) (string, error) {
 	// Build transaction
 	txBuilder := c.txConfig.NewTxBuilder()
 	if err := txBuilder.SetMsgs(msgs...); err != nil {
@@ -435,13 +771,19 @@ func (c *Client) broadcastTx(ctx context.Context, msgs ...sdk.Msg) (string, erro
 		return "", fmt.Errorf("failed to get node: %w", err)
 	}
 
-	result, err := node.BroadcastTxSync(ctx, txBytes)
+	// Broadcasts aren't retried here, since a stale sequence has to be
+	// resynced first, which broadcastTx does
+	var result *coretypes.ResultBroadcastTx
+	err = c.rpc.Once(ctx, func(ctx context.Context) (err error) {
+		result, err = node.BroadcastTxSync(ctx, txBytes)
+		return err
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to broadcast transaction: %w", err)
+		return "", chain_errors.Classify(fmt.Errorf("failed to broadcast transaction: %w", err))
 	}
 
 	if result.Code != 0 {
-		return "", fmt.Errorf("transaction failed with code %d: %s", result.Code, result.Log)
+		return "", checkTxError(result.Code, result.Log)
 	}
 
 	// Increment sequence for next transaction
@@ -450,12 +792,25 @@ func (c *Client) broadcastTx(ctx context.Context, msgs ...sdk.Msg) (string, erro
 	return fmt.Sprintf("%X", result.Hash), nil
 }
 
+// checkTxError classifies a transaction rejected in CheckTx. Rejections that
+// don't match a known class are treated as reverted, since resending the same
+// transaction fails the same way
+func checkTxError(code uint32, log string) error {
+	err := fmt.Errorf("transaction failed with code %d: %s", code, log)
+	if classified := chain_errors.Classify(err); classified != err {
+		return classified
+	}
+	return chain_errors.Reverted(err)
+}
+
 // updateAccountInfo updates the account number and sequence
 func (c *Client) updateAccountInfo() error {
 	accountRetriever := authtypes.AccountRetriever{}
-	account, err := accountRetriever.GetAccount(c.clientCtx, c.account)
+	account, err := rpc_retry.Call(context.Background(), c.rpc, func(context.Context) (client.Account, error) {
+		return accountRetriever.GetAccount(c.clientCtx, c.account)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get account: %w", err)
+		return chain_errors.Classify(fmt.Errorf("failed to get account: %w", err))
 	}
 
 	c.accountNum = account.GetAccountNumber()
@@ -494,12 +849,21 @@ type CreateDestEscrowParams struct {
 
 // makeEncodingConfig creates the encoding configuration
 func makeEncodingConfig() EncodingConfig {
-	// This is a simplified version - in practice, you'd import the actual Cronos app encoding config
+	// Only the interfaces the relayer signs and stores are registered, rather
+	// than the full Cronos app encoding config
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(interfaceRegistry)
+	authtypes.RegisterInterfaces(interfaceRegistry)
+	wasmtypes.RegisterInterfaces(interfaceRegistry)
+	ibctransfertypes.RegisterInterfaces(interfaceRegistry)
+
+	marshaler := codec.NewProtoCodec(interfaceRegistry)
+
 	return EncodingConfig{
-		InterfaceRegistry: nil, // TODO: Initialize with proper registry
-		Marshaler:         nil, // TODO: Initialize with proper codec
-		TxConfig:          nil, // TODO: Initialize with proper tx config
-		Amino:             nil, // TODO: Initialize with proper amino codec
+		InterfaceRegistry: interfaceRegistry,
+		Marshaler:         marshaler,
+		TxConfig:          authtx.NewTxConfig(marshaler, authtx.DefaultSignModes),
+		Amino:             codec.NewLegacyAmino(),
 	}
 }
 
@@ -0,0 +1,257 @@
+package cronos_client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestParseHDPath(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "default path", path: "m/44'/60'/0'/0/0", expected: "m/44'/60'/0'/0/0"},
+		{name: "custom account index", path: "m/44'/60'/3'/0/0", expected: "m/44'/60'/3'/0/0"},
+		{name: "custom address index", path: "m/44'/60'/0'/0/7", expected: "m/44'/60'/0'/0/7"},
+		{name: "change address", path: "m/44'/60'/0'/1/2", expected: "m/44'/60'/0'/1/2"},
+		{name: "cosmos coin type", path: "m/44'/118'/0'/0/0", expected: "m/44'/118'/0'/0/0"},
+		{name: "surrounding whitespace", path: " m/44'/60'/0'/0/0 ", expected: "m/44'/60'/0'/0/0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hdPath, err := parseHDPath(tc.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if hdPath.String() != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, hdPath.String())
+			}
+		})
+	}
+}
+
+func TestParseHDPathInvalid(t *testing.T) {
+	for _, path := range []string{
+		"",
+		"m/44'/60'/0'/0",
+		"m/44'/60'/0'/0/0/0",
+		"m/45'/60'/0'/0/0",
+		"m/44/60'/0'/0/0",
+		"m/44'/60/0'/0/0",
+		"m/44'/60'/0/0/0",
+		"m/44'/60'/0'/2/0",
+		"m/44'/60'/0'/0'/0",
+		"m/44'/60'/0'/0/x",
+		"m/44'/60'/0'/0/-1",
+	} {
+		t.Run(path, func(t *testing.T) {
+			if _, err := parseHDPath(path); err == nil {
+				t.Fatalf("expected error for %q", path)
+			}
+		})
+	}
+}
+
+func TestImportPrivateKey(t *testing.T) {
+	// Private key 1 has the well-known compressed public key hash
+	// 751e76e8199196d454941c45d1b3a323f1433bd6
+	const privKey = "0000000000000000000000000000000000000000000000000000000000000001"
+	expected, err := sdk.Bech32ifyAddressBytes("crc", []byte{
+		0x75, 0x1e, 0x76, 0xe8, 0x19, 0x91, 0x96, 0xd4, 0x54, 0x94,
+		0x1c, 0x45, 0xd1, 0xb3, 0xa3, 0x23, 0xf1, 0x43, 0x3b, 0xd6,
+	})
+	if err != nil {
+		t.Fatalf("failed to encode expected address: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		key  string
+	}{
+		{name: "bare hex", key: privKey},
+		{name: "0x prefixed", key: "0x" + privKey},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kb := keyring.NewInMemory(makeEncodingConfig().Marshaler)
+
+			account, err := importPrivateKey(kb, "relayer", tc.key)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			address, err := sdk.Bech32ifyAddressBytes("crc", account)
+			if err != nil {
+				t.Fatalf("failed to encode address: %v", err)
+			}
+			if address != expected {
+				t.Fatalf("expected %s, got %s", expected, address)
+			}
+
+			record, err := kb.Key("relayer")
+			if err != nil {
+				t.Fatalf("key not found in keyring: %v", err)
+			}
+			stored, err := record.GetAddress()
+			if err != nil {
+				t.Fatalf("failed to get stored address: %v", err)
+			}
+			if !stored.Equals(account) {
+				t.Fatalf("keyring address %s does not match %s", stored, account)
+			}
+		})
+	}
+}
+
+func TestSetBech32Prefix(t *testing.T) {
+	previous := sdk.GetConfig().GetBech32AccountAddrPrefix()
+	t.Cleanup(func() { setBech32Prefix(previous) })
+
+	// Encoded addresses are cached by their bytes regardless of prefix, which
+	// only changes between subtests here
+	sdk.SetAddrCacheEnabled(false)
+	t.Cleanup(func() { sdk.SetAddrCacheEnabled(true) })
+
+	// Private key 1, as in TestImportPrivateKey
+	pubKeyHash := []byte{
+		0x75, 0x1e, 0x76, 0xe8, 0x19, 0x91, 0x96, 0xd4, 0x54, 0x94,
+		0x1c, 0x45, 0xd1, 0xb3, 0xa3, 0x23, 0xf1, 0x43, 0x3b, 0xd6,
+	}
+
+	for _, prefix := range []string{"crc", "cro", "wasm"} {
+		t.Run(prefix, func(t *testing.T) {
+			expected, err := sdk.Bech32ifyAddressBytes(prefix, pubKeyHash)
+			if err != nil {
+				t.Fatalf("failed to encode expected address: %v", err)
+			}
+
+			setBech32Prefix(prefix)
+			kb := keyring.NewInMemory(makeEncodingConfig().Marshaler)
+			account, err := importPrivateKey(kb, "relayer", "0000000000000000000000000000000000000000000000000000000000000001")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if address := account.String(); address != expected {
+				t.Fatalf("expected %s, got %s", expected, address)
+			}
+		})
+	}
+}
+
+func TestImportPrivateKeyInvalid(t *testing.T) {
+	for _, key := range []string{
+		"",
+		"0x",
+		"not-hex",
+		"0x0001",
+		"000000000000000000000000000000000000000000000000000000000000000001",
+	} {
+		t.Run(key, func(t *testing.T) {
+			kb := keyring.NewInMemory(makeEncodingConfig().Marshaler)
+			if _, err := importPrivateKey(kb, "relayer", key); err == nil {
+				t.Fatalf("expected error for %q", key)
+			}
+		})
+	}
+}
+
+func TestParseEscrowStatus(t *testing.T) {
+	for _, tc := range []struct {
+		response string
+		expected string
+	}{
+		{response: `{"status":"active"}`, expected: "active"},
+		{response: `{"status":"partially_filled"}`, expected: "active"},
+		{response: `{"status":"withdrawn"}`, expected: "completed"},
+		{response: `{"status":"cancelled"}`, expected: "cancelled"},
+	} {
+		t.Run(tc.response, func(t *testing.T) {
+			status, err := parseEscrowStatus([]byte(tc.response))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, status)
+			}
+		})
+	}
+}
+
+func TestParseEscrowStatusInvalid(t *testing.T) {
+	for _, response := range []string{
+		``,
+		`not-json`,
+		`{}`,
+		`{"status":"refunded"}`,
+	} {
+		t.Run(response, func(t *testing.T) {
+			if _, err := parseEscrowStatus([]byte(response)); err == nil {
+				t.Fatalf("expected error for %q", response)
+			}
+		})
+	}
+}
+
+func TestParseEscrowList(t *testing.T) {
+	escrows, err := parseEscrowList([]byte(`{"escrows":[{"address":"crc1escrow","escrow_type":"source","salt":"order-1"}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(escrows) != 1 || escrows[0].Address != "crc1escrow" || escrows[0].Salt != "order-1" {
+		t.Fatalf("unexpected escrows %+v", escrows)
+	}
+
+	// A factory without escrows may answer with an empty array or null
+	for _, response := range []string{`{"escrows":[]}`, `{"escrows":null}`, `null`} {
+		t.Run(response, func(t *testing.T) {
+			escrows, err := parseEscrowList([]byte(response))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if escrows == nil || len(escrows) != 0 {
+				t.Fatalf("expected empty escrow list, got %#v", escrows)
+			}
+		})
+	}
+}
+
+func TestParseEscrowListInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		response string
+		wantErr  string
+	}{
+		{response: `{"error":"factory paused"}`, wantErr: "escrow list query failed: factory paused"},
+		{response: `{"generic_err":{"msg":"invalid start_after"}}`, wantErr: "escrow list query failed: generic_err: invalid start_after"},
+		{response: `{"items":[]}`, wantErr: "no escrows field"},
+		{response: `{"escrows":"none"}`, wantErr: "failed to unmarshal"},
+		{response: `not-json`, wantErr: "failed to unmarshal"},
+	} {
+		t.Run(tc.response, func(t *testing.T) {
+			_, err := parseEscrowList([]byte(tc.response))
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseSimulateFill(t *testing.T) {
+	output, err := parseSimulateFill([]byte(`{"output_amount":"500000000000000000","current_price":"2000"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != "500000000000000000" {
+		t.Fatalf("expected output 500000000000000000, got %s", output)
+	}
+
+	for _, response := range []string{``, `not-json`, `{}`, `{"output_amount":""}`} {
+		t.Run(response, func(t *testing.T) {
+			if _, err := parseSimulateFill([]byte(response)); err == nil {
+				t.Fatalf("expected error for %q", response)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package cronos_client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// eventSubscriber names the relayer's websocket subscriptions
+	eventSubscriber = "relayer"
+
+	// eventBufferSize is the number of events buffered for slow consumers
+	eventBufferSize = 64
+
+	// eventStallTimeout is how long the websocket may go without a new block
+	// before the subscription is treated as dead and redialled
+	eventStallTimeout = time.Minute
+)
+
+// eventSubscription is a websocket connection subscribed to a query, plus a
+// block header subscription showing the connection is alive
+type eventSubscription struct {
+	client *rpchttp.HTTP
+	txs    <-chan coretypes.ResultEvent
+	blocks <-chan coretypes.ResultEvent
+}
+
+// close drops the websocket connection
+func (s *eventSubscription) close() {
+	if s.client != nil {
+		_ = s.client.Stop()
+	}
+}
+
+// SubscribeContractEvents streams the events of every transaction matching
+// query, e.g. tm.event='Tx' AND wasm._contract_address='crc1...', over the
+// node's websocket. The connection is redialled whenever it drops or stops
+// delivering blocks, and the channel is closed once ctx is done
+func (c *Client) SubscribeContractEvents(ctx context.Context, query string) (<-chan abci.Event, error) {
+	if c.config.WSEndpoint == "" {
+		return nil, fmt.Errorf("cronos ws_endpoint is not configured")
+	}
+	remote, endpoint, err := splitWSEndpoint(c.config.WSEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := subscribeEvents(ctx, remote, endpoint, query)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan abci.Event, eventBufferSize)
+	go c.streamEvents(ctx, remote, endpoint, query, sub, events)
+	return events, nil
+}
+
+// streamEvents forwards events from sub, resubscribing with backoff when the
+// subscription dies
+func (c *Client) streamEvents(ctx context.Context, remote, endpoint, query string, sub *eventSubscription, events chan<- abci.Event) {
+	defer close(events)
+
+	for {
+		err := forwardEvents(ctx, sub, events, eventStallTimeout)
+		sub.close()
+		if ctx.Err() != nil {
+			return
+		}
+		c.logger.Warn("Cronos event subscription dropped, reconnecting",
+			zap.String("query", query),
+			zap.Error(err))
+
+		delay := c.config.RPCRetry.InitialBackoff
+		if delay <= 0 {
+			delay = time.Second
+		}
+		for {
+			if !sleepCtx(ctx, delay) {
+				return
+			}
+
+			sub, err = subscribeEvents(ctx, remote, endpoint, query)
+			if err == nil {
+				break
+			}
+			c.logger.Warn("Failed to resubscribe to Cronos events",
+				zap.String("query", query),
+				zap.Duration("retry_in", delay),
+				zap.Error(err))
+
+			delay *= 2
+			if maxBackoff := c.config.RPCRetry.MaxBackoff; maxBackoff > 0 && delay > maxBackoff {
+				delay = maxBackoff
+			}
+		}
+		c.logger.Info("Cronos event subscription restored", zap.String("query", query))
+	}
+}
+
+// subscribeEvents dials the websocket and subscribes to query
+func subscribeEvents(ctx context.Context, remote, endpoint, query string) (*eventSubscription, error) {
+	client, err := rpchttp.New(remote, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create websocket client: %w", err)
+	}
+	if err := client.Start(); err != nil {
+		return nil, fmt.Errorf("failed to connect to websocket: %w", err)
+	}
+
+	sub := &eventSubscription{client: client}
+	sub.txs, err = client.Subscribe(ctx, eventSubscriber, query, eventBufferSize)
+	if err != nil {
+		sub.close()
+		return nil, fmt.Errorf("failed to subscribe to %q: %w", query, err)
+	}
+	sub.blocks, err = client.Subscribe(ctx, eventSubscriber, tmtypes.EventQueryNewBlockHeader.String())
+	if err != nil {
+		sub.close()
+		return nil, fmt.Errorf("failed to subscribe to block headers: %w", err)
+	}
+	return sub, nil
+}
+
+// forwardEvents sends the events of every transaction delivered by sub until
+// ctx is done or no block arrives for stallTimeout
+func forwardEvents(ctx context.Context, sub *eventSubscription, events chan<- abci.Event, stallTimeout time.Duration) error {
+	stall := time.NewTimer(stallTimeout)
+	defer stall.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-stall.C:
+			return fmt.Errorf("no new block for %s", stallTimeout)
+		case <-sub.blocks:
+			if !stall.Stop() {
+				<-stall.C
+			}
+			stall.Reset(stallTimeout)
+		case result := <-sub.txs:
+			tx, ok := result.Data.(tmtypes.EventDataTx)
+			if !ok {
+				continue
+			}
+			for _, event := range tx.Result.Events {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// splitWSEndpoint splits a websocket URL such as wss://host:26657/websocket
+// into the node address and websocket path the CometBFT client expects
+func splitWSEndpoint(endpoint string) (remote, path string, err error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid ws_endpoint %q: %w", endpoint, err)
+	}
+
+	scheme := u.Scheme
+	switch scheme {
+	case "ws":
+		scheme = "http"
+	case "wss":
+		scheme = "https"
+	case "http", "https", "tcp":
+	default:
+		return "", "", fmt.Errorf("invalid ws_endpoint %q: unsupported scheme %q", endpoint, u.Scheme)
+	}
+	if u.Host == "" {
+		return "", "", fmt.Errorf("invalid ws_endpoint %q: missing host", endpoint)
+	}
+
+	path = u.Path
+	if path == "" || path == "/" {
+		path = "/websocket"
+	}
+	return scheme + "://" + u.Host, path, nil
+}
+
+// sleepCtx waits for d and reports whether ctx is still live
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
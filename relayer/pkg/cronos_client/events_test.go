@@ -0,0 +1,91 @@
+package cronos_client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+)
+
+func TestSplitWSEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		endpoint string
+		remote   string
+		path     string
+	}{
+		{endpoint: "ws://localhost:26657/websocket", remote: "http://localhost:26657", path: "/websocket"},
+		{endpoint: "wss://rpc.cronos.org/websocket", remote: "https://rpc.cronos.org", path: "/websocket"},
+		{endpoint: "tcp://127.0.0.1:26657", remote: "tcp://127.0.0.1:26657", path: "/websocket"},
+		{endpoint: "https://rpc.cronos.org/", remote: "https://rpc.cronos.org", path: "/websocket"},
+	} {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			remote, path, err := splitWSEndpoint(tc.endpoint)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if remote != tc.remote || path != tc.path {
+				t.Fatalf("expected %s %s, got %s %s", tc.remote, tc.path, remote, path)
+			}
+		})
+	}
+
+	for _, endpoint := range []string{"", "localhost:26657", "ftp://localhost/websocket", "ws:///websocket"} {
+		t.Run(endpoint, func(t *testing.T) {
+			if _, _, err := splitWSEndpoint(endpoint); err == nil {
+				t.Fatalf("expected error for %q", endpoint)
+			}
+		})
+	}
+}
+
+func TestForwardEvents(t *testing.T) {
+	txs := make(chan coretypes.ResultEvent, 2)
+	blocks := make(chan coretypes.ResultEvent)
+	sub := &eventSubscription{txs: txs, blocks: blocks}
+
+	txs <- coretypes.ResultEvent{Data: tmtypes.EventDataNewBlockHeader{}}
+	txs <- coretypes.ResultEvent{Data: tmtypes.EventDataTx{TxResult: abci.TxResult{
+		Result: abci.ResponseDeliverTx{Events: []abci.Event{
+			{Type: "message"},
+			{Type: "wasm", Attributes: []abci.EventAttribute{{Key: "_contract_address", Value: "crc1factory"}}},
+		}},
+	}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan abci.Event, 4)
+	done := make(chan error, 1)
+	go func() { done <- forwardEvents(ctx, sub, events, time.Minute) }()
+
+	for _, expected := range []string{"message", "wasm"} {
+		select {
+		case event := <-events:
+			if event.Type != expected {
+				t.Fatalf("expected %s event, got %s", expected, event.Type)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s event", expected)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("expected no error once cancelled, got %v", err)
+	}
+}
+
+func TestForwardEventsStalls(t *testing.T) {
+	sub := &eventSubscription{
+		txs:    make(chan coretypes.ResultEvent),
+		blocks: make(chan coretypes.ResultEvent),
+	}
+
+	err := forwardEvents(context.Background(), sub, make(chan abci.Event), 20*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected a stalled subscription to be reported")
+	}
+}
@@ -0,0 +1,95 @@
+package cronos_client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+)
+
+// defaultInstantiateWaitTimeout bounds the wait for an instantiation when
+// tx_wait_timeout isn't set
+const defaultInstantiateWaitTimeout = time.Minute
+
+// InstantiateEscrow instantiates a contract from codeID with initMsg and
+// returns the new contract's address, read from the instantiate event once
+// the transaction is included in a block. An empty admin leaves the contract
+// without one
+func (c *Client) InstantiateEscrow(ctx context.Context, codeID uint64, initMsg interface{}, label string, admin string) (string, error) {
+	msg, err := newInstantiateContractMsg(c.account, codeID, initMsg, label, admin)
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := c.broadcastTx(ctx, msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	timeout := c.config.TxWaitTimeout
+	if timeout <= 0 {
+		timeout = defaultInstantiateWaitTimeout
+	}
+	result, err := c.WaitForTx(ctx, txHash, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	contractAddr, err := parseContractAddress(result.Events)
+	if err != nil {
+		return "", fmt.Errorf("failed to read address of contract instantiated in %s: %w", txHash, err)
+	}
+
+	c.logger.Info("Contract instantiated successfully",
+		zap.String("contract", contractAddr),
+		zap.Uint64("code_id", codeID),
+		zap.String("label", label),
+		zap.String("tx_hash", txHash))
+
+	return contractAddr, nil
+}
+
+// newInstantiateContractMsg builds the message instantiating a contract from
+// codeID. A nil initMsg is sent as an empty JSON object
+func newInstantiateContractMsg(sender sdk.AccAddress, codeID uint64, initMsg interface{}, label, admin string) (*wasmtypes.MsgInstantiateContract, error) {
+	if initMsg == nil {
+		initMsg = map[string]interface{}{}
+	}
+	msgBytes, err := json.Marshal(initMsg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal instantiate message: %w", err)
+	}
+
+	msg := &wasmtypes.MsgInstantiateContract{
+		Sender: sender.String(),
+		Admin:  admin,
+		CodeID: codeID,
+		Label:  label,
+		Msg:    msgBytes,
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid instantiate message: %w", err)
+	}
+	return msg, nil
+}
+
+// parseContractAddress returns the _contract_address of the first
+// instantiate event
+func parseContractAddress(events []abci.Event) (string, error) {
+	for _, event := range events {
+		if event.Type != wasmtypes.EventTypeInstantiate {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == wasmtypes.AttributeKeyContractAddr && attr.Value != "" {
+				return attr.Value, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no %s event with a %s attribute", wasmtypes.EventTypeInstantiate, wasmtypes.AttributeKeyContractAddr)
+}
@@ -0,0 +1,87 @@
+package cronos_client
+
+import (
+	"bytes"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+func TestNewInstantiateContractMsg(t *testing.T) {
+	sender := testAccount("sender")
+	admin := testAccount("admin").String()
+
+	msg, err := newInstantiateContractMsg(sender, 7, map[string]interface{}{
+		"maker": "crc1maker",
+	}, "escrow-1", admin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if msg.Sender != sender.String() || msg.Admin != admin {
+		t.Fatalf("unexpected sender %s or admin %s", msg.Sender, msg.Admin)
+	}
+	if msg.CodeID != 7 || msg.Label != "escrow-1" {
+		t.Fatalf("unexpected code ID %d or label %q", msg.CodeID, msg.Label)
+	}
+	if !bytes.Equal(msg.Msg, []byte(`{"maker":"crc1maker"}`)) {
+		t.Fatalf("unexpected instantiate payload %s", msg.Msg)
+	}
+	if len(msg.Funds) != 0 {
+		t.Fatalf("expected no funds, got %s", msg.Funds)
+	}
+
+	// No admin and no init message
+	msg, err = newInstantiateContractMsg(sender, 7, nil, "escrow-2", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Admin != "" || string(msg.Msg) != "{}" {
+		t.Fatalf("expected no admin and an empty payload, got %q and %s", msg.Admin, msg.Msg)
+	}
+}
+
+func TestNewInstantiateContractMsgInvalid(t *testing.T) {
+	sender := testAccount("sender")
+
+	if _, err := newInstantiateContractMsg(sender, 0, nil, "escrow", ""); err == nil {
+		t.Fatal("expected an error for a zero code ID")
+	}
+	if _, err := newInstantiateContractMsg(sender, 7, nil, "", ""); err == nil {
+		t.Fatal("expected an error for an empty label")
+	}
+	if _, err := newInstantiateContractMsg(sender, 7, nil, "escrow", "not-an-address"); err == nil {
+		t.Fatal("expected an error for an invalid admin")
+	}
+	if _, err := newInstantiateContractMsg(sender, 7, func() {}, "escrow", ""); err == nil {
+		t.Fatal("expected an error for a payload that isn't JSON")
+	}
+}
+
+func TestParseContractAddress(t *testing.T) {
+	contract := testAccount("contract").String()
+
+	events := []abci.Event{
+		{Type: "message", Attributes: []abci.EventAttribute{{Key: "action", Value: "/cosmwasm.wasm.v1.MsgInstantiateContract"}}},
+		{Type: "instantiate", Attributes: []abci.EventAttribute{
+			{Key: "code_id", Value: "7"},
+			{Key: "_contract_address", Value: contract},
+		}},
+		{Type: "wasm", Attributes: []abci.EventAttribute{{Key: "_contract_address", Value: contract}}},
+	}
+	addr, err := parseContractAddress(events)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addr != contract {
+		t.Fatalf("expected %s, got %s", contract, addr)
+	}
+
+	// A wasm event alone isn't proof of an instantiation
+	if _, err := parseContractAddress(events[2:]); err == nil {
+		t.Fatal("expected an error without an instantiate event")
+	}
+	if _, err := parseContractAddress(nil); err == nil {
+		t.Fatal("expected an error without events")
+	}
+}
@@ -0,0 +1,100 @@
+package cronos_client
+
+// The types below mirror the execute messages of the escrow factory and
+// escrow contracts, with fields in the order the contracts declare them.
+// CosmWasm encodes Uint128 amounts as decimal strings and u64 values such as
+// timelocks as JSON numbers, and a missing Option is omitted rather than sent
+// empty, which an Option<Uint128> would fail to parse
+
+// createSourceEscrowMsg is the factory's create_source_escrow message
+type createSourceEscrowMsg struct {
+	CreateSourceEscrow createSourceEscrow `json:"create_source_escrow"`
+}
+
+type createSourceEscrow struct {
+	Maker             string `json:"maker"`
+	Taker             string `json:"taker,omitempty"`
+	SecretHash        string `json:"secret_hash"`
+	Timelock          uint64 `json:"timelock"`
+	DstChainID        string `json:"dst_chain_id"`
+	DstAsset          string `json:"dst_asset"`
+	DstAmount         string `json:"dst_amount"`
+	InitialPrice      string `json:"initial_price,omitempty"`
+	PriceDecayRate    string `json:"price_decay_rate,omitempty"`
+	MinimumPrice      string `json:"minimum_price,omitempty"`
+	AllowPartialFill  bool   `json:"allow_partial_fill"`
+	MinimumFillAmount string `json:"minimum_fill_amount,omitempty"`
+	Label             string `json:"label"`
+}
+
+// createDestinationEscrowMsg is the factory's create_destination_escrow
+// message
+type createDestinationEscrowMsg struct {
+	CreateDestinationEscrow createDestinationEscrow `json:"create_destination_escrow"`
+}
+
+type createDestinationEscrow struct {
+	Taker            string `json:"taker"`
+	Maker            string `json:"maker"`
+	SecretHash       string `json:"secret_hash"`
+	Timelock         uint64 `json:"timelock"`
+	SrcChainID       string `json:"src_chain_id"`
+	SrcEscrowAddress string `json:"src_escrow_address"`
+	ExpectedAmount   string `json:"expected_amount"`
+	Label            string `json:"label"`
+}
+
+// withdrawMsg is an escrow's withdraw message
+type withdrawMsg struct {
+	Withdraw withdraw `json:"withdraw"`
+}
+
+type withdraw struct {
+	Secret string `json:"secret"`
+}
+
+// partialWithdrawMsg is a source escrow's partial_withdraw message
+type partialWithdrawMsg struct {
+	PartialWithdraw partialWithdraw `json:"partial_withdraw"`
+}
+
+type partialWithdraw struct {
+	Secret string `json:"secret"`
+	Amount string `json:"amount"`
+}
+
+// cancelMsg is an escrow's cancel message, which takes no fields
+type cancelMsg struct {
+	Cancel struct{} `json:"cancel"`
+}
+
+func newCreateSourceEscrowMsg(params CreateEscrowParams) createSourceEscrowMsg {
+	return createSourceEscrowMsg{CreateSourceEscrow: createSourceEscrow{
+		Maker:             params.Maker,
+		Taker:             params.Taker,
+		SecretHash:        params.SecretHash,
+		Timelock:          params.Timelock,
+		DstChainID:        params.DstChainID,
+		DstAsset:          params.DstAsset,
+		DstAmount:         params.DstAmount,
+		InitialPrice:      params.InitialPrice,
+		PriceDecayRate:    params.PriceDecayRate,
+		MinimumPrice:      params.MinimumPrice,
+		AllowPartialFill:  params.AllowPartialFill,
+		MinimumFillAmount: params.MinimumFillAmount,
+		Label:             params.Label,
+	}}
+}
+
+func newCreateDestinationEscrowMsg(params CreateDestEscrowParams) createDestinationEscrowMsg {
+	return createDestinationEscrowMsg{CreateDestinationEscrow: createDestinationEscrow{
+		Taker:            params.Taker,
+		Maker:            params.Maker,
+		SecretHash:       params.SecretHash,
+		Timelock:         params.Timelock,
+		SrcChainID:       params.SrcChainID,
+		SrcEscrowAddress: params.SrcEscrowAddress,
+		ExpectedAmount:   params.ExpectedAmount,
+		Label:            params.Label,
+	}}
+}
@@ -0,0 +1,92 @@
+package cronos_client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExecuteMessagesJSON(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		msg  interface{}
+		want string
+	}{
+		{
+			name: "create source escrow",
+			msg: newCreateSourceEscrowMsg(CreateEscrowParams{
+				Maker:             "crc1maker",
+				Taker:             "crc1taker",
+				SecretHash:        "ab01",
+				Timelock:          1700000000,
+				DstChainID:        "ethereum",
+				DstAsset:          "ETH",
+				DstAmount:         "340282366920938463463374607431768211455",
+				InitialPrice:      "100",
+				PriceDecayRate:    "1",
+				MinimumPrice:      "50",
+				AllowPartialFill:  true,
+				MinimumFillAmount: "10",
+				Label:             "swap",
+			}),
+			want: `{"create_source_escrow":{"maker":"crc1maker","taker":"crc1taker","secret_hash":"ab01","timelock":1700000000,` +
+				`"dst_chain_id":"ethereum","dst_asset":"ETH","dst_amount":"340282366920938463463374607431768211455",` +
+				`"initial_price":"100","price_decay_rate":"1","minimum_price":"50","allow_partial_fill":true,` +
+				`"minimum_fill_amount":"10","label":"swap"}}`,
+		},
+		{
+			name: "create source escrow without options",
+			msg: newCreateSourceEscrowMsg(CreateEscrowParams{
+				Maker:      "crc1maker",
+				SecretHash: "ab01",
+				Timelock:   1700000000,
+				DstChainID: "ethereum",
+				DstAsset:   "ETH",
+				DstAmount:  "5",
+				Label:      "swap",
+			}),
+			want: `{"create_source_escrow":{"maker":"crc1maker","secret_hash":"ab01","timelock":1700000000,` +
+				`"dst_chain_id":"ethereum","dst_asset":"ETH","dst_amount":"5","allow_partial_fill":false,"label":"swap"}}`,
+		},
+		{
+			name: "create destination escrow",
+			msg: newCreateDestinationEscrowMsg(CreateDestEscrowParams{
+				Taker:            "crc1taker",
+				Maker:            "crc1maker",
+				SecretHash:       "ab01",
+				Timelock:         1700000000,
+				SrcChainID:       "ethereum",
+				SrcEscrowAddress: "0xescrow",
+				ExpectedAmount:   "1000000000000000000000",
+				Label:            "dest_order",
+			}),
+			want: `{"create_destination_escrow":{"taker":"crc1taker","maker":"crc1maker","secret_hash":"ab01",` +
+				`"timelock":1700000000,"src_chain_id":"ethereum","src_escrow_address":"0xescrow",` +
+				`"expected_amount":"1000000000000000000000","label":"dest_order"}}`,
+		},
+		{
+			name: "withdraw",
+			msg:  withdrawMsg{Withdraw: withdraw{Secret: "cafe"}},
+			want: `{"withdraw":{"secret":"cafe"}}`,
+		},
+		{
+			name: "partial withdraw",
+			msg:  partialWithdrawMsg{PartialWithdraw: partialWithdraw{Secret: "cafe", Amount: "250"}},
+			want: `{"partial_withdraw":{"secret":"cafe","amount":"250"}}`,
+		},
+		{
+			name: "cancel",
+			msg:  cancelMsg{},
+			want: `{"cancel":{}}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.Marshal(tc.msg)
+			if err != nil {
+				t.Fatalf("failed to marshal message: %v", err)
+			}
+			if string(got) != tc.want {
+				t.Fatalf("unexpected message JSON\n got: %s\nwant: %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package cronos_client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/rpc_retry"
+)
+
+// contractInfoPath is the gRPC query path of a contract's metadata
+const contractInfoPath = "/cosmwasm.wasm.v1.Query/ContractInfo"
+
+// MigrateContract migrates a contract to newCodeID, passing it migrateMsg. The
+// relayer's account must be the contract's admin
+func (c *Client) MigrateContract(ctx context.Context, contractAddr string, newCodeID uint64, migrateMsg interface{}) (string, error) {
+	admin, err := c.GetContractAdmin(ctx, contractAddr)
+	if err != nil {
+		return "", err
+	}
+	if err := checkContractAdmin(contractAddr, admin, c.account); err != nil {
+		return "", err
+	}
+
+	msg, err := newMigrateContractMsg(c.account, contractAddr, newCodeID, migrateMsg)
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := c.broadcastTx(ctx, msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	c.logger.Info("Contract migrated successfully",
+		zap.String("contract", contractAddr),
+		zap.Uint64("code_id", newCodeID),
+		zap.String("tx_hash", txHash))
+
+	return txHash, nil
+}
+
+// GetContractAdmin returns the admin of a contract, or "" if it has none
+func (c *Client) GetContractAdmin(ctx context.Context, contractAddr string) (string, error) {
+	req := &wasmtypes.QueryContractInfoRequest{Address: contractAddr}
+	data, err := req.Marshal()
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal contract info request: %w", err)
+	}
+
+	node, err := c.clientCtx.GetNode()
+	if err != nil {
+		return "", fmt.Errorf("failed to get node: %w", err)
+	}
+
+	result, err := rpc_retry.Call(ctx, c.rpc, func(ctx context.Context) (*coretypes.ResultABCIQuery, error) {
+		return node.ABCIQuery(ctx, contractInfoPath, data)
+	})
+	if err != nil {
+		return "", chain_errors.Classify(fmt.Errorf("failed to query contract info: %w", err))
+	}
+	if result.Response.Code != 0 {
+		return "", fmt.Errorf("failed to query contract info of %s: %s", contractAddr, result.Response.Log)
+	}
+
+	var res wasmtypes.QueryContractInfoResponse
+	if err := res.Unmarshal(result.Response.Value); err != nil {
+		return "", fmt.Errorf("failed to unmarshal contract info response: %w", err)
+	}
+	return res.Admin, nil
+}
+
+// checkContractAdmin checks that sender may migrate a contract whose admin
+// is admin
+func checkContractAdmin(contractAddr, admin string, sender sdk.AccAddress) error {
+	if admin == "" {
+		return fmt.Errorf("contract %s has no admin and can't be migrated", contractAddr)
+	}
+	if admin != sender.String() {
+		return fmt.Errorf("relayer account %s is not the admin %s of contract %s", sender, admin, contractAddr)
+	}
+	return nil
+}
+
+// newMigrateContractMsg builds the message migrating a contract to codeID. A
+// nil migrateMsg is sent as an empty JSON object
+func newMigrateContractMsg(sender sdk.AccAddress, contractAddr string, codeID uint64, migrateMsg interface{}) (*wasmtypes.MsgMigrateContract, error) {
+	if migrateMsg == nil {
+		migrateMsg = map[string]interface{}{}
+	}
+	msgBytes, err := json.Marshal(migrateMsg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal migrate message: %w", err)
+	}
+
+	msg := &wasmtypes.MsgMigrateContract{
+		Sender:   sender.String(),
+		Contract: contractAddr,
+		CodeID:   codeID,
+		Msg:      msgBytes,
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid migrate message: %w", err)
+	}
+	return msg, nil
+}
@@ -0,0 +1,73 @@
+package cronos_client
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+)
+
+func testAccount(seed string) sdk.AccAddress {
+	return sdk.AccAddress(address.Module(seed, []byte("relayer")))
+}
+
+func TestNewMigrateContractMsg(t *testing.T) {
+	sender := testAccount("sender")
+	contract := testAccount("contract").String()
+
+	msg, err := newMigrateContractMsg(sender, contract, 42, map[string]interface{}{
+		"set_fee": map[string]interface{}{"bps": 5},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if msg.Sender != sender.String() || msg.Contract != contract {
+		t.Fatalf("unexpected sender %s or contract %s", msg.Sender, msg.Contract)
+	}
+	if msg.CodeID != 42 {
+		t.Fatalf("expected code ID 42, got %d", msg.CodeID)
+	}
+	if !bytes.Equal(msg.Msg, []byte(`{"set_fee":{"bps":5}}`)) {
+		t.Fatalf("unexpected migrate payload %s", msg.Msg)
+	}
+
+	msg, err = newMigrateContractMsg(sender, contract, 42, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(msg.Msg) != "{}" {
+		t.Fatalf("expected an empty migrate payload, got %s", msg.Msg)
+	}
+}
+
+func TestNewMigrateContractMsgInvalid(t *testing.T) {
+	sender := testAccount("sender")
+	contract := testAccount("contract").String()
+
+	if _, err := newMigrateContractMsg(sender, contract, 0, nil); err == nil {
+		t.Fatal("expected an error for a zero code ID")
+	}
+	if _, err := newMigrateContractMsg(sender, "not-an-address", 42, nil); err == nil {
+		t.Fatal("expected an error for an invalid contract address")
+	}
+	if _, err := newMigrateContractMsg(sender, contract, 42, func() {}); err == nil {
+		t.Fatal("expected an error for a payload that isn't JSON")
+	}
+}
+
+func TestCheckContractAdmin(t *testing.T) {
+	sender := testAccount("sender")
+	contract := testAccount("contract").String()
+
+	if err := checkContractAdmin(contract, sender.String(), sender); err != nil {
+		t.Fatalf("expected the admin to be allowed, got %v", err)
+	}
+	if err := checkContractAdmin(contract, testAccount("other").String(), sender); err == nil {
+		t.Fatal("expected another account to be rejected")
+	}
+	if err := checkContractAdmin(contract, "", sender); err == nil {
+		t.Fatal("expected a contract without an admin to be rejected")
+	}
+}
@@ -0,0 +1,154 @@
+package cronos_client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/rpc_retry"
+)
+
+// txPollInterval is how often WaitForTx checks whether a transaction landed
+const txPollInterval = time.Second
+
+// TxResult is the DeliverTx outcome of a transaction included in a block
+type TxResult struct {
+	Hash      string `json:"hash"`
+	Height    int64  `json:"height"`
+	Code      uint32 `json:"code"`
+	Codespace string `json:"codespace,omitempty"`
+	Log       string `json:"log,omitempty"`
+	GasUsed   int64  `json:"gas_used"`
+
+	// Events are the events the transaction emitted in DeliverTx
+	Events []abci.Event `json:"events,omitempty"`
+}
+
+// TxError is returned for transactions that passed CheckTx but failed when
+// executed in a block. It matches chain_errors.ErrReverted
+type TxError struct {
+	Result TxResult
+}
+
+func (e *TxError) Error() string {
+	return fmt.Sprintf("transaction %s failed at height %d with code %d (%s): %s",
+		e.Result.Hash, e.Result.Height, e.Result.Code, e.Result.Codespace, e.Result.Log)
+}
+
+func (e *TxError) Unwrap() error {
+	return chain_errors.ErrReverted
+}
+
+// txLookup returns a transaction's result, or found=false while it is not
+// yet included in a block
+type txLookup func(ctx context.Context, hash []byte) (result *TxResult, found bool, err error)
+
+// WaitForTx polls until the transaction with the given hex hash is included
+// in a block and returns its result. A transaction that failed in DeliverTx
+// is returned together with a *TxError
+func (c *Client) WaitForTx(ctx context.Context, txHash string, timeout time.Duration) (*TxResult, error) {
+	return waitForTx(ctx, c.lookupTx, txHash, timeout, txPollInterval)
+}
+
+// CheckTransaction reports whether the transaction with the given hex hash
+// is included in a block, without waiting for it. One that failed in
+// DeliverTx is returned as a *TxError
+func (c *Client) CheckTransaction(ctx context.Context, txHash string) (bool, error) {
+	return checkTx(ctx, c.lookupTx, txHash)
+}
+
+// lookupTx fetches a committed transaction from the node
+func (c *Client) lookupTx(ctx context.Context, hash []byte) (*TxResult, bool, error) {
+	node, err := c.clientCtx.GetNode()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get node: %w", err)
+	}
+
+	res, err := rpc_retry.Call(ctx, c.rpc, func(ctx context.Context) (*coretypes.ResultTx, error) {
+		return node.Tx(ctx, hash, false)
+	})
+	if err != nil {
+		if isTxNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, chain_errors.Classify(fmt.Errorf("failed to query transaction: %w", err))
+	}
+
+	return &TxResult{
+		Hash:      fmt.Sprintf("%X", res.Hash),
+		Height:    res.Height,
+		Code:      res.TxResult.Code,
+		Codespace: res.TxResult.Codespace,
+		Log:       res.TxResult.Log,
+		GasUsed:   res.TxResult.GasUsed,
+		Events:    res.TxResult.Events,
+	}, true, nil
+}
+
+// checkTx looks the transaction up once
+func checkTx(ctx context.Context, lookup txLookup, txHash string) (bool, error) {
+	hash, err := hex.DecodeString(strings.TrimPrefix(txHash, "0x"))
+	if err != nil {
+		return false, fmt.Errorf("invalid transaction hash %q: %w", txHash, err)
+	}
+
+	result, found, err := lookup(ctx, hash)
+	switch {
+	case err != nil:
+		return false, err
+	case found && result.Code != 0:
+		return true, &TxError{Result: *result}
+	default:
+		return found, nil
+	}
+}
+
+// waitForTx polls lookup every interval until the transaction is found or
+// timeout passes. Lookup errors are retried, since the node may be briefly
+// unreachable, and the last one is reported on timeout
+func waitForTx(ctx context.Context, lookup txLookup, txHash string, timeout, interval time.Duration) (*TxResult, error) {
+	hash, err := hex.DecodeString(strings.TrimPrefix(txHash, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash %q: %w", txHash, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		result, found, err := lookup(ctx, hash)
+		switch {
+		case err != nil:
+			lastErr = err
+		case found && result.Code != 0:
+			return result, &TxError{Result: *result}
+		case found:
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, fmt.Errorf("timed out waiting for transaction %s: %w", txHash, lastErr)
+			}
+			return nil, fmt.Errorf("timed out waiting for transaction %s: %w", txHash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// isTxNotFoundError reports whether the node rejected a tx query because the
+// transaction is not indexed yet
+func isTxNotFoundError(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "not found")
+}
@@ -0,0 +1,104 @@
+package cronos_client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForTx(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		result   TxResult
+		wantCode uint32
+		wantErr  bool
+	}{
+		{name: "success", result: TxResult{Hash: "AB", Height: 10}, wantCode: 0},
+		{name: "deliver tx failure", result: TxResult{Hash: "AB", Height: 10, Code: 5, Codespace: "wasm", Log: "execute wasm contract failed"}, wantCode: 5, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			lookup := func(ctx context.Context, hash []byte) (*TxResult, bool, error) {
+				calls++
+				switch calls {
+				case 1:
+					return nil, false, nil
+				case 2:
+					return nil, false, errors.New("connection refused")
+				default:
+					result := tc.result
+					return &result, true, nil
+				}
+			}
+
+			result, err := waitForTx(context.Background(), lookup, "0xab", time.Second, time.Millisecond)
+			if calls != 3 {
+				t.Fatalf("expected 3 lookups, got %d", calls)
+			}
+			if result == nil || result.Code != tc.wantCode {
+				t.Fatalf("expected result with code %d, got %+v", tc.wantCode, result)
+			}
+
+			var txErr *TxError
+			if tc.wantErr != errors.As(err, &txErr) {
+				t.Fatalf("expected TxError=%v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr && !strings.Contains(err.Error(), tc.result.Log) {
+				t.Fatalf("expected error to carry the DeliverTx log, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWaitForTxTimeout(t *testing.T) {
+	rpcErr := errors.New("connection refused")
+	lookup := func(ctx context.Context, hash []byte) (*TxResult, bool, error) {
+		return nil, false, rpcErr
+	}
+
+	_, err := waitForTx(context.Background(), lookup, "AB", 20*time.Millisecond, time.Millisecond)
+	if !errors.Is(err, rpcErr) {
+		t.Fatalf("expected timeout to report the last lookup error, got %v", err)
+	}
+}
+
+func TestWaitForTxInvalidHash(t *testing.T) {
+	lookup := func(ctx context.Context, hash []byte) (*TxResult, bool, error) {
+		t.Fatal("lookup must not be called for an invalid hash")
+		return nil, false, nil
+	}
+
+	if _, err := waitForTx(context.Background(), lookup, "not-hex", time.Second, time.Millisecond); err == nil {
+		t.Fatal("expected an error for an invalid hash")
+	}
+}
+
+func TestCheckTx(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		result    *TxResult
+		wantFound bool
+		wantErr   bool
+	}{
+		{name: "pending"},
+		{name: "success", result: &TxResult{Hash: "AB", Height: 10}, wantFound: true},
+		{name: "deliver tx failure", result: &TxResult{Hash: "AB", Height: 10, Code: 5}, wantFound: true, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			lookup := func(ctx context.Context, hash []byte) (*TxResult, bool, error) {
+				return tc.result, tc.result != nil, nil
+			}
+
+			found, err := checkTx(context.Background(), lookup, "0xab")
+			if found != tc.wantFound {
+				t.Fatalf("expected found=%v, got %v", tc.wantFound, found)
+			}
+			var txErr *TxError
+			if tc.wantErr != errors.As(err, &txErr) {
+				t.Fatalf("expected TxError=%v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package ethereum_client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+)
+
+// NonceSource returns the next nonce the node expects from an account
+type NonceSource func(ctx context.Context, account common.Address) (uint64, error)
+
+// account is a relayer key with its own nonce sequence
+type account struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+
+	// inFlight counts transactions being built or sent, guarded by the pool
+	inFlight int
+
+	nonceMutex  sync.Mutex
+	nonce       uint64
+	nonceLoaded bool
+}
+
+// nextNonce reserves the account's next nonce, loading it from the node the
+// first time and after a reset
+func (a *account) nextNonce(ctx context.Context, source NonceSource) (uint64, error) {
+	a.nonceMutex.Lock()
+	defer a.nonceMutex.Unlock()
+
+	if !a.nonceLoaded {
+		nonce, err := source(ctx, a.address)
+		if err != nil {
+			return 0, err
+		}
+		a.nonce = nonce
+		a.nonceLoaded = true
+	}
+
+	nonce := a.nonce
+	a.nonce++
+	return nonce, nil
+}
+
+// resetNonce makes the next reservation reload the nonce from the node. It is
+// used when a reserved nonce may not have been consumed
+func (a *account) resetNonce() {
+	a.nonceMutex.Lock()
+	defer a.nonceMutex.Unlock()
+	a.nonceLoaded = false
+}
+
+// nonceGap describes an account whose local nonce disagrees with the node's
+type nonceGap struct {
+	local     uint64
+	pending   uint64
+	confirmed uint64
+}
+
+// accountPool hands out relayer accounts so concurrent transactions are
+// spread across keys instead of queueing behind a single nonce
+type accountPool struct {
+	mutex     sync.Mutex
+	accounts  []*account
+	next      int
+	selection string
+}
+
+func newAccountPool(keys []*ecdsa.PrivateKey, selection string) *accountPool {
+	pool := &accountPool{selection: selection}
+	for _, key := range keys {
+		pool.accounts = append(pool.accounts, &account{
+			privateKey: key,
+			address:    crypto.PubkeyToAddress(key.PublicKey),
+		})
+	}
+	return pool
+}
+
+// acquire picks the account for a transaction. Callers must release it once
+// the transaction has been sent
+func (p *accountPool) acquire() *account {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	chosen := p.accounts[p.next]
+	if p.selection == config.KeySelectionLeastBusy {
+		// Scan from the round robin position so idle accounts take turns
+		for i := range p.accounts {
+			candidate := p.accounts[(p.next+i)%len(p.accounts)]
+			if candidate.inFlight < chosen.inFlight {
+				chosen = candidate
+			}
+		}
+	}
+
+	for i, acct := range p.accounts {
+		if acct == chosen {
+			p.next = (i + 1) % len(p.accounts)
+		}
+	}
+	chosen.inFlight++
+	return chosen
+}
+
+// release marks a transaction from the account as done
+func (p *accountPool) release(acct *account) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	acct.inFlight--
+}
+
+// reconcile checks acct's local nonce against the node's pending and mined
+// nonces and resyncs it when they disagree. Accounts with a transaction in
+// flight, or whose nonce hasn't been loaded, are skipped, since their local
+// nonce may legitimately lead the node's. Sends from acct wait until the
+// check is done
+func (p *accountPool) reconcile(ctx context.Context, acct *account, pending, confirmed NonceSource) (*nonceGap, error) {
+	p.mutex.Lock()
+	if acct.inFlight > 0 {
+		p.mutex.Unlock()
+		return nil, nil
+	}
+	acct.nonceMutex.Lock()
+	defer acct.nonceMutex.Unlock()
+	p.mutex.Unlock()
+
+	if !acct.nonceLoaded {
+		return nil, nil
+	}
+
+	pendingNonce, err := pending(ctx, acct.address)
+	if err != nil {
+		return nil, err
+	}
+	confirmedNonce, err := confirmed(ctx, acct.address)
+	if err != nil {
+		return nil, err
+	}
+
+	// The pending nonce only counts transactions the node can execute, so
+	// ones queued behind a dropped transaction don't advance it, and an idle
+	// account's local nonce should match it exactly
+	next := pendingNonce
+	if confirmedNonce > next {
+		next = confirmedNonce
+	}
+	if acct.nonce == next {
+		return nil, nil
+	}
+
+	gap := &nonceGap{local: acct.nonce, pending: pendingNonce, confirmed: confirmedNonce}
+	acct.nonce = next
+	return gap, nil
+}
+
+// addresses returns the pool's account addresses
+func (p *accountPool) addresses() []common.Address {
+	addresses := make([]common.Address, len(p.accounts))
+	for i, acct := range p.accounts {
+		addresses[i] = acct.address
+	}
+	return addresses
+}
+
+// loadPrivateKeys parses the primary key and any additional keys, skipping
+// duplicates. The primary key, if set, comes first
+func loadPrivateKeys(cfg *config.ChainConfig) ([]*ecdsa.PrivateKey, error) {
+	var hexKeys []string
+	if cfg.PrivateKey != "" {
+		hexKeys = append(hexKeys, cfg.PrivateKey)
+	}
+	hexKeys = append(hexKeys, cfg.PrivateKeys...)
+
+	seen := make(map[common.Address]bool)
+	var keys []*ecdsa.PrivateKey
+	for i, hexKey := range hexKeys {
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load private key %d: %w", i, err)
+		}
+
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no private key configured")
+	}
+	return keys, nil
+}
@@ -0,0 +1,232 @@
+package ethereum_client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+)
+
+func newTestPool(t *testing.T, size int, selection string) *accountPool {
+	t.Helper()
+
+	keys := make([]*ecdsa.PrivateKey, size)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		keys[i] = key
+	}
+	return newAccountPool(keys, selection)
+}
+
+// fakeNonces returns a fixed starting nonce per account and counts lookups
+type fakeNonces struct {
+	mutex   sync.Mutex
+	start   map[common.Address]uint64
+	lookups int
+}
+
+func (f *fakeNonces) source(ctx context.Context, address common.Address) (uint64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.lookups++
+	return f.start[address], nil
+}
+
+func TestAccountPoolConcurrentSendsUseDistinctAccounts(t *testing.T) {
+	for _, selection := range []string{config.KeySelectionRoundRobin, config.KeySelectionLeastBusy} {
+		t.Run(selection, func(t *testing.T) {
+			pool := newTestPool(t, 2, selection)
+			nonces := &fakeNonces{start: map[common.Address]uint64{
+				pool.accounts[0].address: 5,
+				pool.accounts[1].address: 9,
+			}}
+
+			type sent struct {
+				from  common.Address
+				nonce uint64
+			}
+			results := make(chan sent, 2)
+			acquired := make(chan struct{}, 2)
+			done := make(chan struct{})
+
+			var wg sync.WaitGroup
+			for i := 0; i < 2; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					acct := pool.acquire()
+					defer pool.release(acct)
+
+					nonce, err := acct.nextNonce(context.Background(), nonces.source)
+					if err != nil {
+						t.Errorf("unexpected error: %v", err)
+						return
+					}
+					results <- sent{from: acct.address, nonce: nonce}
+
+					// Hold the account until both sends are in flight
+					acquired <- struct{}{}
+					<-done
+				}()
+			}
+
+			<-acquired
+			<-acquired
+			close(done)
+			wg.Wait()
+			close(results)
+
+			got := make(map[common.Address]uint64)
+			for result := range results {
+				got[result.from] = result.nonce
+			}
+			if len(got) != 2 {
+				t.Fatalf("expected sends from 2 distinct accounts, got %v", got)
+			}
+			if got[pool.accounts[0].address] != 5 || got[pool.accounts[1].address] != 9 {
+				t.Fatalf("expected each account to use its own nonce, got %v", got)
+			}
+		})
+	}
+}
+
+func TestAccountPoolTracksNoncesPerAccount(t *testing.T) {
+	pool := newTestPool(t, 2, config.KeySelectionRoundRobin)
+	nonces := &fakeNonces{start: map[common.Address]uint64{pool.accounts[1].address: 3}}
+
+	want := []struct {
+		account int
+		nonce   uint64
+	}{{0, 0}, {1, 3}, {0, 1}, {1, 4}}
+
+	for i, w := range want {
+		acct := pool.acquire()
+		nonce, err := acct.nextNonce(context.Background(), nonces.source)
+		pool.release(acct)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if acct != pool.accounts[w.account] || nonce != w.nonce {
+			t.Fatalf("send %d: expected account %d nonce %d, got %s nonce %d", i, w.account, w.nonce, acct.address.Hex(), nonce)
+		}
+	}
+	if nonces.lookups != 2 {
+		t.Fatalf("expected one nonce lookup per account, got %d", nonces.lookups)
+	}
+
+	// A reset reloads the nonce from the node
+	pool.accounts[0].resetNonce()
+	nonces.start[pool.accounts[0].address] = 7
+	if nonce, _ := pool.accounts[0].nextNonce(context.Background(), nonces.source); nonce != 7 {
+		t.Fatalf("expected nonce 7 after reset, got %d", nonce)
+	}
+}
+
+func TestAccountPoolLeastBusy(t *testing.T) {
+	pool := newTestPool(t, 3, config.KeySelectionLeastBusy)
+
+	first := pool.acquire()
+	second := pool.acquire()
+	pool.release(first)
+
+	// first is idle again while second is busy, and third has never been used
+	third := pool.acquire()
+	if third == second {
+		t.Fatal("least busy selection picked a busy account")
+	}
+	fourth := pool.acquire()
+	if fourth == second || fourth == third {
+		t.Fatal("least busy selection picked a busy account")
+	}
+}
+
+func TestLoadPrivateKeys(t *testing.T) {
+	const (
+		keyA = "0x0000000000000000000000000000000000000000000000000000000000000001"
+		keyB = "0000000000000000000000000000000000000000000000000000000000000002"
+	)
+
+	keys, err := loadPrivateKeys(&config.ChainConfig{PrivateKey: keyA, PrivateKeys: []string{keyB, keyA}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("expected duplicate keys to be skipped, got %d keys", len(keys))
+	}
+	if keys[0].D.Int64() != 1 {
+		t.Fatal("expected the primary key first")
+	}
+
+	if _, err := loadPrivateKeys(&config.ChainConfig{}); err == nil {
+		t.Fatal("expected error without keys")
+	}
+	if _, err := loadPrivateKeys(&config.ChainConfig{PrivateKeys: []string{"not-hex"}}); err == nil {
+		t.Fatal("expected error for an invalid key")
+	}
+}
+
+func TestAccountPoolReconcile(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		local     uint64
+		pending   uint64
+		confirmed uint64
+		want      uint64
+		gap       bool
+	}{
+		{name: "in sync", local: 5, pending: 5, confirmed: 4, want: 5},
+		{name: "dropped transaction", local: 7, pending: 5, confirmed: 5, want: 5, gap: true},
+		{name: "used elsewhere", local: 5, pending: 8, confirmed: 8, want: 8, gap: true},
+		{name: "pending behind mined", local: 9, pending: 5, confirmed: 6, want: 6, gap: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := newTestPool(t, 1, config.KeySelectionRoundRobin)
+			acct := pool.accounts[0]
+			acct.nonce, acct.nonceLoaded = tc.local, true
+
+			pending := &fakeNonces{start: map[common.Address]uint64{acct.address: tc.pending}}
+			confirmed := &fakeNonces{start: map[common.Address]uint64{acct.address: tc.confirmed}}
+			gap, err := pool.reconcile(context.Background(), acct, pending.source, confirmed.source)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (gap != nil) != tc.gap {
+				t.Fatalf("expected gap %v, got %+v", tc.gap, gap)
+			}
+			if gap != nil && (gap.local != tc.local || gap.pending != tc.pending || gap.confirmed != tc.confirmed) {
+				t.Fatalf("unexpected gap %+v", gap)
+			}
+			if nonce, _ := acct.nextNonce(context.Background(), pending.source); nonce != tc.want {
+				t.Fatalf("expected next nonce %d, got %d", tc.want, nonce)
+			}
+		})
+	}
+}
+
+func TestAccountPoolReconcileSkipsBusyAccounts(t *testing.T) {
+	pool := newTestPool(t, 1, config.KeySelectionRoundRobin)
+	acct := pool.acquire()
+	acct.nonce, acct.nonceLoaded = 7, true
+
+	nonces := &fakeNonces{start: map[common.Address]uint64{acct.address: 5}}
+	if gap, err := pool.reconcile(context.Background(), acct, nonces.source, nonces.source); gap != nil || err != nil {
+		t.Fatalf("expected an account with a send in flight to be skipped, got %+v, %v", gap, err)
+	}
+	if nonces.lookups != 0 {
+		t.Fatalf("expected no nonce lookups, got %d", nonces.lookups)
+	}
+
+	// Accounts that haven't loaded a nonce have nothing to reconcile
+	pool.release(acct)
+	acct.resetNonce()
+	if gap, _ := pool.reconcile(context.Background(), acct, nonces.source, nonces.source); gap != nil || nonces.lookups != 0 {
+		t.Fatalf("expected an unloaded account to be skipped, got %+v", gap)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -14,17 +15,23 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
 	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/rpc_retry"
 	"go.uber.org/zap"
 )
 
 // Client represents an Ethereum blockchain client
 type Client struct {
 	config     *config.ChainConfig
-	client     *ethclient.Client
-	privateKey *ecdsa.PrivateKey
+	relayerCfg *config.RelayerConfig
+	client     *rpcClient
+	// accounts are the relayer keys transactions are sent from; address is
+	// the first, primary account
+	accounts   *accountPool
 	address    common.Address
+	// chainID is nil until the node has been reached. Guarded by chainMu
+	chainMu    sync.Mutex
 	chainID    *big.Int
 	logger     *zap.Logger
 	
@@ -34,6 +41,19 @@ type Client struct {
 	escrowABI        abi.ABI
 	ibcHandlerABI    abi.ABI
 	lopABI           abi.ABI
+	erc20ABI         abi.ABI
+
+	// Limit Order Protocol contract orders are signed for
+	lopAddress common.Address
+
+	// ERC20 metadata already read from chain
+	tokenMu    sync.Mutex
+	tokenCache map[common.Address]TokenMetadata
+
+	// Transactions sent and not yet mined, replaced with a higher fee when
+	// they get stuck. maxGasPrice caps the replacements' fees
+	sentTxs     *sentTxTracker
+	maxGasPrice *big.Int
 }
 
 // EscrowOrder represents an escrow order from Ethereum
@@ -47,10 +67,17 @@ type EscrowOrder struct {
 	SrcAsset        string    `json:"src_asset"`
 	SrcAmount       *big.Int  `json:"src_amount"`
 	DepositedAmount *big.Int  `json:"deposited_amount"`
+	// ReceivedAmount is what the escrow actually received, less than
+	// DepositedAmount for fee-on-transfer tokens
+	ReceivedAmount *big.Int `json:"received_amount,omitempty"`
 	TokenAddress    string    `json:"token_address,omitempty"`
 	Status          string    `json:"status"`
 	CreatedAt       uint64    `json:"created_at"`
 	EscrowAddress   string    `json:"escrow_address"`
+	BlockNumber     uint64    `json:"block_number"`
+	// SrcImmutables are the immutables the escrow was deployed with, as the
+	// factory emitted them in SrcEscrowCreated, or nil if it emitted none
+	SrcImmutables *Immutables `json:"src_immutables,omitempty"`
 }
 
 // ContractAddresses holds the addresses of deployed contracts
@@ -62,31 +89,29 @@ type ContractAddresses struct {
 }
 
 // NewClient creates a new Ethereum client
-func NewClient(cfg *config.ChainConfig, contracts *config.EthereumContracts, logger *zap.Logger) (*Client, error) {
-	// Connect to Ethereum node
-	client, err := ethclient.Dial(cfg.RPCEndpoint)
+func NewClient(cfg *config.ChainConfig, contracts *config.EthereumContracts, relayerCfg *config.RelayerConfig, logger *zap.Logger) (*Client, error) {
+	// Connect to the Ethereum nodes
+	client, err := newRPCClient(cfg, relayerCfg.MaxRPCPerSecond.Ethereum, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
+		return nil, err
 	}
 
-	// Load private key
-	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.PrivateKey, "0x"))
+	// Load private keys
+	privateKeys, err := loadPrivateKeys(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load private key: %w", err)
+		return nil, err
 	}
+	accounts := newAccountPool(privateKeys, cfg.KeySelection)
+	address := accounts.accounts[0].address
 
-	// Get address from private key
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("failed to get public key")
-	}
-	address := crypto.PubkeyToAddress(*publicKeyECDSA)
-
-	// Get chain ID
+	// Get chain ID. An unreachable node doesn't stop the client from being
+	// created; the chain ID is fetched before the first transaction instead
 	chainID, err := client.ChainID(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+		if !chain_errors.IsTransient(err) {
+			return nil, fmt.Errorf("failed to get chain ID: %w", err)
+		}
+		logger.Warn("Ethereum node unreachable, starting disconnected", zap.Error(err))
 	}
 
 	// Load contract ABIs
@@ -115,10 +140,25 @@ func NewClient(cfg *config.ChainConfig, contracts *config.EthereumContracts, log
 		return nil, fmt.Errorf("failed to parse LOP ABI: %w", err)
 	}
 
+	erc20ABI, err := abi.JSON(strings.NewReader(ERC20ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ERC20 ABI: %w", err)
+	}
+
+	var maxGasPrice *big.Int
+	if cfg.GasBump.StuckTimeout > 0 {
+		var ok bool
+		maxGasPrice, ok = new(big.Int).SetString(cfg.GasBump.MaxGasPrice, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid gas_bump.max_gas_price %q", cfg.GasBump.MaxGasPrice)
+		}
+	}
+
 	ethClient := &Client{
 		config:           cfg,
+		relayerCfg:       relayerCfg,
 		client:           client,
-		privateKey:       privateKey,
+		accounts:         accounts,
 		address:          address,
 		chainID:          chainID,
 		logger:           logger,
@@ -127,10 +167,16 @@ func NewClient(cfg *config.ChainConfig, contracts *config.EthereumContracts, log
 		escrowABI:        escrowABI,
 		ibcHandlerABI:    ibcHandlerABI,
 		lopABI:           lopABI,
+		erc20ABI:         erc20ABI,
+		lopAddress:       common.HexToAddress(contracts.LimitOrderProtocol),
+		tokenCache:       make(map[common.Address]TokenMetadata),
+		sentTxs:          newSentTxTracker(),
+		maxGasPrice:      maxGasPrice,
 	}
 
 	logger.Info("Ethereum client initialized",
 		zap.String("address", address.Hex()),
+		zap.Int("accounts", len(privateKeys)),
 		zap.String("chain_id", chainID.String()))
 
 	return ethClient, nil
@@ -140,26 +186,44 @@ func NewClient(cfg *config.ChainConfig, contracts *config.EthereumContracts, log
 func (c *Client) GetLatestBlock(ctx context.Context) (uint64, error) {
 	header, err := c.client.HeaderByNumber(ctx, nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get latest block: %w", err)
+		return 0, chain_errors.Classify(fmt.Errorf("failed to get latest block: %w", err))
 	}
 	return header.Number.Uint64(), nil
 }
 
-// GetEscrowOrders retrieves escrow orders from the factory contract
-func (c *Client) GetEscrowOrders(ctx context.Context, factoryAddr string, fromBlock uint64) ([]EscrowOrder, error) {
-	contractAddr := common.HexToAddress(factoryAddr)
-	
-	// Query for EscrowCreated events
-	query := ethereum.FilterQuery{
-		FromBlock: big.NewInt(int64(fromBlock)),
-		ToBlock:   nil,
-		Addresses: []common.Address{contractAddr},
-		Topics:    [][]common.Hash{{crypto.Keccak256Hash([]byte("EscrowCreated(address,address,address,bytes32,uint256)"))}},
+// GetEscrowOrders retrieves escrow orders created by the factory contract
+// between fromBlock and toBlock (inclusive)
+func (c *Client) GetEscrowOrders(ctx context.Context, factoryAddr string, fromBlock, toBlock uint64) ([]EscrowOrder, error) {
+	return c.getEscrowOrders(ctx, escrowCreatedQuery(common.HexToAddress(factoryAddr), nil), fromBlock, toBlock)
+}
+
+// GetEscrowOrdersByMaker is GetEscrowOrders for the escrows of one maker,
+// filtered by the node on the indexed maker topic
+func (c *Client) GetEscrowOrdersByMaker(ctx context.Context, factoryAddr string, maker common.Address, fromBlock, toBlock uint64) ([]EscrowOrder, error) {
+	return c.getEscrowOrders(ctx, escrowCreatedQuery(common.HexToAddress(factoryAddr), &maker), fromBlock, toBlock)
+}
+
+// escrowCreatedQuery builds the log query for a factory's EscrowCreated
+// events, optionally only those of maker. The indexed escrow comes first, so
+// the maker is the topic after it
+func escrowCreatedQuery(factory common.Address, maker *common.Address) ethereum.FilterQuery {
+	topics := [][]common.Hash{{crypto.Keccak256Hash([]byte("EscrowCreated(address,address,address,bytes32,uint256)"))}}
+	if maker != nil {
+		topics = append(topics, nil, []common.Hash{common.BytesToHash(maker.Bytes())})
 	}
 
-	logs, err := c.client.FilterLogs(ctx, query)
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{factory},
+		Topics:    topics,
+	}
+}
+
+// getEscrowOrders runs an EscrowCreated query over [fromBlock, toBlock] and
+// parses the matching events
+func (c *Client) getEscrowOrders(ctx context.Context, query ethereum.FilterQuery, fromBlock, toBlock uint64) ([]EscrowOrder, error) {
+	logs, err := filterLogsInChunks(ctx, c.client, query, fromBlock, toBlock, c.relayerCfg.LogScanBatchSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to filter logs: %w", err)
+		return nil, err
 	}
 
 	var orders []EscrowOrder
@@ -177,6 +241,50 @@ func (c *Client) GetEscrowOrders(ctx context.Context, factoryAddr string, fromBl
 	return orders, nil
 }
 
+// filterLogsInChunks runs a log query over [fromBlock, toBlock] in windows of at
+// most batchSize blocks. If the node rejects a window for returning too many
+// results, the window is halved and the same range is retried.
+func filterLogsInChunks(ctx context.Context, filterer ethereum.LogFilterer, query ethereum.FilterQuery, fromBlock, toBlock, batchSize uint64) ([]types.Log, error) {
+	if batchSize == 0 {
+		batchSize = 1
+	}
+
+	var logs []types.Log
+	window := batchSize
+	for start := fromBlock; start <= toBlock; {
+		end := toBlock
+		if toBlock-start >= window {
+			end = start + window - 1
+		}
+
+		query.FromBlock = new(big.Int).SetUint64(start)
+		query.ToBlock = new(big.Int).SetUint64(end)
+
+		chunk, err := filterer.FilterLogs(ctx, query)
+		if err != nil {
+			if isTooManyResultsError(err) && window > 1 {
+				window /= 2
+				continue
+			}
+			return nil, chain_errors.Classify(fmt.Errorf("failed to filter logs for blocks %d-%d: %w", start, end, err))
+		}
+
+		logs = append(logs, chunk...)
+		start = end + 1
+	}
+
+	return logs, nil
+}
+
+// isTooManyResultsError reports whether a log query failed because the node
+// limits the number of results or the size of the block range
+func isTooManyResultsError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "query returned more than") ||
+		strings.Contains(msg, "block range is too wide") ||
+		strings.Contains(msg, "response size exceeded")
+}
+
 // parseEscrowCreatedEvent parses an EscrowCreated event log
 func (c *Client) parseEscrowCreatedEvent(ctx context.Context, log types.Log) (*EscrowOrder, error) {
 	// Parse the event data
@@ -193,6 +301,17 @@ func (c *Client) parseEscrowCreatedEvent(ctx context.Context, log types.Log) (*E
 		return nil, fmt.Errorf("failed to unpack event data: %w", err)
 	}
 
+	// The escrow's immutables are only emitted in SrcEscrowCreated, in the
+	// same transaction
+	receipt, err := c.client.TransactionReceipt(ctx, log.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt of escrow creation: %w", err)
+	}
+	immutables, err := srcEscrowCreatedImmutables(c.escrowFactoryABI, receipt, log.Address, event.SecretHash)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get additional escrow details
 	escrowDetails, err := c.getEscrowDetails(ctx, event.Escrow.Hex())
 	if err != nil {
@@ -206,22 +325,44 @@ func (c *Client) parseEscrowCreatedEvent(ctx context.Context, log types.Log) (*E
 		SecretHash:      fmt.Sprintf("0x%x", event.SecretHash),
 		Timelock:        event.Timelock.Uint64(),
 		EscrowAddress:   event.Escrow.Hex(),
+		SrcImmutables:   immutables,
 		DepositedAmount: escrowDetails.DepositedAmount,
 		TokenAddress:    escrowDetails.TokenAddress,
 		Status:          escrowDetails.Status,
 		CreatedAt:       escrowDetails.CreatedAt,
+		BlockNumber:     log.BlockNumber,
+	}
+
+	if err := checkDeposit(ctx, c.client, c.erc20ABI, order, c.relayerCfg.RejectFeeOnTransferTokens); err != nil {
+		return nil, err
+	}
+	if order.ReceivedAmount != nil && order.DepositedAmount != nil && order.ReceivedAmount.Cmp(order.DepositedAmount) < 0 {
+		c.logger.Warn("Escrow received less than its deposited amount",
+			zap.String("escrow", order.EscrowAddress),
+			zap.String("token", order.TokenAddress),
+			zap.String("deposited", order.DepositedAmount.String()),
+			zap.String("received", order.ReceivedAmount.String()))
 	}
 
 	return order, nil
 }
 
+// GetEscrowDeposit returns the amount deposited into an escrow
+func (c *Client) GetEscrowDeposit(ctx context.Context, escrowAddr string) (*big.Int, error) {
+	details, err := c.getEscrowDetails(ctx, escrowAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escrow %s: %w", escrowAddr, err)
+	}
+	if details.DepositedAmount == nil {
+		return new(big.Int), nil
+	}
+	return details.DepositedAmount, nil
+}
+
 // getEscrowDetails retrieves detailed information about a specific escrow
 func (c *Client) getEscrowDetails(ctx context.Context, escrowAddr string) (*EscrowOrder, error) {
 	contractAddr := common.HexToAddress(escrowAddr)
 	
-	// Call the escrow contract to get details
-	callOpts := &bind.CallOpts{Context: ctx}
-	
 	// Pack the call data for getting escrow info
 	data, err := c.escrowABI.Pack("getEscrowInfo")
 	if err != nil {
@@ -234,7 +375,7 @@ func (c *Client) getEscrowDetails(ctx context.Context, escrowAddr string) (*Escr
 		Data: data,
 	}, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call contract: %w", err)
+		return nil, chain_errors.Classify(fmt.Errorf("failed to call contract: %w", err))
 	}
 
 	// Unpack the result
@@ -264,45 +405,68 @@ func (c *Client) getEscrowDetails(ctx context.Context, escrowAddr string) (*Escr
 	}, nil
 }
 
+// GetRevealedSecrets returns the secrets revealed by withdrawals from an escrow
+func (c *Client) GetRevealedSecrets(ctx context.Context, escrowAddr string, fromBlock uint64) ([]RevealedSecret, error) {
+	withdrawnEvent := c.escrowABI.Events["Withdrawn"]
+	partialWithdrawnEvent := c.escrowABI.Events["PartialWithdrawn"]
+
+	query := ethereum.FilterQuery{
+		FromBlock: big.NewInt(int64(fromBlock)),
+		ToBlock:   nil,
+		Addresses: []common.Address{common.HexToAddress(escrowAddr)},
+		Topics:    [][]common.Hash{{withdrawnEvent.ID, partialWithdrawnEvent.ID}},
+	}
+
+	logs, err := c.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, chain_errors.Classify(fmt.Errorf("failed to filter logs: %w", err))
+	}
+
+	var secrets []RevealedSecret
+	for _, log := range logs {
+		eventName := withdrawnEvent.Name
+		if log.Topics[0] == partialWithdrawnEvent.ID {
+			eventName = partialWithdrawnEvent.Name
+		}
+
+		event := struct {
+			Amount          *big.Int
+			RemainingAmount *big.Int
+			Secret          [32]byte
+		}{}
+		if err := c.escrowABI.UnpackIntoInterface(&event, eventName, log.Data); err != nil {
+			c.logger.Warn("Failed to parse withdrawal event",
+				zap.String("tx_hash", log.TxHash.Hex()),
+				zap.Error(err))
+			continue
+		}
+
+		secrets = append(secrets, RevealedSecret{
+			Secret: fmt.Sprintf("0x%x", event.Secret),
+			TxHash: log.TxHash.Hex(),
+		})
+	}
+
+	return secrets, nil
+}
+
 // CreateDestinationEscrow creates a new destination escrow through the resolver
 func (c *Client) CreateDestinationEscrow(ctx context.Context, resolverAddr string, params CreateDestEscrowParams) (string, error) {
 	contractAddr := common.HexToAddress(resolverAddr)
 	
-	// Create transaction options
-	auth, err := c.createTransactOpts(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to create transaction options: %w", err)
-	}
-
 	// Pack the function call
 	data, err := c.resolverABI.Pack("deployDst",
-		params.DstImmutables,
+		params.DstImmutables.tuple(),
 		params.SrcCancellationTimestamp,
 	)
 	if err != nil {
 		return "", fmt.Errorf("failed to pack function call: %w", err)
 	}
 
-	// Create transaction
-	tx := types.NewTransaction(
-		auth.Nonce.Uint64(),
-		contractAddr,
-		params.Value,
-		auth.GasLimit,
-		auth.GasPrice,
-		data,
-	)
-
-	// Sign transaction
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(c.chainID), c.privateKey)
+	// Sign and send the transaction
+	signedTx, err := c.sendTransaction(ctx, contractAddr, params.Value, data)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %w", err)
-	}
-
-	// Send transaction
-	err = c.client.SendTransaction(ctx, signedTx)
-	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
+		return "", err
 	}
 
 	c.logger.Info("Destination escrow creation transaction sent",
@@ -312,15 +476,9 @@ func (c *Client) CreateDestinationEscrow(ctx context.Context, resolverAddr strin
 }
 
 // WithdrawFromEscrow withdraws funds from an escrow using the resolver
-func (c *Client) WithdrawFromEscrow(ctx context.Context, resolverAddr string, escrowAddr string, secret string, immutables interface{}) (string, error) {
+func (c *Client) WithdrawFromEscrow(ctx context.Context, resolverAddr string, escrowAddr string, secret string, immutables Immutables) (string, error) {
 	contractAddr := common.HexToAddress(resolverAddr)
 	
-	// Create transaction options
-	auth, err := c.createTransactOpts(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to create transaction options: %w", err)
-	}
-
 	// Convert secret to bytes32
 	secretBytes := crypto.Keccak256([]byte(secret))
 	var secretHash [32]byte
@@ -330,30 +488,16 @@ func (c *Client) WithdrawFromEscrow(ctx context.Context, resolverAddr string, es
 	data, err := c.resolverABI.Pack("withdraw",
 		common.HexToAddress(escrowAddr),
 		secretHash,
-		immutables,
+		immutables.tuple(),
 	)
 	if err != nil {
 		return "", fmt.Errorf("failed to pack function call: %w", err)
 	}
 
 	// Create and send transaction
-	tx := types.NewTransaction(
-		auth.Nonce.Uint64(),
-		contractAddr,
-		big.NewInt(0),
-		auth.GasLimit,
-		auth.GasPrice,
-		data,
-	)
-
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(c.chainID), c.privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %w", err)
-	}
-
-	err = c.client.SendTransaction(ctx, signedTx)
+	signedTx, err := c.sendTransaction(ctx, contractAddr, big.NewInt(0), data)
 	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
+		return "", err
 	}
 
 	c.logger.Info("Withdraw transaction sent",
@@ -364,42 +508,22 @@ func (c *Client) WithdrawFromEscrow(ctx context.Context, resolverAddr string, es
 }
 
 // CancelEscrow cancels an escrow through the resolver
-func (c *Client) CancelEscrow(ctx context.Context, resolverAddr string, escrowAddr string, immutables interface{}) (string, error) {
+func (c *Client) CancelEscrow(ctx context.Context, resolverAddr string, escrowAddr string, immutables Immutables) (string, error) {
 	contractAddr := common.HexToAddress(resolverAddr)
 	
-	// Create transaction options
-	auth, err := c.createTransactOpts(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to create transaction options: %w", err)
-	}
-
 	// Pack the function call
 	data, err := c.resolverABI.Pack("cancel",
 		common.HexToAddress(escrowAddr),
-		immutables,
+		immutables.tuple(),
 	)
 	if err != nil {
 		return "", fmt.Errorf("failed to pack function call: %w", err)
 	}
 
 	// Create and send transaction
-	tx := types.NewTransaction(
-		auth.Nonce.Uint64(),
-		contractAddr,
-		big.NewInt(0),
-		auth.GasLimit,
-		auth.GasPrice,
-		data,
-	)
-
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(c.chainID), c.privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %w", err)
-	}
-
-	err = c.client.SendTransaction(ctx, signedTx)
+	signedTx, err := c.sendTransaction(ctx, contractAddr, big.NewInt(0), data)
 	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
+		return "", err
 	}
 
 	c.logger.Info("Cancel transaction sent",
@@ -413,12 +537,6 @@ func (c *Client) CancelEscrow(ctx context.Context, resolverAddr string, escrowAd
 func (c *Client) FillLimitOrder(ctx context.Context, lopAddr string, order interface{}, signature []byte, amount *big.Int, takerTraits *big.Int, args []byte) (string, error) {
 	contractAddr := common.HexToAddress(lopAddr)
 	
-	// Create transaction options
-	auth, err := c.createTransactOpts(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to create transaction options: %w", err)
-	}
-
 	// Pack the function call
 	data, err := c.lopABI.Pack("fillOrderArgs",
 		order,
@@ -432,23 +550,9 @@ func (c *Client) FillLimitOrder(ctx context.Context, lopAddr string, order inter
 	}
 
 	// Create and send transaction
-	tx := types.NewTransaction(
-		auth.Nonce.Uint64(),
-		contractAddr,
-		big.NewInt(0),
-		auth.GasLimit,
-		auth.GasPrice,
-		data,
-	)
-
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(c.chainID), c.privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %w", err)
-	}
-
-	err = c.client.SendTransaction(ctx, signedTx)
+	signedTx, err := c.sendTransaction(ctx, contractAddr, big.NewInt(0), data)
 	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %w", err)
+		return "", err
 	}
 
 	c.logger.Info("Limit order fill transaction sent",
@@ -457,7 +561,40 @@ func (c *Client) FillLimitOrder(ctx context.Context, lopAddr string, order inter
 	return signedTx.Hash().Hex(), nil
 }
 
-// WaitForTransaction waits for a transaction to be mined
+// GetTransactionBlock returns the block a transaction was mined in. found is
+// false when the transaction isn't part of the canonical chain, e.g. after a reorg
+func (c *Client) GetTransactionBlock(ctx context.Context, txHash string) (blockNumber uint64, found bool, err error) {
+	receipt, err := c.transactionReceipt(ctx, common.HexToHash(txHash))
+	if err == ethereum.NotFound {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, chain_errors.Classify(fmt.Errorf("failed to get transaction receipt: %w", err))
+	}
+
+	return receipt.BlockNumber.Uint64(), true, nil
+}
+
+// CheckTransaction reports whether a transaction has been mined, without
+// waiting for it. A transaction that was mined but failed returns an error
+// wrapping chain_errors.ErrReverted
+func (c *Client) CheckTransaction(ctx context.Context, txHash string) (bool, error) {
+	receipt, err := c.transactionReceipt(ctx, common.HexToHash(txHash))
+	if err == ethereum.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, chain_errors.Classify(fmt.Errorf("failed to get transaction receipt: %w", err))
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return true, chain_errors.Reverted(fmt.Errorf("transaction %s failed in block %d", txHash, receipt.BlockNumber.Uint64()))
+	}
+	return true, nil
+}
+
+// WaitForTransaction waits for a transaction to be mined. A transaction that
+// was mined but failed is returned with its receipt and an error wrapping
+// chain_errors.ErrReverted
 func (c *Client) WaitForTransaction(ctx context.Context, txHash string, timeout time.Duration) (*types.Receipt, error) {
 	hash := common.HexToHash(txHash)
 	
@@ -470,24 +607,50 @@ func (c *Client) WaitForTransaction(ctx context.Context, txHash string, timeout
 	for {
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf("timeout waiting for transaction %s", txHash)
+			return nil, fmt.Errorf("timeout waiting for transaction %s: %w", txHash, ctx.Err())
 		case <-ticker.C:
-			receipt, err := c.client.TransactionReceipt(ctx, hash)
+			receipt, err := c.transactionReceipt(ctx, hash)
 			if err == nil {
+				if receipt.Status == types.ReceiptStatusFailed {
+					return receipt, chain_errors.Reverted(fmt.Errorf("transaction %s failed in block %d", txHash, receipt.BlockNumber.Uint64()))
+				}
 				return receipt, nil
 			}
 			if err != ethereum.NotFound {
-				return nil, fmt.Errorf("error getting transaction receipt: %w", err)
+				return nil, chain_errors.Classify(fmt.Errorf("error getting transaction receipt: %w", err))
 			}
 		}
 	}
 }
 
-// GetBalance returns the balance of the relayer account
+// GetBalance returns the balance of the primary relayer account
 func (c *Client) GetBalance(ctx context.Context) (*big.Int, error) {
 	return c.client.BalanceAt(ctx, c.address, nil)
 }
 
+// Breaker returns the circuit breaker guarding the node
+func (c *Client) Breaker() *rpc_retry.Breaker {
+	return c.client.retry.Breaker()
+}
+
+// Addresses returns the addresses of all relayer accounts, primary first
+func (c *Client) Addresses() []common.Address {
+	return c.accounts.addresses()
+}
+
+// GetBalances returns the balance of every relayer account
+func (c *Client) GetBalances(ctx context.Context) (map[common.Address]*big.Int, error) {
+	balances := make(map[common.Address]*big.Int)
+	for _, address := range c.accounts.addresses() {
+		balance, err := c.client.BalanceAt(ctx, address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance of %s: %w", address.Hex(), err)
+		}
+		balances[address] = balance
+	}
+	return balances, nil
+}
+
 // GetTokenBalance returns the balance of a specific ERC20 token
 func (c *Client) GetTokenBalance(ctx context.Context, tokenAddr string) (*big.Int, error) {
 	// This would require the ERC20 ABI to make the balanceOf call
@@ -495,35 +658,208 @@ func (c *Client) GetTokenBalance(ctx context.Context, tokenAddr string) (*big.In
 	return big.NewInt(0), nil
 }
 
-// createTransactOpts creates transaction options for sending transactions
-func (c *Client) createTransactOpts(ctx context.Context) (*bind.TransactOpts, error) {
-	nonce, err := c.client.PendingNonceAt(ctx, c.address)
+// sendTransaction signs and sends a transaction from the next relayer account
+// in the pool
+func (c *Client) sendTransaction(ctx context.Context, to common.Address, value *big.Int, data []byte) (*types.Transaction, error) {
+	acct := c.accounts.acquire()
+	defer c.accounts.release(acct)
+
+	// A nonce the node rejects as stale is resynced and the transaction
+	// rebuilt once, since every later send from the account would fail too
+	var signedTx *types.Transaction
+	err := chain_errors.RetryStaleNonce(func() (err error) {
+		signedTx, err = c.sendFrom(ctx, acct, to, value, data)
+		return err
+	}, func() error {
+		// sendFrom has already made the account reload its nonce
+		c.logger.Warn("Nonce rejected as stale, resyncing from node",
+			zap.String("from", acct.address.Hex()))
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+		return nil, err
 	}
+	if c.gasBumpEnabled() {
+		c.sentTxs.track(acct, signedTx)
+	}
+
+	c.logger.Debug("Transaction sent",
+		zap.String("from", acct.address.Hex()),
+		zap.Uint64("nonce", signedTx.Nonce()),
+		zap.String("tx_hash", signedTx.Hash().Hex()))
 
-	gasPrice, err := c.client.SuggestGasPrice(ctx)
+	return signedTx, nil
+}
+
+// sendFrom builds, signs and sends a transaction with acct's next nonce
+func (c *Client) sendFrom(ctx context.Context, acct *account, to common.Address, value *big.Int, data []byte) (*types.Transaction, error) {
+	auth, err := c.createTransactOpts(ctx, acct)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price: %w", err)
+		return nil, chain_errors.Classify(fmt.Errorf("failed to create transaction options: %w", err))
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(c.privateKey, c.chainID)
+	tx := c.newTransaction(auth, to, value, data)
+
+	signedTx, err := c.signTransaction(tx, acct.privateKey)
+	if err != nil {
+		acct.resetNonce()
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	if err := c.client.SendTransaction(ctx, signedTx); err != nil {
+		// The node may not have taken the reserved nonce, so resync it
+		acct.resetNonce()
+		return nil, chain_errors.Classify(fmt.Errorf("failed to send transaction: %w", err))
+	}
+	return signedTx, nil
+}
+
+// ReconcileNonces compares each idle relayer account's local nonce with the
+// node's and resyncs the ones that disagree. A local nonce ahead of the
+// node's pending nonce is a gap left by a transaction dropped from the
+// mempool, which later transactions would queue behind forever, and one
+// behind it would be rejected as too low. It returns the number of accounts
+// resynced
+func (c *Client) ReconcileNonces(ctx context.Context) (int, error) {
+	resynced := 0
+	for _, acct := range c.accounts.accounts {
+		gap, err := c.accounts.reconcile(ctx, acct, c.client.PendingNonceAt, c.client.NonceAt)
+		if err != nil {
+			return resynced, fmt.Errorf("failed to reconcile nonce of %s: %w", acct.address.Hex(), err)
+		}
+		if gap == nil {
+			continue
+		}
+
+		c.logger.Warn("Nonce out of sync with node, resyncing",
+			zap.String("account", acct.address.Hex()),
+			zap.Uint64("local_nonce", gap.local),
+			zap.Uint64("pending_nonce", gap.pending),
+			zap.Uint64("confirmed_nonce", gap.confirmed))
+		resynced++
+	}
+	return resynced, nil
+}
+
+// createTransactOpts creates transaction options for sending a transaction
+// from acct
+func (c *Client) createTransactOpts(ctx context.Context, acct *account) (*bind.TransactOpts, error) {
+	chainID, err := c.getChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(acct.privateKey, chainID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transactor: %w", err)
 	}
 
-	auth.Nonce = big.NewInt(int64(nonce))
 	auth.Value = big.NewInt(0)
 	auth.GasLimit = c.config.GasLimit
-	auth.GasPrice = gasPrice
 	auth.Context = ctx
 
+	if c.config.TxType == config.TxTypeDynamic {
+		if err := c.setDynamicFees(ctx, auth); err != nil {
+			return nil, err
+		}
+	} else {
+		gasPrice, err := c.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+		auth.GasPrice = gasPrice
+	}
+
+	// Reserve the nonce last so a failed fee lookup doesn't leave a gap
+	nonce, err := acct.nextNonce(ctx, c.client.PendingNonceAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	auth.Nonce = new(big.Int).SetUint64(nonce)
+
 	return auth, nil
 }
 
+// setDynamicFees populates the EIP-1559 fee caps on the transaction options.
+// The fee cap is computed as baseFee*2 + tip so the transaction stays valid
+// across several blocks of rising base fees.
+func (c *Client) setDynamicFees(ctx context.Context, auth *bind.TransactOpts) error {
+	tip, err := c.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get gas tip cap: %w", err)
+	}
+
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest header: %w", err)
+	}
+	if header.BaseFee == nil {
+		return fmt.Errorf("chain does not support EIP-1559 dynamic fee transactions")
+	}
+
+	auth.GasTipCap = tip
+	auth.GasFeeCap = new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tip)
+
+	return nil
+}
+
+// signTransaction signs a transaction with a signer for the latest fork rules
+// of the chain, so both legacy and typed transactions are signed correctly
+func (c *Client) signTransaction(tx *types.Transaction, privateKey *ecdsa.PrivateKey) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(c.knownChainID()), privateKey)
+}
+
+// getChainID returns the chain ID, asking the node for it if the node was
+// unreachable when the client was created
+func (c *Client) getChainID(ctx context.Context) (*big.Int, error) {
+	c.chainMu.Lock()
+	defer c.chainMu.Unlock()
+
+	if c.chainID == nil {
+		chainID, err := c.client.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain ID: %w", err)
+		}
+		c.chainID = chainID
+	}
+	return c.chainID, nil
+}
+
+// knownChainID returns the chain ID, or nil if the node hasn't been reached
+// yet. Transactions are only built once createTransactOpts has fetched it
+func (c *Client) knownChainID() *big.Int {
+	c.chainMu.Lock()
+	defer c.chainMu.Unlock()
+	return c.chainID
+}
+
+// newTransaction builds a legacy or EIP-1559 dynamic fee transaction depending
+// on the configured transaction type
+func (c *Client) newTransaction(auth *bind.TransactOpts, to common.Address, value *big.Int, data []byte) *types.Transaction {
+	if c.config.TxType == config.TxTypeDynamic {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   c.knownChainID(),
+			Nonce:     auth.Nonce.Uint64(),
+			GasTipCap: auth.GasTipCap,
+			GasFeeCap: auth.GasFeeCap,
+			Gas:       auth.GasLimit,
+			To:        &to,
+			Value:     value,
+			Data:      data,
+		})
+	}
+
+	return types.NewTransaction(auth.Nonce.Uint64(), to, value, auth.GasLimit, auth.GasPrice, data)
+}
+
+// RevealedSecret is a secret published on-chain by an escrow withdrawal
+type RevealedSecret struct {
+	Secret string
+	TxHash string
+}
+
 // Helper types for method parameters
 type CreateDestEscrowParams struct {
-	DstImmutables             interface{}
+	DstImmutables             Immutables
 	SrcCancellationTimestamp  *big.Int
 	Value                     *big.Int
 }
@@ -541,13 +877,46 @@ const EscrowFactoryABI = `[
 		],
 		"name": "EscrowCreated",
 		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "srcImmutables", "type": "tuple", "components": [
+				{"name": "orderHash", "type": "bytes32"},
+				{"name": "hashlock", "type": "bytes32"},
+				{"name": "maker", "type": "uint256"},
+				{"name": "taker", "type": "uint256"},
+				{"name": "token", "type": "uint256"},
+				{"name": "amount", "type": "uint256"},
+				{"name": "safetyDeposit", "type": "uint256"},
+				{"name": "timelocks", "type": "uint256"}
+			]},
+			{"indexed": false, "name": "dstImmutablesComplement", "type": "tuple", "components": [
+				{"name": "maker", "type": "uint256"},
+				{"name": "amount", "type": "uint256"},
+				{"name": "token", "type": "uint256"},
+				{"name": "safetyDeposit", "type": "uint256"},
+				{"name": "chainId", "type": "uint256"}
+			]}
+		],
+		"name": "SrcEscrowCreated",
+		"type": "event"
 	}
 ]`
 
 const ResolverABI = `[
 	{
 		"inputs": [
-			{"name": "dstImmutables", "type": "tuple"},
+			{"name": "dstImmutables", "type": "tuple", "components": [
+				{"name": "orderHash", "type": "bytes32"},
+				{"name": "hashlock", "type": "bytes32"},
+				{"name": "maker", "type": "uint256"},
+				{"name": "taker", "type": "uint256"},
+				{"name": "token", "type": "uint256"},
+				{"name": "amount", "type": "uint256"},
+				{"name": "safetyDeposit", "type": "uint256"},
+				{"name": "timelocks", "type": "uint256"}
+			]},
 			{"name": "srcCancellationTimestamp", "type": "uint256"}
 		],
 		"name": "deployDst",
@@ -559,7 +928,16 @@ const ResolverABI = `[
 		"inputs": [
 			{"name": "escrow", "type": "address"},
 			{"name": "secret", "type": "bytes32"},
-			{"name": "immutables", "type": "tuple"}
+			{"name": "immutables", "type": "tuple", "components": [
+				{"name": "orderHash", "type": "bytes32"},
+				{"name": "hashlock", "type": "bytes32"},
+				{"name": "maker", "type": "uint256"},
+				{"name": "taker", "type": "uint256"},
+				{"name": "token", "type": "uint256"},
+				{"name": "amount", "type": "uint256"},
+				{"name": "safetyDeposit", "type": "uint256"},
+				{"name": "timelocks", "type": "uint256"}
+			]}
 		],
 		"name": "withdraw",
 		"outputs": [],
@@ -569,7 +947,16 @@ const ResolverABI = `[
 	{
 		"inputs": [
 			{"name": "escrow", "type": "address"},
-			{"name": "immutables", "type": "tuple"}
+			{"name": "immutables", "type": "tuple", "components": [
+				{"name": "orderHash", "type": "bytes32"},
+				{"name": "hashlock", "type": "bytes32"},
+				{"name": "maker", "type": "uint256"},
+				{"name": "taker", "type": "uint256"},
+				{"name": "token", "type": "uint256"},
+				{"name": "amount", "type": "uint256"},
+				{"name": "safetyDeposit", "type": "uint256"},
+				{"name": "timelocks", "type": "uint256"}
+			]}
 		],
 		"name": "cancel",
 		"outputs": [],
@@ -590,6 +977,27 @@ const EscrowABI = `[
 		],
 		"stateMutability": "view",
 		"type": "function"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "recipient", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"},
+			{"indexed": false, "name": "secret", "type": "bytes32"}
+		],
+		"name": "Withdrawn",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "recipient", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"},
+			{"indexed": false, "name": "remainingAmount", "type": "uint256"},
+			{"indexed": false, "name": "secret", "type": "bytes32"}
+		],
+		"name": "PartialWithdrawn",
+		"type": "event"
 	}
 ]`
 
@@ -0,0 +1,275 @@
+package ethereum_client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"go.uber.org/zap"
+)
+
+func newTestClient(t *testing.T, txType string) *Client {
+	t.Helper()
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return &Client{
+		config:   &config.ChainConfig{GasLimit: 300000, TxType: txType},
+		accounts: newAccountPool([]*ecdsa.PrivateKey{privateKey}, config.KeySelectionRoundRobin),
+		address:  crypto.PubkeyToAddress(privateKey.PublicKey),
+		chainID:  big.NewInt(11155111),
+	}
+}
+
+func TestSignTransactionRecoversSender(t *testing.T) {
+	for _, tc := range []struct {
+		txType string
+		want   uint8
+	}{
+		{txType: config.TxTypeLegacy, want: types.LegacyTxType},
+		{txType: config.TxTypeDynamic, want: types.DynamicFeeTxType},
+	} {
+		t.Run(tc.txType, func(t *testing.T) {
+			c := newTestClient(t, tc.txType)
+			auth := &bind.TransactOpts{
+				Nonce:     big.NewInt(7),
+				GasLimit:  c.config.GasLimit,
+				GasPrice:  big.NewInt(20000000000),
+				GasTipCap: big.NewInt(1000000000),
+				GasFeeCap: big.NewInt(41000000000),
+			}
+
+			tx := c.newTransaction(auth, common.HexToAddress("0x1111111111111111111111111111111111111111"), big.NewInt(1), []byte{0x01})
+			if tx.Type() != tc.want {
+				t.Fatalf("expected tx type %d, got %d", tc.want, tx.Type())
+			}
+
+			signedTx, err := c.signTransaction(tx, c.accounts.accounts[0].privateKey)
+			if err != nil {
+				t.Fatalf("failed to sign transaction: %v", err)
+			}
+
+			sender, err := types.Sender(types.LatestSignerForChainID(c.chainID), signedTx)
+			if err != nil {
+				t.Fatalf("failed to recover sender: %v", err)
+			}
+			if sender != c.address {
+				t.Fatalf("expected sender %s, got %s", c.address.Hex(), sender.Hex())
+			}
+			if signedTx.ChainId().Cmp(c.chainID) != 0 {
+				t.Fatalf("expected chain id %s, got %s", c.chainID, signedTx.ChainId())
+			}
+		})
+	}
+}
+
+// mockLogFilterer records the block ranges it is queried with
+type mockLogFilterer struct {
+	maxRange uint64
+	ranges   [][2]uint64
+}
+
+func (m *mockLogFilterer) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if m.maxRange > 0 && to-from+1 > m.maxRange {
+		return nil, fmt.Errorf("query returned more than 10000 results")
+	}
+	m.ranges = append(m.ranges, [2]uint64{from, to})
+	return []types.Log{{BlockNumber: from}}, nil
+}
+
+func (m *mockLogFilterer) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func TestFilterLogsInChunks(t *testing.T) {
+	filterer := &mockLogFilterer{}
+
+	logs, err := filterLogsInChunks(context.Background(), filterer, ethereum.FilterQuery{}, 1, 50000, 5000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filterer.ranges) != 10 {
+		t.Fatalf("expected 10 chunk requests, got %d", len(filterer.ranges))
+	}
+	if len(logs) != 10 {
+		t.Fatalf("expected 10 aggregated logs, got %d", len(logs))
+	}
+
+	next := uint64(1)
+	for _, r := range filterer.ranges {
+		if r[0] != next {
+			t.Fatalf("expected chunk to start at %d, got %d", next, r[0])
+		}
+		next = r[1] + 1
+	}
+	if next != 50001 {
+		t.Fatalf("expected scan to end at block 50000, ended at %d", next-1)
+	}
+}
+
+func TestFilterLogsInChunksHalvesWindow(t *testing.T) {
+	filterer := &mockLogFilterer{maxRange: 2500}
+
+	_, err := filterLogsInChunks(context.Background(), filterer, ethereum.FilterQuery{}, 1, 50000, 5000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filterer.ranges) != 20 {
+		t.Fatalf("expected 20 chunk requests after halving, got %d", len(filterer.ranges))
+	}
+}
+
+func TestEscrowCreatedQuery(t *testing.T) {
+	factory := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	maker := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	signature := crypto.Keccak256Hash([]byte("EscrowCreated(address,address,address,bytes32,uint256)"))
+
+	query := escrowCreatedQuery(factory, nil)
+	if len(query.Addresses) != 1 || query.Addresses[0] != factory {
+		t.Fatalf("expected the query to target the factory, got %v", query.Addresses)
+	}
+	if len(query.Topics) != 1 || len(query.Topics[0]) != 1 || query.Topics[0][0] != signature {
+		t.Fatalf("expected only the event signature topic, got %v", query.Topics)
+	}
+
+	query = escrowCreatedQuery(factory, &maker)
+	if len(query.Topics) != 3 || query.Topics[0][0] != signature {
+		t.Fatalf("expected signature, escrow and maker topics, got %v", query.Topics)
+	}
+	if query.Topics[1] != nil {
+		t.Fatalf("expected any escrow to match, got %v", query.Topics[1])
+	}
+	if len(query.Topics[2]) != 1 || query.Topics[2][0] != common.BytesToHash(maker.Bytes()) {
+		t.Fatalf("expected the maker topic %s, got %v", common.BytesToHash(maker.Bytes()).Hex(), query.Topics[2])
+	}
+}
+
+func TestNewClientUnreachableNode(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	cfg := &config.ChainConfig{
+		RPCEndpoint: "http://127.0.0.1:1",
+		PrivateKey:  fmt.Sprintf("%x", crypto.FromECDSA(key)),
+	}
+
+	c, err := NewClient(cfg, &config.EthereumContracts{}, &config.RelayerConfig{}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("expected client to start disconnected, got %v", err)
+	}
+	if c.knownChainID() != nil {
+		t.Fatalf("expected chain ID to be unknown, got %s", c.knownChainID())
+	}
+	if _, err := c.getChainID(context.Background()); err == nil {
+		t.Fatal("expected chain ID fetch to fail while the node is down")
+	}
+}
+
+// fakeNonceNode is a JSON-RPC node whose account nonce can be moved past the
+// client's, rejecting transactions below it as the real node would
+type fakeNonceNode struct {
+	mutex   sync.Mutex
+	pending uint64
+	sent    []uint64
+}
+
+func (n *fakeNonceNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	switch req.Method {
+	case "eth_chainId":
+		resp["result"] = "0xaa36a7"
+	case "eth_gasPrice":
+		resp["result"] = "0x3b9aca00"
+	case "eth_getTransactionCount":
+		resp["result"] = fmt.Sprintf("0x%x", n.pending)
+	case "eth_sendRawTransaction":
+		var raw string
+		_ = json.Unmarshal(req.Params[0], &raw)
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(common.FromHex(raw)); err != nil {
+			resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+			break
+		}
+		n.sent = append(n.sent, tx.Nonce())
+		if tx.Nonce() < n.pending {
+			resp["error"] = map[string]interface{}{"code": -32000, "message": fmt.Sprintf("nonce too low: next nonce %d, tx nonce %d", n.pending, tx.Nonce())}
+			break
+		}
+		n.pending = tx.Nonce() + 1
+		resp["result"] = tx.Hash().Hex()
+	default:
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestSendTransactionRecoversStaleNonce(t *testing.T) {
+	node := &fakeNonceNode{pending: 3}
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	cfg := &config.ChainConfig{
+		RPCEndpoint: server.URL,
+		PrivateKey:  fmt.Sprintf("%x", crypto.FromECDSA(key)),
+		GasLimit:    21000,
+	}
+	c, err := NewClient(cfg, &config.EthereumContracts{}, &config.RelayerConfig{}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	if _, err := c.sendTransaction(context.Background(), to, big.NewInt(0), nil); err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+
+	// Another sender takes nonces 4 and 5 behind the client's back
+	node.mutex.Lock()
+	node.pending = 6
+	node.mutex.Unlock()
+
+	tx, err := c.sendTransaction(context.Background(), to, big.NewInt(0), nil)
+	if err != nil {
+		t.Fatalf("expected the stale nonce to be resynced, got %v", err)
+	}
+	if tx.Nonce() != 6 {
+		t.Fatalf("expected the retry to use nonce 6, got %d", tx.Nonce())
+	}
+	if want := []uint64{3, 4, 6}; fmt.Sprint(node.sent) != fmt.Sprint(want) {
+		t.Fatalf("expected nonces %v to be sent, got %v", want, node.sent)
+	}
+}
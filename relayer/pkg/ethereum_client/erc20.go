@@ -0,0 +1,224 @@
+package ethereum_client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+)
+
+// ERC20ABI covers the ERC20 metadata and balance methods the relayer reads
+const ERC20ABI = `[
+	{
+		"inputs": [],
+		"name": "symbol",
+		"outputs": [{"name": "", "type": "string"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "decimals",
+		"outputs": [{"name": "", "type": "uint8"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [{"name": "account", "type": "address"}],
+		"name": "balanceOf",
+		"outputs": [{"name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+// ErrFeeOnTransfer is returned for escrows whose token delivered less than
+// the deposited amount, when fee-on-transfer tokens are rejected
+var ErrFeeOnTransfer = errors.New("token delivered less than the deposited amount")
+
+// erc20Bytes32SymbolABI decodes symbols of tokens like MKR that predate the
+// string return type
+const erc20Bytes32SymbolABI = `[
+	{
+		"inputs": [],
+		"name": "symbol",
+		"outputs": [{"name": "", "type": "bytes32"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+// TokenMetadata is the display information of an ERC20 token
+type TokenMetadata struct {
+	Symbol   string
+	Decimals uint8
+}
+
+// IsNativeToken reports whether tokenAddr refers to native ETH rather than an
+// ERC20 token, which escrows record as the zero address
+func IsNativeToken(tokenAddr string) bool {
+	return tokenAddr == "" || common.HexToAddress(tokenAddr) == (common.Address{})
+}
+
+// GetTokenMetadata returns the symbol and decimals of an ERC20 token. Token
+// metadata never changes, so results are cached for the client's lifetime
+func (c *Client) GetTokenMetadata(ctx context.Context, tokenAddr string) (TokenMetadata, error) {
+	token := common.HexToAddress(tokenAddr)
+
+	c.tokenMu.Lock()
+	metadata, ok := c.tokenCache[token]
+	c.tokenMu.Unlock()
+	if ok {
+		return metadata, nil
+	}
+
+	metadata, err := fetchTokenMetadata(ctx, c.client, c.erc20ABI, token)
+	if err != nil {
+		return TokenMetadata{}, err
+	}
+
+	c.tokenMu.Lock()
+	if c.tokenCache == nil {
+		c.tokenCache = make(map[common.Address]TokenMetadata)
+	}
+	c.tokenCache[token] = metadata
+	c.tokenMu.Unlock()
+
+	return metadata, nil
+}
+
+// fetchTokenMetadata reads a token's symbol and decimals through caller
+func fetchTokenMetadata(ctx context.Context, caller ethereum.ContractCaller, erc20ABI abi.ABI, token common.Address) (TokenMetadata, error) {
+	symbolResult, err := callView(ctx, caller, erc20ABI, token, "symbol")
+	if err != nil {
+		return TokenMetadata{}, err
+	}
+	symbol, err := unpackSymbol(erc20ABI, symbolResult)
+	if err != nil {
+		return TokenMetadata{}, fmt.Errorf("failed to unpack symbol of %s: %w", token.Hex(), err)
+	}
+
+	decimalsResult, err := callView(ctx, caller, erc20ABI, token, "decimals")
+	if err != nil {
+		return TokenMetadata{}, err
+	}
+	var decimals uint8
+	if err := erc20ABI.UnpackIntoInterface(&decimals, "decimals", decimalsResult); err != nil {
+		return TokenMetadata{}, fmt.Errorf("failed to unpack decimals of %s: %w", token.Hex(), err)
+	}
+
+	return TokenMetadata{Symbol: symbol, Decimals: decimals}, nil
+}
+
+// callView calls a method without arguments on contract
+func callView(ctx context.Context, caller ethereum.ContractCaller, contractABI abi.ABI, contract common.Address, method string) ([]byte, error) {
+	data, err := contractABI.Pack(method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call: %w", method, err)
+	}
+
+	result, err := caller.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return nil, chain_errors.Classify(fmt.Errorf("failed to call %s on %s: %w", method, contract.Hex(), err))
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%s returned no data from %s", method, contract.Hex())
+	}
+
+	return result, nil
+}
+
+// unpackSymbol decodes a symbol returned as either a string or a bytes32
+func unpackSymbol(erc20ABI abi.ABI, result []byte) (string, error) {
+	var symbol string
+	if err := erc20ABI.UnpackIntoInterface(&symbol, "symbol", result); err == nil {
+		return symbol, nil
+	}
+
+	bytes32ABI, err := abi.JSON(strings.NewReader(erc20Bytes32SymbolABI))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse bytes32 symbol ABI: %w", err)
+	}
+
+	var raw [32]byte
+	if err := bytes32ABI.UnpackIntoInterface(&raw, "symbol", result); err != nil {
+		return "", err
+	}
+	return string(bytes.TrimRight(raw[:], "\x00")), nil
+}
+
+// tokenBalanceAt returns the token balance of holder as of block
+func tokenBalanceAt(ctx context.Context, caller ethereum.ContractCaller, erc20ABI abi.ABI, token, holder common.Address, block *big.Int) (*big.Int, error) {
+	data, err := erc20ABI.Pack("balanceOf", holder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack balanceOf call: %w", err)
+	}
+
+	result, err := caller.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, block)
+	if err != nil {
+		return nil, chain_errors.Classify(fmt.Errorf("failed to call balanceOf on %s: %w", token.Hex(), err))
+	}
+
+	var balance *big.Int
+	if err := erc20ABI.UnpackIntoInterface(&balance, "balanceOf", result); err != nil {
+		return nil, fmt.Errorf("failed to unpack balance of %s in %s: %w", holder.Hex(), token.Hex(), err)
+	}
+	return balance, nil
+}
+
+// measureDeposit returns how many tokens holder received in block, from its
+// balance before and after the block. Fee-on-transfer tokens deliver less
+// than the amount transferred, so this is what an escrow funded in block
+// actually holds; other transfers to holder in the same block count too
+func measureDeposit(ctx context.Context, caller ethereum.ContractCaller, erc20ABI abi.ABI, token, holder common.Address, block uint64) (*big.Int, error) {
+	if block == 0 {
+		return nil, fmt.Errorf("cannot measure a deposit in the genesis block")
+	}
+
+	before, err := tokenBalanceAt(ctx, caller, erc20ABI, token, holder, new(big.Int).SetUint64(block-1))
+	if err != nil {
+		return nil, err
+	}
+	after, err := tokenBalanceAt(ctx, caller, erc20ABI, token, holder, new(big.Int).SetUint64(block))
+	if err != nil {
+		return nil, err
+	}
+
+	received := new(big.Int).Sub(after, before)
+	if received.Sign() < 0 {
+		received.SetInt64(0)
+	}
+	return received, nil
+}
+
+// checkDeposit sets the amount an escrow actually received in the block it
+// was created in. ERC20 deposits are measured from the escrow's token
+// balance; native ETH always arrives in full. When reject is set, escrows
+// that received less than their deposited amount fail with ErrFeeOnTransfer
+func checkDeposit(ctx context.Context, caller ethereum.ContractCaller, erc20ABI abi.ABI, order *EscrowOrder, reject bool) error {
+	if order.DepositedAmount == nil || IsNativeToken(order.TokenAddress) {
+		order.ReceivedAmount = order.DepositedAmount
+		return nil
+	}
+
+	received, err := measureDeposit(ctx, caller, erc20ABI,
+		common.HexToAddress(order.TokenAddress), common.HexToAddress(order.EscrowAddress), order.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to measure deposit into escrow %s: %w", order.EscrowAddress, err)
+	}
+	order.ReceivedAmount = received
+
+	if reject && received.Cmp(order.DepositedAmount) < 0 {
+		return fmt.Errorf("%w: escrow %s received %s of %s deposited in token %s",
+			ErrFeeOnTransfer, order.EscrowAddress, received, order.DepositedAmount, order.TokenAddress)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package ethereum_client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// fakeTokenCaller answers ERC20 calls with canned return data per method ID
+type fakeTokenCaller map[string][]byte
+
+func (f fakeTokenCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return f[string(call.Data[:4])], nil
+}
+
+func newFakeTokenCaller(t *testing.T, symbol []byte, decimals uint8) fakeTokenCaller {
+	t.Helper()
+
+	erc20ABI, err := abi.JSON(strings.NewReader(ERC20ABI))
+	if err != nil {
+		t.Fatalf("failed to parse ERC20 ABI: %v", err)
+	}
+	decimalsResult, err := erc20ABI.Methods["decimals"].Outputs.Pack(decimals)
+	if err != nil {
+		t.Fatalf("failed to pack decimals: %v", err)
+	}
+
+	return fakeTokenCaller{
+		string(erc20ABI.Methods["symbol"].ID):   symbol,
+		string(erc20ABI.Methods["decimals"].ID): decimalsResult,
+	}
+}
+
+func TestFetchTokenMetadata(t *testing.T) {
+	erc20ABI, err := abi.JSON(strings.NewReader(ERC20ABI))
+	if err != nil {
+		t.Fatalf("failed to parse ERC20 ABI: %v", err)
+	}
+
+	stringSymbol, err := erc20ABI.Methods["symbol"].Outputs.Pack("USDC")
+	if err != nil {
+		t.Fatalf("failed to pack symbol: %v", err)
+	}
+	var bytes32Symbol [32]byte
+	copy(bytes32Symbol[:], "MKR")
+
+	for _, tc := range []struct {
+		name     string
+		symbol   []byte
+		decimals uint8
+		want     TokenMetadata
+	}{
+		{name: "string symbol", symbol: stringSymbol, decimals: 6, want: TokenMetadata{Symbol: "USDC", Decimals: 6}},
+		{name: "bytes32 symbol", symbol: bytes32Symbol[:], decimals: 18, want: TokenMetadata{Symbol: "MKR", Decimals: 18}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			caller := newFakeTokenCaller(t, tc.symbol, tc.decimals)
+			metadata, err := fetchTokenMetadata(context.Background(), caller, erc20ABI, common.HexToAddress("0x01"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if metadata != tc.want {
+				t.Fatalf("expected %+v, got %+v", tc.want, metadata)
+			}
+		})
+	}
+}
+
+func TestFetchTokenMetadataNotAContract(t *testing.T) {
+	erc20ABI, err := abi.JSON(strings.NewReader(ERC20ABI))
+	if err != nil {
+		t.Fatalf("failed to parse ERC20 ABI: %v", err)
+	}
+
+	_, err = fetchTokenMetadata(context.Background(), fakeTokenCaller{}, erc20ABI, common.HexToAddress("0x01"))
+	if err == nil {
+		t.Fatal("expected an error for an address without code")
+	}
+}
+
+func TestIsNativeToken(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"": true,
+		"0x0000000000000000000000000000000000000000": true,
+		"0x2222222222222222222222222222222222222222": false,
+	} {
+		if got := IsNativeToken(addr); got != want {
+			t.Fatalf("IsNativeToken(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+// feeOnTransferToken is a fake ERC20 that keeps a fee of every transfer, so
+// an escrow's balance grows by less than the deposited amount in the block
+// it is funded in
+type feeOnTransferToken struct {
+	erc20ABI abi.ABI
+	// balances of the escrow by block number
+	balances map[uint64]*big.Int
+}
+
+func (f *feeOnTransferToken) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	balance, ok := f.balances[blockNumber.Uint64()]
+	if !ok {
+		balance = new(big.Int)
+	}
+	return f.erc20ABI.Methods["balanceOf"].Outputs.Pack(balance)
+}
+
+func TestCheckDepositFeeOnTransfer(t *testing.T) {
+	erc20ABI, err := abi.JSON(strings.NewReader(ERC20ABI))
+	if err != nil {
+		t.Fatalf("failed to parse ERC20 ABI: %v", err)
+	}
+
+	// The escrow already held 5 tokens; a deposit of 1000 with a 1% fee
+	// leaves it 990 more
+	token := &feeOnTransferToken{erc20ABI: erc20ABI, balances: map[uint64]*big.Int{
+		99:  big.NewInt(5),
+		100: big.NewInt(995),
+	}}
+	newOrder := func() *EscrowOrder {
+		return &EscrowOrder{
+			EscrowAddress:   "0x1111111111111111111111111111111111111111",
+			TokenAddress:    "0x2222222222222222222222222222222222222222",
+			DepositedAmount: big.NewInt(1000),
+			BlockNumber:     100,
+		}
+	}
+
+	order := newOrder()
+	if err := checkDeposit(context.Background(), token, erc20ABI, order, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.ReceivedAmount.Cmp(big.NewInt(990)) != 0 {
+		t.Fatalf("expected 990 received, got %s", order.ReceivedAmount)
+	}
+
+	order = newOrder()
+	err = checkDeposit(context.Background(), token, erc20ABI, order, true)
+	if !errors.Is(err, ErrFeeOnTransfer) {
+		t.Fatalf("expected ErrFeeOnTransfer, got %v", err)
+	}
+
+	// A token transferring its full amount is accepted either way
+	token.balances[100] = big.NewInt(1005)
+	order = newOrder()
+	if err := checkDeposit(context.Background(), token, erc20ABI, order, true); err != nil {
+		t.Fatalf("unexpected error for a full transfer: %v", err)
+	}
+	if order.ReceivedAmount.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("expected 1000 received, got %s", order.ReceivedAmount)
+	}
+}
+
+func TestCheckDepositNativeToken(t *testing.T) {
+	order := &EscrowOrder{DepositedAmount: big.NewInt(1000), BlockNumber: 100}
+	if err := checkDeposit(context.Background(), fakeTokenCaller{}, abi.ABI{}, order, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.ReceivedAmount.Cmp(order.DepositedAmount) != 0 {
+		t.Fatalf("expected native deposits to arrive in full, got %s", order.ReceivedAmount)
+	}
+}
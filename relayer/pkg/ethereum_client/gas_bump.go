@@ -0,0 +1,314 @@
+package ethereum_client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"go.uber.org/zap"
+)
+
+// replacedTxRetention is how long a replaced transaction is remembered after
+// it was mined, so callers holding its original hash still find the receipt
+// of the version that was mined
+const replacedTxRetention = 24 * time.Hour
+
+// sentTx is a transaction the client sent, with the replacements sent for it
+type sentTx struct {
+	acct *account
+	// tx is the latest version sent
+	tx *types.Transaction
+	// hashes of every version sent, original first
+	hashes []common.Hash
+	// sentAt is when the latest version was sent
+	sentAt time.Time
+	// minedAt is when the transaction's nonce was seen mined, zero while it
+	// is pending
+	minedAt time.Time
+}
+
+// sentTxTracker remembers the transactions the client sent until their
+// nonce is mined, so stuck ones can be replaced
+type sentTxTracker struct {
+	mutex  sync.Mutex
+	txs    []*sentTx
+	byHash map[common.Hash]*sentTx
+	now    func() time.Time
+}
+
+func newSentTxTracker() *sentTxTracker {
+	return &sentTxTracker{byHash: make(map[common.Hash]*sentTx), now: time.Now}
+}
+
+// track starts monitoring a transaction sent from acct
+func (t *sentTxTracker) track(acct *account, tx *types.Transaction) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	sent := &sentTx{acct: acct, tx: tx, hashes: []common.Hash{tx.Hash()}, sentAt: t.now()}
+	t.txs = append(t.txs, sent)
+	t.byHash[tx.Hash()] = sent
+}
+
+// replace records replacement as the latest version of sent
+func (t *sentTxTracker) replace(sent *sentTx, replacement *types.Transaction) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	sent.tx = replacement
+	sent.hashes = append(sent.hashes, replacement.Hash())
+	sent.sentAt = t.now()
+	t.byHash[replacement.Hash()] = sent
+}
+
+// postpone restarts the stuck timeout of sent without replacing it
+func (t *sentTxTracker) postpone(sent *sentTx) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	sent.sentAt = t.now()
+}
+
+// pending returns the transactions whose nonce isn't mined yet
+func (t *sentTxTracker) pending() []*sentTx {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var pending []*sentTx
+	for _, sent := range t.txs {
+		if sent.minedAt.IsZero() {
+			pending = append(pending, sent)
+		}
+	}
+	return pending
+}
+
+// stuck reports whether sent's latest version has been pending for timeout
+func (t *sentTxTracker) stuck(sent *sentTx, timeout time.Duration) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.now().Sub(sent.sentAt) >= timeout
+}
+
+// markMined stops monitoring the transactions from address whose nonce is
+// below the account's mined nonce. Transactions that were never replaced are
+// forgotten right away, and replaced ones once replacedTxRetention passed
+func (t *sentTxTracker) markMined(address common.Address, minedNonce uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.now()
+	kept := t.txs[:0]
+	for _, sent := range t.txs {
+		if sent.minedAt.IsZero() && sent.acct.address == address && sent.tx.Nonce() < minedNonce {
+			sent.minedAt = now
+		}
+		if !sent.minedAt.IsZero() && (len(sent.hashes) == 1 || now.Sub(sent.minedAt) >= replacedTxRetention) {
+			for _, hash := range sent.hashes {
+				delete(t.byHash, hash)
+			}
+			continue
+		}
+		kept = append(kept, sent)
+	}
+	t.txs = kept
+}
+
+// versions returns the hashes of every version of the transaction sent as
+// hash, newest first, or just hash for a transaction the client doesn't track
+func (t *sentTxTracker) versions(hash common.Hash) []common.Hash {
+	if t == nil {
+		return []common.Hash{hash}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	sent, ok := t.byHash[hash]
+	if !ok {
+		return []common.Hash{hash}
+	}
+	versions := make([]common.Hash, len(sent.hashes))
+	for i, h := range sent.hashes {
+		versions[len(sent.hashes)-1-i] = h
+	}
+	return versions
+}
+
+// transactionReceipt returns the receipt of the transaction sent as txHash,
+// looking at the replacements sent for it newest first. It returns
+// ethereum.NotFound when no version has been mined
+func (c *Client) transactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	for _, hash := range c.sentTxs.versions(txHash) {
+		receipt, err := c.client.TransactionReceipt(ctx, hash)
+		if err == ethereum.NotFound {
+			continue
+		}
+		return receipt, err
+	}
+	return nil, ethereum.NotFound
+}
+
+// gasBumpEnabled reports whether sent transactions are monitored and
+// replaced when they get stuck
+func (c *Client) gasBumpEnabled() bool {
+	return c.config.GasBump.StuckTimeout > 0 && c.sentTxs != nil
+}
+
+// BumpStuckTransactions checks the transactions the client sent that aren't
+// mined yet. Ones whose nonce was mined since the last check stop being
+// monitored, and ones pending for longer than gas_bump.stuck_timeout are
+// resent with the same nonce and a fee raised by gas_bump.percent, or to the
+// node's current suggestion when that is higher, up to
+// gas_bump.max_gas_price. It returns the number of transactions replaced
+func (c *Client) BumpStuckTransactions(ctx context.Context) (int, error) {
+	if !c.gasBumpEnabled() {
+		return 0, nil
+	}
+
+	pending := c.sentTxs.pending()
+	minedNonces := make(map[common.Address]uint64)
+	for _, sent := range pending {
+		address := sent.acct.address
+		if _, checked := minedNonces[address]; checked {
+			continue
+		}
+		nonce, err := c.client.NonceAt(ctx, address)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get mined nonce of %s: %w", address.Hex(), err)
+		}
+		minedNonces[address] = nonce
+		c.sentTxs.markMined(address, nonce)
+	}
+
+	replaced := 0
+	for _, sent := range pending {
+		if sent.tx.Nonce() < minedNonces[sent.acct.address] || !c.sentTxs.stuck(sent, c.config.GasBump.StuckTimeout) {
+			continue
+		}
+
+		replacement, err := c.bumpTransaction(ctx, sent)
+		if err != nil {
+			c.logger.Warn("Failed to replace stuck transaction",
+				zap.String("tx_hash", sent.hashes[0].Hex()),
+				zap.Uint64("nonce", sent.tx.Nonce()),
+				zap.Error(err))
+			continue
+		}
+		if replacement == nil {
+			continue
+		}
+		replaced++
+	}
+	return replaced, nil
+}
+
+// bumpTransaction signs and sends the replacement of a stuck transaction. It
+// returns nil without sending anything when the transaction already pays the
+// most gas_bump.max_gas_price allows
+func (c *Client) bumpTransaction(ctx context.Context, sent *sentTx) (*types.Transaction, error) {
+	previous := sent.tx
+	tx, err := c.replacementTx(ctx, previous)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		c.logger.Warn("Stuck transaction already pays the maximum gas price",
+			zap.String("tx_hash", sent.hashes[0].Hex()),
+			zap.Uint64("nonce", previous.Nonce()),
+			zap.String("max_gas_price", c.maxGasPrice.String()))
+		c.sentTxs.postpone(sent)
+		return nil, nil
+	}
+
+	signedTx, err := c.signTransaction(tx, sent.acct.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign replacement: %w", err)
+	}
+	if err := c.client.SendTransaction(ctx, signedTx); err != nil {
+		return nil, chain_errors.Classify(fmt.Errorf("failed to send replacement: %w", err))
+	}
+	c.sentTxs.replace(sent, signedTx)
+
+	c.logger.Warn("Replaced stuck transaction with a higher fee",
+		zap.String("from", sent.acct.address.Hex()),
+		zap.Uint64("nonce", signedTx.Nonce()),
+		zap.String("original_tx_hash", sent.hashes[0].Hex()),
+		zap.String("previous_tx_hash", previous.Hash().Hex()),
+		zap.String("tx_hash", signedTx.Hash().Hex()),
+		zap.String("gas_price", signedTx.GasFeeCap().String()),
+		zap.Int("replacements", len(sent.hashes)-1))
+	return signedTx, nil
+}
+
+// replacementTx builds tx again with the same nonce and a higher fee. Each
+// fee is raised by gas_bump.percent, or to the node's current suggestion when
+// that is higher, and capped at maxGasPrice. It returns nil when the cap
+// leaves no room for a raise nodes accept as a replacement
+func (c *Client) replacementTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	percent := int64(c.config.GasBump.Percent)
+
+	if tx.Type() == types.DynamicFeeTxType {
+		current := &bind.TransactOpts{}
+		if err := c.setDynamicFees(ctx, current); err != nil {
+			return nil, err
+		}
+
+		feeCap := capFee(maxFee(raiseFee(tx.GasFeeCap(), percent), current.GasFeeCap), c.maxGasPrice)
+		tip := capFee(maxFee(raiseFee(tx.GasTipCap(), percent), current.GasTipCap), feeCap)
+		if feeCap.Cmp(raiseFee(tx.GasFeeCap(), config.MinGasBumpPercent)) < 0 ||
+			tip.Cmp(raiseFee(tx.GasTipCap(), config.MinGasBumpPercent)) < 0 {
+			return nil, nil
+		}
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   tx.ChainId(),
+			Nonce:     tx.Nonce(),
+			GasTipCap: tip,
+			GasFeeCap: feeCap,
+			Gas:       tx.Gas(),
+			To:        tx.To(),
+			Value:     tx.Value(),
+			Data:      tx.Data(),
+		}), nil
+	}
+
+	suggested, err := c.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	gasPrice := capFee(maxFee(raiseFee(tx.GasPrice(), percent), suggested), c.maxGasPrice)
+	if gasPrice.Cmp(raiseFee(tx.GasPrice(), config.MinGasBumpPercent)) < 0 {
+		return nil, nil
+	}
+	return types.NewTransaction(tx.Nonce(), *tx.To(), tx.Value(), tx.Gas(), gasPrice, tx.Data()), nil
+}
+
+// raiseFee returns fee raised by percent, rounded up
+func raiseFee(fee *big.Int, percent int64) *big.Int {
+	raised := new(big.Int).Mul(fee, big.NewInt(100+percent))
+	raised.Add(raised, big.NewInt(99))
+	return raised.Div(raised, big.NewInt(100))
+}
+
+// maxFee returns the higher of two fees
+func maxFee(a, b *big.Int) *big.Int {
+	if b != nil && b.Cmp(a) > 0 {
+		return b
+	}
+	return a
+}
+
+// capFee returns fee, or limit when fee exceeds it
+func capFee(fee, limit *big.Int) *big.Int {
+	if limit != nil && fee.Cmp(limit) > 0 {
+		return new(big.Int).Set(limit)
+	}
+	return fee
+}
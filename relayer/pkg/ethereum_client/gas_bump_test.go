@@ -0,0 +1,232 @@
+package ethereum_client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"go.uber.org/zap"
+)
+
+// fakeMempoolNode is a JSON-RPC node that keeps every transaction it is sent
+// pending until the test mines one
+type fakeMempoolNode struct {
+	mutex    sync.Mutex
+	gasPrice *big.Int
+	sent     []*types.Transaction
+	// mined maps the hash of each mined transaction to its block
+	mined      map[common.Hash]uint64
+	minedNonce uint64
+}
+
+// mine includes tx in block, advancing the sender's mined nonce past it
+func (n *fakeMempoolNode) mine(tx *types.Transaction, block uint64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.mined[tx.Hash()] = block
+	n.minedNonce = tx.Nonce() + 1
+}
+
+func (n *fakeMempoolNode) sentTxs() []*types.Transaction {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return append([]*types.Transaction(nil), n.sent...)
+}
+
+func (n *fakeMempoolNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	switch req.Method {
+	case "eth_chainId":
+		resp["result"] = "0xaa36a7"
+	case "eth_gasPrice":
+		resp["result"] = hexutil.EncodeBig(n.gasPrice)
+	case "eth_getTransactionCount":
+		var block string
+		_ = json.Unmarshal(req.Params[1], &block)
+		nonce := n.minedNonce
+		if block == "pending" {
+			for _, tx := range n.sent {
+				if tx.Nonce() >= nonce {
+					nonce = tx.Nonce() + 1
+				}
+			}
+		}
+		resp["result"] = hexutil.EncodeUint64(nonce)
+	case "eth_sendRawTransaction":
+		var raw string
+		_ = json.Unmarshal(req.Params[0], &raw)
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(common.FromHex(raw)); err != nil {
+			resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+			break
+		}
+		n.sent = append(n.sent, tx)
+		resp["result"] = tx.Hash().Hex()
+	case "eth_getTransactionReceipt":
+		var hash common.Hash
+		_ = json.Unmarshal(req.Params[0], &hash)
+		block, mined := n.mined[hash]
+		if !mined {
+			resp["result"] = nil
+			break
+		}
+		resp["result"] = &types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21000,
+			GasUsed:           21000,
+			Logs:              []*types.Log{},
+			TxHash:            hash,
+			BlockNumber:       new(big.Int).SetUint64(block),
+		}
+	default:
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestBumpStuckTransaction(t *testing.T) {
+	gwei := big.NewInt(1000000000)
+	node := &fakeMempoolNode{gasPrice: new(big.Int).Set(gwei), mined: make(map[common.Hash]uint64), minedNonce: 3}
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	cfg := &config.ChainConfig{
+		RPCEndpoint: server.URL,
+		PrivateKey:  fmt.Sprintf("%x", crypto.FromECDSA(key)),
+		GasLimit:    21000,
+		GasBump: config.GasBumpConfig{
+			StuckTimeout: time.Minute,
+			Percent:      20,
+			MaxGasPrice:  "6500000000",
+		},
+	}
+	c, err := NewClient(cfg, &config.EthereumContracts{}, &config.RelayerConfig{}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	c.sentTxs.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	original, err := c.sendTransaction(ctx, to, big.NewInt(0), []byte{0x01})
+	if err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+
+	// Not stuck until the timeout passed
+	if replaced, err := c.BumpStuckTransactions(ctx); err != nil || replaced != 0 {
+		t.Fatalf("expected no replacement before the timeout, got %d, %v", replaced, err)
+	}
+
+	// Gas prices spike, so the replacement follows the node's suggestion
+	node.mutex.Lock()
+	node.gasPrice = new(big.Int).Mul(gwei, big.NewInt(5))
+	node.mutex.Unlock()
+	now = now.Add(time.Minute)
+	if replaced, err := c.BumpStuckTransactions(ctx); err != nil || replaced != 1 {
+		t.Fatalf("expected the stuck transaction to be replaced, got %d, %v", replaced, err)
+	}
+
+	// Still stuck, so it is raised by 20%
+	now = now.Add(time.Minute)
+	if replaced, err := c.BumpStuckTransactions(ctx); err != nil || replaced != 1 {
+		t.Fatalf("expected the replacement to be replaced, got %d, %v", replaced, err)
+	}
+
+	// 7.2 gwei would exceed the cap, and 6.5 gwei is too small a raise
+	now = now.Add(time.Minute)
+	if replaced, err := c.BumpStuckTransactions(ctx); err != nil || replaced != 0 {
+		t.Fatalf("expected no replacement above the cap, got %d, %v", replaced, err)
+	}
+
+	sent := node.sentTxs()
+	if len(sent) != 3 {
+		t.Fatalf("expected the original and 2 replacements, got %d transactions", len(sent))
+	}
+	for i, want := range []int64{1000000000, 5000000000, 6000000000} {
+		tx := sent[i]
+		if tx.Nonce() != original.Nonce() {
+			t.Fatalf("expected transaction %d to reuse nonce %d, got %d", i, original.Nonce(), tx.Nonce())
+		}
+		if tx.GasPrice().Int64() != want {
+			t.Fatalf("expected transaction %d to pay %d wei, got %s", i, want, tx.GasPrice())
+		}
+		if *tx.To() != to || tx.Gas() != original.Gas() || common.Bytes2Hex(tx.Data()) != "01" {
+			t.Fatalf("expected transaction %d to repeat the original call, got %+v", i, tx)
+		}
+	}
+
+	// The first replacement gets mined; the original hash finds its receipt
+	node.mine(sent[1], 42)
+	mined, err := c.CheckTransaction(ctx, original.Hash().Hex())
+	if err != nil || !mined {
+		t.Fatalf("expected the original hash to be reported mined, got %v, %v", mined, err)
+	}
+	block, found, err := c.GetTransactionBlock(ctx, original.Hash().Hex())
+	if err != nil || !found || block != 42 {
+		t.Fatalf("expected the replacement's block 42, got %d, %v, %v", block, found, err)
+	}
+
+	// Monitoring stops once the nonce is mined
+	now = now.Add(time.Minute)
+	if replaced, err := c.BumpStuckTransactions(ctx); err != nil || replaced != 0 {
+		t.Fatalf("expected no replacement after mining, got %d, %v", replaced, err)
+	}
+	if pending := c.sentTxs.pending(); len(pending) != 0 {
+		t.Fatalf("expected no pending transaction, got %d", len(pending))
+	}
+	if len(node.sentTxs()) != 3 {
+		t.Fatalf("expected no transaction sent after mining, got %d", len(node.sentTxs()))
+	}
+}
+
+func TestRaiseFee(t *testing.T) {
+	for _, tc := range []struct {
+		fee, percent, want int64
+	}{
+		{fee: 100, percent: 20, want: 120},
+		// Rounded up so a small fee still rises
+		{fee: 1, percent: 10, want: 2},
+		{fee: 55, percent: 10, want: 61},
+	} {
+		if got := raiseFee(big.NewInt(tc.fee), tc.percent); got.Int64() != tc.want {
+			t.Fatalf("expected %d raised by %d%% to be %d, got %s", tc.fee, tc.percent, tc.want, got)
+		}
+	}
+
+	if got := capFee(big.NewInt(120), big.NewInt(100)); got.Int64() != 100 {
+		t.Fatalf("expected fee capped at 100, got %s", got)
+	}
+	if got := maxFee(big.NewInt(120), nil); got.Int64() != 120 {
+		t.Fatalf("expected a missing suggestion to be ignored, got %s", got)
+	}
+}
@@ -0,0 +1,171 @@
+package ethereum_client
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Timelock stages in the order the 1inch contracts pack them, from the least
+// significant 32 bits up. The top 32 bits hold the deployment timestamp
+const (
+	stageSrcWithdrawal = iota
+	stageSrcPublicWithdrawal
+	stageSrcCancellation
+	stageSrcPublicCancellation
+	stageDstWithdrawal
+	stageDstPublicWithdrawal
+	stageDstCancellation
+	deployedAtOffset = 224
+)
+
+// Immutables mirrors IBaseEscrow.Immutables, the parameters an escrow is
+// deployed with. The resolver needs them to deploy, withdraw from and cancel
+// escrows, and they must match the deployed escrow exactly
+type Immutables struct {
+	OrderHash     [32]byte
+	Hashlock      [32]byte
+	Maker         common.Address
+	Taker         common.Address
+	Token         common.Address
+	Amount        *big.Int
+	SafetyDeposit *big.Int
+	Timelocks     Timelocks
+}
+
+// Timelocks holds the start of each escrow stage in seconds after DeployedAt
+type Timelocks struct {
+	DeployedAt            uint32
+	SrcWithdrawal         uint32
+	SrcPublicWithdrawal   uint32
+	SrcCancellation       uint32
+	SrcPublicCancellation uint32
+	DstWithdrawal         uint32
+	DstPublicWithdrawal   uint32
+	DstCancellation       uint32
+}
+
+// immutablesTuple is the ABI form of Immutables. The contracts' Address type
+// is a uint256, so addresses are encoded as integers
+type immutablesTuple struct {
+	OrderHash     [32]byte
+	Hashlock      [32]byte
+	Maker         *big.Int
+	Taker         *big.Int
+	Token         *big.Int
+	Amount        *big.Int
+	SafetyDeposit *big.Int
+	Timelocks     *big.Int
+}
+
+// Pack encodes the timelocks into the uint256 the contracts expect
+func (t Timelocks) Pack() *big.Int {
+	stages := []uint32{
+		stageSrcWithdrawal:         t.SrcWithdrawal,
+		stageSrcPublicWithdrawal:   t.SrcPublicWithdrawal,
+		stageSrcCancellation:       t.SrcCancellation,
+		stageSrcPublicCancellation: t.SrcPublicCancellation,
+		stageDstWithdrawal:         t.DstWithdrawal,
+		stageDstPublicWithdrawal:   t.DstPublicWithdrawal,
+		stageDstCancellation:       t.DstCancellation,
+	}
+
+	packed := new(big.Int).Lsh(new(big.Int).SetUint64(uint64(t.DeployedAt)), deployedAtOffset)
+	for stage, offset := range stages {
+		value := new(big.Int).Lsh(new(big.Int).SetUint64(uint64(offset)), uint(stage*32))
+		packed.Or(packed, value)
+	}
+	return packed
+}
+
+// UnpackTimelocks decodes timelocks packed by Timelocks.Pack
+func UnpackTimelocks(packed *big.Int) Timelocks {
+	word := func(shift uint) uint32 {
+		return uint32(new(big.Int).Rsh(packed, shift).Uint64())
+	}
+
+	return Timelocks{
+		DeployedAt:            word(deployedAtOffset),
+		SrcWithdrawal:         word(stageSrcWithdrawal * 32),
+		SrcPublicWithdrawal:   word(stageSrcPublicWithdrawal * 32),
+		SrcCancellation:       word(stageSrcCancellation * 32),
+		SrcPublicCancellation: word(stageSrcPublicCancellation * 32),
+		DstWithdrawal:         word(stageDstWithdrawal * 32),
+		DstPublicWithdrawal:   word(stageDstPublicWithdrawal * 32),
+		DstCancellation:       word(stageDstCancellation * 32),
+	}
+}
+
+// tuple converts the immutables to their ABI form
+func (i Immutables) tuple() immutablesTuple {
+	amount := i.Amount
+	if amount == nil {
+		amount = big.NewInt(0)
+	}
+	safetyDeposit := i.SafetyDeposit
+	if safetyDeposit == nil {
+		safetyDeposit = big.NewInt(0)
+	}
+
+	return immutablesTuple{
+		OrderHash:     i.OrderHash,
+		Hashlock:      i.Hashlock,
+		Maker:         new(big.Int).SetBytes(i.Maker.Bytes()),
+		Taker:         new(big.Int).SetBytes(i.Taker.Bytes()),
+		Token:         new(big.Int).SetBytes(i.Token.Bytes()),
+		Amount:        amount,
+		SafetyDeposit: safetyDeposit,
+		Timelocks:     i.Timelocks.Pack(),
+	}
+}
+
+// immutablesFromABI converts a tuple decoded by go-ethereum back to Immutables
+func immutablesFromABI(decoded interface{}) (Immutables, error) {
+	t, ok := abi.ConvertType(decoded, new(immutablesTuple)).(*immutablesTuple)
+	if !ok {
+		return Immutables{}, fmt.Errorf("unexpected immutables type %T", decoded)
+	}
+
+	return Immutables{
+		OrderHash:     t.OrderHash,
+		Hashlock:      t.Hashlock,
+		Maker:         common.BigToAddress(t.Maker),
+		Taker:         common.BigToAddress(t.Taker),
+		Token:         common.BigToAddress(t.Token),
+		Amount:        t.Amount,
+		SafetyDeposit: t.SafetyDeposit,
+		Timelocks:     UnpackTimelocks(t.Timelocks),
+	}, nil
+}
+
+// srcEscrowCreatedImmutables returns the immutables factory emitted in a
+// SrcEscrowCreated event of receipt for the escrow locked with hashlock, or
+// nil if the transaction emitted none for it
+func srcEscrowCreatedImmutables(factoryABI abi.ABI, receipt *types.Receipt, factory common.Address, hashlock [32]byte) (*Immutables, error) {
+	event, ok := factoryABI.Events["SrcEscrowCreated"]
+	if !ok {
+		return nil, fmt.Errorf("factory ABI has no SrcEscrowCreated event")
+	}
+
+	for _, log := range receipt.Logs {
+		if log.Address != factory || len(log.Topics) == 0 || log.Topics[0] != event.ID {
+			continue
+		}
+
+		values, err := event.Inputs.Unpack(log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack SrcEscrowCreated: %w", err)
+		}
+		immutables, err := immutablesFromABI(values[0])
+		if err != nil {
+			return nil, err
+		}
+		if immutables.Hashlock == hashlock {
+			return &immutables, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,165 @@
+package ethereum_client
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func testImmutables() Immutables {
+	return Immutables{
+		OrderHash:     crypto.Keccak256Hash([]byte("order")),
+		Hashlock:      crypto.Keccak256Hash([]byte("secret")),
+		Maker:         common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Taker:         common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		Token:         common.HexToAddress("0x3333333333333333333333333333333333333333"),
+		Amount:        big.NewInt(1000000),
+		SafetyDeposit: big.NewInt(1000),
+		Timelocks: Timelocks{
+			DeployedAt:          1700000000,
+			DstWithdrawal:       60,
+			DstPublicWithdrawal: 1800,
+			DstCancellation:     3600,
+		},
+	}
+}
+
+func TestTimelocksPackRoundTrip(t *testing.T) {
+	timelocks := Timelocks{
+		DeployedAt:            1700000000,
+		SrcWithdrawal:         1,
+		SrcPublicWithdrawal:   2,
+		SrcCancellation:       3,
+		SrcPublicCancellation: 4,
+		DstWithdrawal:         5,
+		DstPublicWithdrawal:   6,
+		DstCancellation:       7,
+	}
+
+	packed := timelocks.Pack()
+	if got := UnpackTimelocks(packed); got != timelocks {
+		t.Fatalf("expected %+v, got %+v", timelocks, got)
+	}
+
+	// Stages are packed from the least significant 32 bits up
+	if low := uint32(packed.Uint64()); low != 1 {
+		t.Fatalf("expected SrcWithdrawal in the lowest word, got %d", low)
+	}
+	if top := new(big.Int).Rsh(packed, 224).Uint64(); top != 1700000000 {
+		t.Fatalf("expected DeployedAt in the top word, got %d", top)
+	}
+}
+
+func TestDeployDstImmutablesRoundTrip(t *testing.T) {
+	resolverABI, err := abi.JSON(strings.NewReader(ResolverABI))
+	if err != nil {
+		t.Fatalf("failed to parse resolver ABI: %v", err)
+	}
+
+	immutables := testImmutables()
+	data, err := resolverABI.Pack("deployDst", immutables.tuple(), big.NewInt(1700007200))
+	if err != nil {
+		t.Fatalf("failed to pack deployDst: %v", err)
+	}
+
+	// The selector must match the resolver's IBaseEscrow.Immutables signature
+	selector := crypto.Keccak256([]byte("deployDst((bytes32,bytes32,uint256,uint256,uint256,uint256,uint256,uint256),uint256)"))[:4]
+	if !bytes.Equal(data[:4], selector) {
+		t.Fatalf("unexpected selector %x, want %x", data[:4], selector)
+	}
+
+	// A static tuple is encoded inline, one word per field
+	if len(data) != 4+9*32 {
+		t.Fatalf("unexpected calldata length %d", len(data))
+	}
+	if maker := common.BytesToAddress(data[4+2*32 : 4+3*32]); maker != immutables.Maker {
+		t.Fatalf("expected maker %s in the third word, got %s", immutables.Maker, maker)
+	}
+
+	values, err := resolverABI.Methods["deployDst"].Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("failed to unpack deployDst: %v", err)
+	}
+
+	decoded, err := immutablesFromABI(values[0])
+	if err != nil {
+		t.Fatalf("failed to convert immutables: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, immutables) {
+		t.Fatalf("immutables did not round-trip:\nwant %+v\ngot  %+v", immutables, decoded)
+	}
+}
+
+func TestWithdrawAndCancelPackImmutables(t *testing.T) {
+	resolverABI, err := abi.JSON(strings.NewReader(ResolverABI))
+	if err != nil {
+		t.Fatalf("failed to parse resolver ABI: %v", err)
+	}
+
+	escrow := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	immutables := testImmutables()
+
+	if _, err := resolverABI.Pack("withdraw", escrow, [32]byte{1}, immutables.tuple()); err != nil {
+		t.Fatalf("failed to pack withdraw: %v", err)
+	}
+	if _, err := resolverABI.Pack("cancel", escrow, immutables.tuple()); err != nil {
+		t.Fatalf("failed to pack cancel: %v", err)
+	}
+
+	// Missing amounts are encoded as zero rather than failing to pack
+	immutables.Amount = nil
+	immutables.SafetyDeposit = nil
+	if _, err := resolverABI.Pack("cancel", escrow, immutables.tuple()); err != nil {
+		t.Fatalf("failed to pack cancel with zero amounts: %v", err)
+	}
+}
+
+func TestSrcEscrowCreatedImmutables(t *testing.T) {
+	factoryABI, err := abi.JSON(strings.NewReader(EscrowFactoryABI))
+	if err != nil {
+		t.Fatalf("failed to parse factory ABI: %v", err)
+	}
+	event := factoryABI.Events["SrcEscrowCreated"]
+
+	immutables := testImmutables()
+	immutables.Timelocks = Timelocks{DeployedAt: 1700000000, SrcWithdrawal: 60, SrcPublicWithdrawal: 600, SrcCancellation: 3600, SrcPublicCancellation: 7200}
+	complement := struct {
+		Maker         *big.Int
+		Amount        *big.Int
+		Token         *big.Int
+		SafetyDeposit *big.Int
+		ChainId       *big.Int
+	}{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(25)}
+	data, err := event.Inputs.Pack(immutables.tuple(), complement)
+	if err != nil {
+		t.Fatalf("failed to pack event: %v", err)
+	}
+
+	factory := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	receipt := &types.Receipt{Logs: []*types.Log{
+		// The same event from another contract is ignored
+		{Address: common.HexToAddress("0x5555555555555555555555555555555555555555"), Topics: []common.Hash{event.ID}, Data: data},
+		{Address: factory, Topics: []common.Hash{event.ID}, Data: data},
+	}}
+
+	got, err := srcEscrowCreatedImmutables(factoryABI, receipt, factory, immutables.Hashlock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil || !reflect.DeepEqual(*got, immutables) {
+		t.Fatalf("expected %+v, got %+v", immutables, got)
+	}
+
+	// An escrow with another hashlock has no immutables in the receipt
+	got, err = srcEscrowCreatedImmutables(factoryABI, receipt, factory, crypto.Keccak256Hash([]byte("other")))
+	if err != nil || got != nil {
+		t.Fatalf("expected no immutables for another hashlock, got %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,83 @@
+package ethereum_client
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSignLimitOrder(t *testing.T) {
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if err != nil {
+		t.Fatalf("failed to parse key: %v", err)
+	}
+	maker := crypto.PubkeyToAddress(key.PublicKey)
+
+	salt, _ := new(big.Int).SetString("102412815611787935992271873344279698181002251432500613888978521074851540062603", 10)
+	order := LimitOrder{
+		Salt:         salt,
+		Maker:        maker,
+		MakerAsset:   common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+		TakerAsset:   common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"),
+		MakingAmount: big.NewInt(1_000_000_000_000_000_000),
+		TakingAmount: big.NewInt(1_420_000_000),
+	}
+	chainID := big.NewInt(1)
+	lop := common.HexToAddress("0x111111125421cA6dc452d289314280a0f8842A65")
+
+	// Vector produced with go-ethereum's apitypes.TypedDataAndHash
+	expectedHash := "0x87de733c8ef90faeb9af669c44a1bf680e5d580bb4f43a9b1acef863cd735798"
+	expectedSignature := "0x5563b809a48acf28bc5dbcaca726471cfd05c9b2b69b6f965a71855d2874fb82002bec87e98a53079a4b8e97fdb9f2ba6ccc5fc29ce9729bc0e5209fe55a4d0c1c"
+
+	hash := LimitOrderHash(order, chainID, lop)
+	if hash.Hex() != expectedHash {
+		t.Fatalf("expected order hash %s, got %s", expectedHash, hash.Hex())
+	}
+
+	client := &Client{
+		accounts:   &accountPool{accounts: []*account{{privateKey: key, address: maker}}},
+		chainID:    chainID,
+		lopAddress: lop,
+	}
+	signature, err := client.SignLimitOrder(order)
+	if err != nil {
+		t.Fatalf("failed to sign order: %v", err)
+	}
+	if hexutil.Encode(signature) != expectedSignature {
+		t.Fatalf("expected signature %s, got %s", expectedSignature, hexutil.Encode(signature))
+	}
+
+	// The signature recovers to the maker once the recovery id is normalised
+	recoverable := bytes.Clone(signature)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(hash.Bytes(), recoverable)
+	if err != nil {
+		t.Fatalf("failed to recover signer: %v", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != maker {
+		t.Fatalf("expected signer %s, got %s", maker, signer)
+	}
+
+	// Any other chain or contract yields a different order hash
+	if LimitOrderHash(order, big.NewInt(25), lop) == hash {
+		t.Fatal("expected chain id to be part of the domain")
+	}
+	if LimitOrderHash(order, chainID, common.Address{1}) == hash {
+		t.Fatal("expected verifying contract to be part of the domain")
+	}
+}
+
+func TestSignLimitOrderRequiresProtocolAddress(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	client := &Client{
+		accounts: &accountPool{accounts: []*account{{privateKey: key}}},
+		chainID:  big.NewInt(1),
+	}
+	if _, err := client.SignLimitOrder(LimitOrder{}); err == nil {
+		t.Fatal("expected an error without a limit order protocol address")
+	}
+}
@@ -0,0 +1,297 @@
+package ethereum_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/rpc_retry"
+)
+
+// defaultEndpointCooldown is how long a failed endpoint is skipped when the
+// chain has no breaker cooldown configured
+const defaultEndpointCooldown = 30 * time.Second
+
+// rpcEndpoint is one node of the chain
+type rpcEndpoint struct {
+	eth *ethclient.Client
+	// failedAt is when the node last failed to answer, zero while it is
+	// healthy. Guarded by rpcClient.mutex
+	failedAt time.Time
+}
+
+// rpcClient is the subset of the node API the client uses, with reads
+// retried and every call guarded by the circuit breaker. Reads are spread
+// round-robin across the healthy endpoints, failing over to the next one
+// when a node is down. Nonce lookups and transactions stay pinned to a
+// single endpoint so they see the node's own pending pool
+type rpcClient struct {
+	endpoints []*rpcEndpoint
+	retry     *rpc_retry.Retrier
+	// cooldown is how long a failed endpoint is skipped before it is tried again
+	cooldown time.Duration
+	logger   *zap.Logger
+
+	mutex sync.Mutex
+	// next is the endpoint the next read starts at
+	next int
+	// pinned is the endpoint nonce lookups and transactions go to
+	pinned int
+	now    func() time.Time
+}
+
+// newRPCClient connects to every configured endpoint of the chain. Nodes
+// are only reached on the first call, so an endpoint that is down doesn't
+// stop the client from being created
+func newRPCClient(cfg *config.ChainConfig, maxPerSecond float64, logger *zap.Logger) (*rpcClient, error) {
+	urls := rpcEndpoints(cfg)
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no RPC endpoint configured")
+	}
+
+	endpoints := make([]*rpcEndpoint, 0, len(urls))
+	for i, url := range urls {
+		eth, err := ethclient.Dial(url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Ethereum node %d: %w", i, err)
+		}
+		endpoints = append(endpoints, &rpcEndpoint{eth: eth})
+	}
+
+	cooldown := cfg.RPCRetry.BreakerCooldown
+	if cooldown <= 0 {
+		cooldown = defaultEndpointCooldown
+	}
+
+	return &rpcClient{
+		endpoints: endpoints,
+		retry:     rpc_retry.New("ethereum", cfg.RPCRetry, maxPerSecond, logger),
+		cooldown:  cooldown,
+		logger:    logger,
+		now:       time.Now,
+	}, nil
+}
+
+// rpcEndpoints returns the configured endpoint URLs, rpc_endpoint first,
+// without duplicates
+func rpcEndpoints(cfg *config.ChainConfig) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, url := range append([]string{cfg.RPCEndpoint}, cfg.RPCEndpoints...) {
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+		urls = append(urls, url)
+	}
+	return urls
+}
+
+// readOrder returns the endpoints a read tries, the healthy ones starting at
+// the next in turn. When every endpoint has failed recently all of them are
+// tried anyway, since one may have recovered
+func (r *rpcClient) readOrder() []int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	start := r.next
+	r.next = (r.next + 1) % len(r.endpoints)
+
+	var healthy, all []int
+	for i := range r.endpoints {
+		index := (start + i) % len(r.endpoints)
+		all = append(all, index)
+		if r.healthyLocked(index) {
+			healthy = append(healthy, index)
+		}
+	}
+	if len(healthy) == 0 {
+		return all
+	}
+	return healthy
+}
+
+// pinnedEndpoint returns the endpoint writes go to, moving the pin to the
+// next healthy endpoint if the pinned one has failed
+func (r *rpcClient) pinnedEndpoint() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.healthyLocked(r.pinned) {
+		return r.pinned
+	}
+	for i := 1; i < len(r.endpoints); i++ {
+		index := (r.pinned + i) % len(r.endpoints)
+		if r.healthyLocked(index) {
+			r.logger.Warn("Pinning Ethereum transactions to another RPC endpoint",
+				zap.Int("from", r.pinned), zap.Int("to", index))
+			r.pinned = index
+			break
+		}
+	}
+	return r.pinned
+}
+
+// healthyLocked reports whether the endpoint hasn't failed within the
+// cooldown. The caller must hold the mutex
+func (r *rpcClient) healthyLocked(index int) bool {
+	failedAt := r.endpoints[index].failedAt
+	return failedAt.IsZero() || r.now().Sub(failedAt) >= r.cooldown
+}
+
+// record updates the health of an endpoint from the outcome of a call.
+// Only an unreachable node counts against it; errors such as a revert mean
+// the node answered
+func (r *rpcClient) record(index int, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	endpoint := r.endpoints[index]
+	switch {
+	case nodeDown(err):
+		if endpoint.failedAt.IsZero() && len(r.endpoints) > 1 {
+			r.logger.Warn("Ethereum RPC endpoint failed, failing over", zap.Int("endpoint", index), zap.Error(err))
+		}
+		endpoint.failedAt = r.now()
+	case !endpoint.failedAt.IsZero():
+		r.logger.Info("Ethereum RPC endpoint recovered", zap.Int("endpoint", index))
+		endpoint.failedAt = time.Time{}
+	}
+}
+
+// nodeDown reports whether err means the node couldn't be reached
+func nodeDown(err error) bool {
+	return errors.Is(chain_errors.Classify(err), chain_errors.ErrRPCUnavailable)
+}
+
+// read runs op against the healthy endpoints in turn until one of them
+// answers, retrying the whole round through the retrier
+func read[T any](ctx context.Context, r *rpcClient, op func(ctx context.Context, eth *ethclient.Client) (T, error)) (T, error) {
+	return rpc_retry.Call(ctx, r.retry, func(ctx context.Context) (T, error) {
+		var (
+			result T
+			err    error
+		)
+		for _, index := range r.readOrder() {
+			result, err = op(ctx, r.endpoints[index].eth)
+			r.record(index, err)
+			if !nodeDown(err) || ctx.Err() != nil {
+				return result, err
+			}
+		}
+		return result, err
+	})
+}
+
+// write runs op against the pinned endpoint. A failure moves the pin, so a
+// retry goes to the next healthy endpoint
+func write[T any](ctx context.Context, r *rpcClient, op func(ctx context.Context, eth *ethclient.Client) (T, error)) (T, error) {
+	return rpc_retry.Call(ctx, r.retry, func(ctx context.Context) (T, error) {
+		index := r.pinnedEndpoint()
+		result, err := op(ctx, r.endpoints[index].eth)
+		r.record(index, err)
+		return result, err
+	})
+}
+
+// ChainID returns the chain ID of the node
+func (r *rpcClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return read(ctx, r, func(ctx context.Context, eth *ethclient.Client) (*big.Int, error) {
+		return eth.ChainID(ctx)
+	})
+}
+
+// HeaderByNumber returns a block header, the latest one if number is nil
+func (r *rpcClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return read(ctx, r, func(ctx context.Context, eth *ethclient.Client) (*types.Header, error) {
+		return eth.HeaderByNumber(ctx, number)
+	})
+}
+
+// CallContract executes a read-only contract call
+func (r *rpcClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return read(ctx, r, func(ctx context.Context, eth *ethclient.Client) ([]byte, error) {
+		return eth.CallContract(ctx, msg, blockNumber)
+	})
+}
+
+// FilterLogs returns the logs matching query
+func (r *rpcClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return read(ctx, r, func(ctx context.Context, eth *ethclient.Client) ([]types.Log, error) {
+		return eth.FilterLogs(ctx, query)
+	})
+}
+
+// SubscribeFilterLogs subscribes to logs matching query. Subscriptions are
+// long-lived, so they are neither retried nor guarded by the breaker, and
+// stay on the pinned endpoint
+func (r *rpcClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return r.endpoints[r.pinnedEndpoint()].eth.SubscribeFilterLogs(ctx, query, ch)
+}
+
+// TransactionReceipt returns the receipt of a mined transaction
+func (r *rpcClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return read(ctx, r, func(ctx context.Context, eth *ethclient.Client) (*types.Receipt, error) {
+		return eth.TransactionReceipt(ctx, txHash)
+	})
+}
+
+// BalanceAt returns the balance of account, at the latest block if
+// blockNumber is nil
+func (r *rpcClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return read(ctx, r, func(ctx context.Context, eth *ethclient.Client) (*big.Int, error) {
+		return eth.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+// SuggestGasPrice returns the node's legacy gas price suggestion
+func (r *rpcClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return read(ctx, r, func(ctx context.Context, eth *ethclient.Client) (*big.Int, error) {
+		return eth.SuggestGasPrice(ctx)
+	})
+}
+
+// SuggestGasTipCap returns the node's EIP-1559 tip suggestion
+func (r *rpcClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return read(ctx, r, func(ctx context.Context, eth *ethclient.Client) (*big.Int, error) {
+		return eth.SuggestGasTipCap(ctx)
+	})
+}
+
+// PendingNonceAt returns the next nonce of account including pending
+// transactions, as seen by the pinned endpoint
+func (r *rpcClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return write(ctx, r, func(ctx context.Context, eth *ethclient.Client) (uint64, error) {
+		return eth.PendingNonceAt(ctx, account)
+	})
+}
+
+// NonceAt returns the next nonce of account counting only mined
+// transactions, as seen by the pinned endpoint
+func (r *rpcClient) NonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return write(ctx, r, func(ctx context.Context, eth *ethclient.Client) (uint64, error) {
+		return eth.NonceAt(ctx, account, nil)
+	})
+}
+
+// SendTransaction submits a signed transaction to the pinned endpoint. It is
+// not retried, since the caller resyncs the nonce when a send fails
+func (r *rpcClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	index := r.pinnedEndpoint()
+	return r.retry.Once(ctx, func(ctx context.Context) error {
+		err := r.endpoints[index].eth.SendTransaction(ctx, tx)
+		r.record(index, err)
+		return err
+	})
+}
@@ -0,0 +1,128 @@
+package ethereum_client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"go.uber.org/zap"
+)
+
+// countingNode counts the requests reaching a node, failing them with a 503
+// while down is set
+type countingNode struct {
+	node  http.Handler
+	down  atomic.Bool
+	calls atomic.Int32
+}
+
+func (n *countingNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.calls.Add(1)
+	if n.down.Load() {
+		http.Error(w, "node down", http.StatusServiceUnavailable)
+		return
+	}
+	n.node.ServeHTTP(w, r)
+}
+
+func newTestRPCClient(t *testing.T, nodes ...*countingNode) *rpcClient {
+	t.Helper()
+
+	cfg := &config.ChainConfig{}
+	for _, node := range nodes {
+		server := httptest.NewServer(node)
+		t.Cleanup(server.Close)
+		cfg.RPCEndpoints = append(cfg.RPCEndpoints, server.URL)
+	}
+
+	client, err := newRPCClient(cfg, 0, zap.NewNop())
+	if err != nil {
+		t.Fatalf("failed to create rpc client: %v", err)
+	}
+	return client
+}
+
+func TestRPCClientSkipsFailingEndpoint(t *testing.T) {
+	failing := &countingNode{node: &fakeNonceNode{}}
+	failing.down.Store(true)
+	backup := &countingNode{node: &fakeNonceNode{}}
+	client := newTestRPCClient(t, failing, backup)
+
+	for i := 0; i < 4; i++ {
+		chainID, err := client.ChainID(context.Background())
+		if err != nil {
+			t.Fatalf("read %d: expected the backup to answer, got %v", i, err)
+		}
+		if chainID.Uint64() != 11155111 {
+			t.Fatalf("unexpected chain ID %s", chainID)
+		}
+	}
+
+	// The failing endpoint is skipped for the cooldown after its first failure
+	if calls := failing.calls.Load(); calls != 1 {
+		t.Fatalf("expected the failing endpoint to be called once, got %d", calls)
+	}
+	if calls := backup.calls.Load(); calls != 4 {
+		t.Fatalf("expected every read on the backup, got %d", calls)
+	}
+}
+
+func TestRPCClientRoundRobinsReads(t *testing.T) {
+	first := &countingNode{node: &fakeNonceNode{}}
+	second := &countingNode{node: &fakeNonceNode{}}
+	client := newTestRPCClient(t, first, second)
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.SuggestGasPrice(context.Background()); err != nil {
+			t.Fatalf("read %d failed: %v", i, err)
+		}
+	}
+	if first.calls.Load() != 2 || second.calls.Load() != 2 {
+		t.Fatalf("expected reads to alternate, got %d and %d", first.calls.Load(), second.calls.Load())
+	}
+}
+
+func TestRPCClientPinsWrites(t *testing.T) {
+	primary := &countingNode{node: &fakeNonceNode{pending: 7}}
+	backup := &countingNode{node: &fakeNonceNode{pending: 7}}
+	client := newTestRPCClient(t, primary, backup)
+	account := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.PendingNonceAt(context.Background(), account); err != nil {
+			t.Fatalf("nonce lookup %d failed: %v", i, err)
+		}
+	}
+	if primary.calls.Load() != 3 || backup.calls.Load() != 0 {
+		t.Fatalf("expected nonce lookups pinned to the primary, got %d and %d", primary.calls.Load(), backup.calls.Load())
+	}
+
+	// Once the pinned endpoint fails, writes move to the backup and stay there
+	primary.down.Store(true)
+	if _, err := client.PendingNonceAt(context.Background(), account); err == nil {
+		t.Fatal("expected the lookup on the failed endpoint to fail")
+	}
+	primary.down.Store(false)
+	for i := 0; i < 2; i++ {
+		if _, err := client.PendingNonceAt(context.Background(), account); err != nil {
+			t.Fatalf("nonce lookup after failover failed: %v", err)
+		}
+	}
+	if primary.calls.Load() != 4 || backup.calls.Load() != 2 {
+		t.Fatalf("expected nonce lookups to move to the backup, got %d and %d", primary.calls.Load(), backup.calls.Load())
+	}
+}
+
+func TestRPCEndpointsDeduplicates(t *testing.T) {
+	cfg := &config.ChainConfig{
+		RPCEndpoint:  "http://a:8545",
+		RPCEndpoints: []string{"http://b:8545", "http://a:8545", ""},
+	}
+	if got := rpcEndpoints(cfg); len(got) != 2 || got[0] != "http://a:8545" || got[1] != "http://b:8545" {
+		t.Fatalf("unexpected endpoints %v", got)
+	}
+}
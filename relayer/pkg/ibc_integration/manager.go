@@ -1,43 +1,231 @@
 package ibc_integration
 
 import (
+	"context"
 	"fmt"
 	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v7/modules/core/02-client/types"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
+	"go.uber.org/zap"
+)
+
+// Packet event types surfaced by MonitorIBCChannel
+const (
+	EventTypeRecvPacket        = "recv_packet"
+	EventTypeAcknowledgePacket = "acknowledge_packet"
 )
 
-// IBCManager handles basic IBC-related operations.
+// CronosClient is the subset of the Cronos client used for IBC operations
+type CronosClient interface {
+	Address() sdk.AccAddress
+	GetLatestBlock(ctx context.Context) (int64, error)
+	BroadcastTx(ctx context.Context, msgs ...sdk.Msg) (string, error)
+	SearchTxEvents(ctx context.Context, query string, eventType string) ([]cronos_client.TxEvent, error)
+}
+
+// IBCManager sends IBC transfers from Cronos and watches channels for packets
 type IBCManager struct {
+	config       *config.IBCConfig
+	cronosClient CronosClient
+	pollInterval time.Duration
+	logger       *zap.Logger
+}
 
-	client *ibccore.Client
-	connectionID string
-	channelID string
+// PacketEvent is a packet received or acknowledged on a monitored channel
+type PacketEvent struct {
+	Type       string `json:"type"`
+	Height     int64  `json:"height"`
+	TxHash     string `json:"tx_hash"`
+	Sequence   string `json:"sequence"`
+	SrcPort    string `json:"src_port"`
+	SrcChannel string `json:"src_channel"`
+	DstPort    string `json:"dst_port"`
+	DstChannel string `json:"dst_channel"`
 }
 
-// NewIBCManager creates a new IBCManager instance.
-func NewIBCManager() *IBCManager {
+// NewIBCManager creates a new IBCManager instance
+func NewIBCManager(cfg *config.Config, cronosClient CronosClient, logger *zap.Logger) *IBCManager {
 	return &IBCManager{
-		client: ibccore.NewClient(),
-		connectionID: "",
-		channelID: "",	
-		
+		config:       &cfg.IBC,
+		cronosClient: cronosClient,
+		pollInterval: cfg.Relayer.EventPollInterval,
+		logger:       logger,
 	}
 }
 
-// SendIBCTransfer simulates sending an IBC transfer.
-func (m *IBCManager) SendIBCTransfer(sender, recipient, denom string, amount float64, timeout time.Duration) error {
-	fmt.Printf("[%s] Simulating IBC transfer: %f %s from %s to %s (timeout: %s)\n",
-		time.Now().Format("2006-01-02 15:04:05"),
-		amount, denom, sender, recipient, timeout.String(),
-	)
-	
-	return nil
+// SendIBCTransfer broadcasts an ICS-20 transfer of amount from the relayer
+// account to recipient over the Cronos to Ethereum channel. A zero timeout
+// falls back to the configured packet timeout
+func (m *IBCManager) SendIBCTransfer(ctx context.Context, recipient string, amount sdk.Coin, timeout time.Duration) (string, error) {
+	msg, err := m.newTransferMsg(recipient, amount, timeout, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := m.cronosClient.BroadcastTx(ctx, msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast IBC transfer: %w", err)
+	}
+
+	m.logger.Info("Sent IBC transfer",
+		zap.String("channel", msg.SourceChannel),
+		zap.String("receiver", recipient),
+		zap.String("amount", amount.String()),
+		zap.String("tx_hash", txHash))
+
+	return txHash, nil
 }
 
-// MonitorIBCChannel simulates monitoring an IBC channel for incoming packets.
-func (m *IBCManager) MonitorIBCChannel(channelID string) {
-	fmt.Printf("[%s] Monitoring IBC channel: %s for incoming packets...\n",
-		time.Now().Format("2006-01-02 15:04:05"),
-		channelID,
+// newTransferMsg builds a MsgTransfer that times out timeout after now
+func (m *IBCManager) newTransferMsg(recipient string, amount sdk.Coin, timeout time.Duration, now time.Time) (*ibctransfertypes.MsgTransfer, error) {
+	if m.config.CronosToEthChannel == "" {
+		return nil, fmt.Errorf("cronos_to_eth_channel is not configured")
+	}
+	if recipient == "" {
+		return nil, fmt.Errorf("recipient cannot be empty")
+	}
+	if !amount.IsValid() || !amount.IsPositive() {
+		return nil, fmt.Errorf("invalid transfer amount: %s", amount)
+	}
+
+	if timeout <= 0 {
+		timeout = m.config.PacketTimeout
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("packet timeout must be positive")
+	}
+
+	msg := ibctransfertypes.NewMsgTransfer(
+		m.transferPort(),
+		m.config.CronosToEthChannel,
+		amount,
+		m.cronosClient.Address().String(),
+		recipient,
+		clienttypes.ZeroHeight(),
+		uint64(now.Add(timeout).UnixNano()),
+		"",
 	)
-	
-}
\ No newline at end of file
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid IBC transfer: %w", err)
+	}
+
+	return msg, nil
+}
+
+// MonitorIBCChannel polls for packets received on or acknowledged from
+// channelID and sends them on the returned channel, which is closed once ctx
+// is done. An empty channelID monitors the Cronos to Ethereum channel
+func (m *IBCManager) MonitorIBCChannel(ctx context.Context, channelID string) (<-chan PacketEvent, error) {
+	if channelID == "" {
+		channelID = m.config.CronosToEthChannel
+	}
+	if channelID == "" {
+		return nil, fmt.Errorf("no IBC channel to monitor")
+	}
+	if m.pollInterval <= 0 {
+		return nil, fmt.Errorf("event poll interval must be positive")
+	}
+
+	lastHeight, err := m.cronosClient.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest Cronos block: %w", err)
+	}
+
+	events := make(chan PacketEvent, 100)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(m.pollInterval)
+		defer ticker.Stop()
+
+		m.logger.Info("Monitoring IBC channel", zap.String("channel", channelID))
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				height, err := m.pollPacketEvents(ctx, channelID, lastHeight, events)
+				if err != nil {
+					m.logger.Error("Failed to poll IBC packet events",
+						zap.String("channel", channelID),
+						zap.Error(err))
+					continue
+				}
+				lastHeight = height
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// pollPacketEvents sends the packet events after fromHeight on events and
+// returns the height scanned up to
+func (m *IBCManager) pollPacketEvents(ctx context.Context, channelID string, fromHeight int64, events chan<- PacketEvent) (int64, error) {
+	latestHeight, err := m.cronosClient.GetLatestBlock(ctx)
+	if err != nil {
+		return fromHeight, fmt.Errorf("failed to get latest Cronos block: %w", err)
+	}
+	if latestHeight <= fromHeight {
+		return fromHeight, nil
+	}
+
+	heightRange := fmt.Sprintf("tx.height>%d AND tx.height<=%d", fromHeight, latestHeight)
+	queries := []struct {
+		eventType string
+		query     string
+	}{
+		// Packets counterparties sent to us arrive on our end of the channel
+		{EventTypeRecvPacket, fmt.Sprintf("%s.packet_dst_channel='%s' AND %s", EventTypeRecvPacket, channelID, heightRange)},
+		// Acknowledgements are for packets we sent from our end of the channel
+		{EventTypeAcknowledgePacket, fmt.Sprintf("%s.packet_src_channel='%s' AND %s", EventTypeAcknowledgePacket, channelID, heightRange)},
+	}
+
+	var found []PacketEvent
+	for _, q := range queries {
+		txEvents, err := m.cronosClient.SearchTxEvents(ctx, q.query, q.eventType)
+		if err != nil {
+			return fromHeight, fmt.Errorf("failed to search %s events: %w", q.eventType, err)
+		}
+		for _, event := range txEvents {
+			found = append(found, newPacketEvent(event))
+		}
+	}
+
+	for _, event := range found {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return fromHeight, ctx.Err()
+		}
+	}
+
+	return latestHeight, nil
+}
+
+// newPacketEvent extracts the packet fields from a transaction event
+func newPacketEvent(event cronos_client.TxEvent) PacketEvent {
+	return PacketEvent{
+		Type:       event.Type,
+		Height:     event.Height,
+		TxHash:     event.TxHash,
+		Sequence:   event.Attributes["packet_sequence"],
+		SrcPort:    event.Attributes["packet_src_port"],
+		SrcChannel: event.Attributes["packet_src_channel"],
+		DstPort:    event.Attributes["packet_dst_port"],
+		DstChannel: event.Attributes["packet_dst_channel"],
+	}
+}
+
+// transferPort returns the configured transfer port, defaulting to ICS-20's
+func (m *IBCManager) transferPort() string {
+	if m.config.TransferPort == "" {
+		return ibctransfertypes.PortID
+	}
+	return m.config.TransferPort
+}
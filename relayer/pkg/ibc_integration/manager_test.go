@@ -0,0 +1,160 @@
+package ibc_integration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
+	"go.uber.org/zap"
+)
+
+type mockCronosClient struct {
+	height       atomic.Int64
+	broadcast    []sdk.Msg
+	broadcastErr error
+	events       map[string][]cronos_client.TxEvent
+	queries      []string
+}
+
+func (m *mockCronosClient) Address() sdk.AccAddress {
+	return sdk.AccAddress([]byte("relayer_____________"))
+}
+
+func (m *mockCronosClient) GetLatestBlock(ctx context.Context) (int64, error) {
+	return m.height.Load(), nil
+}
+
+func (m *mockCronosClient) BroadcastTx(ctx context.Context, msgs ...sdk.Msg) (string, error) {
+	if m.broadcastErr != nil {
+		return "", m.broadcastErr
+	}
+	m.broadcast = append(m.broadcast, msgs...)
+	return "ABCDEF", nil
+}
+
+func (m *mockCronosClient) SearchTxEvents(ctx context.Context, query string, eventType string) ([]cronos_client.TxEvent, error) {
+	m.queries = append(m.queries, query)
+	return m.events[eventType], nil
+}
+
+func newTestManager(client CronosClient) *IBCManager {
+	cfg := &config.Config{}
+	cfg.IBC.CronosToEthChannel = "channel-7"
+	cfg.IBC.TransferPort = "transfer"
+	cfg.IBC.PacketTimeout = 10 * time.Minute
+	cfg.Relayer.EventPollInterval = 10 * time.Millisecond
+	return NewIBCManager(cfg, client, zap.NewNop())
+}
+
+func TestSendIBCTransfer(t *testing.T) {
+	client := &mockCronosClient{}
+	manager := newTestManager(client)
+
+	before := time.Now()
+	txHash, err := manager.SendIBCTransfer(context.Background(), "eth1receiver", sdk.NewInt64Coin("basecro", 100), time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if txHash != "ABCDEF" {
+		t.Fatalf("unexpected tx hash %s", txHash)
+	}
+	if len(client.broadcast) != 1 {
+		t.Fatalf("expected 1 broadcast message, got %d", len(client.broadcast))
+	}
+
+	msg, ok := client.broadcast[0].(*ibctransfertypes.MsgTransfer)
+	if !ok {
+		t.Fatalf("expected MsgTransfer, got %T", client.broadcast[0])
+	}
+	if msg.SourcePort != "transfer" || msg.SourceChannel != "channel-7" {
+		t.Fatalf("unexpected port/channel %s/%s", msg.SourcePort, msg.SourceChannel)
+	}
+	if msg.Sender != client.Address().String() || msg.Receiver != "eth1receiver" {
+		t.Fatalf("unexpected sender/receiver %s/%s", msg.Sender, msg.Receiver)
+	}
+
+	timeout := time.Unix(0, int64(msg.TimeoutTimestamp))
+	if timeout.Before(before.Add(time.Hour)) || timeout.After(time.Now().Add(time.Hour)) {
+		t.Fatalf("timeout timestamp %s is not an hour from now", timeout)
+	}
+}
+
+func TestSendIBCTransferDefaultTimeout(t *testing.T) {
+	manager := newTestManager(&mockCronosClient{})
+
+	now := time.Now()
+	msg, err := manager.newTransferMsg("eth1receiver", sdk.NewInt64Coin("basecro", 100), 0, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.TimeoutTimestamp != uint64(now.Add(10*time.Minute).UnixNano()) {
+		t.Fatalf("expected the configured packet timeout, got %d", msg.TimeoutTimestamp)
+	}
+}
+
+func TestSendIBCTransferErrors(t *testing.T) {
+	client := &mockCronosClient{broadcastErr: errors.New("out of gas")}
+	manager := newTestManager(client)
+
+	if _, err := manager.SendIBCTransfer(context.Background(), "eth1receiver", sdk.NewInt64Coin("basecro", 100), time.Hour); err == nil {
+		t.Fatal("expected broadcast error")
+	}
+	if _, err := manager.SendIBCTransfer(context.Background(), "", sdk.NewInt64Coin("basecro", 100), time.Hour); err == nil {
+		t.Fatal("expected error for empty recipient")
+	}
+	if _, err := manager.SendIBCTransfer(context.Background(), "eth1receiver", sdk.NewInt64Coin("basecro", 0), time.Hour); err == nil {
+		t.Fatal("expected error for zero amount")
+	}
+}
+
+func TestMonitorIBCChannel(t *testing.T) {
+	client := &mockCronosClient{
+		events: map[string][]cronos_client.TxEvent{
+			EventTypeRecvPacket: {{
+				Height: 11,
+				TxHash: "AA",
+				Type:   EventTypeRecvPacket,
+				Attributes: map[string]string{
+					"packet_sequence":    "3",
+					"packet_dst_channel": "channel-7",
+				},
+			}},
+		},
+	}
+	client.height.Store(10)
+	manager := newTestManager(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := manager.MonitorIBCChannel(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	client.height.Store(12)
+	select {
+	case event := <-events:
+		if event.Type != EventTypeRecvPacket || event.Sequence != "3" || event.DstChannel != "channel-7" {
+			t.Fatalf("unexpected event %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for packet event")
+	}
+
+	cancel()
+	for range events {
+	}
+
+	if len(client.queries) < 2 || !strings.Contains(client.queries[0], "recv_packet.packet_dst_channel='channel-7'") ||
+		!strings.Contains(client.queries[1], "acknowledge_packet.packet_src_channel='channel-7'") {
+		t.Fatalf("unexpected queries %v", client.queries)
+	}
+}
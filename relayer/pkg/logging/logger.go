@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Supported log formats
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+	// FormatText is accepted as an alias for FormatConsole
+	FormatText = "text"
+)
+
+// NewLogger builds a logger from the logging configuration. Output goes to
+// stdout, stderr or the file at OutputPath; empty fields fall back to info
+// level JSON on stdout
+func NewLogger(cfg config.LoggingConfig) (*zap.Logger, error) {
+	level := zapcore.InfoLevel
+	if cfg.Level != "" {
+		parsed, err := zapcore.ParseLevel(cfg.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+		}
+		level = parsed
+	}
+
+	zapCfg := zap.NewProductionConfig()
+	zapCfg.Level = zap.NewAtomicLevelAt(level)
+	zapCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	switch strings.ToLower(cfg.Format) {
+	case "", FormatJSON:
+		zapCfg.Encoding = FormatJSON
+	case FormatConsole, FormatText:
+		zapCfg.Encoding = FormatConsole
+		zapCfg.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	default:
+		return nil, fmt.Errorf("invalid log format %q: must be %s or %s", cfg.Format, FormatJSON, FormatConsole)
+	}
+
+	outputPath := cfg.OutputPath
+	if outputPath == "" {
+		outputPath = "stdout"
+	}
+	zapCfg.OutputPaths = []string{outputPath}
+	zapCfg.ErrorOutputPaths = []string{"stderr"}
+
+	logger, err := zapCfg.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build logger: %w", err)
+	}
+
+	return logger, nil
+}
@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+)
+
+// readLines returns the non-empty lines of the log file at path
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestNewLoggerDebugLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relayer.log")
+	logger, err := NewLogger(config.LoggingConfig{Level: "debug", Format: "json", OutputPath: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Debug("debug entry")
+	logger.Info("info entry")
+	_ = logger.Sync()
+
+	lines := readLines(t, path)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %v", len(lines), lines)
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("expected JSON output, got %q", lines[0])
+	}
+	if entry["level"] != "debug" || entry["msg"] != "debug entry" {
+		t.Fatalf("unexpected entry %v", entry)
+	}
+}
+
+func TestNewLoggerErrorLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relayer.log")
+	logger, err := NewLogger(config.LoggingConfig{Level: "error", Format: "console", OutputPath: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Info("info entry")
+	logger.Warn("warn entry")
+	logger.Error("error entry")
+	_ = logger.Sync()
+
+	lines := readLines(t, path)
+	if len(lines) == 0 || !strings.Contains(lines[0], "ERROR") || !strings.Contains(lines[0], "error entry") {
+		t.Fatalf("expected the error entry first, got %v", lines)
+	}
+	// Error entries are followed by a stacktrace, but nothing below error is logged
+	for _, line := range lines {
+		if strings.Contains(line, "info entry") || strings.Contains(line, "warn entry") {
+			t.Fatalf("unexpected entry below error level %q", line)
+		}
+	}
+}
+
+func TestNewLoggerInvalidConfig(t *testing.T) {
+	if _, err := NewLogger(config.LoggingConfig{Level: "verbose"}); err == nil {
+		t.Fatal("expected error for invalid level")
+	}
+	if _, err := NewLogger(config.LoggingConfig{Format: "xml"}); err == nil {
+		t.Fatal("expected error for invalid format")
+	}
+}
@@ -0,0 +1,58 @@
+package matching
+
+import (
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+)
+
+// DutchAuctionMatcher fills Dutch auction orders first. Each auction is the
+// maker at its current price and is matched with the taker whose limit it
+// has decayed to that pays the most, so the auction clears as soon as its
+// price crosses a limit. Orders left over are matched by price-time priority
+type DutchAuctionMatcher struct{}
+
+// Match pairs each order with at most one complementary order
+func (DutchAuctionMatcher) Match(orders []*order_manager.Order) []MatchResult {
+	book := groupByPair(orders)
+	matched := make(map[string]bool)
+
+	var results []MatchResult
+	for _, pair := range sortedPairs(book, false) {
+		var auctions []*order_manager.Order
+		for _, order := range book[pair] {
+			if isAuction(order) {
+				auctions = append(auctions, order)
+			}
+		}
+		var takers []*order_manager.Order
+		for _, order := range book[pair.Reverse()] {
+			if !isAuction(order) {
+				takers = append(takers, order)
+			}
+		}
+		// A taker asking fewer units per unit sold pays more per unit bought,
+		// so the lowest ask is the most generous limit
+		takers = sortedByPriority(takers)
+
+		// Cheapest auctions first, as in the price-time book
+		for _, auction := range sortedByPriority(auctions) {
+			for _, taker := range takers {
+				if matched[taker.ID] {
+					continue
+				}
+				result, ok := matchPair(auction, taker)
+				if !ok {
+					continue
+				}
+				matched[auction.ID] = true
+				matched[taker.ID] = true
+				results = append(results, result)
+				break
+			}
+		}
+	}
+
+	for _, pair := range sortedPairs(book, true) {
+		results = append(results, matchPriceTime(book[pair], book[pair.Reverse()], matched)...)
+	}
+	return results
+}
@@ -0,0 +1,109 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+)
+
+func newAuctionOrder(id string, age int, sellAmount int64, startPrice, currentPrice int64) *order_manager.Order {
+	order := newBookOrder(id, age, "CRO", sellAmount, 18, "USDC", sellAmount*startPrice, 6)
+	order.DutchAuction = &order_manager.DutchAuctionParams{}
+	order.CurrentPrice = scaled(currentPrice, 6)
+	return order
+}
+
+func TestDutchAuctionFillsAtCurrentPrice(t *testing.T) {
+	// The taker pays up to 2.5 USDC per CRO for 200 CRO and is older than the
+	// auction, which has decayed to 2 USDC per CRO
+	taker := newBookOrder("taker", 0, "USDC", 500, 6, "CRO", 200, 18)
+	taker.PartialFill = &order_manager.PartialFillParams{AllowPartialFill: true}
+	auction := newAuctionOrder("auction", 1, 100, 3, 2)
+
+	results := DutchAuctionMatcher{}.Match([]*order_manager.Order{taker, auction})
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+	result := results[0]
+	if result.MakerOrderID != "auction" || result.TakerOrderID != "taker" {
+		t.Fatalf("expected the auction to be the maker, got %s/%s", result.MakerOrderID, result.TakerOrderID)
+	}
+	if result.MakerAmount.Cmp(scaled(100, 18)) != 0 || result.TakerAmount.Cmp(scaled(200, 6)) != 0 {
+		t.Fatalf("expected 100 CRO for 200 USDC, got %s/%s", result.MakerAmount, result.TakerAmount)
+	}
+
+	// Price-time priority makes the older taker the maker, so the same fill
+	// executes at the taker's limit instead
+	results = PriceTimeMatcher{}.Match([]*order_manager.Order{taker, auction})
+	if len(results) != 1 || results[0].TakerAmount.Cmp(scaled(100, 18)) != 0 || results[0].MakerAmount.Cmp(scaled(250, 6)) != 0 {
+		t.Fatalf("expected price-time to fill 250 USDC for 100 CRO, got %+v", results)
+	}
+}
+
+func TestDutchAuctionWaitsForPriceToCross(t *testing.T) {
+	taker := newBookOrder("taker", 0, "USDC", 250, 6, "CRO", 100, 18)
+
+	// 3 USDC per CRO is above the taker's limit of 2.5
+	auction := newAuctionOrder("auction", 1, 100, 4, 3)
+	if results := (DutchAuctionMatcher{}).Match([]*order_manager.Order{taker, auction}); len(results) != 0 {
+		t.Fatalf("expected no match, got %d", len(results))
+	}
+
+	// Once it decays to 2.5 the auction crosses the limit
+	auction.CurrentPrice = scaled(25, 5)
+	if results := (DutchAuctionMatcher{}).Match([]*order_manager.Order{taker, auction}); len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+}
+
+func TestDutchAuctionPrefersMostGenerousTaker(t *testing.T) {
+	stingy := newBookOrder("stingy", 0, "USDC", 210, 6, "CRO", 100, 18)
+	generous := newBookOrder("generous", 2, "USDC", 240, 6, "CRO", 100, 18)
+	auction := newAuctionOrder("auction", 1, 100, 3, 2)
+
+	results := DutchAuctionMatcher{}.Match([]*order_manager.Order{stingy, generous, auction})
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+	if results[0].MakerOrderID != "auction" || results[0].TakerOrderID != "generous" {
+		t.Fatalf("expected the auction to fill the most generous taker, got %s/%s", results[0].MakerOrderID, results[0].TakerOrderID)
+	}
+}
+
+func TestDutchAuctionFallsBackToPriceTime(t *testing.T) {
+	// The auction hasn't crossed, but a plain order on the same side has, and
+	// an unrelated pair still matches
+	auction := newAuctionOrder("auction", 0, 100, 4, 3)
+	maker := newBookOrder("maker", 1, "CRO", 100, 18, "USDC", 200, 6)
+	taker := newBookOrder("taker", 2, "USDC", 250, 6, "CRO", 100, 18)
+	ethMaker := newBookOrder("eth-maker", 3, "ETH", 1, 18, "USDT", 2000, 6)
+	ethTaker := newBookOrder("eth-taker", 4, "USDT", 2000, 6, "ETH", 1, 18)
+
+	results := DutchAuctionMatcher{}.Match([]*order_manager.Order{auction, maker, taker, ethMaker, ethTaker})
+	if len(results) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(results))
+	}
+	matched := make(map[string]string)
+	for _, result := range results {
+		matched[result.MakerOrderID] = result.TakerOrderID
+	}
+	if matched["maker"] != "taker" || matched["eth-maker"] != "eth-taker" {
+		t.Fatalf("unexpected matches %v", matched)
+	}
+}
+
+func TestDutchAuctionMatchesOrderOnce(t *testing.T) {
+	// Two auctions compete for one taker; only the cheaper one fills it
+	cheap := newAuctionOrder("cheap", 0, 100, 3, 2)
+	dear := newAuctionOrder("dear", 1, 100, 3, 2)
+	dear.CurrentPrice = scaled(22, 5)
+	taker := newBookOrder("taker", 2, "USDC", 250, 6, "CRO", 100, 18)
+
+	results := DutchAuctionMatcher{}.Match([]*order_manager.Order{dear, cheap, taker})
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+	if results[0].MakerOrderID != "cheap" {
+		t.Fatalf("expected the cheaper auction to match, got %s", results[0].MakerOrderID)
+	}
+}
@@ -0,0 +1,204 @@
+// Package matching pairs complementary orders so the relayer can fill one
+// with the other
+package matching
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+)
+
+// Matcher pairs each order with at most one complementary order
+type Matcher interface {
+	Match(orders []*order_manager.Order) []MatchResult
+}
+
+// New returns the matcher for a configured strategy, defaulting to
+// price-time priority
+func New(strategy string) (Matcher, error) {
+	switch strategy {
+	case "", config.MatchingStrategyPriceTime:
+		return PriceTimeMatcher{}, nil
+	case config.MatchingStrategyDutchAuction:
+		return DutchAuctionMatcher{}, nil
+	default:
+		return nil, fmt.Errorf("unknown matching strategy %q", strategy)
+	}
+}
+
+// AssetPair identifies the side of the book an order sits on
+type AssetPair struct {
+	Sell string `json:"sell"`
+	Buy  string `json:"buy"`
+}
+
+// Reverse returns the pair complementary orders sit on
+func (p AssetPair) Reverse() AssetPair {
+	return AssetPair{Sell: p.Buy, Buy: p.Sell}
+}
+
+// String returns the pair as "SELL/BUY", the form price oracles take
+func (p AssetPair) String() string {
+	return p.Sell + "/" + p.Buy
+}
+
+// PairOf returns the asset pair an order sells and buys
+func PairOf(order *order_manager.Order) AssetPair {
+	return AssetPair{Sell: order.SourceAsset.Symbol, Buy: order.DestinationAsset.Symbol}
+}
+
+// MatchResult links two complementary orders and the amounts each one fills,
+// in the base units of the asset it sells
+type MatchResult struct {
+	MakerOrderID string   `json:"maker_order_id"`
+	TakerOrderID string   `json:"taker_order_id"`
+	MakerAmount  *big.Int `json:"maker_amount"`
+	TakerAmount  *big.Int `json:"taker_amount"`
+	// Price is the executed price in whole taker-asset units per maker-asset unit
+	Price *big.Rat `json:"price"`
+}
+
+// groupByPair groups orders by asset pair, dropping orders without a usable
+// price or amount
+func groupByPair(orders []*order_manager.Order) map[AssetPair][]*order_manager.Order {
+	book := make(map[AssetPair][]*order_manager.Order)
+	for _, order := range orders {
+		if askPrice(order) == nil || order_manager.RemainingAmount(order) == nil {
+			continue
+		}
+		pair := PairOf(order)
+		book[pair] = append(book[pair], order)
+	}
+	return book
+}
+
+// sortedPairs returns the pairs of a book in a stable order. With
+// oneSided set each pair of complementary sides is returned once
+func sortedPairs(book map[AssetPair][]*order_manager.Order, oneSided bool) []AssetPair {
+	pairs := make([]AssetPair, 0, len(book))
+	for pair := range book {
+		if !oneSided || pair.Sell < pair.Buy {
+			pairs = append(pairs, pair)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Sell < pairs[j].Sell || (pairs[i].Sell == pairs[j].Sell && pairs[i].Buy < pairs[j].Buy)
+	})
+	return pairs
+}
+
+// matchPair checks whether a maker and a complementary taker cross and
+// computes the amounts each side fills at the maker's price
+func matchPair(maker, taker *order_manager.Order) (MatchResult, bool) {
+	makerPrice := askPrice(maker)
+	takerPrice := askPrice(taker)
+
+	// The maker asks makerPrice taker-units per maker-unit and the taker asks
+	// takerPrice maker-units per taker-unit; they cross when the product is at
+	// most one
+	if new(big.Rat).Mul(makerPrice, takerPrice).Cmp(big.NewRat(1, 1)) > 0 {
+		return MatchResult{}, false
+	}
+
+	makerQty := toUnits(order_manager.RemainingAmount(maker), maker.SourceAsset.Decimals)
+	takerQty := toUnits(order_manager.RemainingAmount(taker), taker.SourceAsset.Decimals)
+
+	// Prefer swapping both orders in full when each side gets at least what it
+	// asks for; otherwise fill as much of the maker as the taker can pay for
+	makerFill, takerFill := makerQty, takerQty
+	if takerQty.Cmp(new(big.Rat).Mul(makerQty, makerPrice)) < 0 ||
+		makerQty.Cmp(new(big.Rat).Mul(takerQty, takerPrice)) < 0 {
+		makerFill = new(big.Rat).Quo(takerQty, makerPrice)
+		if makerFill.Cmp(makerQty) > 0 {
+			makerFill = makerQty
+		}
+		takerFill = new(big.Rat).Mul(makerFill, makerPrice)
+	}
+
+	makerAmount := toBaseUnits(makerFill, maker.SourceAsset.Decimals)
+	takerAmount := toBaseUnits(takerFill, taker.SourceAsset.Decimals)
+	if makerAmount.Sign() <= 0 || takerAmount.Sign() <= 0 {
+		return MatchResult{}, false
+	}
+	if !acceptsFill(maker, makerAmount) || !acceptsFill(taker, takerAmount) {
+		return MatchResult{}, false
+	}
+
+	return MatchResult{
+		MakerOrderID: maker.ID,
+		TakerOrderID: taker.ID,
+		MakerAmount:  makerAmount,
+		TakerAmount:  takerAmount,
+		Price:        new(big.Rat).Quo(takerFill, makerFill),
+	}, true
+}
+
+// isAuction reports whether an order is a Dutch auction with a known price
+func isAuction(order *order_manager.Order) bool {
+	return order.DutchAuction != nil && order.CurrentPrice != nil
+}
+
+// askPrice returns the whole destination units an order asks per whole source
+// unit. Dutch auction orders use their current price, which is quoted in
+// destination base units per whole source unit
+func askPrice(order *order_manager.Order) *big.Rat {
+	if isAuction(order) {
+		if order.CurrentPrice.Sign() <= 0 {
+			return nil
+		}
+		return toUnits(order.CurrentPrice, order.DestinationAsset.Decimals)
+	}
+
+	if order.SourceAsset.Amount == nil || order.DestinationAsset.Amount == nil ||
+		order.SourceAsset.Amount.Sign() <= 0 || order.DestinationAsset.Amount.Sign() <= 0 {
+		return nil
+	}
+
+	return new(big.Rat).Quo(
+		toUnits(order.DestinationAsset.Amount, order.DestinationAsset.Decimals),
+		toUnits(order.SourceAsset.Amount, order.SourceAsset.Decimals),
+	)
+}
+
+// acceptsFill reports whether an order can be filled by amount of its source asset
+func acceptsFill(order *order_manager.Order, amount *big.Int) bool {
+	remaining := order_manager.RemainingAmount(order)
+	if amount.Cmp(remaining) == 0 {
+		return true
+	}
+	if order.PartialFill == nil || !order.PartialFill.AllowPartialFill {
+		return false
+	}
+	minimum := order.PartialFill.MinimumFillAmount
+	return minimum == nil || amount.Cmp(minimum) >= 0
+}
+
+// sortedByPriority returns orders sorted by best (lowest) ask, then age
+func sortedByPriority(orders []*order_manager.Order) []*order_manager.Order {
+	sorted := append([]*order_manager.Order(nil), orders...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if cmp := askPrice(sorted[i]).Cmp(askPrice(sorted[j])); cmp != 0 {
+			return cmp < 0
+		}
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+	return sorted
+}
+
+// toUnits converts a base-unit amount to whole units
+func toUnits(amount *big.Int, decimals int) *big.Rat {
+	return new(big.Rat).SetFrac(amount, pow10(decimals))
+}
+
+// toBaseUnits converts whole units to base units, rounding down
+func toBaseUnits(units *big.Rat, decimals int) *big.Int {
+	scaled := new(big.Rat).Mul(units, new(big.Rat).SetInt(pow10(decimals)))
+	return new(big.Int).Quo(scaled.Num(), scaled.Denom())
+}
+
+func pow10(decimals int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
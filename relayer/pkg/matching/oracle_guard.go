@@ -0,0 +1,70 @@
+package matching
+
+import (
+	"math/big"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/price_oracle"
+)
+
+// OracleGuard wraps a matcher so Dutch auction orders are only filled while
+// their current price is within MaxDeviationPercent percent of the oracle's
+// market price for the pair. Auctions the oracle can't price are left
+// unfilled too. Other orders are passed through unchecked
+type OracleGuard struct {
+	Matcher             Matcher
+	Oracle              price_oracle.PriceOracle
+	MaxDeviationPercent float64
+}
+
+// Match pairs each order with at most one complementary order, leaving out
+// auctions priced too far from the market
+func (g OracleGuard) Match(orders []*order_manager.Order) []MatchResult {
+	prices := make(map[AssetPair]*big.Rat)
+
+	fillable := make([]*order_manager.Order, 0, len(orders))
+	for _, order := range orders {
+		if isAuction(order) && !g.withinBounds(order, prices) {
+			continue
+		}
+		fillable = append(fillable, order)
+	}
+	return g.Matcher.Match(fillable)
+}
+
+// withinBounds reports whether an auction's current price is close enough to
+// the market price. Market prices are fetched once per pair and cached in
+// prices, with nil marking a pair the oracle failed to price
+func (g OracleGuard) withinBounds(order *order_manager.Order, prices map[AssetPair]*big.Rat) bool {
+	ask := askPrice(order)
+	if ask == nil {
+		return false
+	}
+
+	pair := PairOf(order)
+	market, fetched := prices[pair]
+	if !fetched {
+		if price, err := g.Oracle.GetPrice(pair.String()); err == nil && price.Sign() > 0 {
+			market = toUnits(price, price_oracle.PriceDecimals)
+		}
+		prices[pair] = market
+	}
+	if market == nil {
+		return false
+	}
+
+	return withinDeviation(ask, market, g.MaxDeviationPercent)
+}
+
+// withinDeviation reports whether price differs from reference by at most
+// maxPercent percent of reference
+func withinDeviation(price, reference *big.Rat, maxPercent float64) bool {
+	limit := new(big.Rat).SetFloat64(maxPercent / 100)
+	if limit == nil {
+		return false
+	}
+
+	deviation := new(big.Rat).Sub(price, reference)
+	deviation.Abs(deviation)
+	return deviation.Cmp(new(big.Rat).Mul(reference, limit)) <= 0
+}
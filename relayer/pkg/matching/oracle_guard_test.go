@@ -0,0 +1,110 @@
+package matching
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/price_oracle"
+)
+
+// mockOracle prices pairs from a fixed table, counting lookups
+type mockOracle struct {
+	prices map[string]*big.Int
+	err    error
+	calls  int
+}
+
+func (o *mockOracle) GetPrice(pair string) (*big.Int, error) {
+	o.calls++
+	if o.err != nil {
+		return nil, o.err
+	}
+	price, ok := o.prices[pair]
+	if !ok {
+		return nil, errors.New("unknown pair")
+	}
+	return price, nil
+}
+
+// oraclePrice returns a price in hundredths of a unit, scaled to the oracle's
+// fixed point
+func oraclePrice(hundredths int64) *big.Int {
+	return new(big.Int).Div(scaled(hundredths, price_oracle.PriceDecimals), big.NewInt(100))
+}
+
+func guardedMatch(oracle price_oracle.PriceOracle, orders ...*order_manager.Order) []MatchResult {
+	guard := OracleGuard{Matcher: DutchAuctionMatcher{}, Oracle: oracle, MaxDeviationPercent: 5}
+	return guard.Match(orders)
+}
+
+func TestOracleGuardWithinBounds(t *testing.T) {
+	// The auction asks 2 USDC per CRO and the market is at 2.05
+	taker := newBookOrder("taker", 0, "USDC", 250, 6, "CRO", 100, 18)
+	auction := newAuctionOrder("auction", 1, 100, 3, 2)
+	oracle := &mockOracle{prices: map[string]*big.Int{"CRO/USDC": oraclePrice(205)}}
+
+	results := guardedMatch(oracle, taker, auction)
+	if len(results) != 1 || results[0].MakerOrderID != "auction" {
+		t.Fatalf("expected the auction to be filled, got %+v", results)
+	}
+}
+
+func TestOracleGuardOutOfBounds(t *testing.T) {
+	// The auction asks 3 USDC per CRO but the market is at 2, so neither the
+	// auction fill nor the price-time fallback may take it
+	taker := newBookOrder("taker", 0, "USDC", 400, 6, "CRO", 100, 18)
+	auction := newAuctionOrder("auction", 1, 100, 4, 3)
+	oracle := &mockOracle{prices: map[string]*big.Int{"CRO/USDC": oraclePrice(200)}}
+
+	if results := guardedMatch(oracle, taker, auction); len(results) != 0 {
+		t.Fatalf("expected no matches, got %+v", results)
+	}
+}
+
+func TestOracleGuardOracleUnavailable(t *testing.T) {
+	taker := newBookOrder("taker", 0, "USDC", 250, 6, "CRO", 200, 18)
+	first := newAuctionOrder("first", 1, 100, 3, 2)
+	second := newAuctionOrder("second", 2, 100, 3, 2)
+	oracle := &mockOracle{err: errors.New("connection refused")}
+
+	if results := guardedMatch(oracle, taker, first, second); len(results) != 0 {
+		t.Fatalf("expected auctions to be left unfilled, got %+v", results)
+	}
+	if oracle.calls != 1 {
+		t.Fatalf("expected one oracle lookup for the pair, got %d", oracle.calls)
+	}
+}
+
+func TestOracleGuardIgnoresPlainOrders(t *testing.T) {
+	maker := newBookOrder("maker", 0, "CRO", 100, 18, "USDC", 300, 6)
+	taker := newBookOrder("taker", 1, "USDC", 300, 6, "CRO", 100, 18)
+	oracle := &mockOracle{err: errors.New("connection refused")}
+
+	results := guardedMatch(oracle, maker, taker)
+	if len(results) != 1 || results[0].MakerOrderID != "maker" {
+		t.Fatalf("expected plain orders to match, got %+v", results)
+	}
+	if oracle.calls != 0 {
+		t.Fatalf("expected no oracle lookups, got %d", oracle.calls)
+	}
+}
+
+func TestWithinDeviation(t *testing.T) {
+	reference := big.NewRat(2, 1)
+	for _, tc := range []struct {
+		price *big.Rat
+		want  bool
+	}{
+		{price: big.NewRat(2, 1), want: true},
+		{price: big.NewRat(21, 10), want: true},
+		{price: big.NewRat(19, 10), want: true},
+		{price: big.NewRat(211, 100), want: false},
+		{price: big.NewRat(189, 100), want: false},
+	} {
+		if got := withinDeviation(tc.price, reference, 5); got != tc.want {
+			t.Fatalf("withinDeviation(%s) = %v, want %v", tc.price.FloatString(2), got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package matching
+
+import (
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+)
+
+// PriceTimeMatcher visits orders best price first, then oldest first, and
+// makes the older order of each match the maker whose price partial fills
+// execute at
+type PriceTimeMatcher struct{}
+
+// Match pairs each order with at most one complementary order
+func (PriceTimeMatcher) Match(orders []*order_manager.Order) []MatchResult {
+	book := groupByPair(orders)
+
+	var results []MatchResult
+	for _, pair := range sortedPairs(book, true) {
+		results = append(results, matchPriceTime(book[pair], book[pair.Reverse()], nil)...)
+	}
+	return results
+}
+
+// matchPriceTime matches asks against complementary bids by price-time
+// priority, skipping and updating the orders already in matched
+func matchPriceTime(asks, bids []*order_manager.Order, matched map[string]bool) []MatchResult {
+	if matched == nil {
+		matched = make(map[string]bool)
+	}
+
+	bids = sortedByPriority(bids)
+
+	var results []MatchResult
+	for _, ask := range sortedByPriority(asks) {
+		if matched[ask.ID] {
+			continue
+		}
+		for _, bid := range bids {
+			if matched[bid.ID] {
+				continue
+			}
+
+			maker, taker := ask, bid
+			if bid.CreatedAt.Before(ask.CreatedAt) {
+				maker, taker = bid, ask
+			}
+			result, ok := matchPair(maker, taker)
+			if !ok {
+				continue
+			}
+			matched[ask.ID] = true
+			matched[bid.ID] = true
+			results = append(results, result)
+			break
+		}
+	}
+	return results
+}
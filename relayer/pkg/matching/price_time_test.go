@@ -0,0 +1,182 @@
+package matching
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+)
+
+var bookStart = time.Unix(1700000000, 0)
+
+func newBookOrder(id string, age int, sell string, sellAmount int64, sellDecimals int, buy string, buyAmount int64, buyDecimals int) *order_manager.Order {
+	return &order_manager.Order{
+		ID:               id,
+		Status:           order_manager.OrderStatusActive,
+		SourceAsset:      order_manager.AssetInfo{Symbol: sell, Amount: scaled(sellAmount, sellDecimals), Decimals: sellDecimals},
+		DestinationAsset: order_manager.AssetInfo{Symbol: buy, Amount: scaled(buyAmount, buyDecimals), Decimals: buyDecimals},
+		CreatedAt:        bookStart.Add(time.Duration(age) * time.Second),
+	}
+}
+
+// scaled returns whole units in base units
+func scaled(units int64, decimals int) *big.Int {
+	return new(big.Int).Mul(big.NewInt(units), pow10(decimals))
+}
+
+func matchBook(orders ...*order_manager.Order) []MatchResult {
+	return PriceTimeMatcher{}.Match(orders)
+}
+
+func TestPriceTimeExactPrice(t *testing.T) {
+	// 100 CRO for 200 USDC against 200 USDC for 100 CRO
+	maker := newBookOrder("maker", 0, "CRO", 100, 18, "USDC", 200, 6)
+	taker := newBookOrder("taker", 1, "USDC", 200, 6, "CRO", 100, 18)
+
+	results := matchBook(taker, maker)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+
+	result := results[0]
+	if result.MakerOrderID != "maker" || result.TakerOrderID != "taker" {
+		t.Fatalf("unexpected order IDs %s/%s", result.MakerOrderID, result.TakerOrderID)
+	}
+	if result.MakerAmount.Cmp(scaled(100, 18)) != 0 {
+		t.Fatalf("unexpected maker amount %s", result.MakerAmount)
+	}
+	if result.TakerAmount.Cmp(scaled(200, 6)) != 0 {
+		t.Fatalf("unexpected taker amount %s", result.TakerAmount)
+	}
+	if result.Price.Cmp(big.NewRat(2, 1)) != 0 {
+		t.Fatalf("unexpected price %s", result.Price.FloatString(6))
+	}
+}
+
+func TestPriceTimeCrossingPrice(t *testing.T) {
+	// The taker pays 250 USDC for 100 CRO when the maker only asks 200
+	maker := newBookOrder("maker", 0, "CRO", 100, 18, "USDC", 200, 6)
+	taker := newBookOrder("taker", 1, "USDC", 250, 6, "CRO", 100, 18)
+
+	results := matchBook(maker, taker)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+	if results[0].MakerAmount.Cmp(scaled(100, 18)) != 0 || results[0].TakerAmount.Cmp(scaled(250, 6)) != 0 {
+		t.Fatalf("expected both orders filled in full, got %s/%s", results[0].MakerAmount, results[0].TakerAmount)
+	}
+}
+
+func TestPriceTimeCrossingPricePartialFill(t *testing.T) {
+	// A larger maker that allows partial fills is filled at its own price
+	maker := newBookOrder("maker", 0, "CRO", 300, 18, "USDC", 600, 6)
+	maker.PartialFill = &order_manager.PartialFillParams{AllowPartialFill: true, MinimumFillAmount: scaled(50, 18)}
+	taker := newBookOrder("taker", 1, "USDC", 200, 6, "CRO", 90, 18)
+
+	results := matchBook(maker, taker)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+	if results[0].MakerAmount.Cmp(scaled(100, 18)) != 0 || results[0].TakerAmount.Cmp(scaled(200, 6)) != 0 {
+		t.Fatalf("unexpected fill %s/%s", results[0].MakerAmount, results[0].TakerAmount)
+	}
+
+	// Without partial fills the same orders can't be matched
+	maker.PartialFill = nil
+	if results := matchBook(maker, taker); len(results) != 0 {
+		t.Fatalf("expected no match, got %d", len(results))
+	}
+}
+
+func TestPriceTimeNonCrossingPrice(t *testing.T) {
+	// The taker only pays 150 USDC for 100 CRO
+	maker := newBookOrder("maker", 0, "CRO", 100, 18, "USDC", 200, 6)
+	taker := newBookOrder("taker", 1, "USDC", 150, 6, "CRO", 100, 18)
+
+	if results := matchBook(maker, taker); len(results) != 0 {
+		t.Fatalf("expected no match, got %d", len(results))
+	}
+}
+
+func TestPriceTimeIgnoresOtherPairs(t *testing.T) {
+	maker := newBookOrder("maker", 0, "CRO", 100, 18, "USDC", 200, 6)
+	taker := newBookOrder("taker", 1, "USDT", 200, 6, "CRO", 100, 18)
+
+	if results := matchBook(maker, taker); len(results) != 0 {
+		t.Fatalf("expected no match, got %d", len(results))
+	}
+}
+
+func TestPriceTimeDutchAuctionPrice(t *testing.T) {
+	maker := newBookOrder("maker", 0, "CRO", 100, 18, "USDC", 300, 6)
+	maker.DutchAuction = &order_manager.DutchAuctionParams{}
+	taker := newBookOrder("taker", 1, "USDC", 200, 6, "CRO", 100, 18)
+
+	// 3 USDC per CRO doesn't cross yet
+	maker.CurrentPrice = scaled(3, 6)
+	if results := matchBook(maker, taker); len(results) != 0 {
+		t.Fatalf("expected no match, got %d", len(results))
+	}
+
+	// Once the auction decays to 2 USDC per CRO it does
+	maker.CurrentPrice = scaled(2, 6)
+	if results := matchBook(maker, taker); len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+}
+
+func TestPriceTimePrefersBestPrice(t *testing.T) {
+	cheap := newBookOrder("cheap", 1, "CRO", 100, 18, "USDC", 180, 6)
+	expensive := newBookOrder("expensive", 0, "CRO", 100, 18, "USDC", 200, 6)
+	taker := newBookOrder("taker", 2, "USDC", 200, 6, "CRO", 100, 18)
+
+	results := matchBook(expensive, cheap, taker)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+	if results[0].MakerOrderID != "cheap" {
+		t.Fatalf("expected the cheapest order to match, got %s", results[0].MakerOrderID)
+	}
+}
+
+func TestPriceTimeOlderOrderIsMaker(t *testing.T) {
+	// The taker is older than the auction, so the fill executes at its limit
+	// rather than the auction's lower current price
+	taker := newBookOrder("taker", 0, "USDC", 250, 6, "CRO", 100, 18)
+	auction := newBookOrder("auction", 1, "CRO", 100, 18, "USDC", 300, 6)
+	auction.DutchAuction = &order_manager.DutchAuctionParams{}
+	auction.CurrentPrice = scaled(2, 6)
+
+	results := matchBook(auction, taker)
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+	if results[0].MakerOrderID != "taker" {
+		t.Fatalf("expected the older order to be the maker, got %s", results[0].MakerOrderID)
+	}
+}
+
+func TestNewMatcher(t *testing.T) {
+	for _, tc := range []struct {
+		strategy string
+		expected Matcher
+	}{
+		{strategy: "", expected: PriceTimeMatcher{}},
+		{strategy: config.MatchingStrategyPriceTime, expected: PriceTimeMatcher{}},
+		{strategy: config.MatchingStrategyDutchAuction, expected: DutchAuctionMatcher{}},
+	} {
+		matcher, err := New(tc.strategy)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tc.strategy, err)
+		}
+		if matcher != tc.expected {
+			t.Fatalf("expected %T for %q, got %T", tc.expected, tc.strategy, matcher)
+		}
+	}
+
+	if _, err := New("pro_rata"); err == nil {
+		t.Fatal("expected an unknown strategy to be rejected")
+	}
+}
@@ -0,0 +1,61 @@
+package order_manager
+
+import (
+	"math"
+	"math/big"
+	"time"
+)
+
+// exponentialAuctionPrice returns the price of an exponential auction after
+// elapsed: minimumPrice + (initialPrice - minimumPrice) * e^(-k*t), with k
+// chosen so the price starts falling at DecayRate per second like a linear
+// auction but slows down as it nears the minimum, which it only reaches at
+// the end of the auction
+func exponentialAuctionPrice(params *DutchAuctionParams, elapsed time.Duration) *big.Int {
+	minimum := new(big.Int)
+	if params.MinimumPrice != nil {
+		minimum.Set(params.MinimumPrice)
+	}
+
+	spread := new(big.Int).Sub(params.InitialPrice, minimum)
+	if spread.Sign() <= 0 {
+		return minimum
+	}
+	if params.DecayRate == nil || params.DecayRate.Sign() <= 0 {
+		return new(big.Int).Set(params.InitialPrice)
+	}
+
+	// k*t = decayRate * t / spread
+	exponent := new(big.Float).SetInt(params.DecayRate)
+	exponent.Mul(exponent, big.NewFloat(elapsed.Seconds()))
+	exponent.Quo(exponent, new(big.Float).SetInt(spread))
+	x, _ := exponent.Float64()
+
+	remaining := new(big.Float).SetInt(spread)
+	remaining.Mul(remaining, big.NewFloat(math.Exp(-x)))
+	price, _ := remaining.Int(nil)
+
+	return price.Add(price, minimum)
+}
+
+// ExponentialDecaySeconds returns how many seconds an exponential auction
+// falling from minimumPrice + spread at decayRate per second takes to come
+// within one unit of its minimum price, the point where its price stops
+// changing
+func ExponentialDecaySeconds(spread, decayRate *big.Int) *big.Int {
+	if spread.Cmp(big.NewInt(1)) <= 0 {
+		return new(big.Int)
+	}
+
+	// spread * e^(-k*t) < 1  <=>  t > spread * ln(spread) / decayRate
+	spreadFloat := new(big.Float).SetInt(spread)
+	logSpread, _ := spreadFloat.Float64()
+	seconds := new(big.Float).Mul(spreadFloat, big.NewFloat(math.Log(logSpread)))
+	seconds.Quo(seconds, new(big.Float).SetInt(decayRate))
+
+	whole, accuracy := seconds.Int(nil)
+	if accuracy == big.Below {
+		whole.Add(whole, big.NewInt(1))
+	}
+	return whole
+}
@@ -0,0 +1,78 @@
+package order_manager
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+)
+
+func TestCalculateDutchAuctionPriceCurves(t *testing.T) {
+	om := &OrderManager{}
+	start := time.Unix(1700000000, 0)
+	newParams := func(curve string) *DutchAuctionParams {
+		return &DutchAuctionParams{
+			InitialPrice: big.NewInt(1000),
+			MinimumPrice: big.NewInt(100),
+			DecayRate:    big.NewInt(10),
+			StartTime:    start,
+			Duration:     time.Hour,
+			CurveType:    curve,
+		}
+	}
+
+	for _, tc := range []struct {
+		elapsed     time.Duration
+		linear      int64
+		exponential int64
+	}{
+		{elapsed: -time.Minute, linear: 1000, exponential: 1000},
+		{elapsed: 0, linear: 1000, exponential: 1000},
+		{elapsed: 10 * time.Second, linear: 900, exponential: 905},
+		{elapsed: 45 * time.Second, linear: 550, exponential: 645},
+		{elapsed: 90 * time.Second, linear: 100, exponential: 431},
+		{elapsed: 612 * time.Second, linear: 100, exponential: 101},
+		{elapsed: 613 * time.Second, linear: 100, exponential: 100},
+		{elapsed: 2 * time.Hour, linear: 100, exponential: 100},
+	} {
+		now := start.Add(tc.elapsed)
+		for curve, want := range map[string]int64{
+			"":                             tc.linear,
+			config.AuctionCurveLinear:      tc.linear,
+			config.AuctionCurveExponential: tc.exponential,
+		} {
+			if got := om.calculateDutchAuctionPrice(newParams(curve), now); got.Int64() != want {
+				t.Fatalf("%q curve after %s: expected %d, got %s", curve, tc.elapsed, want, got)
+			}
+		}
+
+		// The exponential curve starts at the same rate but never falls faster
+		if tc.exponential < tc.linear {
+			t.Fatalf("after %s the exponential price %d is below the linear price %d", tc.elapsed, tc.exponential, tc.linear)
+		}
+	}
+}
+
+func TestExponentialAuctionWithoutDecay(t *testing.T) {
+	params := &DutchAuctionParams{InitialPrice: big.NewInt(1000), CurveType: config.AuctionCurveExponential}
+	if got := exponentialAuctionPrice(params, time.Hour); got.Int64() != 1000 {
+		t.Fatalf("expected an auction without decay rate to keep its price, got %s", got)
+	}
+
+	params.DecayRate = big.NewInt(10)
+	params.MinimumPrice = big.NewInt(2000)
+	if got := exponentialAuctionPrice(params, time.Second); got.Int64() != 2000 {
+		t.Fatalf("expected a minimum above the initial price to win, got %s", got)
+	}
+}
+
+func TestExponentialDecaySeconds(t *testing.T) {
+	// 900 * e^(-10t/900) drops below one unit after 900*ln(900)/10 = 612.2s
+	if got := ExponentialDecaySeconds(big.NewInt(900), big.NewInt(10)); got.Int64() != 613 {
+		t.Fatalf("expected 613s, got %s", got)
+	}
+	if got := ExponentialDecaySeconds(big.NewInt(1), big.NewInt(10)); got.Sign() != 0 {
+		t.Fatalf("expected a one unit spread to need no decay, got %s", got)
+	}
+}
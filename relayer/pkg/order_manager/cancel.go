@@ -0,0 +1,102 @@
+package order_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrOrderNotFound is returned for orders the relayer isn't tracking
+	ErrOrderNotFound = errors.New("order not found")
+
+	// ErrOrderNotCancellable is returned when an order's state doesn't allow
+	// cancelling it yet, or at all
+	ErrOrderNotCancellable = errors.New("order cannot be cancelled")
+)
+
+// CancelOrder aborts an in-flight order on an operator's request. An order
+// the relayer hasn't funded an escrow for is simply dropped from tracking. An
+// order whose destination escrow the relayer funded has the escrow cancelled
+// on chain, which the escrow contracts only allow once its timelock has
+// passed; the order stays cancelling until the transaction is confirmed. It
+// returns the hash of the cancel transaction, or "" when no transaction was
+// needed
+func (om *OrderManager) CancelOrder(ctx context.Context, orderID string) (string, error) {
+	om.ordersMutex.Lock()
+	if _, queued := om.queuedOrders[orderID]; queued {
+		// processNewOrders drops orders that are no longer queued
+		delete(om.queuedOrders, orderID)
+		om.recentOrders.add(orderID)
+		om.ordersMutex.Unlock()
+
+		om.logger.Info("Cancelled queued order", zap.String("order_id", orderID))
+		return "", nil
+	}
+
+	order, exists := om.trackedOrder(orderID)
+	if !exists {
+		om.ordersMutex.Unlock()
+		return "", ErrOrderNotFound
+	}
+
+	if err := checkCancellable(order, time.Now()); err != nil {
+		om.ordersMutex.Unlock()
+		return "", err
+	}
+
+	if order.DestTxHash == "" && order.DestEscrowAddr == "" {
+		om.setStatus(order, OrderStatusCancelled, "cancelled by operator", "")
+		om.retireOrder(order)
+		om.ordersMutex.Unlock()
+
+		om.logger.Info("Cancelled unfunded order", zap.String("order_id", orderID))
+		return "", nil
+	}
+	om.ordersMutex.Unlock()
+
+	if err := om.cancelExpiredOrder(ctx, order); err != nil {
+		return "", err
+	}
+
+	// A failed order goes back to the active orders, whose checks confirm
+	// the cancellation
+	om.ordersMutex.Lock()
+	if _, failed := om.failedOrders[orderID]; failed {
+		delete(om.failedOrders, orderID)
+		om.activeOrders[orderID] = order
+	}
+	om.ordersMutex.Unlock()
+
+	om.logger.Info("Cancelling funded order on operator request", zap.String("order_id", orderID))
+	return order.CancelTxHash, nil
+}
+
+// checkCancellable returns why an order can't be cancelled at now, or nil if
+// it can
+func checkCancellable(order *Order, now time.Time) error {
+	switch order.Status {
+	case OrderStatusCompleted, OrderStatusCancelling, OrderStatusCancelled:
+		return fmt.Errorf("%w: order is already %s", ErrOrderNotCancellable, order.Status)
+	case OrderStatusMatched:
+		return fmt.Errorf("%w: order is being settled", ErrOrderNotCancellable)
+	case OrderStatusExpired:
+		if order.DestEscrowAddr != "" {
+			return fmt.Errorf("%w: order expired and its destination escrow is being cancelled", ErrOrderNotCancellable)
+		}
+	}
+
+	if order.DestTxHash == "" && order.DestEscrowAddr == "" {
+		return nil
+	}
+	if order.DestEscrowAddr == "" {
+		return fmt.Errorf("%w: destination escrow deployment %s is not confirmed yet", ErrOrderNotCancellable, order.DestTxHash)
+	}
+	if cancellableAt := time.Unix(int64(order.DestTimelock), 0); now.Before(cancellableAt) {
+		return fmt.Errorf("%w: destination escrow can't be cancelled until %s", ErrOrderNotCancellable, cancellableAt.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
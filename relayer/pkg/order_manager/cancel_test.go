@@ -0,0 +1,294 @@
+package order_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+)
+
+func newFundedOrder(id string, destTimelock time.Time) *Order {
+	return &Order{
+		ID:             id,
+		Type:           OrderTypeEthereumToCronos,
+		Status:         OrderStatusActive,
+		DestTxHash:     "0xcreate",
+		DestEscrowAddr: "crc1dest",
+		DestTimelock:   uint64(destTimelock.Unix()),
+		ExpiresAt:      destTimelock.Add(time.Hour),
+	}
+}
+
+// confirmingCronosClient reports cancel transactions as pending until
+// confirmed is set, or as failed with confirmErr, and counts cancels
+type confirmingCronosClient struct {
+	slowCronosClient
+	confirmed  bool
+	confirmErr error
+	cancels    int
+}
+
+func (c *confirmingCronosClient) CancelEscrow(ctx context.Context, escrowAddr string) (string, error) {
+	c.cancels++
+	return fmt.Sprintf("0xcancel%d", c.cancels), nil
+}
+
+func (c *confirmingCronosClient) CheckTransaction(ctx context.Context, txHash string) (bool, error) {
+	if c.confirmErr != nil {
+		return true, c.confirmErr
+	}
+	return c.confirmed, nil
+}
+
+func TestCancelOrder(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		order *Order
+	}{
+		{
+			name:  "pending",
+			order: &Order{ID: "order-1", Status: OrderStatusPending},
+		},
+		{
+			name:  "failed before funding",
+			order: &Order{ID: "order-1", Status: OrderStatusFailed, LastError: "malformed escrow"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			om := newTestOrderManager(t, &slowCronosClient{})
+			om.activeOrders[tc.order.ID] = tc.order
+
+			txHash, err := om.CancelOrder(context.Background(), tc.order.ID)
+			if err != nil {
+				t.Fatalf("expected order to be cancelled, got %v", err)
+			}
+			if txHash != "" {
+				t.Fatalf("expected no cancel transaction, got %q", txHash)
+			}
+
+			if tc.order.Status != OrderStatusCancelled {
+				t.Fatalf("expected cancelled status, got %s", tc.order.Status)
+			}
+			if _, tracked := om.GetOrder(tc.order.ID); tracked {
+				t.Fatal("cancelled order should no longer be tracked")
+			}
+			if finished := <-om.completedOrders; finished != tc.order {
+				t.Fatalf("expected cancelled order to be reported, got %+v", finished)
+			}
+
+			// A rescan doesn't pick the order up again
+			om.AddOrder(&Order{ID: tc.order.ID})
+			if queued := len(om.newOrdersChan); queued != 0 {
+				t.Fatalf("expected cancelled order not to be queued again, got %d queued", queued)
+			}
+		})
+	}
+}
+
+func TestCancelOrderFunded(t *testing.T) {
+	client := &confirmingCronosClient{}
+	om := newTestOrderManager(t, client)
+	order := newFundedOrder("order-1", time.Now().Add(-time.Minute))
+	om.trackOrder(order)
+
+	txHash, err := om.CancelOrder(context.Background(), order.ID)
+	if err != nil {
+		t.Fatalf("expected order to be cancelled, got %v", err)
+	}
+	if txHash != "0xcancel1" || order.CancelTxHash != txHash {
+		t.Fatalf("expected cancel transaction 0xcancel1, got %q", txHash)
+	}
+
+	// The order is kept until the cancel transaction is confirmed
+	if order.Status != OrderStatusCancelling {
+		t.Fatalf("expected cancelling status, got %s", order.Status)
+	}
+	if _, tracked := om.GetOrder(order.ID); !tracked {
+		t.Fatal("cancelling order should still be tracked")
+	}
+	if _, err := om.CancelOrder(context.Background(), order.ID); !errors.Is(err, ErrOrderNotCancellable) {
+		t.Fatalf("expected a cancelling order not to be cancelled twice, got %v", err)
+	}
+}
+
+func TestConfirmCancellation(t *testing.T) {
+	client := &confirmingCronosClient{}
+	om := newTestOrderManager(t, client)
+	order := newFundedOrder("order-1", time.Now().Add(-time.Minute))
+	order.Status = OrderStatusExpired
+	om.trackOrder(order)
+
+	if err := om.handleOrderUpdate(context.Background(), order); err != nil {
+		t.Fatalf("failed to cancel escrow: %v", err)
+	}
+	if order.Status != OrderStatusCancelling || isFinished(order) {
+		t.Fatalf("expected an unfinished cancelling order, got %s", order.Status)
+	}
+
+	// A pending cancel transaction leaves the order cancelling
+	if err := om.handleOrderUpdate(context.Background(), order); err != nil {
+		t.Fatalf("unexpected error while the cancel is pending: %v", err)
+	}
+	if order.Status != OrderStatusCancelling {
+		t.Fatalf("expected cancelling status while pending, got %s", order.Status)
+	}
+
+	client.confirmed = true
+	if err := om.handleOrderUpdate(context.Background(), order); err != nil {
+		t.Fatalf("failed to confirm cancellation: %v", err)
+	}
+	if order.Status != OrderStatusCancelled || !isFinished(order) {
+		t.Fatalf("expected a finished cancelled order, got %s", order.Status)
+	}
+	if client.cancels != 1 {
+		t.Fatalf("expected a single cancel transaction, got %d", client.cancels)
+	}
+}
+
+func TestConfirmCancellationFailed(t *testing.T) {
+	client := &confirmingCronosClient{confirmErr: chain_errors.Reverted(errors.New("escrow not expired"))}
+	om := newTestOrderManager(t, client)
+	order := newFundedOrder("order-1", time.Now().Add(-time.Minute))
+	order.Status = OrderStatusExpired
+	om.trackOrder(order)
+
+	if err := om.handleOrderUpdate(context.Background(), order); err != nil {
+		t.Fatalf("failed to cancel escrow: %v", err)
+	}
+
+	// The failed transaction is dropped and the order kept for a retry
+	err := om.handleOrderUpdate(context.Background(), order)
+	if !errors.Is(err, chain_errors.ErrReverted) {
+		t.Fatalf("expected the cancel transaction to have failed, got %v", err)
+	}
+	if order.CancelTxHash != "" || order.Status != OrderStatusCancelling {
+		t.Fatalf("expected a cancelling order without cancel transaction, got %s with %q", order.Status, order.CancelTxHash)
+	}
+	if !shouldRetry(order, err) || isFinished(order) {
+		t.Fatal("expected the failed cancellation to be retried")
+	}
+
+	// The next update broadcasts the cancel again and confirms it
+	client.confirmErr = nil
+	client.confirmed = true
+	if err := om.handleOrderUpdate(context.Background(), order); err != nil {
+		t.Fatalf("failed to retry the cancel: %v", err)
+	}
+	if order.CancelTxHash != "0xcancel2" || client.cancels != 2 {
+		t.Fatalf("expected the cancel to be broadcast again, got %q after %d cancels", order.CancelTxHash, client.cancels)
+	}
+	if err := om.handleOrderUpdate(context.Background(), order); err != nil {
+		t.Fatalf("failed to confirm cancellation: %v", err)
+	}
+	if order.Status != OrderStatusCancelled {
+		t.Fatalf("expected cancelled status, got %s", order.Status)
+	}
+}
+
+func TestCheckOrderTimeoutsQueuesCancellingOrders(t *testing.T) {
+	om := newTestOrderManager(t, &confirmingCronosClient{})
+	order := newFundedOrder("order-1", time.Now().Add(time.Hour))
+	order.Status = OrderStatusCancelling
+	order.CancelTxHash = "0xcancel"
+	om.trackOrder(order)
+
+	om.checkOrderTimeouts()
+	select {
+	case queued := <-om.updateOrdersChan:
+		if queued != order {
+			t.Fatalf("expected the cancelling order to be queued, got %s", queued.ID)
+		}
+	default:
+		t.Fatal("expected the cancelling order to be queued for confirmation")
+	}
+	if order.Status != OrderStatusCancelling {
+		t.Fatalf("expected cancelling status to be kept, got %s", order.Status)
+	}
+}
+
+func TestCancelOrderNotCancellable(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		order *Order
+	}{
+		{name: "completed", order: &Order{ID: "order-1", Status: OrderStatusCompleted}},
+		{name: "matched", order: newMatchedOrder("order-1")},
+		{
+			name: "deployment unconfirmed",
+			order: &Order{
+				ID:         "order-1",
+				Status:     OrderStatusActive,
+				DestTxHash: "0xcreate",
+			},
+		},
+		{name: "before destination timelock", order: newFundedOrder("order-1", time.Now().Add(time.Hour))},
+		{
+			name: "expired and being cancelled",
+			order: func() *Order {
+				order := newFundedOrder("order-1", time.Now().Add(-time.Hour))
+				order.Status = OrderStatusExpired
+				return order
+			}(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			om := newTestOrderManager(t, &slowCronosClient{})
+			om.activeOrders[tc.order.ID] = tc.order
+			status := tc.order.Status
+
+			txHash, err := om.CancelOrder(context.Background(), tc.order.ID)
+			if !errors.Is(err, ErrOrderNotCancellable) {
+				t.Fatalf("expected order not to be cancellable, got %v", err)
+			}
+			if txHash != "" || tc.order.CancelTxHash != "" {
+				t.Fatalf("expected no cancel transaction, got %q", txHash)
+			}
+			if tc.order.Status != status {
+				t.Fatalf("expected status %s to be kept, got %s", status, tc.order.Status)
+			}
+			if _, tracked := om.GetOrder(tc.order.ID); !tracked {
+				t.Fatal("order should still be tracked")
+			}
+		})
+	}
+}
+
+func TestCancelOrderQueued(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	om.AddOrder(newEthereumOrder("order-1"))
+
+	if _, err := om.CancelOrder(context.Background(), "order-1"); err != nil {
+		t.Fatalf("expected queued order to be cancelled, got %v", err)
+	}
+	if reason := om.seenOrder("order-1"); reason != "recently finished" {
+		t.Fatalf("expected cancelled order to be remembered, got %q", reason)
+	}
+
+	if err := om.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start order manager: %v", err)
+	}
+	defer om.Stop(context.Background())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(om.newOrdersChan) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("queued order was never consumed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if _, tracked := om.GetOrder("order-1"); tracked {
+		t.Fatal("order cancelled while queued should not be processed")
+	}
+}
+
+func TestCancelOrderNotFound(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+
+	if _, err := om.CancelOrder(context.Background(), "missing"); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
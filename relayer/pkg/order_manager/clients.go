@@ -0,0 +1,37 @@
+package order_manager
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/ethereum_client"
+)
+
+// CronosClient is the subset of the Cronos client used by the order manager
+type CronosClient interface {
+	CreateDestinationEscrow(ctx context.Context, factoryAddr string, params cronos_client.CreateDestEscrowParams) (string, error)
+	WithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string) (string, error)
+	PartialWithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string, amount string) (string, error)
+	CancelEscrow(ctx context.Context, escrowAddr string) (string, error)
+	GetEscrowStatus(ctx context.Context, escrowAddr string) (string, error)
+	GetEscrowDeposit(ctx context.Context, escrowAddr string) (*big.Int, error)
+	CheckTransaction(ctx context.Context, txHash string) (bool, error)
+	SimulateFill(ctx context.Context, escrowAddr string, inputAmount string) (string, error)
+}
+
+// EthereumClient is the subset of the Ethereum client used by the order manager
+type EthereumClient interface {
+	CreateDestinationEscrow(ctx context.Context, resolverAddr string, params ethereum_client.CreateDestEscrowParams) (string, error)
+	WithdrawFromEscrow(ctx context.Context, resolverAddr string, escrowAddr string, secret string, immutables ethereum_client.Immutables) (string, error)
+	CancelEscrow(ctx context.Context, resolverAddr string, escrowAddr string, immutables ethereum_client.Immutables) (string, error)
+	GetLatestBlock(ctx context.Context) (uint64, error)
+	GetRevealedSecrets(ctx context.Context, escrowAddr string, fromBlock uint64) ([]ethereum_client.RevealedSecret, error)
+	GetEscrowDeposit(ctx context.Context, escrowAddr string) (*big.Int, error)
+	CheckTransaction(ctx context.Context, txHash string) (bool, error)
+}
+
+var (
+	_ CronosClient   = (*cronos_client.Client)(nil)
+	_ EthereumClient = (*ethereum_client.Client)(nil)
+)
@@ -0,0 +1,54 @@
+package order_manager
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// RelayerFee returns the relayer's fee of percentage percent of amount. The
+// fee is rounded down to the asset's smallest unit, so the maker is never
+// charged more than the configured percentage
+func RelayerFee(amount *big.Int, percentage float64) *big.Int {
+	if amount == nil || amount.Sign() <= 0 || percentage <= 0 {
+		return new(big.Int)
+	}
+
+	// Parse the decimal form so percentages like 0.1 are exact
+	rate, ok := new(big.Rat).SetString(strconv.FormatFloat(percentage, 'f', -1, 64))
+	if !ok {
+		return new(big.Int)
+	}
+	fee := new(big.Rat).Mul(new(big.Rat).SetInt(amount), rate)
+	fee.Quo(fee, big.NewRat(100, 1))
+
+	return new(big.Int).Quo(fee.Num(), fee.Denom())
+}
+
+// validateRelayerFee checks that amount is large enough to pay a fee of at
+// least one unit when the relayer charges one
+func validateRelayerFee(amount *big.Int, percentage float64) error {
+	if percentage <= 0 {
+		return nil
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return fmt.Errorf("amount must be positive to cover the relayer fee")
+	}
+	if RelayerFee(amount, percentage).Sign() == 0 {
+		return fmt.Errorf("amount %s is too small to cover the relayer fee of %g%%", amount, percentage)
+	}
+	return nil
+}
+
+// recordRelayerFee adds the relayer's fee for withdrawing amount to the fee
+// the order owes and returns it
+func (om *OrderManager) recordRelayerFee(order *Order, amount *big.Int) *big.Int {
+	fee := RelayerFee(amount, om.config.Relayer.RelayerFeePercentage)
+
+	owed := new(big.Int)
+	if order.RelayerFee != nil {
+		owed.Set(order.RelayerFee)
+	}
+	order.RelayerFee = owed.Add(owed, fee)
+	return fee
+}
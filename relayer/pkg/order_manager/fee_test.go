@@ -0,0 +1,110 @@
+package order_manager
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func TestRelayerFee(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		amount     string
+		percentage float64
+		fee        string
+	}{
+		{name: "default percentage", amount: "1000000", percentage: 0.1, fee: "1000"},
+		{name: "rounds down", amount: "1999", percentage: 0.1, fee: "1"},
+		{name: "too small for a fee", amount: "999", percentage: 0.1, fee: "0"},
+		{name: "whole percent", amount: "250", percentage: 3, fee: "7"},
+		{name: "fractional percent", amount: "1000000", percentage: 0.25, fee: "2500"},
+		{name: "18 decimals", amount: "1000000000000000000", percentage: 0.3, fee: "3000000000000000"},
+		{name: "beyond int64", amount: "123456789012345678901234567890", percentage: 0.1, fee: "123456789012345678901234567"},
+		{name: "disabled", amount: "1000000", percentage: 0, fee: "0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			amount, _ := new(big.Int).SetString(tc.amount, 10)
+			if fee := RelayerFee(amount, tc.percentage); fee.String() != tc.fee {
+				t.Fatalf("expected fee %s, got %s", tc.fee, fee)
+			}
+		})
+	}
+
+	if fee := RelayerFee(nil, 0.1); fee.Sign() != 0 {
+		t.Fatalf("expected no fee for a missing amount, got %s", fee)
+	}
+}
+
+func TestValidateRelayerFee(t *testing.T) {
+	if err := validateRelayerFee(big.NewInt(1000), 0.1); err != nil {
+		t.Fatalf("expected an amount paying a fee of 1 to be accepted, got %v", err)
+	}
+	if err := validateRelayerFee(big.NewInt(999), 0.1); err == nil {
+		t.Fatal("expected an amount too small for the fee to be rejected")
+	}
+	if err := validateRelayerFee(nil, 0.1); err == nil {
+		t.Fatal("expected a missing amount to be rejected")
+	}
+	if err := validateRelayerFee(big.NewInt(1), 0); err != nil {
+		t.Fatalf("expected any amount to be accepted without a fee, got %v", err)
+	}
+}
+
+func TestHandleNewOrderRejectsAmountBelowFee(t *testing.T) {
+	om := newTestOrderManager(t, &recordingCronosClient{})
+	om.config.Relayer.RelayerFeePercentage = 0.1
+
+	order := newMatchedOrder("order-1")
+	order.Status = OrderStatusPending
+	order.Timelock = 10000
+	order.DestTimelock = 5000
+	order.SourceAsset.Amount = big.NewInt(999)
+
+	if err := om.handleNewOrder(context.Background(), order); err == nil {
+		t.Fatal("expected an order too small for the relayer fee to be rejected")
+	}
+}
+
+func TestExecuteSwapRecordsRelayerFee(t *testing.T) {
+	client := &recordingCronosClient{}
+	om := newTestOrderManager(t, client)
+	om.config.Relayer.RelayerFeePercentage = 0.5
+
+	order := newPartialFillOrder("order-1")
+	order.PartialFill.RemainingAmount = big.NewInt(10000)
+	order.PartialFill.MinimumFillAmount = big.NewInt(100)
+
+	for _, fill := range []int64{3000, 7000} {
+		order.Status = OrderStatusMatched
+		order.PartialFill.PendingFillAmount = big.NewInt(fill)
+		if err := om.executeSwap(context.Background(), order); err != nil {
+			t.Fatalf("executeSwap failed: %v", err)
+		}
+	}
+
+	if order.Status != OrderStatusCompleted {
+		t.Fatalf("expected order to be completed, got %s", order.Status)
+	}
+	if order.RelayerFee == nil || order.RelayerFee.Int64() != 50 {
+		t.Fatalf("expected a relayer fee of 50 over both fills, got %v", order.RelayerFee)
+	}
+}
+
+func TestExecuteSwapRejectsFillBelowRelayerFee(t *testing.T) {
+	client := &recordingCronosClient{}
+	om := newTestOrderManager(t, client)
+	om.config.Relayer.RelayerFeePercentage = 0.1
+
+	order := newPartialFillOrder("order-1")
+	order.PartialFill.PendingFillAmount = big.NewInt(50)
+
+	if err := om.executeSwap(context.Background(), order); err != nil {
+		t.Fatalf("executeSwap failed: %v", err)
+	}
+	if len(client.withdrawals) != 0 {
+		t.Fatalf("expected no withdrawal, got %v", client.withdrawals)
+	}
+	if order.Status != OrderStatusActive || order.RelayerFee != nil {
+		t.Fatalf("expected the fill to be rejected without a fee, got %s with fee %v", order.Status, order.RelayerFee)
+	}
+}
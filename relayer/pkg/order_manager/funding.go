@@ -0,0 +1,55 @@
+package order_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"go.uber.org/zap"
+)
+
+// ErrDestinationUnderfunded is returned when a swap's destination escrow
+// holds less than the order's destination amount, or can't be checked yet.
+// The swap is held, with its secret unrevealed, and retried
+var ErrDestinationUnderfunded = errors.New("destination escrow underfunded")
+
+// verifyDestinationFunded checks the destination escrow holds at least the
+// order's destination amount before the secret is revealed. Partial fills
+// are matched against the order book rather than a single destination
+// escrow, so they aren't checked here
+func (om *OrderManager) verifyDestinationFunded(ctx context.Context, order *Order) error {
+	if order.PartialFill != nil && order.PartialFill.AllowPartialFill {
+		return nil
+	}
+
+	expected := order.DestinationAsset.Amount
+	if expected == nil || expected.Sign() <= 0 {
+		return fmt.Errorf("order %s has no destination amount", order.ID)
+	}
+	if order.DestEscrowAddr == "" {
+		return fmt.Errorf("%w: destination escrow of order %s is not known yet", ErrDestinationUnderfunded, order.ID)
+	}
+
+	var deposited *big.Int
+	var err error
+	if order.Type == OrderTypeCronosToEthereum {
+		deposited, err = om.ethereumClient.GetEscrowDeposit(ctx, order.DestEscrowAddr)
+	} else {
+		deposited, err = om.cronosClient.GetEscrowDeposit(ctx, order.DestEscrowAddr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get destination escrow deposit: %w", err)
+	}
+
+	if deposited.Cmp(expected) < 0 {
+		om.logger.Warn("Holding swap until destination escrow is funded",
+			zap.String("order_id", order.ID),
+			zap.String("escrow", order.DestEscrowAddr),
+			zap.String("deposited", deposited.String()),
+			zap.String("expected", expected.String()))
+		return fmt.Errorf("%w: escrow %s holds %s, expected %s",
+			ErrDestinationUnderfunded, order.DestEscrowAddr, deposited, expected)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package order_manager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// depositCronosClient reports deposit as the amount in every escrow and
+// counts withdrawals
+type depositCronosClient struct {
+	recordingCronosClient
+	deposit     *big.Int
+	withdrawals int
+}
+
+func (c *depositCronosClient) WithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string) (string, error) {
+	c.withdrawals++
+	return "0xwithdraw", nil
+}
+
+func (c *depositCronosClient) GetEscrowDeposit(ctx context.Context, escrowAddr string) (*big.Int, error) {
+	return c.deposit, nil
+}
+
+func TestExecuteSwapHoldsUnderfundedDestination(t *testing.T) {
+	client := &depositCronosClient{}
+	ethClient := &fundedEthereumClient{deposit: big.NewInt(999)}
+	om := newTestOrderManager(t, client)
+	om.ethereumClient = ethClient
+
+	order := newMatchedOrder("order-1")
+	om.trackOrder(order)
+
+	err := om.executeSwap(context.Background(), order)
+	if !errors.Is(err, ErrDestinationUnderfunded) {
+		t.Fatalf("expected ErrDestinationUnderfunded, got %v", err)
+	}
+	if client.withdrawals != 0 {
+		t.Fatalf("expected the secret to stay unrevealed, got %d withdrawals", client.withdrawals)
+	}
+	if order.Status != OrderStatusMatched || order.SourceTxHash != "" {
+		t.Fatalf("expected the order to be held as matched, got %s", order.Status)
+	}
+	if !shouldRetry(order, err) {
+		t.Fatal("expected a held swap to be retried")
+	}
+
+	// Once the escrow is topped up the swap goes through
+	ethClient.deposit = big.NewInt(1000)
+	if err := om.executeSwap(context.Background(), order); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.withdrawals != 1 || order.Status != OrderStatusCompleted {
+		t.Fatalf("expected one withdrawal and a completed order, got %d and %s", client.withdrawals, order.Status)
+	}
+}
+
+func TestVerifyDestinationFunded(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		order       func() *Order
+		deposit     int64
+		underfunded bool
+	}{
+		{name: "funded on ethereum", order: func() *Order { return newMatchedOrder("order-1") }, deposit: 1000},
+		{name: "overfunded on ethereum", order: func() *Order { return newMatchedOrder("order-1") }, deposit: 1001},
+		{name: "underfunded on ethereum", order: func() *Order { return newMatchedOrder("order-1") }, deposit: 999, underfunded: true},
+		{
+			name: "underfunded on cronos",
+			order: func() *Order {
+				order := newMatchedOrder("order-1")
+				order.Type = OrderTypeEthereumToCronos
+				order.DestEscrowAddr = "crc1dest"
+				return order
+			},
+			deposit:     0,
+			underfunded: true,
+		},
+		{
+			name: "escrow not known yet",
+			order: func() *Order {
+				order := newMatchedOrder("order-1")
+				order.DestEscrowAddr = ""
+				return order
+			},
+			deposit:     1000,
+			underfunded: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			om := newTestOrderManager(t, &depositCronosClient{deposit: big.NewInt(tc.deposit)})
+			om.ethereumClient = &fundedEthereumClient{deposit: big.NewInt(tc.deposit)}
+
+			err := om.verifyDestinationFunded(context.Background(), tc.order())
+			if tc.underfunded != errors.Is(err, ErrDestinationUnderfunded) || (!tc.underfunded && err != nil) {
+				t.Fatalf("expected underfunded=%v, got %v", tc.underfunded, err)
+			}
+		})
+	}
+}
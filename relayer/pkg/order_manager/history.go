@@ -0,0 +1,69 @@
+package order_manager
+
+import "time"
+
+// StateTransition records a single status change of an order
+type StateTransition struct {
+	Timestamp time.Time   `json:"timestamp"`
+	From      OrderStatus `json:"from"`
+	To        OrderStatus `json:"to"`
+	Note      string      `json:"note,omitempty"`
+	TxHash    string      `json:"tx_hash,omitempty"`
+}
+
+// SetStatus moves order to status and records the transition in its
+// history, notifying the webhook when the order is done. note and txHash are
+// optional context for the change
+func (om *OrderManager) SetStatus(order *Order, status OrderStatus, note, txHash string) {
+	om.ordersMutex.Lock()
+	defer om.ordersMutex.Unlock()
+	om.setStatus(order, status, note, txHash)
+}
+
+// setStatus is SetStatus for callers already holding ordersMutex, which
+// guards the order's history
+func (om *OrderManager) setStatus(order *Order, status OrderStatus, note, txHash string) {
+	if order.Status == status {
+		return
+	}
+
+	transition := StateTransition{
+		Timestamp: time.Now(),
+		From:      order.Status,
+		To:        status,
+		Note:      note,
+		TxHash:    txHash,
+	}
+	recordTransition(order, transition, om.config.Relayer.MaxOrderHistory)
+	om.statusIndex.setStatus(order, status)
+
+	om.notifyTransition(order, transition)
+}
+
+// GetOrderHistory returns a copy of the status history of a tracked order
+func (om *OrderManager) GetOrderHistory(orderID string) ([]StateTransition, bool) {
+	om.ordersMutex.RLock()
+	defer om.ordersMutex.RUnlock()
+
+	order, exists := om.trackedOrder(orderID)
+	if !exists {
+		return nil, false
+	}
+
+	history := make([]StateTransition, len(order.History))
+	copy(history, order.History)
+	return history, true
+}
+
+// recordTransition appends transition to the order's history, dropping the
+// oldest entries beyond maxEntries. A zero maxEntries disables the history
+func recordTransition(order *Order, transition StateTransition, maxEntries int) {
+	if maxEntries <= 0 {
+		return
+	}
+
+	order.History = append(order.History, transition)
+	if excess := len(order.History) - maxEntries; excess > 0 {
+		order.History = append(order.History[:0:0], order.History[excess:]...)
+	}
+}
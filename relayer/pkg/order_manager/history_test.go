@@ -0,0 +1,165 @@
+package order_manager
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/webhook"
+)
+
+func TestOrderHistoryRecordsTransitions(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	om.config.Relayer.MaxOrderHistory = 10
+
+	order := &Order{
+		ID:               "order-1",
+		Type:             OrderTypeEthereumToCronos,
+		Status:           OrderStatusPending,
+		DestinationAsset: AssetInfo{Amount: big.NewInt(100)},
+		ExpiresAt:        time.Now().Add(time.Hour),
+	}
+	if err := om.handleEthereumToCronosOrder(context.Background(), order); err != nil {
+		t.Fatalf("failed to handle order: %v", err)
+	}
+	om.activeOrders[order.ID] = order
+
+	om.SetStatus(order, OrderStatusMatched, "matched in test", "")
+	// Setting the current status again is not a transition
+	om.SetStatus(order, OrderStatusMatched, "duplicate", "")
+
+	order.ExpiresAt = time.Now().Add(-time.Second)
+	om.checkOrderTimeouts()
+
+	history, ok := om.GetOrderHistory(order.ID)
+	if !ok {
+		t.Fatal("expected history for active order")
+	}
+
+	expected := []StateTransition{
+		{From: OrderStatusPending, To: OrderStatusActive, TxHash: "0xcreate"},
+		{From: OrderStatusActive, To: OrderStatusMatched, Note: "matched in test"},
+		{From: OrderStatusMatched, To: OrderStatusExpired},
+	}
+	if len(history) != len(expected) {
+		t.Fatalf("expected %d transitions, got %+v", len(expected), history)
+	}
+	for i, want := range expected {
+		got := history[i]
+		if got.From != want.From || got.To != want.To || got.TxHash != want.TxHash ||
+			(want.Note != "" && got.Note != want.Note) {
+			t.Fatalf("transition %d: expected %+v, got %+v", i, want, got)
+		}
+		if got.Timestamp.IsZero() {
+			t.Fatalf("transition %d has no timestamp", i)
+		}
+		if i > 0 && got.Timestamp.Before(history[i-1].Timestamp) {
+			t.Fatalf("transition %d recorded out of order", i)
+		}
+	}
+}
+
+func TestOrderHistoryIsBounded(t *testing.T) {
+	order := &Order{}
+	for _, status := range []OrderStatus{OrderStatusActive, OrderStatusMatched, OrderStatusCompleted} {
+		recordTransition(order, StateTransition{To: status}, 2)
+	}
+
+	if len(order.History) != 2 || order.History[0].To != OrderStatusMatched || order.History[1].To != OrderStatusCompleted {
+		t.Fatalf("expected the two newest transitions, got %+v", order.History)
+	}
+
+	recordTransition(order, StateTransition{To: OrderStatusFailed}, 0)
+	if len(order.History) != 2 {
+		t.Fatalf("expected history to be left alone when disabled, got %+v", order.History)
+	}
+}
+
+func TestOrderHistoryConcurrentAccess(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	om.config.Relayer.MaxOrderHistory = 3
+
+	order := newMatchedOrder("order-1")
+	om.ordersMutex.Lock()
+	om.trackOrder(order)
+	om.ordersMutex.Unlock()
+
+	// Run with -race: the history is trimmed while it is being read
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			status := OrderStatusActive
+			if i%2 == 1 {
+				status = OrderStatusMatched
+			}
+			om.SetStatus(order, status, "", "")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if history, ok := om.GetOrderHistory(order.ID); !ok || len(history) > 3 {
+				t.Errorf("expected at most 3 transitions, got %d", len(history))
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	if history, _ := om.GetOrderHistory(order.ID); len(history) != 3 {
+		t.Fatalf("expected the 3 newest transitions, got %+v", history)
+	}
+}
+
+func TestTerminalTransitionNotifiesWebhook(t *testing.T) {
+	received := make(chan []byte, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(webhook.SignatureHeader) != webhook.Sign(body, "secret") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		received <- body
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{}
+	cfg.Relayer.WebhookURL = server.URL
+	cfg.Relayer.WebhookSecret = "secret"
+	cfg.Relayer.MaxOrderHistory = 10
+	om := NewOrderManager(cfg, nil, nil, zap.NewNop())
+
+	order := newMatchedOrder("order-1")
+	om.SetStatus(order, OrderStatusActive, "not terminal", "")
+	om.SetStatus(order, OrderStatusExpired, "timelock passed", "")
+	om.webhook.Close()
+
+	if len(received) != 1 {
+		t.Fatalf("expected one webhook delivery, got %d", len(received))
+	}
+
+	var event OrderEvent
+	if err := json.Unmarshal(<-received, &event); err != nil {
+		t.Fatalf("failed to decode webhook payload: %v", err)
+	}
+	if event.Event != "order.expired" || event.Reason != "timelock passed" || event.Transition.From != OrderStatusActive {
+		t.Fatalf("unexpected event %+v", event)
+	}
+	if event.Order.ID != "order-1" || event.Order.Status != OrderStatusExpired {
+		t.Fatalf("unexpected order in event %+v", event.Order)
+	}
+	if event.Order.Secret != "" {
+		t.Fatal("expected the secret to be left out of the webhook")
+	}
+}
@@ -0,0 +1,144 @@
+package order_manager
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/ethereum_client"
+)
+
+// sourceImmutables returns the immutables of the Ethereum source escrow of an
+// Ethereum to Cronos order, as its factory emitted them. They can't be
+// rebuilt from the order, since the escrow only accepts an exact match
+func (om *OrderManager) sourceImmutables(order *Order) (ethereum_client.Immutables, error) {
+	if order.SourceImmutables == nil {
+		return ethereum_client.Immutables{}, fmt.Errorf("order %s has no immutables for source escrow %s", order.ID, order.SourceEscrowAddr)
+	}
+	return *order.SourceImmutables, nil
+}
+
+// sourceCancellation returns when the maker can cancel the order's source
+// escrow: the cancellation stage of an Ethereum source escrow, or the order's
+// timelock otherwise
+func sourceCancellation(order *Order) uint64 {
+	if imm := order.SourceImmutables; imm != nil && imm.Timelocks.SrcCancellation > 0 {
+		return uint64(imm.Timelocks.DeployedAt) + uint64(imm.Timelocks.SrcCancellation)
+	}
+	return order.Timelock
+}
+
+// destinationImmutables builds the immutables of the Ethereum destination
+// escrow the resolver deploys for a Cronos to Ethereum order at deployedAt
+func (om *OrderManager) destinationImmutables(order *Order, deployedAt uint64) (ethereum_client.Immutables, error) {
+	cancellation := stageOffset(deployedAt, order.DestTimelock)
+
+	return newImmutables(order, order.Maker, om.config.Contracts.Ethereum.Resolver, order.DestinationAsset, ethereum_client.Timelocks{
+		DeployedAt:          uint32(deployedAt),
+		DstPublicWithdrawal: cancellation,
+		DstCancellation:     cancellation,
+	})
+}
+
+func newImmutables(order *Order, maker, taker string, asset AssetInfo, timelocks ethereum_client.Timelocks) (ethereum_client.Immutables, error) {
+	hashlock, err := parseHashlock(order.SecretHash)
+	if err != nil {
+		return ethereum_client.Immutables{}, err
+	}
+
+	makerAddr, err := evmAddress(maker)
+	if err != nil {
+		return ethereum_client.Immutables{}, fmt.Errorf("invalid maker: %w", err)
+	}
+
+	takerAddr, err := evmAddress(taker)
+	if err != nil {
+		return ethereum_client.Immutables{}, fmt.Errorf("invalid taker: %w", err)
+	}
+
+	// Native ETH escrows use the zero token address
+	var token common.Address
+	if asset.Address != "" {
+		if !common.IsHexAddress(asset.Address) {
+			return ethereum_client.Immutables{}, fmt.Errorf("invalid token address %q", asset.Address)
+		}
+		token = common.HexToAddress(asset.Address)
+	}
+
+	if asset.Amount == nil || asset.Amount.Sign() <= 0 {
+		return ethereum_client.Immutables{}, fmt.Errorf("order %s has no %s amount", order.ID, asset.Symbol)
+	}
+
+	return ethereum_client.Immutables{
+		OrderHash:     orderHash(order.ID),
+		Hashlock:      hashlock,
+		Maker:         makerAddr,
+		Taker:         takerAddr,
+		Token:         token,
+		Amount:        asset.Amount,
+		SafetyDeposit: big.NewInt(0),
+		Timelocks:     timelocks,
+	}, nil
+}
+
+// escrowValue returns the ETH to send when deploying an escrow: the safety
+// deposit, plus the amount itself for native ETH escrows
+func escrowValue(immutables ethereum_client.Immutables) *big.Int {
+	value := new(big.Int).Set(immutables.SafetyDeposit)
+	if immutables.Token == (common.Address{}) {
+		value.Add(value, immutables.Amount)
+	}
+	return value
+}
+
+// stageOffset returns the seconds from deployedAt until timestamp, or zero if
+// timestamp has already passed
+func stageOffset(deployedAt, timestamp uint64) uint32 {
+	if timestamp <= deployedAt {
+		return 0
+	}
+	return uint32(timestamp - deployedAt)
+}
+
+// evmAddress parses a hex or bech32 address. Cronos accounts are Ethereum
+// accounts, so a bech32 address maps to the same 20 bytes on Ethereum
+func evmAddress(addr string) (common.Address, error) {
+	if common.IsHexAddress(addr) {
+		return common.HexToAddress(addr), nil
+	}
+
+	_, bz, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	if len(bz) != common.AddressLength {
+		return common.Address{}, fmt.Errorf("address %q is %d bytes, expected %d", addr, len(bz), common.AddressLength)
+	}
+	return common.BytesToAddress(bz), nil
+}
+
+// orderHash returns the order hash of an escrow the relayer deploys for an
+// order. An ID that is a hex-encoded 32-byte hash, like a canonical order ID,
+// is used as is; other IDs are hashed
+func orderHash(id string) [32]byte {
+	if bz, err := hex.DecodeString(strings.TrimPrefix(id, "0x")); err == nil && len(bz) == 32 {
+		return [32]byte(bz)
+	}
+	return crypto.Keccak256Hash([]byte(id))
+}
+
+// parseHashlock decodes a hex-encoded 32-byte secret hash
+func parseHashlock(secretHash string) ([32]byte, error) {
+	bz, err := hex.DecodeString(strings.TrimPrefix(secretHash, "0x"))
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("invalid secret hash %q: %w", secretHash, err)
+	}
+	if len(bz) != 32 {
+		return [32]byte{}, fmt.Errorf("secret hash %q is %d bytes, expected 32", secretHash, len(bz))
+	}
+	return [32]byte(bz), nil
+}
@@ -0,0 +1,84 @@
+package order_manager
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/ethereum_client"
+)
+
+func TestDestinationImmutables(t *testing.T) {
+	maker := common.HexToAddress("0x751e76e8199196d454941c45d1b3a323f1433bd6")
+	bech32Maker, err := bech32.ConvertAndEncode("crc", maker.Bytes())
+	if err != nil {
+		t.Fatalf("failed to encode maker: %v", err)
+	}
+
+	om := &OrderManager{config: &config.Config{}}
+	om.config.Contracts.Ethereum.Resolver = "0x2222222222222222222222222222222222222222"
+
+	order := &Order{
+		ID:               "cronos-order-1",
+		Maker:            bech32Maker,
+		SecretHash:       "0x" + "ab" + "00000000000000000000000000000000000000000000000000000000000000",
+		DestTimelock:     1700003600,
+		DestinationAsset: AssetInfo{Symbol: "ETH", Amount: big.NewInt(5)},
+		CreatedAt:        time.Unix(1700000000, 0),
+	}
+
+	immutables, err := om.destinationImmutables(order, 1700000000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if immutables.Maker != maker {
+		t.Fatalf("expected bech32 maker to map to %s, got %s", maker, immutables.Maker)
+	}
+	if immutables.Taker != common.HexToAddress(om.config.Contracts.Ethereum.Resolver) {
+		t.Fatalf("expected the resolver as taker, got %s", immutables.Taker)
+	}
+	if immutables.Hashlock[0] != 0xab {
+		t.Fatalf("unexpected hashlock %x", immutables.Hashlock)
+	}
+	if immutables.Timelocks.DeployedAt != 1700000000 || immutables.Timelocks.DstCancellation != 3600 {
+		t.Fatalf("unexpected timelocks %+v", immutables.Timelocks)
+	}
+
+	// Native ETH escrows are funded with the amount itself
+	if value := escrowValue(immutables); value.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("expected escrow value 5, got %s", value)
+	}
+
+	order.SecretHash = "0x1234"
+	if _, err := om.destinationImmutables(order, 1700000000); err == nil {
+		t.Fatal("expected error for a short secret hash")
+	}
+}
+
+func TestSourceImmutables(t *testing.T) {
+	om := &OrderManager{config: &config.Config{}}
+	order := &Order{ID: "order-1", SourceEscrowAddr: "0x3333333333333333333333333333333333333333"}
+
+	// Immutables can't be rebuilt from the order
+	if _, err := om.sourceImmutables(order); err == nil {
+		t.Fatal("expected an error for an order without source immutables")
+	}
+
+	emitted := ethereum_client.Immutables{
+		Hashlock:      [32]byte{0xab},
+		Amount:        big.NewInt(5),
+		SafetyDeposit: big.NewInt(1),
+		Timelocks:     ethereum_client.Timelocks{DeployedAt: 1700000000, SrcCancellation: 3600},
+	}
+	order.SourceImmutables = &emitted
+	immutables, err := om.sourceImmutables(order)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if immutables.SafetyDeposit.Cmp(big.NewInt(1)) != 0 || immutables.Timelocks != emitted.Timelocks {
+		t.Fatalf("expected the emitted immutables, got %+v", immutables)
+	}
+}
@@ -2,27 +2,54 @@ package order_manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
 	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
 	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
 	"github.com/manus-ai/cronos-eth-bridge/pkg/ethereum_client"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/secret_manager"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/webhook"
 	"go.uber.org/zap"
 )
 
+// drainPollInterval is how often Drain checks for in-flight orders
+const drainPollInterval = 100 * time.Millisecond
+
 // OrderManager manages cross-chain swap orders
 type OrderManager struct {
 	config        *config.Config
-	cronosClient  *cronos_client.Client
-	ethereumClient *ethereum_client.Client
+	cronosClient  CronosClient
+	ethereumClient EthereumClient
+	secretManager *secret_manager.SecretManager
 	logger        *zap.Logger
+
+	// Notified of terminal order transitions; nil when no webhook is set
+	webhook *webhook.Notifier
 	
 	// Order tracking
 	activeOrders  map[string]*Order
 	ordersMutex   sync.RWMutex
+
+	// Failed orders, set aside so the processing loops stop visiting them
+	// while they stay tracked for reporting and operator resolution. Guarded
+	// by ordersMutex
+	failedOrders map[string]*Order
+
+	// activeOrders and failedOrders bucketed by status, kept in step by
+	// trackOrder, retireOrder and SetStatus
+	statusIndex *statusIndex
+
+	// IDs of orders queued on newOrdersChan and of recently finished orders,
+	// so AddOrder drops orders it has already seen. Guarded by ordersMutex
+	queuedOrders map[string]struct{}
+	recentOrders *recentOrderIDs
 	
 	// Channels for order processing
 	newOrdersChan    chan *Order
@@ -32,6 +59,13 @@ type OrderManager struct {
 	// Stop channel
 	stopChan chan struct{}
 	wg       sync.WaitGroup
+
+	// cancel aborts the context the processing goroutines run under
+	cancel context.CancelFunc
+
+	// Names of the processing goroutines that haven't returned yet
+	running      map[string]struct{}
+	runningMutex sync.Mutex
 }
 
 // Order represents a cross-chain swap order
@@ -48,14 +82,29 @@ type Order struct {
 	SecretHash        string                 `json:"secret_hash"`
 	Secret            string                 `json:"secret,omitempty"`
 	Timelock          uint64                 `json:"timelock"`
+	DestTimelock      uint64                 `json:"dest_timelock,omitempty"`
+	// DestDeployedAt is when the relayer deployed an Ethereum destination escrow
+	DestDeployedAt    uint64                 `json:"dest_deployed_at,omitempty"`
+	// SourceBlock and DestBlock are the Ethereum blocks withdrawals from the
+	// order's Ethereum source or destination escrow are looked up from: the
+	// block the escrow was created in, or the latest block before the relayer
+	// deployed it
+	SourceBlock       uint64                 `json:"source_block,omitempty"`
+	DestBlock         uint64                 `json:"dest_block,omitempty"`
 	
 	// Asset information
 	SourceAsset       AssetInfo              `json:"source_asset"`
 	DestinationAsset  AssetInfo              `json:"destination_asset"`
+	// RelayerFee is the fee owed to the relayer for the source amount
+	// withdrawn so far
+	RelayerFee        *big.Int               `json:"relayer_fee,omitempty"`
 	
 	// Escrow addresses
 	SourceEscrowAddr  string                 `json:"source_escrow_addr,omitempty"`
 	DestEscrowAddr    string                 `json:"dest_escrow_addr,omitempty"`
+	// SourceImmutables are the immutables an Ethereum source escrow was
+	// deployed with, which withdrawing from it must repeat exactly
+	SourceImmutables  *ethereum_client.Immutables `json:"source_immutables,omitempty"`
 	
 	// Dutch auction parameters
 	DutchAuction      *DutchAuctionParams    `json:"dutch_auction,omitempty"`
@@ -72,10 +121,14 @@ type Order struct {
 	// Transaction hashes
 	SourceTxHash      string                 `json:"source_tx_hash,omitempty"`
 	DestTxHash        string                 `json:"dest_tx_hash,omitempty"`
+	CancelTxHash      string                 `json:"cancel_tx_hash,omitempty"`
 	
 	// Retry information
 	RetryCount        int                    `json:"retry_count"`
 	LastError         string                 `json:"last_error,omitempty"`
+
+	// Status changes, oldest first
+	History           []StateTransition      `json:"history,omitempty"`
 }
 
 // OrderType represents the type of order
@@ -94,6 +147,9 @@ const (
 	OrderStatusActive     OrderStatus = "active"
 	OrderStatusMatched    OrderStatus = "matched"
 	OrderStatusCompleted  OrderStatus = "completed"
+	// OrderStatusCancelling is an order whose escrow cancel transaction was
+	// broadcast but isn't confirmed yet
+	OrderStatusCancelling OrderStatus = "cancelling"
 	OrderStatusCancelled  OrderStatus = "cancelled"
 	OrderStatusExpired    OrderStatus = "expired"
 	OrderStatusFailed     OrderStatus = "failed"
@@ -114,6 +170,9 @@ type DutchAuctionParams struct {
 	DecayRate       *big.Int      `json:"decay_rate"`
 	StartTime       time.Time     `json:"start_time"`
 	Duration        time.Duration `json:"duration"`
+	// CurveType is config.AuctionCurveLinear or config.AuctionCurveExponential;
+	// empty means linear
+	CurveType string `json:"curve_type,omitempty"`
 }
 
 // PartialFillParams represents partial fill parameters
@@ -122,25 +181,34 @@ type PartialFillParams struct {
 	MinimumFillAmount *big.Int `json:"minimum_fill_amount,omitempty"`
 	FilledAmount      *big.Int `json:"filled_amount"`
 	RemainingAmount   *big.Int `json:"remaining_amount"`
+	// PendingFillAmount is the matched amount the next withdrawal takes
+	PendingFillAmount *big.Int `json:"pending_fill_amount,omitempty"`
 }
 
 // NewOrderManager creates a new order manager
 func NewOrderManager(
 	config *config.Config,
-	cronosClient *cronos_client.Client,
-	ethereumClient *ethereum_client.Client,
+	cronosClient CronosClient,
+	ethereumClient EthereumClient,
 	logger *zap.Logger,
 ) *OrderManager {
 	return &OrderManager{
 		config:           config,
 		cronosClient:     cronosClient,
 		ethereumClient:   ethereumClient,
+		secretManager:    secret_manager.NewSecretManager(logger.Named("secret_manager")),
 		logger:           logger,
+		webhook:          webhook.New(&config.Relayer, logger.Named("webhook")),
 		activeOrders:     make(map[string]*Order),
+		failedOrders:     make(map[string]*Order),
+		statusIndex:      newStatusIndex(),
+		queuedOrders:     make(map[string]struct{}),
+		recentOrders:     newRecentOrderIDs(recentOrdersLimit),
 		newOrdersChan:    make(chan *Order, 100),
 		updateOrdersChan: make(chan *Order, 100),
 		completedOrders:  make(chan *Order, 100),
 		stopChan:         make(chan struct{}),
+		running:          make(map[string]struct{}),
 	}
 }
 
@@ -148,43 +216,290 @@ func NewOrderManager(
 func (om *OrderManager) Start(ctx context.Context) error {
 	om.logger.Info("Starting order manager")
 
-	// Start order processing goroutines
-	om.wg.Add(4)
-	go om.processNewOrders(ctx)
-	go om.processOrderUpdates(ctx)
-	go om.monitorActiveOrders(ctx)
-	go om.updateDutchAuctionPrices(ctx)
+	if err := om.restoreOrders(); err != nil {
+		return err
+	}
+
+	// Start order processing goroutines. They run under a context Stop
+	// cancels, so RPCs they are blocked on are abandoned at shutdown
+	ctx, om.cancel = context.WithCancel(ctx)
+	om.spawn(ctx, "process_new_orders", om.processNewOrders)
+	om.spawn(ctx, "process_order_updates", om.processOrderUpdates)
+	om.spawn(ctx, "monitor_active_orders", om.monitorActiveOrders)
+	om.spawn(ctx, "update_dutch_auction_prices", om.updateDutchAuctionPrices)
 
 	return nil
 }
 
-// Stop stops the order manager
-func (om *OrderManager) Stop() error {
+// spawn runs a processing goroutine, tracking it by name until it returns
+func (om *OrderManager) spawn(ctx context.Context, name string, run func(context.Context)) {
+	om.runningMutex.Lock()
+	om.running[name] = struct{}{}
+	om.runningMutex.Unlock()
+
+	om.wg.Add(1)
+	go func() {
+		defer om.wg.Done()
+		defer func() {
+			om.runningMutex.Lock()
+			delete(om.running, name)
+			om.runningMutex.Unlock()
+		}()
+		run(ctx)
+	}()
+}
+
+// Stop stops the order manager, waiting for its goroutines until ctx is
+// done. Goroutines still running then, e.g. blocked on a call that ignores
+// cancellation, are logged and left behind
+func (om *OrderManager) Stop(ctx context.Context) error {
 	om.logger.Info("Stopping order manager")
 	
 	close(om.stopChan)
-	om.wg.Wait()
-	
+	if om.cancel != nil {
+		om.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		om.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		stuck := om.runningGoroutines()
+		om.logger.Error("Order manager goroutines did not exit before the shutdown deadline",
+			zap.Strings("goroutines", stuck))
+		return fmt.Errorf("failed to stop order manager, %s still running: %w", strings.Join(stuck, ", "), ctx.Err())
+	}
+
+	om.webhook.Close()
+	return nil
+}
+
+// runningGoroutines returns the names of the processing goroutines that
+// haven't returned, in sorted order
+func (om *OrderManager) runningGoroutines() []string {
+	om.runningMutex.Lock()
+	defer om.runningMutex.Unlock()
+
+	names := make([]string, 0, len(om.running))
+	for name := range om.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Drain waits until no order is mid-swap or mid-cancellation, re-queuing
+// in-flight orders so they keep progressing. It gives up when ctx is done and
+// returns the orders that were still in flight
+func (om *OrderManager) Drain(ctx context.Context) []*Order {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		inFlight := om.inFlightOrders()
+		if len(inFlight) == 0 {
+			return nil
+		}
+
+		for _, order := range inFlight {
+			select {
+			case om.updateOrdersChan <- order:
+			default:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return om.inFlightOrders()
+		case <-ticker.C:
+		}
+	}
+}
+
+// inFlightOrders returns orders with an on-chain withdrawal or cancellation
+// still pending
+func (om *OrderManager) inFlightOrders() []*Order {
+	om.ordersMutex.RLock()
+	defer om.ordersMutex.RUnlock()
+
+	var orders []*Order
+	for _, order := range om.activeOrders {
+		if order.Status == OrderStatusMatched ||
+			(order.Status == OrderStatusExpired && !isFinished(order)) {
+			orders = append(orders, order)
+		}
+	}
+
+	return orders
+}
+
+// FlushOrders saves unfinished orders, including any known secrets, to the
+// order store. It must only be called once the order manager is stopped
+func (om *OrderManager) FlushOrders() error {
+	path := om.config.Relayer.OrderStorePath
+	if path == "" {
+		return nil
+	}
+
+	om.ordersMutex.RLock()
+	orders := make([]*Order, 0, len(om.activeOrders)+len(om.failedOrders))
+	for _, order := range om.trackedOrders() {
+		if isFinished(order) {
+			continue
+		}
+		if order.Secret == "" {
+			if secret, ok := om.secretManager.GetSecret(order.SecretHash); ok {
+				order.Secret = secret
+			}
+		}
+		orders = append(orders, order)
+	}
+	om.ordersMutex.RUnlock()
+
+	if err := SaveOrders(path, orders); err != nil {
+		return err
+	}
+
+	om.logger.Info("Flushed unfinished orders", zap.Int("count", len(orders)), zap.String("path", path))
 	return nil
 }
 
-// AddOrder adds a new order to be processed
+// restoreOrders loads orders flushed by a previous run
+func (om *OrderManager) restoreOrders() error {
+	path := om.config.Relayer.OrderStorePath
+	if path == "" {
+		return nil
+	}
+
+	orders, err := LoadOrders(path)
+	if err != nil {
+		return err
+	}
+
+	om.ordersMutex.Lock()
+	for _, order := range orders {
+		// Pending orders recovered by reconcile have no destination escrow
+		// yet, so they go through new order handling again
+		if order.Status == OrderStatusPending {
+			om.enqueueOrder(order)
+			continue
+		}
+		om.trackOrder(order)
+	}
+	om.ordersMutex.Unlock()
+
+	if len(orders) > 0 {
+		om.logger.Info("Restored unfinished orders", zap.Int("count", len(orders)), zap.String("path", path))
+	}
+	return nil
+}
+
+// AddOrder adds a new order to be processed. It is idempotent: an order
+// whose ID is already queued, tracked or recently finished is dropped, so
+// rescanning a block never processes an escrow twice
 func (om *OrderManager) AddOrder(order *Order) {
+	om.ordersMutex.Lock()
+	defer om.ordersMutex.Unlock()
+
+	om.enqueueOrder(order)
+}
+
+// enqueueOrder queues an order for new order handling unless its ID has
+// been seen. The caller must hold ordersMutex
+func (om *OrderManager) enqueueOrder(order *Order) {
+	if reason := om.seenOrder(order.ID); reason != "" {
+		om.logger.Debug("Dropping duplicate order",
+			zap.String("order_id", order.ID),
+			zap.String("reason", reason))
+		return
+	}
+
 	select {
 	case om.newOrdersChan <- order:
+		om.queuedOrders[order.ID] = struct{}{}
 		om.logger.Info("New order added", zap.String("order_id", order.ID))
 	default:
 		om.logger.Warn("New orders channel is full, dropping order", zap.String("order_id", order.ID))
 	}
 }
 
-// GetOrder retrieves an order by ID
+// seenOrder returns why an order ID was already seen, or "" if it is new.
+// The caller must hold ordersMutex
+func (om *OrderManager) seenOrder(id string) string {
+	if _, queued := om.queuedOrders[id]; queued {
+		return "already queued"
+	}
+	if _, tracked := om.trackedOrder(id); tracked {
+		return "already tracked"
+	}
+	if om.recentOrders.contains(id) {
+		return "recently finished"
+	}
+	return ""
+}
+
+// QuarantineOrder tracks an order the relayer can't process as failed, so it
+// is reported but never acted on. It reports whether the order was new; one
+// already being tracked is left alone
+func (om *OrderManager) QuarantineOrder(order *Order, reason string) bool {
+	om.ordersMutex.Lock()
+	defer om.ordersMutex.Unlock()
+
+	if _, exists := om.trackedOrder(order.ID); exists {
+		return false
+	}
+	om.failOrder(order, reason)
+	om.trackOrder(order)
+	return true
+}
+
+// failOrder marks an order failed, recording the reason as its last error.
+// The caller must hold ordersMutex
+func (om *OrderManager) failOrder(order *Order, reason string) {
+	order.LastError = reason
+	om.setStatus(order, OrderStatusFailed, reason, "")
+}
+
+// SetPendingFill sets the amount the next withdrawal of a partially fillable
+// order takes
+func (om *OrderManager) SetPendingFill(order *Order, amount *big.Int) {
+	om.ordersMutex.Lock()
+	defer om.ordersMutex.Unlock()
+
+	if order.PartialFill != nil {
+		order.PartialFill.PendingFillAmount = amount
+	}
+}
+
+// AssignSecret generates a secret for a maker-initiated order and sets the
+// order's hashlock to match it
+func (om *OrderManager) AssignSecret(order *Order) error {
+	record, err := om.secretManager.GenerateSecret(order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to assign secret: %w", err)
+	}
+
+	order.SecretHash = "0x" + record.HashLock
+	return nil
+}
+
+// GetOrder retrieves a tracked order, active or failed, by ID
 func (om *OrderManager) GetOrder(orderID string) (*Order, bool) {
 	om.ordersMutex.RLock()
 	defer om.ordersMutex.RUnlock()
 	
-	order, exists := om.activeOrders[orderID]
-	return order, exists
+	return om.trackedOrder(orderID)
+}
+
+// GetOrdersByStatus returns the tracked orders with the given status without
+// scanning the others
+func (om *OrderManager) GetOrdersByStatus(status OrderStatus) []*Order {
+	return om.statusIndex.withStatus(status)
 }
 
 // GetActiveOrders returns all active orders
@@ -202,8 +517,6 @@ func (om *OrderManager) GetActiveOrders() []*Order {
 
 // processNewOrders processes new orders
 func (om *OrderManager) processNewOrders(ctx context.Context) {
-	defer om.wg.Done()
-	
 	for {
 		select {
 		case <-ctx.Done():
@@ -211,16 +524,27 @@ func (om *OrderManager) processNewOrders(ctx context.Context) {
 		case <-om.stopChan:
 			return
 		case order := <-om.newOrdersChan:
-			if err := om.handleNewOrder(ctx, order); err != nil {
+			om.ordersMutex.RLock()
+			_, queued := om.queuedOrders[order.ID]
+			om.ordersMutex.RUnlock()
+			if !queued {
+				om.logger.Info("Dropping order cancelled while queued", zap.String("order_id", order.ID))
+				continue
+			}
+
+			err := om.handleNewOrder(ctx, order)
+			if err != nil {
 				om.logger.Error("Failed to handle new order",
 					zap.String("order_id", order.ID),
 					zap.Error(err))
-				order.Status = OrderStatusFailed
-				order.LastError = err.Error()
 			}
 			
 			om.ordersMutex.Lock()
-			om.activeOrders[order.ID] = order
+			if err != nil {
+				om.failOrder(order, err.Error())
+			}
+			delete(om.queuedOrders, order.ID)
+			om.trackOrder(order)
 			om.ordersMutex.Unlock()
 		}
 	}
@@ -228,8 +552,6 @@ func (om *OrderManager) processNewOrders(ctx context.Context) {
 
 // processOrderUpdates processes order updates
 func (om *OrderManager) processOrderUpdates(ctx context.Context) {
-	defer om.wg.Done()
-	
 	for {
 		select {
 		case <-ctx.Done():
@@ -237,38 +559,116 @@ func (om *OrderManager) processOrderUpdates(ctx context.Context) {
 		case <-om.stopChan:
 			return
 		case order := <-om.updateOrdersChan:
-			if err := om.handleOrderUpdate(ctx, order); err != nil {
+			err := om.handleOrderUpdate(ctx, order)
+			om.recordUpdateAttempt(order, err)
+			if err != nil {
 				om.logger.Error("Failed to handle order update",
 					zap.String("order_id", order.ID),
 					zap.Error(err))
-				order.RetryCount++
-				order.LastError = err.Error()
+
+				if !shouldRetry(order, err) {
+					om.logger.Error("Order failed permanently",
+						zap.String("order_id", order.ID),
+						zap.Error(err))
+					om.SetStatus(order, OrderStatusFailed, err.Error(), "")
+				}
 			}
 			
-			order.UpdatedAt = time.Now()
+			// Give up on cancelling after too many attempts so the order is
+			// left for manual recovery instead of being retried forever
+			if (order.Status == OrderStatusExpired || order.Status == OrderStatusCancelling) &&
+				order.CancelTxHash == "" && order.RetryCount >= om.config.Relayer.MaxRetries {
+				om.logger.Error("Giving up on cancelling expired order",
+					zap.String("order_id", order.ID),
+					zap.Int("retry_count", order.RetryCount))
+				om.SetStatus(order, OrderStatusFailed, "gave up cancelling after max retries", "")
+			}
 			
-			// Remove completed or failed orders
-			if order.Status == OrderStatusCompleted || 
-			   order.Status == OrderStatusCancelled || 
-			   order.Status == OrderStatusExpired {
+			// Retire finished orders, and set failed ones aside
+			if isFinished(order) || order.Status == OrderStatusFailed {
 				om.ordersMutex.Lock()
-				delete(om.activeOrders, order.ID)
-				om.ordersMutex.Unlock()
-				
-				select {
-				case om.completedOrders <- order:
-				default:
-					om.logger.Warn("Completed orders channel is full")
+				if isFinished(order) {
+					om.retireOrder(order)
+				} else {
+					om.setAsideFailedOrder(order)
 				}
+				om.ordersMutex.Unlock()
 			}
 		}
 	}
 }
 
+// recordUpdateAttempt stamps an order after an update attempt, counting a
+// failed one against its retries
+func (om *OrderManager) recordUpdateAttempt(order *Order, err error) {
+	om.ordersMutex.Lock()
+	defer om.ordersMutex.Unlock()
+
+	if err != nil {
+		order.RetryCount++
+		order.LastError = err.Error()
+	}
+	order.UpdatedAt = time.Now()
+}
+
+// trackOrder starts tracking an order, among the failed orders if it failed.
+// The caller must hold ordersMutex
+func (om *OrderManager) trackOrder(order *Order) {
+	if order.Status == OrderStatusFailed {
+		om.failedOrders[order.ID] = order
+	} else {
+		om.activeOrders[order.ID] = order
+	}
+	om.statusIndex.add(order)
+}
+
+// setAsideFailedOrder moves an order that failed from the active orders to
+// the failed orders. The caller must hold ordersMutex
+func (om *OrderManager) setAsideFailedOrder(order *Order) {
+	delete(om.activeOrders, order.ID)
+	om.failedOrders[order.ID] = order
+}
+
+// trackedOrder looks up an active or failed order. The caller must hold
+// ordersMutex
+func (om *OrderManager) trackedOrder(id string) (*Order, bool) {
+	if order, active := om.activeOrders[id]; active {
+		return order, true
+	}
+	order, failed := om.failedOrders[id]
+	return order, failed
+}
+
+// trackedOrders returns the active and failed orders. The caller must hold
+// ordersMutex
+func (om *OrderManager) trackedOrders() []*Order {
+	orders := make([]*Order, 0, len(om.activeOrders)+len(om.failedOrders))
+	for _, order := range om.activeOrders {
+		orders = append(orders, order)
+	}
+	for _, order := range om.failedOrders {
+		orders = append(orders, order)
+	}
+	return orders
+}
+
+// retireOrder stops tracking a finished order and hands it to the completed
+// orders consumers. The caller must hold ordersMutex
+func (om *OrderManager) retireOrder(order *Order) {
+	delete(om.activeOrders, order.ID)
+	delete(om.failedOrders, order.ID)
+	om.statusIndex.remove(order.ID)
+	om.recentOrders.add(order.ID)
+
+	select {
+	case om.completedOrders <- order:
+	default:
+		om.logger.Warn("Completed orders channel is full")
+	}
+}
+
 // monitorActiveOrders monitors active orders for timeouts and updates
 func (om *OrderManager) monitorActiveOrders(ctx context.Context) {
-	defer om.wg.Done()
-	
 	ticker := time.NewTicker(om.config.Relayer.OrderUpdateInterval)
 	defer ticker.Stop()
 	
@@ -280,6 +680,7 @@ func (om *OrderManager) monitorActiveOrders(ctx context.Context) {
 			return
 		case <-ticker.C:
 			om.checkOrderTimeouts()
+			om.queueOrderUpdates()
 			om.syncOrderStates(ctx)
 		}
 	}
@@ -287,8 +688,6 @@ func (om *OrderManager) monitorActiveOrders(ctx context.Context) {
 
 // updateDutchAuctionPrices updates prices for Dutch auction orders
 func (om *OrderManager) updateDutchAuctionPrices(ctx context.Context) {
-	defer om.wg.Done()
-	
 	ticker := time.NewTicker(om.config.DutchAuction.PriceUpdateInterval)
 	defer ticker.Stop()
 	
@@ -310,6 +709,28 @@ func (om *OrderManager) handleNewOrder(ctx context.Context, order *Order) error
 		zap.String("order_id", order.ID),
 		zap.String("type", string(order.Type)))
 
+	margin := om.config.Relayer.TimelockSafetyMargin
+	if order.DestTimelock == 0 && order.Timelock > uint64(margin.Seconds()) {
+		// Derive the destination timelock when the order doesn't carry one
+		order.DestTimelock = order.Timelock - uint64(margin.Seconds())
+	}
+	// The maker can cancel the source escrow from its cancellation stage,
+	// which may come before the order's timelock
+	if err := validateTimelocks(sourceCancellation(order), order.DestTimelock, margin); err != nil {
+		return err
+	}
+	if err := validateRelayerFee(order.SourceAsset.Amount, om.config.Relayer.RelayerFeePercentage); err != nil {
+		return err
+	}
+
+	// Maker-initiated orders arrive without a hashlock; the relayer generates
+	// their secret
+	if order.SecretHash == "" {
+		if err := om.AssignSecret(order); err != nil {
+			return err
+		}
+	}
+
 	switch order.Type {
 	case OrderTypeCronosToEthereum:
 		return om.handleCronosToEthereumOrder(ctx, order)
@@ -320,14 +741,40 @@ func (om *OrderManager) handleNewOrder(ctx context.Context, order *Order) error
 	}
 }
 
+// validateTimelocks ensures the destination escrow expires at least margin
+// before the source escrow can be cancelled, so the maker can't refund the
+// source leg while the taker's destination funds are still claimable
+func validateTimelocks(srcCancellation, destTimelock uint64, margin time.Duration) error {
+	if destTimelock == 0 {
+		return fmt.Errorf("destination timelock is not set (source cancellation %d, margin %s)", srcCancellation, margin)
+	}
+	if destTimelock+uint64(margin.Seconds()) > srcCancellation {
+		return fmt.Errorf("destination timelock %d plus safety margin %s exceeds source cancellation %d",
+			destTimelock, margin, srcCancellation)
+	}
+	return nil
+}
+
 // handleCronosToEthereumOrder handles an order from Cronos to Ethereum
 func (om *OrderManager) handleCronosToEthereumOrder(ctx context.Context, order *Order) error {
+	// The escrow is created after the current block, so its withdrawals are
+	// looked up from there
+	startBlock, err := om.ethereumClient.GetLatestBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest Ethereum block: %w", err)
+	}
+
 	// Create destination escrow on Ethereum
+	deployedAt := uint64(time.Now().Unix())
+	immutables, err := om.destinationImmutables(order, deployedAt)
+	if err != nil {
+		return fmt.Errorf("failed to build destination immutables: %w", err)
+	}
+
 	params := ethereum_client.CreateDestEscrowParams{
-		// TODO: Fill in the actual parameters
-		DstImmutables:            nil,
+		DstImmutables:            immutables,
 		SrcCancellationTimestamp: big.NewInt(int64(order.Timelock)),
-		Value:                    big.NewInt(0),
+		Value:                    escrowValue(immutables),
 	}
 	
 	txHash, err := om.ethereumClient.CreateDestinationEscrow(
@@ -340,7 +787,9 @@ func (om *OrderManager) handleCronosToEthereumOrder(ctx context.Context, order *
 	}
 	
 	order.DestTxHash = txHash
-	order.Status = OrderStatusActive
+	order.DestDeployedAt = deployedAt
+	order.DestBlock = startBlock
+	om.SetStatus(order, OrderStatusActive, "destination escrow created on Ethereum", txHash)
 	
 	om.logger.Info("Created destination escrow on Ethereum",
 		zap.String("order_id", order.ID),
@@ -356,7 +805,7 @@ func (om *OrderManager) handleEthereumToCronosOrder(ctx context.Context, order *
 		Taker:             order.Taker,
 		Maker:             order.Maker,
 		SecretHash:        order.SecretHash,
-		Timelock:          order.Timelock,
+		Timelock:          order.DestTimelock,
 		SrcChainID:        order.SourceChain,
 		SrcEscrowAddress:  order.SourceEscrowAddr,
 		ExpectedAmount:    order.DestinationAsset.Amount.String(),
@@ -373,7 +822,7 @@ func (om *OrderManager) handleEthereumToCronosOrder(ctx context.Context, order *
 	}
 	
 	order.DestTxHash = txHash
-	order.Status = OrderStatusActive
+	om.SetStatus(order, OrderStatusActive, "destination escrow created on Cronos", txHash)
 	
 	om.logger.Info("Created destination escrow on Cronos",
 		zap.String("order_id", order.ID),
@@ -389,20 +838,142 @@ func (om *OrderManager) handleOrderUpdate(ctx context.Context, order *Order) err
 		return om.executeSwap(ctx, order)
 	case OrderStatusActive:
 		return om.checkForMatches(ctx, order)
+	case OrderStatusExpired:
+		return om.cancelExpiredOrder(ctx, order)
+	case OrderStatusCancelling:
+		return om.confirmCancellation(ctx, order)
 	default:
 		return nil
 	}
 }
 
+// shouldRetry reports whether an order update that failed with err should be
+// retried. Only transient errors are, with three exceptions: cancellations
+// are rejected until the timelock passes, an order whose destination escrow
+// is deployed must stay open so the escrow is cancelled once it expires, and
+// a swap is held until its destination escrow is funded
+func shouldRetry(order *Order, err error) bool {
+	if chain_errors.IsTransient(err) || errors.Is(err, ErrDestinationUnderfunded) {
+		return true
+	}
+	return order.Status == OrderStatusExpired || order.DestEscrowAddr != ""
+}
+
+// isFinished reports whether an order needs no further processing. Expired
+// orders holding a relayer escrow go on to be cancelled, and are finished
+// once the cancellation is confirmed
+func isFinished(order *Order) bool {
+	switch order.Status {
+	case OrderStatusCompleted, OrderStatusCancelled:
+		return true
+	case OrderStatusExpired:
+		return order.DestEscrowAddr == ""
+	default:
+		return false
+	}
+}
+
+// cancelExpiredOrder reclaims the funds the relayer locked in the destination
+// escrow, leaving the order cancelling until the cancel transaction is
+// confirmed. The escrow contracts reject cancellation until their timelock
+// has passed, so failures are retried on later timeout checks
+func (om *OrderManager) cancelExpiredOrder(ctx context.Context, order *Order) error {
+	if order.DestEscrowAddr == "" || order.CancelTxHash != "" {
+		return nil
+	}
+
+	var txHash string
+	var err error
+
+	if order.Type == OrderTypeCronosToEthereum {
+		immutables, buildErr := om.destinationImmutables(order, order.DestDeployedAt)
+		if buildErr != nil {
+			return fmt.Errorf("failed to build destination immutables: %w", buildErr)
+		}
+		txHash, err = om.ethereumClient.CancelEscrow(
+			ctx,
+			om.config.Contracts.Ethereum.Resolver,
+			order.DestEscrowAddr,
+			immutables,
+		)
+	} else {
+		txHash, err = om.cronosClient.CancelEscrow(ctx, order.DestEscrowAddr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to cancel destination escrow: %w", err)
+	}
+
+	order.CancelTxHash = txHash
+	om.SetStatus(order, OrderStatusCancelling, "destination escrow cancel broadcast", txHash)
+
+	om.logger.Info("Cancelling order",
+		zap.String("order_id", order.ID),
+		zap.String("escrow", order.DestEscrowAddr),
+		zap.String("tx_hash", txHash))
+
+	return nil
+}
+
+// confirmCancellation checks the cancel transaction of a cancelling order,
+// cancelling the order once it succeeded. A failed transaction is cleared so
+// the escrow cancel is broadcast again on the next update
+func (om *OrderManager) confirmCancellation(ctx context.Context, order *Order) error {
+	if order.CancelTxHash == "" {
+		return om.cancelExpiredOrder(ctx, order)
+	}
+
+	var confirmed bool
+	var err error
+	if order.Type == OrderTypeCronosToEthereum {
+		confirmed, err = om.ethereumClient.CheckTransaction(ctx, order.CancelTxHash)
+	} else {
+		confirmed, err = om.cronosClient.CheckTransaction(ctx, order.CancelTxHash)
+	}
+
+	if errors.Is(err, chain_errors.ErrReverted) {
+		txHash := order.CancelTxHash
+		order.CancelTxHash = ""
+		return fmt.Errorf("cancel transaction %s failed: %w", txHash, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check cancel transaction: %w", err)
+	}
+	if !confirmed {
+		return nil
+	}
+
+	om.SetStatus(order, OrderStatusCancelled, "destination escrow cancel confirmed", order.CancelTxHash)
+	om.secretManager.Forget(order.SecretHash)
+
+	om.logger.Info("Cancelled order",
+		zap.String("order_id", order.ID),
+		zap.String("escrow", order.DestEscrowAddr),
+		zap.String("tx_hash", order.CancelTxHash))
+
+	return nil
+}
+
 // executeSwap executes the atomic swap
 func (om *OrderManager) executeSwap(ctx context.Context, order *Order) error {
 	om.logger.Info("Executing swap", zap.String("order_id", order.ID))
 	
 	// Reveal secret and complete the swap
+	if order.Secret == "" {
+		if secret, ok := om.secretManager.GetSecret(order.SecretHash); ok {
+			order.Secret = secret
+		}
+	}
 	if order.Secret == "" {
 		return fmt.Errorf("secret not available for order %s", order.ID)
 	}
 	
+	// Withdrawing reveals the secret, which releases the destination escrow
+	// to the maker, so make sure it holds what the maker was promised
+	if err := om.verifyDestinationFunded(ctx, order); err != nil {
+		return err
+	}
+	
 	// Withdraw from source escrow
 	var sourceWithdrawTx string
 	var err error
@@ -410,56 +981,96 @@ func (om *OrderManager) executeSwap(ctx context.Context, order *Order) error {
 	if order.Type == OrderTypeCronosToEthereum {
 		// Withdraw from Cronos source escrow
 		if order.PartialFill != nil && order.PartialFill.AllowPartialFill {
-			sourceWithdrawTx, err = om.cronosClient.PartialWithdrawFromEscrow(
-				ctx,
-				order.SourceEscrowAddr,
-				order.Secret,
-				order.PartialFill.FilledAmount.String(),
-			)
-		} else {
-			sourceWithdrawTx, err = om.cronosClient.WithdrawFromEscrow(
-				ctx,
-				order.SourceEscrowAddr,
-				order.Secret,
-			)
+			return om.executePartialFill(ctx, order)
 		}
+		sourceWithdrawTx, err = om.cronosClient.WithdrawFromEscrow(
+			ctx,
+			order.SourceEscrowAddr,
+			order.Secret,
+		)
 	} else {
 		// Withdraw from Ethereum source escrow
+		immutables, buildErr := om.sourceImmutables(order)
+		if buildErr != nil {
+			return fmt.Errorf("failed to build source immutables: %w", buildErr)
+		}
 		sourceWithdrawTx, err = om.ethereumClient.WithdrawFromEscrow(
 			ctx,
 			om.config.Contracts.Ethereum.Resolver,
 			order.SourceEscrowAddr,
 			order.Secret,
-			nil, // TODO: Pass proper immutables
+			immutables,
 		)
 	}
 	
 	if err != nil {
+		if om.completeIfSpent(ctx, order, err) {
+			return nil
+		}
 		return fmt.Errorf("failed to withdraw from source escrow: %w", err)
 	}
 	
 	order.SourceTxHash = sourceWithdrawTx
-	order.Status = OrderStatusCompleted
+	fee := om.recordRelayerFee(order, order.SourceAsset.Amount)
+	om.SetStatus(order, OrderStatusCompleted, "source escrow withdrawn", sourceWithdrawTx)
+	om.secretManager.Forget(order.SecretHash)
 	
 	om.logger.Info("Swap completed successfully",
 		zap.String("order_id", order.ID),
-		zap.String("source_tx", sourceWithdrawTx))
+		zap.String("source_tx", sourceWithdrawTx),
+		zap.String("relayer_fee", fee.String()))
 	
 	return nil
 }
 
-// checkForMatches checks if an order can be matched
+// checkForMatches checks if an order can be matched, queuing it for
+// execution once its secret is known
 func (om *OrderManager) checkForMatches(ctx context.Context, order *Order) error {
-	// This is a simplified implementation
-	// In practice, you would implement sophisticated matching logic
-	
-	// For now, just check if the order has been filled on the destination
-	// This would involve querying the destination escrow contract
-	
+	// Later fills of a partially filled order only come from the order book
+	if isPartiallyFilled(order) {
+		return nil
+	}
+
+	// An order is ready to execute once its secret is known, either because
+	// the relayer generated it or because a counterparty revealed it on-chain
+	if om.secretManager.IsRevealed(order.SecretHash) {
+		om.SetStatus(order, OrderStatusMatched, "secret known", "")
+		om.QueueOrder(order)
+		return nil
+	}
+
+	// Secrets are revealed on Ethereum when the destination escrow is withdrawn
+	if order.Type != OrderTypeCronosToEthereum || order.DestEscrowAddr == "" {
+		return nil
+	}
+
+	secrets, err := om.ethereumClient.GetRevealedSecrets(ctx, order.DestEscrowAddr, order.DestBlock)
+	if err != nil {
+		return fmt.Errorf("failed to get revealed secrets: %w", err)
+	}
+
+	for _, revealed := range secrets {
+		if err := om.secretManager.RecordRevealedSecret(order.SecretHash, revealed.Secret, revealed.TxHash); err != nil {
+			om.logger.Debug("Ignoring non-matching revealed secret",
+				zap.String("order_id", order.ID),
+				zap.String("tx_hash", revealed.TxHash),
+				zap.Error(err))
+			continue
+		}
+
+		om.SetStatus(order, OrderStatusMatched, "secret revealed on Ethereum", revealed.TxHash)
+		om.QueueOrder(order)
+		om.logger.Info("Detected revealed secret",
+			zap.String("order_id", order.ID),
+			zap.String("tx_hash", revealed.TxHash))
+		break
+	}
+
 	return nil
 }
 
-// checkOrderTimeouts checks for expired orders
+// checkOrderTimeouts checks for expired orders and orders that went
+// unmatched for longer than the maximum order lifetime
 func (om *OrderManager) checkOrderTimeouts() {
 	now := time.Now()
 	
@@ -467,13 +1078,96 @@ func (om *OrderManager) checkOrderTimeouts() {
 	defer om.ordersMutex.Unlock()
 	
 	for _, order := range om.activeOrders {
-		if now.After(order.ExpiresAt) {
-			order.Status = OrderStatusExpired
+		switch order.Status {
+		case OrderStatusCompleted, OrderStatusCancelled, OrderStatusFailed:
+			continue
+		case OrderStatusCancelling:
+			// Check on the cancel transaction, broadcasting it again if it
+			// failed
+			om.queueCancellation(order)
+			continue
+		}
+
+		timedOut := now.After(order.ExpiresAt)
+		if !timedOut && order.Status != OrderStatusExpired {
+			if !exceededLifetime(order, om.config.Relayer.MaxOrderLifetime, now) {
+				continue
+			}
+			om.setStatus(order, OrderStatusExpired, "order exceeded maximum lifetime", "")
+			om.logger.Info("Order exceeded maximum lifetime",
+				zap.String("order_id", order.ID),
+				zap.Time("created_at", order.CreatedAt))
+		}
+
+		if order.Status != OrderStatusExpired {
+			om.setStatus(order, OrderStatusExpired, "order expired", "")
 			om.logger.Info("Order expired", zap.String("order_id", order.ID))
 		}
+
+		// The escrow contracts reject cancellation until the destination
+		// timelock, so an order expired early for its lifetime waits for it
+		// rather than using up its retries
+		if !timedOut && order.DestEscrowAddr != "" && now.Unix() < int64(order.DestTimelock) {
+			continue
+		}
+
+		// Queue the order so its escrow gets cancelled; orders whose cancel
+		// failed are re-queued on every check until it succeeds
+		om.queueCancellation(order)
 	}
 }
 
+// queueCancellation hands an order to processOrderUpdates to cancel its
+// escrow or confirm the cancellation, deferring it to the next check when
+// the channel is full
+func (om *OrderManager) queueCancellation(order *Order) {
+	select {
+	case om.updateOrdersChan <- order:
+	default:
+		om.logger.Warn("Update orders channel is full, deferring cancellation",
+			zap.String("order_id", order.ID))
+	}
+}
+
+// queueOrderUpdates hands the active orders to processOrderUpdates to look
+// for their secret revealed on-chain, and the matched ones to retry their
+// swap
+func (om *OrderManager) queueOrderUpdates() {
+	for _, status := range []OrderStatus{OrderStatusActive, OrderStatusMatched} {
+		for _, order := range om.GetOrdersByStatus(status) {
+			om.QueueOrder(order)
+		}
+	}
+}
+
+// QueueOrder hands a matched order to processOrderUpdates to execute its
+// swap. An order dropped because the channel is full is queued again by the
+// next active order check
+func (om *OrderManager) QueueOrder(order *Order) {
+	select {
+	case om.updateOrdersChan <- order:
+	default:
+		om.logger.Warn("Update orders channel is full, dropping order update",
+			zap.String("order_id", order.ID))
+	}
+}
+
+// exceededLifetime reports whether order has gone unmatched for longer than
+// maxLifetime since it was created. Orders being executed or partially
+// filled are left to finish, and a zero maxLifetime disables the limit
+func exceededLifetime(order *Order, maxLifetime time.Duration, now time.Time) bool {
+	if maxLifetime <= 0 || order.CreatedAt.IsZero() {
+		return false
+	}
+	if order.Status != OrderStatusPending && order.Status != OrderStatusActive {
+		return false
+	}
+	if isPartiallyFilled(order) {
+		return false
+	}
+	return now.Sub(order.CreatedAt) > maxLifetime
+}
+
 // syncOrderStates synchronizes order states with the blockchain
 func (om *OrderManager) syncOrderStates(ctx context.Context) {
 	om.ordersMutex.RLock()
@@ -492,11 +1186,37 @@ func (om *OrderManager) syncOrderStates(ctx context.Context) {
 	}
 }
 
-// syncOrderState synchronizes a single order's state
+// syncOrderState reconciles an order with the status of its Cronos source
+// escrow, so an escrow withdrawn or cancelled outside the relayer isn't acted
+// on again
 func (om *OrderManager) syncOrderState(ctx context.Context, order *Order) error {
-	// Query the blockchain to get the current state of the order
-	// This is a simplified implementation
-	
+	// The update loop may be moving the order on concurrently
+	om.ordersMutex.RLock()
+	finished := isFinished(order)
+	om.ordersMutex.RUnlock()
+
+	if order.Type != OrderTypeCronosToEthereum || order.SourceEscrowAddr == "" || finished {
+		return nil
+	}
+
+	status, err := om.cronosClient.GetEscrowStatus(ctx, order.SourceEscrowAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get source escrow status: %w", err)
+	}
+
+	switch OrderStatus(status) {
+	case OrderStatusCompleted:
+		om.SetStatus(order, OrderStatusCompleted, "source escrow withdrawn on chain", "")
+		om.secretManager.Forget(order.SecretHash)
+	case OrderStatusCancelled:
+		om.SetStatus(order, OrderStatusCancelled, "source escrow cancelled on chain", "")
+	default:
+		return nil
+	}
+
+	om.logger.Info("Order reconciled with source escrow",
+		zap.String("order_id", order.ID),
+		zap.String("escrow_status", status))
 	return nil
 }
 
@@ -531,6 +1251,10 @@ func (om *OrderManager) calculateDutchAuctionPrice(params *DutchAuctionParams, c
 		return params.MinimumPrice
 	}
 	
+	if params.CurveType == config.AuctionCurveExponential {
+		return exponentialAuctionPrice(params, elapsed)
+	}
+
 	// Calculate price decay: price = initialPrice - (decayRate * elapsed_seconds)
 	elapsedSeconds := big.NewInt(int64(elapsed.Seconds()))
 	decay := new(big.Int).Mul(params.DecayRate, elapsedSeconds)
@@ -553,12 +1277,13 @@ func (om *OrderManager) GetOrderStats() map[string]interface{} {
 	statusCounts := make(map[OrderStatus]int)
 	typeCounts := make(map[OrderType]int)
 	
-	for _, order := range om.activeOrders {
+	for _, order := range om.trackedOrders() {
 		statusCounts[order.Status]++
 		typeCounts[order.Type]++
 	}
 	
 	stats["total_active_orders"] = len(om.activeOrders)
+	stats["total_failed_orders"] = len(om.failedOrders)
 	stats["status_counts"] = statusCounts
 	stats["type_counts"] = typeCounts
 	
@@ -0,0 +1,728 @@
+package order_manager
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/ethereum_client"
+	"go.uber.org/zap"
+)
+
+// slowCronosClient blocks withdrawals until release is closed
+type slowCronosClient struct {
+	release chan struct{}
+}
+
+func (c *slowCronosClient) CreateDestinationEscrow(ctx context.Context, factoryAddr string, params cronos_client.CreateDestEscrowParams) (string, error) {
+	return "0xcreate", nil
+}
+
+func (c *slowCronosClient) WithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string) (string, error) {
+	<-c.release
+	return "0xwithdraw", nil
+}
+
+func (c *slowCronosClient) PartialWithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string, amount string) (string, error) {
+	<-c.release
+	return "0xpartial", nil
+}
+
+func (c *slowCronosClient) CancelEscrow(ctx context.Context, escrowAddr string) (string, error) {
+	return "0xcancel", nil
+}
+
+func (c *slowCronosClient) GetEscrowStatus(ctx context.Context, escrowAddr string) (string, error) {
+	return "active", nil
+}
+
+func (c *slowCronosClient) SimulateFill(ctx context.Context, escrowAddr string, inputAmount string) (string, error) {
+	return inputAmount, nil
+}
+
+func (c *slowCronosClient) GetEscrowDeposit(ctx context.Context, escrowAddr string) (*big.Int, error) {
+	return big.NewInt(1000), nil
+}
+
+func (c *slowCronosClient) CheckTransaction(ctx context.Context, txHash string) (bool, error) {
+	return true, nil
+}
+
+// fundedEthereumClient reports deposit as the amount in every escrow,
+// 1000 when nil, and records the blocks withdrawals are looked up from
+type fundedEthereumClient struct {
+	deposit     *big.Int
+	revealed    []ethereum_client.RevealedSecret
+	fromBlocks  []uint64
+	latestBlock uint64
+}
+
+func (c *fundedEthereumClient) GetLatestBlock(ctx context.Context) (uint64, error) {
+	return c.latestBlock, nil
+}
+
+func (c *fundedEthereumClient) CreateDestinationEscrow(ctx context.Context, resolverAddr string, params ethereum_client.CreateDestEscrowParams) (string, error) {
+	return "0xcreate", nil
+}
+
+func (c *fundedEthereumClient) WithdrawFromEscrow(ctx context.Context, resolverAddr string, escrowAddr string, secret string, immutables ethereum_client.Immutables) (string, error) {
+	return "0xwithdraw", nil
+}
+
+func (c *fundedEthereumClient) CancelEscrow(ctx context.Context, resolverAddr string, escrowAddr string, immutables ethereum_client.Immutables) (string, error) {
+	return "0xcancel", nil
+}
+
+func (c *fundedEthereumClient) GetRevealedSecrets(ctx context.Context, escrowAddr string, fromBlock uint64) ([]ethereum_client.RevealedSecret, error) {
+	c.fromBlocks = append(c.fromBlocks, fromBlock)
+	return c.revealed, nil
+}
+
+func (c *fundedEthereumClient) GetEscrowDeposit(ctx context.Context, escrowAddr string) (*big.Int, error) {
+	if c.deposit == nil {
+		return big.NewInt(1000), nil
+	}
+	return c.deposit, nil
+}
+
+func (c *fundedEthereumClient) CheckTransaction(ctx context.Context, txHash string) (bool, error) {
+	return true, nil
+}
+
+func newTestOrderManager(t *testing.T, cronosClient CronosClient) *OrderManager {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Relayer.OrderUpdateInterval = time.Hour
+	cfg.Relayer.OrderStorePath = filepath.Join(t.TempDir(), "orders.json")
+	cfg.DutchAuction.PriceUpdateInterval = time.Hour
+
+	return NewOrderManager(cfg, cronosClient, &fundedEthereumClient{}, zap.NewNop())
+}
+
+func newMatchedOrder(id string) *Order {
+	return &Order{
+		ID:               id,
+		Type:             OrderTypeCronosToEthereum,
+		Status:           OrderStatusMatched,
+		Secret:           "secret",
+		SourceEscrowAddr: "crc1escrow",
+		DestEscrowAddr:   "0xdest",
+		DestinationAsset: AssetInfo{Symbol: "ETH", Amount: big.NewInt(1000), Decimals: 18},
+		ExpiresAt:        time.Now().Add(time.Hour),
+	}
+}
+
+func TestValidateTimelocks(t *testing.T) {
+	margin := time.Hour
+
+	for _, tc := range []struct {
+		name         string
+		srcTimelock  uint64
+		destTimelock uint64
+		valid        bool
+	}{
+		{name: "margin exactly met", srcTimelock: 10000, destTimelock: 6400, valid: true},
+		{name: "margin exceeded", srcTimelock: 10000, destTimelock: 5000, valid: true},
+		{name: "margin violated by one second", srcTimelock: 10000, destTimelock: 6401, valid: false},
+		{name: "destination after source", srcTimelock: 10000, destTimelock: 20000, valid: false},
+		{name: "destination not set", srcTimelock: 10000, destTimelock: 0, valid: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTimelocks(tc.srcTimelock, tc.destTimelock, margin)
+			if tc.valid && err != nil {
+				t.Fatalf("expected valid timelocks, got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatal("expected timelock validation error")
+			}
+		})
+	}
+}
+
+func TestHandleNewOrderValidatesSourceCancellation(t *testing.T) {
+	om := newTestOrderManager(t, &recordingCronosClient{})
+	om.config.Relayer.TimelockSafetyMargin = time.Hour
+
+	// The source escrow becomes cancellable an hour before the order's
+	// timelock, so the derived destination timelock leaves no margin
+	now := time.Now()
+	order := newMatchedOrder("order-1")
+	order.Type = OrderTypeEthereumToCronos
+	order.Status = OrderStatusPending
+	order.Timelock = uint64(now.Add(2 * time.Hour).Unix())
+	order.SourceImmutables = &ethereum_client.Immutables{
+		Timelocks: ethereum_client.Timelocks{DeployedAt: uint32(now.Unix()), SrcCancellation: 3600},
+	}
+
+	err := om.handleNewOrder(context.Background(), order)
+	if err == nil || !strings.Contains(err.Error(), "exceeds source cancellation") {
+		t.Fatalf("expected the destination timelock to be rejected, got %v", err)
+	}
+
+	if got, want := sourceCancellation(order), uint64(now.Add(time.Hour).Unix()); got != want {
+		t.Fatalf("expected source cancellation %d, got %d", want, got)
+	}
+	order.SourceImmutables = nil
+	if got := sourceCancellation(order); got != order.Timelock {
+		t.Fatalf("expected the order timelock without immutables, got %d", got)
+	}
+}
+
+func TestIsFinished(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		order    Order
+		finished bool
+	}{
+		{name: "completed", order: Order{Status: OrderStatusCompleted}, finished: true},
+		{name: "active", order: Order{Status: OrderStatusActive}, finished: false},
+		{name: "expired without escrow", order: Order{Status: OrderStatusExpired}, finished: true},
+		{name: "expired awaiting cancel", order: Order{Status: OrderStatusExpired, DestEscrowAddr: "0xescrow"}, finished: false},
+		{name: "cancel unconfirmed", order: Order{Status: OrderStatusCancelling, DestEscrowAddr: "0xescrow", CancelTxHash: "0xtx"}, finished: false},
+		{name: "cancel confirmed", order: Order{Status: OrderStatusCancelled, DestEscrowAddr: "0xescrow", CancelTxHash: "0xtx"}, finished: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isFinished(&tc.order); got != tc.finished {
+				t.Fatalf("expected finished=%v, got %v", tc.finished, got)
+			}
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	reverted := chain_errors.Reverted(errors.New("execution reverted"))
+	unavailable := chain_errors.Classify(errors.New("connection refused"))
+
+	for _, tc := range []struct {
+		name  string
+		order Order
+		err   error
+		retry bool
+	}{
+		{name: "transient error", order: Order{Status: OrderStatusMatched}, err: unavailable, retry: true},
+		{name: "reverted before escrow deployed", order: Order{Status: OrderStatusMatched}, err: reverted, retry: false},
+		{name: "unclassified error", order: Order{Status: OrderStatusActive}, err: errors.New("unknown order type"), retry: false},
+		{name: "reverted with escrow deployed", order: Order{Status: OrderStatusMatched, DestEscrowAddr: "0xescrow"}, err: reverted, retry: true},
+		{name: "cancel before timelock", order: Order{Status: OrderStatusExpired, DestEscrowAddr: "0xescrow"}, err: reverted, retry: true},
+		{name: "destination underfunded", order: Order{Status: OrderStatusMatched}, err: fmt.Errorf("%w: escrow unknown", ErrDestinationUnderfunded), retry: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := shouldRetry(&tc.order, tc.err); got != tc.retry {
+				t.Fatalf("expected retry=%v, got %v", tc.retry, got)
+			}
+		})
+	}
+}
+
+func TestDrainCompletesInFlightOrders(t *testing.T) {
+	client := &slowCronosClient{release: make(chan struct{})}
+	close(client.release)
+
+	om := newTestOrderManager(t, client)
+	om.activeOrders["order-1"] = newMatchedOrder("order-1")
+	if err := om.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start order manager: %v", err)
+	}
+	defer om.Stop(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if remaining := om.Drain(ctx); len(remaining) != 0 {
+		t.Fatalf("expected all orders drained, %d remaining", len(remaining))
+	}
+	if _, exists := om.GetOrder("order-1"); exists {
+		t.Fatal("completed order should be removed")
+	}
+}
+
+func TestDrainRespectsDeadline(t *testing.T) {
+	client := &slowCronosClient{release: make(chan struct{})}
+	defer close(client.release)
+
+	om := newTestOrderManager(t, client)
+	om.activeOrders["order-1"] = newMatchedOrder("order-1")
+	if err := om.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start order manager: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	remaining := om.Drain(ctx)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("drain ignored the deadline, took %s", elapsed)
+	}
+	if len(remaining) != 1 || remaining[0].ID != "order-1" {
+		t.Fatalf("expected order-1 to remain in flight, got %v", remaining)
+	}
+}
+
+func TestStopReturnsAtDeadline(t *testing.T) {
+	// The withdrawal ignores cancellation, so the update goroutine never exits
+	client := &slowCronosClient{release: make(chan struct{})}
+	defer close(client.release)
+
+	om := newTestOrderManager(t, client)
+	om.activeOrders["order-1"] = newMatchedOrder("order-1")
+	if err := om.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start order manager: %v", err)
+	}
+	om.updateOrdersChan <- om.activeOrders["order-1"]
+
+	// Wait for the withdrawal to be in progress
+	deadline := time.Now().Add(5 * time.Second)
+	for len(om.updateOrdersChan) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("order update was never picked up")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := om.Stop(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected Stop to give up at the deadline, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected Stop to return at the deadline, took %s", elapsed)
+	}
+	if stuck := om.runningGoroutines(); len(stuck) != 1 || stuck[0] != "process_order_updates" {
+		t.Fatalf("expected only the update goroutine to be stuck, got %v", stuck)
+	}
+}
+
+func TestStopWaitsForGoroutines(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	if err := om.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start order manager: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := om.Stop(ctx); err != nil {
+		t.Fatalf("expected a clean stop, got %v", err)
+	}
+	if stuck := om.runningGoroutines(); len(stuck) != 0 {
+		t.Fatalf("expected every goroutine to exit, got %v", stuck)
+	}
+}
+
+// countingCronosClient counts destination escrows created
+type countingCronosClient struct {
+	recordingCronosClient
+	creates atomic.Int32
+}
+
+func (c *countingCronosClient) CreateDestinationEscrow(ctx context.Context, factoryAddr string, params cronos_client.CreateDestEscrowParams) (string, error) {
+	c.creates.Add(1)
+	return "0xcreate", nil
+}
+
+func newEthereumOrder(id string) *Order {
+	now := time.Now()
+	return &Order{
+		ID:           id,
+		Type:         OrderTypeEthereumToCronos,
+		Status:       OrderStatusPending,
+		Timelock:     uint64(now.Add(2 * time.Hour).Unix()),
+		DestTimelock: uint64(now.Add(time.Hour).Unix()),
+		ExpiresAt:    now.Add(time.Hour),
+	}
+}
+
+func TestAddOrderIsIdempotent(t *testing.T) {
+	client := &countingCronosClient{}
+	om := newTestOrderManager(t, client)
+
+	// The same escrow found by two overlapping scans is queued once
+	om.AddOrder(newEthereumOrder("order-1"))
+	om.AddOrder(newEthereumOrder("order-1"))
+	if queued := len(om.newOrdersChan); queued != 1 {
+		t.Fatalf("expected 1 queued order, got %d", queued)
+	}
+
+	if err := om.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start order manager: %v", err)
+	}
+	defer om.Stop(context.Background())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, exists := om.GetOrder("order-1"); exists {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("order was not processed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Once tracked, and once finished, the order is still recognised
+	om.AddOrder(newEthereumOrder("order-1"))
+
+	om.ordersMutex.Lock()
+	delete(om.activeOrders, "order-1")
+	om.recentOrders.add("order-1")
+	om.ordersMutex.Unlock()
+	om.AddOrder(newEthereumOrder("order-1"))
+
+	time.Sleep(50 * time.Millisecond)
+	if creates := client.creates.Load(); creates != 1 {
+		t.Fatalf("expected the order to be processed once, got %d", creates)
+	}
+
+	// A different order still goes through
+	om.AddOrder(newEthereumOrder("order-2"))
+	deadline = time.Now().Add(5 * time.Second)
+	for client.creates.Load() != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("second order was not processed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFlushOrdersRoundTrip(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{release: make(chan struct{})})
+	om.activeOrders["order-1"] = newMatchedOrder("order-1")
+	om.activeOrders["order-2"] = &Order{ID: "order-2", Status: OrderStatusCompleted}
+
+	if err := om.FlushOrders(); err != nil {
+		t.Fatalf("failed to flush orders: %v", err)
+	}
+
+	orders, err := LoadOrders(om.config.Relayer.OrderStorePath)
+	if err != nil {
+		t.Fatalf("failed to load orders: %v", err)
+	}
+	if len(orders) != 1 || orders[0].ID != "order-1" {
+		t.Fatalf("expected only the unfinished order to be flushed, got %v", orders)
+	}
+	if orders[0].Secret != "secret" {
+		t.Fatalf("expected secret to be persisted, got %q", orders[0].Secret)
+	}
+}
+
+// escrowStatusClient reports a fixed escrow status
+type escrowStatusClient struct {
+	recordingCronosClient
+	status string
+}
+
+func (c *escrowStatusClient) GetEscrowStatus(ctx context.Context, escrowAddr string) (string, error) {
+	return c.status, nil
+}
+
+func TestFailedOrdersAreSetAside(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	order := newMatchedOrder("order-1")
+	order.DestEscrowAddr = ""
+	order.Secret = ""
+	om.ordersMutex.Lock()
+	om.trackOrder(order)
+	om.ordersMutex.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		om.processOrderUpdates(ctx)
+	}()
+	om.updateOrdersChan <- order
+
+	// Without its secret the order can't be settled, so it fails
+	failed := false
+	for deadline := time.Now().Add(time.Second); !failed && time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		om.ordersMutex.RLock()
+		_, failed = om.failedOrders[order.ID]
+		om.ordersMutex.RUnlock()
+	}
+	cancel()
+	<-done
+
+	if !failed {
+		t.Fatal("expected the order to be set aside as failed")
+	}
+	if _, active := om.activeOrders[order.ID]; active {
+		t.Fatal("expected the failed order to leave the active orders")
+	}
+	if got, tracked := om.GetOrder(order.ID); !tracked || got != order {
+		t.Fatal("expected the failed order to stay tracked")
+	}
+	if byStatus := om.GetOrdersByStatus(OrderStatusFailed); len(byStatus) != 1 {
+		t.Fatalf("expected 1 failed order, got %d", len(byStatus))
+	}
+	if stats := om.GetOrderStats(); stats["total_active_orders"] != 0 || stats["total_failed_orders"] != 1 {
+		t.Fatalf("expected the order counted as failed, got %v", stats)
+	}
+
+	// Settling it by hand retires it
+	if err := om.ForceCompleteOrder(order.ID, "refunded maker", "alice"); err != nil {
+		t.Fatalf("expected failed order to be completed, got %v", err)
+	}
+	if _, tracked := om.GetOrder(order.ID); tracked {
+		t.Fatal("completed order should no longer be tracked")
+	}
+}
+
+func TestFailedUpdateRecordedUnderLock(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	order := newMatchedOrder("order-1")
+	order.DestEscrowAddr = ""
+	order.Secret = ""
+	om.ordersMutex.Lock()
+	om.trackOrder(order)
+	om.ordersMutex.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		om.processOrderUpdates(ctx)
+	}()
+	om.updateOrdersChan <- order
+
+	// Readers holding ordersMutex see the failed attempt recorded
+	var (
+		retries   int
+		lastError string
+	)
+	for deadline := time.Now().Add(time.Second); lastError == "" && time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		om.ordersMutex.RLock()
+		retries, lastError = order.RetryCount, order.LastError
+		om.ordersMutex.RUnlock()
+	}
+	cancel()
+	<-done
+
+	if retries != 1 || lastError == "" {
+		t.Fatalf("expected the failed attempt to be recorded, got %d retries, %q", retries, lastError)
+	}
+}
+
+func TestSyncOrderState(t *testing.T) {
+	for _, tc := range []struct {
+		escrowStatus string
+		expected     OrderStatus
+	}{
+		{escrowStatus: "active", expected: OrderStatusMatched},
+		{escrowStatus: "completed", expected: OrderStatusCompleted},
+		{escrowStatus: "cancelled", expected: OrderStatusCancelled},
+	} {
+		t.Run(tc.escrowStatus, func(t *testing.T) {
+			om := newTestOrderManager(t, &escrowStatusClient{status: tc.escrowStatus})
+			order := newMatchedOrder("order-1")
+
+			if err := om.syncOrderState(context.Background(), order); err != nil {
+				t.Fatalf("syncOrderState failed: %v", err)
+			}
+			if order.Status != tc.expected {
+				t.Fatalf("expected status %s, got %s", tc.expected, order.Status)
+			}
+		})
+	}
+}
+
+func TestSyncOrderStateSkipsFinishedOrders(t *testing.T) {
+	om := newTestOrderManager(t, &escrowStatusClient{status: "cancelled"})
+	order := newMatchedOrder("order-1")
+	order.Status = OrderStatusCompleted
+
+	if err := om.syncOrderState(context.Background(), order); err != nil {
+		t.Fatalf("syncOrderState failed: %v", err)
+	}
+	if order.Status != OrderStatusCompleted {
+		t.Fatalf("expected completed order to be left alone, got %s", order.Status)
+	}
+}
+
+func TestNewOrderSecretAndRevealBlock(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	om.config.Contracts.Ethereum.Resolver = "0x2222222222222222222222222222222222222222"
+	ethereum := &fundedEthereumClient{latestBlock: 1234}
+	om.ethereumClient = ethereum
+
+	// A maker-initiated order comes without a hashlock
+	order := &Order{
+		ID:               "order-1",
+		Type:             OrderTypeCronosToEthereum,
+		Status:           OrderStatusPending,
+		Maker:            "0x1111111111111111111111111111111111111111",
+		Timelock:         uint64(time.Now().Add(2 * time.Hour).Unix()),
+		DestinationAsset: AssetInfo{Amount: big.NewInt(1000)},
+		ExpiresAt:        time.Now().Add(time.Hour),
+	}
+	if err := om.handleNewOrder(context.Background(), order); err != nil {
+		t.Fatalf("failed to handle order: %v", err)
+	}
+	secret, known := om.secretManager.GetSecret(order.SecretHash)
+	if order.SecretHash == "" || !known {
+		t.Fatalf("expected a secret to be assigned, got hashlock %q", order.SecretHash)
+	}
+	if order.DestBlock != 1234 {
+		t.Fatalf("expected destination escrow block 1234, got %d", order.DestBlock)
+	}
+
+	// Withdrawals are looked up from the block the escrow was deployed after
+	order.DestEscrowAddr = "0xdest"
+	ethereum.revealed = []ethereum_client.RevealedSecret{{Secret: "0x" + secret, TxHash: "0xreveal"}}
+	if err := om.checkForMatches(context.Background(), order); err != nil {
+		t.Fatalf("failed to check for matches: %v", err)
+	}
+	if order.Status != OrderStatusMatched {
+		t.Fatalf("expected revealed secret to match the order, got %s", order.Status)
+	}
+
+	source := newMatchedOrder("order-2")
+	source.Type = OrderTypeEthereumToCronos
+	source.SourceEscrowAddr = "0xsource"
+	source.SourceBlock = 99
+	if _, err := om.sourceEscrowWithdrawn(context.Background(), source); err != nil {
+		t.Fatalf("failed to check source escrow: %v", err)
+	}
+
+	if want := []uint64{1234, 99}; fmt.Sprint(ethereum.fromBlocks) != fmt.Sprint(want) {
+		t.Fatalf("expected withdrawals looked up from blocks %v, got %v", want, ethereum.fromBlocks)
+	}
+}
+
+func TestRevealedSecretDetectedByMonitor(t *testing.T) {
+	om := newTestOrderManager(t, &recordingCronosClient{})
+	om.config.Relayer.OrderUpdateInterval = 20 * time.Millisecond
+
+	// The taker chose the hashlock, so the relayer only learns the secret
+	// when it is revealed by the destination escrow's withdrawal
+	secret := bytes.Repeat([]byte{0x42}, 32)
+	hashlock := sha256.Sum256(secret)
+	ethereum := &fundedEthereumClient{revealed: []ethereum_client.RevealedSecret{
+		{Secret: "0x" + hex.EncodeToString(secret), TxHash: "0xreveal"},
+	}}
+	om.ethereumClient = ethereum
+
+	order := newMatchedOrder("order-1")
+	order.Status = OrderStatusActive
+	order.Secret = ""
+	order.SecretHash = "0x" + hex.EncodeToString(hashlock[:])
+	om.trackOrder(order)
+
+	if err := om.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start order manager: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, tracked := om.GetOrder(order.ID); !tracked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("revealed secret was never detected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := om.Stop(context.Background()); err != nil {
+		t.Fatalf("failed to stop order manager: %v", err)
+	}
+
+	if order.Status != OrderStatusCompleted || order.Secret != hex.EncodeToString(secret) {
+		t.Fatalf("expected the order completed with the revealed secret, got %s with %q", order.Status, order.Secret)
+	}
+	if order.SourceTxHash != "0xwithdraw" {
+		t.Fatalf("expected the source escrow withdrawn, got tx %q", order.SourceTxHash)
+	}
+}
+
+func TestCheckOrderTimeoutsMaxOrderLifetime(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	om.config.Relayer.MaxOrderLifetime = 24 * time.Hour
+
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	// Unmatched for two days, with its destination escrow past its timelock
+	// and its source timelock a week out
+	stale := newMatchedOrder("stale")
+	stale.Status = OrderStatusActive
+	stale.CreatedAt = old
+	stale.DestTimelock = uint64(now.Add(-time.Minute).Unix())
+	stale.ExpiresAt = now.Add(7 * 24 * time.Hour)
+
+	// Just as old but being executed
+	executing := newMatchedOrder("executing")
+	executing.CreatedAt = old
+	executing.ExpiresAt = now.Add(7 * 24 * time.Hour)
+
+	// Unmatched but recent
+	recent := newMatchedOrder("recent")
+	recent.Status = OrderStatusActive
+	recent.CreatedAt = now.Add(-time.Hour)
+	recent.ExpiresAt = now.Add(7 * 24 * time.Hour)
+
+	// Past its lifetime, but its destination escrow can't be cancelled yet
+	early := newMatchedOrder("early")
+	early.Status = OrderStatusActive
+	early.CreatedAt = old
+	early.DestTimelock = uint64(now.Add(time.Hour).Unix())
+	early.ExpiresAt = now.Add(7 * 24 * time.Hour)
+
+	for _, order := range []*Order{stale, executing, recent, early} {
+		om.trackOrder(order)
+	}
+
+	om.checkOrderTimeouts()
+
+	if stale.Status != OrderStatusExpired || early.Status != OrderStatusExpired {
+		t.Fatalf("expected orders past their lifetime to expire, got %s and %s", stale.Status, early.Status)
+	}
+	if executing.Status != OrderStatusMatched {
+		t.Fatalf("expected the executing order to be left alone, got %s", executing.Status)
+	}
+	if recent.Status != OrderStatusActive {
+		t.Fatalf("expected the recent order to stay active, got %s", recent.Status)
+	}
+
+	// Only the escrow that can be cancelled is queued for cancellation
+	if len(om.updateOrdersChan) != 1 {
+		t.Fatalf("expected one cancellation queued, got %d", len(om.updateOrdersChan))
+	}
+	if queued := <-om.updateOrdersChan; queued != stale {
+		t.Fatalf("expected the stale order to be queued, got %s", queued.ID)
+	}
+}
+
+func TestExceededLifetime(t *testing.T) {
+	now := time.Now()
+	order := &Order{Status: OrderStatusPending, CreatedAt: now.Add(-2 * time.Hour)}
+
+	if !exceededLifetime(order, time.Hour, now) {
+		t.Fatal("expected a pending order past its lifetime to be exceeded")
+	}
+	if exceededLifetime(order, 0, now) {
+		t.Fatal("expected a zero lifetime to disable the limit")
+	}
+
+	order.Status = OrderStatusActive
+	order.PartialFill = &PartialFillParams{AllowPartialFill: true, FilledAmount: big.NewInt(10)}
+	order.SourceAsset.Amount = big.NewInt(100)
+	if exceededLifetime(order, time.Hour, now) {
+		t.Fatal("expected a partially filled order to be left to finish")
+	}
+}
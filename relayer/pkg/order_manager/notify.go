@@ -0,0 +1,50 @@
+package order_manager
+
+import "go.uber.org/zap"
+
+// OrderEvent is the webhook payload sent when an order reaches a terminal
+// status
+type OrderEvent struct {
+	// Event is "order." followed by the new status, e.g. "order.expired"
+	Event      string          `json:"event"`
+	Reason     string          `json:"reason,omitempty"`
+	Transition StateTransition `json:"transition"`
+	Order      Order           `json:"order"`
+}
+
+// isTerminal reports whether an order in status is done for good
+func isTerminal(status OrderStatus) bool {
+	switch status {
+	case OrderStatusCompleted, OrderStatusFailed, OrderStatusExpired, OrderStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// notifyTransition sends the order to the webhook if transition left it in a
+// terminal status
+func (om *OrderManager) notifyTransition(order *Order, transition StateTransition) {
+	if om.webhook == nil || !isTerminal(transition.To) {
+		return
+	}
+
+	reason := transition.Note
+	if reason == "" {
+		reason = order.LastError
+	}
+
+	// The secret is never sent to third parties
+	snapshot := *order
+	snapshot.Secret = ""
+
+	event := OrderEvent{
+		Event:      "order." + string(transition.To),
+		Reason:     reason,
+		Transition: transition,
+		Order:      snapshot,
+	}
+	if err := om.webhook.Send(event); err != nil {
+		om.logger.Error("Failed to send order webhook", zap.String("order_id", order.ID), zap.Error(err))
+	}
+}
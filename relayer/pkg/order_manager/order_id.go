@@ -0,0 +1,56 @@
+package order_manager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go.uber.org/zap"
+)
+
+// OrderID derives the canonical ID of a swap from its hashlock and the two
+// chains it spans, as sha256(hashlock || srcChain || dstChain). Each escrow
+// only knows its own chain as the source, so the chains are hashed in sorted
+// order and the escrows on both sides of a swap yield the same ID
+func OrderID(hashLock, srcChain, dstChain string) string {
+	if dstChain < srcChain {
+		srcChain, dstChain = dstChain, srcChain
+	}
+
+	lock, err := hex.DecodeString(normalizeHash(hashLock))
+	if err != nil {
+		lock = []byte(normalizeHash(hashLock))
+	}
+
+	h := sha256.New()
+	h.Write(lock)
+	h.Write([]byte(srcChain))
+	h.Write([]byte(dstChain))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// MergeOrder folds an escrow seen on-chain into the order already tracked
+// under its ID and reports whether there was one. An escrow on the other
+// chain than the order's source is the order's destination escrow
+func (om *OrderManager) MergeOrder(order *Order) bool {
+	om.ordersMutex.Lock()
+	defer om.ordersMutex.Unlock()
+
+	existing, exists := om.activeOrders[order.ID]
+	if !exists {
+		return false
+	}
+	if existing.SourceChain == order.SourceChain || existing.DestEscrowAddr != "" {
+		return true
+	}
+
+	existing.DestEscrowAddr = order.SourceEscrowAddr
+	existing.DestTimelock = order.Timelock
+	existing.DestDeployedAt = uint64(order.CreatedAt.Unix())
+	existing.DestBlock = order.SourceBlock
+
+	om.logger.Info("Merged destination escrow into order",
+		zap.String("order_id", existing.ID),
+		zap.String("chain", order.SourceChain),
+		zap.String("escrow", existing.DestEscrowAddr))
+	return true
+}
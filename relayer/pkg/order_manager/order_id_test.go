@@ -0,0 +1,80 @@
+package order_manager
+
+import (
+	"testing"
+	"time"
+)
+
+const testHashLock = "0x9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658"
+
+func TestOrderID(t *testing.T) {
+	id := OrderID(testHashLock, "cronos", "ethereum")
+	if len(id) != 64 {
+		t.Fatalf("expected a hex sha256 digest, got %q", id)
+	}
+	if other := OrderID(testHashLock, "ethereum", "cronos"); other != id {
+		t.Fatalf("expected both sides of the swap to share an ID, got %s and %s", id, other)
+	}
+	if other := OrderID("9C22FF5F21F0B81B113E63F7DB6DA94FEDEF11B2119B4088B89664FB9A3CB658", "cronos", "ethereum"); other != id {
+		t.Fatalf("expected the hashlock encoding not to matter, got %s and %s", id, other)
+	}
+	if other := OrderID("0x01", "cronos", "ethereum"); other == id {
+		t.Fatal("expected different hashlocks to yield different IDs")
+	}
+	if other := OrderID(testHashLock, "cronos", "osmosis"); other == id {
+		t.Fatal("expected different chains to yield different IDs")
+	}
+}
+
+func TestMergeOrder(t *testing.T) {
+	om := newTestOrderManager(t, nil)
+
+	cronosHalf := &Order{
+		ID:               OrderID(testHashLock, "cronos", "ethereum"),
+		Type:             OrderTypeCronosToEthereum,
+		Status:           OrderStatusActive,
+		SourceChain:      "cronos",
+		SecretHash:       testHashLock,
+		Timelock:         2000,
+		SourceEscrowAddr: "crc1escrow",
+	}
+	ethereumHalf := &Order{
+		ID:               OrderID(testHashLock, "ethereum", "cronos"),
+		Type:             OrderTypeEthereumToCronos,
+		SourceChain:      "ethereum",
+		SecretHash:       testHashLock,
+		Timelock:         1000,
+		SourceEscrowAddr: "0xescrow",
+		CreatedAt:        time.Unix(500, 0),
+	}
+
+	if om.MergeOrder(ethereumHalf) {
+		t.Fatal("expected nothing to merge into before the order is tracked")
+	}
+
+	om.activeOrders[cronosHalf.ID] = cronosHalf
+	if !om.MergeOrder(ethereumHalf) {
+		t.Fatal("expected the Ethereum escrow to merge into the Cronos order")
+	}
+
+	order, ok := om.GetOrder(cronosHalf.ID)
+	if !ok || len(om.GetActiveOrders()) != 1 {
+		t.Fatal("expected a single order for the swap")
+	}
+	if order.Type != OrderTypeCronosToEthereum || order.SourceEscrowAddr != "crc1escrow" {
+		t.Fatalf("expected the source half to be kept, got %s from %s", order.Type, order.SourceEscrowAddr)
+	}
+	if order.DestEscrowAddr != "0xescrow" || order.DestTimelock != 1000 || order.DestDeployedAt != 500 {
+		t.Fatalf("expected the destination escrow to be tracked, got %s timelock %d deployed %d",
+			order.DestEscrowAddr, order.DestTimelock, order.DestDeployedAt)
+	}
+
+	// Seeing the Cronos escrow again leaves the order untouched
+	if !om.MergeOrder(&Order{ID: cronosHalf.ID, SourceChain: "cronos", SourceEscrowAddr: "crc1other"}) {
+		t.Fatal("expected a rescanned escrow to be recognised")
+	}
+	if order.SourceEscrowAddr != "crc1escrow" || order.DestEscrowAddr != "0xescrow" {
+		t.Fatalf("expected the order to be unchanged, got source %s destination %s",
+			order.SourceEscrowAddr, order.DestEscrowAddr)
+	}
+}
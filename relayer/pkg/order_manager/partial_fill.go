@@ -0,0 +1,139 @@
+package order_manager
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"go.uber.org/zap"
+)
+
+// executePartialFill withdraws the next fill of a partially fillable Cronos
+// order from its source escrow. The order stays active until the whole
+// amount has been filled; a fill the order can't take is dropped and the
+// order goes back to the book
+func (om *OrderManager) executePartialFill(ctx context.Context, order *Order) error {
+	fill := nextFillAmount(order)
+	err := validateFill(order, fill)
+	if err == nil {
+		err = validateRelayerFee(fill, om.config.Relayer.RelayerFeePercentage)
+	}
+	if err != nil {
+		order.PartialFill.PendingFillAmount = nil
+		om.SetStatus(order, OrderStatusActive, fmt.Sprintf("fill rejected: %v", err), "")
+		om.logger.Warn("Rejected partial fill",
+			zap.String("order_id", order.ID),
+			zap.Error(err))
+		return nil
+	}
+
+	txHash, err := om.cronosClient.PartialWithdrawFromEscrow(
+		ctx,
+		order.SourceEscrowAddr,
+		order.Secret,
+		fill.String(),
+	)
+	if err != nil {
+		if om.completeIfSpent(ctx, order, err) {
+			return nil
+		}
+		return fmt.Errorf("failed to withdraw from source escrow: %w", err)
+	}
+
+	order.SourceTxHash = txHash
+	fee := om.recordRelayerFee(order, fill)
+	if applyFill(order, fill) {
+		om.SetStatus(order, OrderStatusCompleted, "source escrow withdrawn", txHash)
+		om.secretManager.Forget(order.SecretHash)
+		om.logger.Info("Swap completed successfully",
+			zap.String("order_id", order.ID),
+			zap.String("source_tx", txHash),
+			zap.String("relayer_fee", order.RelayerFee.String()))
+		return nil
+	}
+
+	// The secret is kept for the next fill
+	remaining := order.PartialFill.RemainingAmount
+	om.SetStatus(order, OrderStatusActive,
+		fmt.Sprintf("partially filled %s, %s remaining", fill, remaining), txHash)
+
+	fields := []zap.Field{
+		zap.String("order_id", order.ID),
+		zap.String("filled", order.PartialFill.FilledAmount.String()),
+		zap.String("remaining", remaining.String()),
+		zap.String("relayer_fee", fee.String()),
+		zap.String("source_tx", txHash),
+	}
+	if minimum := order.PartialFill.MinimumFillAmount; minimum != nil && remaining.Cmp(minimum) <= 0 {
+		// Only a fill of the whole remainder can complete the order now
+		fields = append(fields, zap.String("minimum_fill", minimum.String()))
+	}
+	om.logger.Info("Order partially filled", fields...)
+
+	return nil
+}
+
+// nextFillAmount returns the amount the next withdrawal takes: the matched
+// fill if one is pending, the whole remainder otherwise
+func nextFillAmount(order *Order) *big.Int {
+	if pending := order.PartialFill.PendingFillAmount; pending != nil {
+		return pending
+	}
+	return RemainingAmount(order)
+}
+
+// validateFill checks that amount is positive, no more than what is left of
+// the order and at least its minimum fill, unless it fills the remainder
+func validateFill(order *Order, amount *big.Int) error {
+	remaining := RemainingAmount(order)
+	if remaining == nil {
+		return fmt.Errorf("order %s has nothing left to fill", order.ID)
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return fmt.Errorf("fill amount must be positive")
+	}
+	if amount.Cmp(remaining) > 0 {
+		return fmt.Errorf("fill amount %s exceeds remaining amount %s", amount, remaining)
+	}
+	if minimum := order.PartialFill.MinimumFillAmount; minimum != nil && amount.Cmp(remaining) != 0 && amount.Cmp(minimum) < 0 {
+		return fmt.Errorf("fill amount %s is below the minimum fill %s", amount, minimum)
+	}
+	return nil
+}
+
+// applyFill records a withdrawn fill of amount and reports whether the order
+// is now fully filled
+func applyFill(order *Order, amount *big.Int) bool {
+	params := order.PartialFill
+
+	filled := new(big.Int)
+	if params.FilledAmount != nil {
+		filled.Set(params.FilledAmount)
+	}
+	params.FilledAmount = filled.Add(filled, amount)
+	params.RemainingAmount = new(big.Int).Sub(RemainingAmount(order), amount)
+	params.PendingFillAmount = nil
+
+	return params.RemainingAmount.Sign() == 0
+}
+
+// isPartiallyFilled reports whether some but not all of order has been filled
+func isPartiallyFilled(order *Order) bool {
+	if order.PartialFill == nil || order.PartialFill.FilledAmount == nil {
+		return false
+	}
+	return order.PartialFill.FilledAmount.Sign() > 0 && RemainingAmount(order) != nil
+}
+
+// RemainingAmount returns how much of an order's source asset is still
+// unfilled, or nil if nothing is
+func RemainingAmount(order *Order) *big.Int {
+	amount := order.SourceAsset.Amount
+	if order.PartialFill != nil && order.PartialFill.RemainingAmount != nil {
+		amount = order.PartialFill.RemainingAmount
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil
+	}
+	return amount
+}
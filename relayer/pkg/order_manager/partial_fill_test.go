@@ -0,0 +1,154 @@
+package order_manager
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
+)
+
+// recordingCronosClient records the amounts of partial withdrawals
+type recordingCronosClient struct {
+	withdrawals []string
+}
+
+func (c *recordingCronosClient) CreateDestinationEscrow(ctx context.Context, factoryAddr string, params cronos_client.CreateDestEscrowParams) (string, error) {
+	return "0xcreate", nil
+}
+
+func (c *recordingCronosClient) WithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string) (string, error) {
+	return "0xwithdraw", nil
+}
+
+func (c *recordingCronosClient) PartialWithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string, amount string) (string, error) {
+	c.withdrawals = append(c.withdrawals, amount)
+	return "0xpartial", nil
+}
+
+func (c *recordingCronosClient) CancelEscrow(ctx context.Context, escrowAddr string) (string, error) {
+	return "0xcancel", nil
+}
+
+func (c *recordingCronosClient) GetEscrowStatus(ctx context.Context, escrowAddr string) (string, error) {
+	return "active", nil
+}
+
+func (c *recordingCronosClient) SimulateFill(ctx context.Context, escrowAddr string, inputAmount string) (string, error) {
+	return inputAmount, nil
+}
+
+func (c *recordingCronosClient) GetEscrowDeposit(ctx context.Context, escrowAddr string) (*big.Int, error) {
+	return big.NewInt(1000), nil
+}
+
+func (c *recordingCronosClient) CheckTransaction(ctx context.Context, txHash string) (bool, error) {
+	return true, nil
+}
+
+func newPartialFillOrder(id string) *Order {
+	order := newMatchedOrder(id)
+	order.PartialFill = &PartialFillParams{
+		AllowPartialFill:  true,
+		MinimumFillAmount: big.NewInt(20),
+		FilledAmount:      big.NewInt(0),
+		RemainingAmount:   big.NewInt(100),
+	}
+	return order
+}
+
+func TestExecuteSwapPartialFillsToCompletion(t *testing.T) {
+	client := &recordingCronosClient{}
+	om := newTestOrderManager(t, client)
+	order := newPartialFillOrder("order-1")
+
+	for i, fill := range []int64{30, 50, 20} {
+		order.Status = OrderStatusMatched
+		order.PartialFill.PendingFillAmount = big.NewInt(fill)
+		if err := om.executeSwap(context.Background(), order); err != nil {
+			t.Fatalf("fill %d: executeSwap failed: %v", i, err)
+		}
+		if order.PartialFill.PendingFillAmount != nil {
+			t.Fatalf("fill %d: expected pending fill to be cleared", i)
+		}
+		if i < 2 && order.Status != OrderStatusActive {
+			t.Fatalf("fill %d: expected partially filled order to stay active, got %s", i, order.Status)
+		}
+		if i < 2 && !isPartiallyFilled(order) {
+			t.Fatalf("fill %d: expected order to be partially filled", i)
+		}
+	}
+
+	if order.Status != OrderStatusCompleted {
+		t.Fatalf("expected order to be completed, got %s", order.Status)
+	}
+	if order.PartialFill.FilledAmount.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("expected filled amount 100, got %s", order.PartialFill.FilledAmount)
+	}
+	if order.PartialFill.RemainingAmount.Sign() != 0 {
+		t.Fatalf("expected nothing remaining, got %s", order.PartialFill.RemainingAmount)
+	}
+
+	expected := []string{"30", "50", "20"}
+	if len(client.withdrawals) != len(expected) {
+		t.Fatalf("expected withdrawals %v, got %v", expected, client.withdrawals)
+	}
+	for i := range expected {
+		if client.withdrawals[i] != expected[i] {
+			t.Fatalf("expected withdrawals %v, got %v", expected, client.withdrawals)
+		}
+	}
+}
+
+func TestExecuteSwapRejectsFillBelowMinimum(t *testing.T) {
+	client := &recordingCronosClient{}
+	om := newTestOrderManager(t, client)
+	order := newPartialFillOrder("order-1")
+	order.PartialFill.PendingFillAmount = big.NewInt(10)
+
+	if err := om.executeSwap(context.Background(), order); err != nil {
+		t.Fatalf("executeSwap failed: %v", err)
+	}
+
+	if len(client.withdrawals) != 0 {
+		t.Fatalf("expected no withdrawal, got %v", client.withdrawals)
+	}
+	if order.Status != OrderStatusActive {
+		t.Fatalf("expected order to go back to active, got %s", order.Status)
+	}
+	if order.PartialFill.PendingFillAmount != nil {
+		t.Fatal("expected rejected fill to be cleared")
+	}
+	if order.PartialFill.FilledAmount.Sign() != 0 || order.PartialFill.RemainingAmount.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("expected amounts to be unchanged, got filled %s remaining %s",
+			order.PartialFill.FilledAmount, order.PartialFill.RemainingAmount)
+	}
+}
+
+func TestValidateFill(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		remaining int64
+		amount    int64
+		valid     bool
+	}{
+		{name: "at minimum", remaining: 100, amount: 20, valid: true},
+		{name: "below minimum", remaining: 100, amount: 19, valid: false},
+		{name: "whole remainder below minimum", remaining: 15, amount: 15, valid: true},
+		{name: "more than remaining", remaining: 100, amount: 101, valid: false},
+		{name: "zero", remaining: 100, amount: 0, valid: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			order := newPartialFillOrder("order-1")
+			order.PartialFill.RemainingAmount = big.NewInt(tc.remaining)
+
+			err := validateFill(order, big.NewInt(tc.amount))
+			if tc.valid && err != nil {
+				t.Fatalf("expected valid fill, got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatal("expected fill to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package order_manager
+
+import (
+	"context"
+	"sort"
+)
+
+// BlockLookup returns the block the transaction that created an order was
+// included in, or found=false if it is no longer part of the canonical chain
+type BlockLookup func(ctx context.Context, txHash string) (blockNumber uint64, found bool, err error)
+
+// PendingOrders holds orders seen on-chain that aren't yet buried under
+// enough blocks to be safe from reorgs. It is not safe for concurrent use
+type PendingOrders struct {
+	depth  uint64
+	orders map[string]*pendingOrder
+}
+
+type pendingOrder struct {
+	order       *Order
+	txHash      string
+	blockNumber uint64
+}
+
+// NewPendingOrders creates a tracker requiring depth confirmations. A depth of
+// zero or one promotes orders as soon as their block is seen
+func NewPendingOrders(depth uint64) *PendingOrders {
+	return &PendingOrders{
+		depth:  depth,
+		orders: make(map[string]*pendingOrder),
+	}
+}
+
+// Add tracks an order created by txHash in blockNumber. Orders already being
+// tracked are ignored
+func (p *PendingOrders) Add(order *Order, txHash string, blockNumber uint64) {
+	if _, exists := p.orders[order.ID]; exists {
+		return
+	}
+	p.orders[order.ID] = &pendingOrder{order: order, txHash: txHash, blockNumber: blockNumber}
+}
+
+// Has reports whether an order is still waiting for confirmations
+func (p *PendingOrders) Has(orderID string) bool {
+	_, exists := p.orders[orderID]
+	return exists
+}
+
+// Len returns the number of orders waiting for confirmations
+func (p *PendingOrders) Len() int {
+	return len(p.orders)
+}
+
+// OldestBlock returns the lowest block an order waiting for confirmations was
+// created in, or found=false if none are waiting
+func (p *PendingOrders) OldestBlock() (blockNumber uint64, found bool) {
+	for _, pending := range p.orders {
+		if !found || pending.blockNumber < blockNumber {
+			blockNumber = pending.blockNumber
+			found = true
+		}
+	}
+	return blockNumber, found
+}
+
+// Refresh re-checks where each pending order's creation tx landed. Orders
+// whose tx was reorged out are dropped and returned; orders re-included in a
+// different block restart their confirmation count from that block
+func (p *PendingOrders) Refresh(ctx context.Context, lookup BlockLookup) ([]*Order, error) {
+	var dropped []*Order
+	for id, pending := range p.orders {
+		blockNumber, found, err := lookup(ctx, pending.txHash)
+		if err != nil {
+			return dropped, err
+		}
+		if !found {
+			delete(p.orders, id)
+			dropped = append(dropped, pending.order)
+			continue
+		}
+		pending.blockNumber = blockNumber
+	}
+
+	return dropped, nil
+}
+
+// Promote removes and returns, oldest block first, the orders with at least
+// the required confirmations at latestBlock
+func (p *PendingOrders) Promote(latestBlock uint64) []*Order {
+	var ready []*pendingOrder
+	for id, pending := range p.orders {
+		if pending.blockNumber > latestBlock {
+			continue
+		}
+		if confirmations := latestBlock - pending.blockNumber + 1; confirmations < p.depth {
+			continue
+		}
+		delete(p.orders, id)
+		ready = append(ready, pending)
+	}
+
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i].blockNumber < ready[j].blockNumber
+	})
+
+	orders := make([]*Order, len(ready))
+	for i, pending := range ready {
+		orders[i] = pending.order
+	}
+	return orders
+}
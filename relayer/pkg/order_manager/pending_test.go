@@ -0,0 +1,112 @@
+package order_manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeChain maps transaction hashes to the block they are included in
+type fakeChain map[string]uint64
+
+func (c fakeChain) lookup(ctx context.Context, txHash string) (uint64, bool, error) {
+	blockNumber, found := c[txHash]
+	return blockNumber, found, nil
+}
+
+func TestPendingOrdersPromotesConfirmedOrders(t *testing.T) {
+	pending := NewPendingOrders(3)
+	pending.Add(&Order{ID: "order-1"}, "0x1", 100)
+	pending.Add(&Order{ID: "order-2"}, "0x2", 101)
+
+	if promoted := pending.Promote(101); len(promoted) != 0 {
+		t.Fatalf("expected no promotions at 2 confirmations, got %d", len(promoted))
+	}
+
+	promoted := pending.Promote(102)
+	if len(promoted) != 1 || promoted[0].ID != "order-1" {
+		t.Fatalf("expected order-1 to be promoted, got %v", promoted)
+	}
+	if !pending.Has("order-2") || pending.Has("order-1") {
+		t.Fatal("only order-2 should still be pending")
+	}
+
+	promoted = pending.Promote(103)
+	if len(promoted) != 1 || promoted[0].ID != "order-2" {
+		t.Fatalf("expected order-2 to be promoted, got %v", promoted)
+	}
+}
+
+func TestPendingOrdersZeroDepth(t *testing.T) {
+	pending := NewPendingOrders(0)
+	pending.Add(&Order{ID: "order-1"}, "0x1", 100)
+
+	if promoted := pending.Promote(100); len(promoted) != 1 {
+		t.Fatalf("expected immediate promotion, got %d", len(promoted))
+	}
+}
+
+func TestPendingOrdersReorg(t *testing.T) {
+	pending := NewPendingOrders(3)
+	pending.Add(&Order{ID: "order-1"}, "0x1", 100)
+	pending.Add(&Order{ID: "order-2"}, "0x2", 100)
+	pending.Add(&Order{ID: "order-3"}, "0x3", 100)
+
+	// A reorg drops 0x2 and re-includes 0x3 two blocks later
+	chain := fakeChain{"0x1": 100, "0x3": 102}
+
+	dropped, err := pending.Refresh(context.Background(), chain.lookup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dropped) != 1 || dropped[0].ID != "order-2" {
+		t.Fatalf("expected order-2 to be dropped, got %v", dropped)
+	}
+	if pending.Has("order-2") {
+		t.Fatal("reorged order must not remain pending")
+	}
+
+	promoted := pending.Promote(102)
+	if len(promoted) != 1 || promoted[0].ID != "order-1" {
+		t.Fatalf("expected only order-1 to be promoted, got %v", promoted)
+	}
+
+	promoted = pending.Promote(104)
+	if len(promoted) != 1 || promoted[0].ID != "order-3" {
+		t.Fatalf("expected order-3 to be promoted from its new block, got %v", promoted)
+	}
+}
+
+func TestPendingOrdersRefreshError(t *testing.T) {
+	pending := NewPendingOrders(3)
+	pending.Add(&Order{ID: "order-1"}, "0x1", 100)
+
+	lookupErr := errors.New("rpc unavailable")
+	_, err := pending.Refresh(context.Background(), func(ctx context.Context, txHash string) (uint64, bool, error) {
+		return 0, false, lookupErr
+	})
+	if !errors.Is(err, lookupErr) {
+		t.Fatalf("expected lookup error, got %v", err)
+	}
+	if !pending.Has("order-1") {
+		t.Fatal("order must stay pending when the lookup fails")
+	}
+}
+
+func TestPendingOrdersOldestBlock(t *testing.T) {
+	pending := NewPendingOrders(3)
+	if _, found := pending.OldestBlock(); found {
+		t.Fatal("expected no oldest block without pending orders")
+	}
+
+	pending.Add(&Order{ID: "order-1"}, "0x1", 105)
+	pending.Add(&Order{ID: "order-2"}, "0x2", 101)
+	if block, found := pending.OldestBlock(); !found || block != 101 {
+		t.Fatalf("expected oldest block 101, got %d (found=%v)", block, found)
+	}
+
+	pending.Promote(103)
+	if block, found := pending.OldestBlock(); !found || block != 105 {
+		t.Fatalf("expected oldest block 105 after promotion, got %d (found=%v)", block, found)
+	}
+}
@@ -0,0 +1,60 @@
+package order_manager
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// FillQuote is the preview of a taker filling a Cronos order
+type FillQuote struct {
+	OrderID string `json:"order_id"`
+	// InputAmount is what the taker pays in the destination asset
+	InputAmount *big.Int `json:"input_amount"`
+	// OutputAmount is what the taker receives from the source escrow
+	OutputAmount *big.Int `json:"output_amount"`
+	// Price is the Dutch auction price the quote was made at, if any
+	Price    *big.Int  `json:"price,omitempty"`
+	QuotedAt time.Time `json:"quoted_at"`
+}
+
+// QuoteFill previews how much of a Cronos order's deposit a taker paying
+// inputAmount would receive right now, so it can be shown before the taker
+// signs. The escrow contract simulates the fill at its current price
+func (om *OrderManager) QuoteFill(ctx context.Context, orderID string, inputAmount *big.Int) (*FillQuote, error) {
+	if inputAmount == nil || inputAmount.Sign() <= 0 {
+		return nil, fmt.Errorf("input amount must be positive")
+	}
+
+	order, exists := om.GetOrder(orderID)
+	if !exists {
+		return nil, fmt.Errorf("order %s not found", orderID)
+	}
+	if order.Type != OrderTypeCronosToEthereum || order.SourceEscrowAddr == "" {
+		return nil, fmt.Errorf("order %s has no Cronos source escrow to fill", orderID)
+	}
+	if isFinished(order) || order.Status == OrderStatusFailed {
+		return nil, fmt.Errorf("order %s is %s", orderID, order.Status)
+	}
+
+	output, err := om.cronosClient.SimulateFill(ctx, order.SourceEscrowAddr, inputAmount.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to quote order %s: %w", orderID, err)
+	}
+	outputAmount, ok := new(big.Int).SetString(output, 10)
+	if !ok || outputAmount.Sign() < 0 {
+		return nil, fmt.Errorf("invalid simulated output amount %q", output)
+	}
+
+	quote := &FillQuote{
+		OrderID:      orderID,
+		InputAmount:  new(big.Int).Set(inputAmount),
+		OutputAmount: outputAmount,
+		QuotedAt:     time.Now(),
+	}
+	if order.DutchAuction != nil && order.CurrentPrice != nil {
+		quote.Price = new(big.Int).Set(order.CurrentPrice)
+	}
+	return quote, nil
+}
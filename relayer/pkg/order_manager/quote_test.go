@@ -0,0 +1,82 @@
+package order_manager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// simulatingCronosClient answers simulate_fill queries with a fixed response
+type simulatingCronosClient struct {
+	recordingCronosClient
+	output string
+	err    error
+	inputs []string
+}
+
+func (c *simulatingCronosClient) SimulateFill(ctx context.Context, escrowAddr string, inputAmount string) (string, error) {
+	c.inputs = append(c.inputs, escrowAddr+":"+inputAmount)
+	return c.output, c.err
+}
+
+func newQuotedOrder(om *OrderManager) *Order {
+	order := newMatchedOrder("order-1")
+	order.Status = OrderStatusActive
+	order.DutchAuction = &DutchAuctionParams{InitialPrice: big.NewInt(2000)}
+	order.CurrentPrice = big.NewInt(1500)
+	om.activeOrders[order.ID] = order
+	return order
+}
+
+func TestQuoteFill(t *testing.T) {
+	client := &simulatingCronosClient{output: "666666666666666666"}
+	om := newTestOrderManager(t, client)
+	newQuotedOrder(om)
+
+	quote, err := om.QuoteFill(context.Background(), "order-1", big.NewInt(1000))
+	if err != nil {
+		t.Fatalf("QuoteFill failed: %v", err)
+	}
+
+	if len(client.inputs) != 1 || client.inputs[0] != "crc1escrow:1000" {
+		t.Fatalf("expected the source escrow to simulate a fill of 1000, got %v", client.inputs)
+	}
+	if quote.OutputAmount.String() != "666666666666666666" || quote.InputAmount.Int64() != 1000 {
+		t.Fatalf("unexpected quote %+v", quote)
+	}
+	if quote.Price == nil || quote.Price.Int64() != 1500 {
+		t.Fatalf("expected the current auction price, got %v", quote.Price)
+	}
+}
+
+func TestQuoteFillErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		client  *simulatingCronosClient
+		orderID string
+		input   *big.Int
+		mutate  func(order *Order)
+	}{
+		{name: "unknown order", client: &simulatingCronosClient{output: "1"}, orderID: "missing", input: big.NewInt(1)},
+		{name: "zero input", client: &simulatingCronosClient{output: "1"}, orderID: "order-1", input: big.NewInt(0)},
+		{name: "completed order", client: &simulatingCronosClient{output: "1"}, orderID: "order-1", input: big.NewInt(1),
+			mutate: func(order *Order) { order.Status = OrderStatusCompleted }},
+		{name: "Ethereum order", client: &simulatingCronosClient{output: "1"}, orderID: "order-1", input: big.NewInt(1),
+			mutate: func(order *Order) { order.Type = OrderTypeEthereumToCronos }},
+		{name: "query fails", client: &simulatingCronosClient{err: errors.New("fill exceeds remaining amount")}, orderID: "order-1", input: big.NewInt(1)},
+		{name: "malformed output", client: &simulatingCronosClient{output: "1.5"}, orderID: "order-1", input: big.NewInt(1)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			om := newTestOrderManager(t, tc.client)
+			order := newQuotedOrder(om)
+			if tc.mutate != nil {
+				tc.mutate(order)
+			}
+
+			if _, err := om.QuoteFill(context.Background(), tc.orderID, tc.input); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
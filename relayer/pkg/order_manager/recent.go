@@ -0,0 +1,37 @@
+package order_manager
+
+// recentOrdersLimit is how many finished order IDs AddOrder remembers
+const recentOrdersLimit = 10000
+
+// recentOrderIDs remembers the IDs of the most recently finished orders, so
+// an escrow found again by a rescan isn't processed a second time once its
+// order has left activeOrders. The oldest ID is forgotten first
+type recentOrderIDs struct {
+	limit int
+	ids   map[string]struct{}
+	queue []string
+}
+
+func newRecentOrderIDs(limit int) *recentOrderIDs {
+	return &recentOrderIDs{limit: limit, ids: make(map[string]struct{})}
+}
+
+// add remembers id, forgetting the oldest ID once over the limit
+func (r *recentOrderIDs) add(id string) {
+	if _, exists := r.ids[id]; exists {
+		return
+	}
+	r.ids[id] = struct{}{}
+	r.queue = append(r.queue, id)
+
+	if len(r.queue) > r.limit {
+		delete(r.ids, r.queue[0])
+		r.queue = r.queue[1:]
+	}
+}
+
+// contains reports whether id is remembered
+func (r *recentOrderIDs) contains(id string) bool {
+	_, exists := r.ids[id]
+	return exists
+}
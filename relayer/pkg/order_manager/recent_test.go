@@ -0,0 +1,22 @@
+package order_manager
+
+import "testing"
+
+func TestRecentOrderIDsForgetsOldest(t *testing.T) {
+	recent := newRecentOrderIDs(2)
+	recent.add("order-1")
+	recent.add("order-2")
+	recent.add("order-1")
+
+	if !recent.contains("order-1") || !recent.contains("order-2") {
+		t.Fatal("expected both orders to be remembered")
+	}
+
+	recent.add("order-3")
+	if recent.contains("order-1") {
+		t.Fatal("expected the oldest order to be forgotten")
+	}
+	if !recent.contains("order-2") || !recent.contains("order-3") {
+		t.Fatal("expected the newest orders to be remembered")
+	}
+}
@@ -0,0 +1,145 @@
+package order_manager
+
+import (
+	"strings"
+)
+
+// Escrow is a destination escrow found on-chain while reconciling
+type Escrow struct {
+	Chain      string `json:"chain"`
+	Address    string `json:"address"`
+	SecretHash string `json:"secret_hash"`
+	Status     string `json:"status"`
+	Timelock   uint64 `json:"timelock"`
+	DeployedAt uint64 `json:"deployed_at,omitempty"`
+	// Block is the block an Ethereum escrow was created in
+	Block uint64 `json:"block,omitempty"`
+}
+
+// ReconcileResult is the outcome of rebuilding order state from chain
+type ReconcileResult struct {
+	// Recovered orders still need the relayer to finish them
+	Recovered []*Order
+	// Completed orders need no further action
+	Completed []*Order
+	// Orphaned escrows are destination escrows without a source escrow
+	Orphaned []Escrow
+}
+
+// Reconcile rebuilds orders from the escrows found on both chains, pairing
+// source and destination escrows by hashlock. The relayer deploys Ethereum
+// destination escrows through the same factory makers use, so an Ethereum
+// escrow carrying a Cronos source escrow's hashlock is that order's
+// destination and every other Ethereum escrow is a source
+func Reconcile(cronosSources, ethereumEscrows []*Order, cronosDestinations []Escrow) *ReconcileResult {
+	result := &ReconcileResult{}
+
+	ethereumByHash := make(map[string]*Order, len(ethereumEscrows))
+	for _, escrow := range ethereumEscrows {
+		ethereumByHash[normalizeHash(escrow.SecretHash)] = escrow
+	}
+
+	for _, order := range cronosSources {
+		hash := normalizeHash(order.SecretHash)
+		var dest *Escrow
+		if escrow, ok := ethereumByHash[hash]; ok {
+			delete(ethereumByHash, hash)
+			dest = &Escrow{
+				Chain:      "ethereum",
+				Address:    escrow.SourceEscrowAddr,
+				SecretHash: escrow.SecretHash,
+				Status:     string(escrow.Status),
+				Timelock:   escrow.Timelock,
+				DeployedAt: uint64(escrow.CreatedAt.Unix()),
+				Block:      escrow.SourceBlock,
+			}
+		}
+		result.add(order, dest)
+	}
+
+	cronosByHash := make(map[string]Escrow, len(cronosDestinations))
+	for _, escrow := range cronosDestinations {
+		cronosByHash[normalizeHash(escrow.SecretHash)] = escrow
+	}
+
+	for _, order := range ethereumEscrows {
+		hash := normalizeHash(order.SecretHash)
+		if _, ok := ethereumByHash[hash]; !ok {
+			// Already paired as the destination of a Cronos order
+			continue
+		}
+		var dest *Escrow
+		if escrow, ok := cronosByHash[hash]; ok {
+			delete(cronosByHash, hash)
+			dest = &escrow
+		}
+		result.add(order, dest)
+	}
+
+	for _, escrow := range cronosDestinations {
+		if _, ok := cronosByHash[normalizeHash(escrow.SecretHash)]; ok {
+			result.Orphaned = append(result.Orphaned, escrow)
+		}
+	}
+
+	return result
+}
+
+// add classifies a source order by the state of its escrows
+func (r *ReconcileResult) add(order *Order, dest *Escrow) {
+	if dest != nil {
+		order.DestEscrowAddr = dest.Address
+		order.DestTimelock = dest.Timelock
+		order.DestDeployedAt = dest.DeployedAt
+		order.DestBlock = dest.Block
+	}
+
+	switch {
+	case isWithdrawnStatus(string(order.Status)):
+		order.Status = OrderStatusCompleted
+		r.Completed = append(r.Completed, order)
+	case isCancelledStatus(string(order.Status)):
+		order.Status = OrderStatusCancelled
+		r.Completed = append(r.Completed, order)
+	case dest == nil:
+		// The destination escrow was never deployed, so the order starts over
+		order.Status = OrderStatusPending
+		r.Recovered = append(r.Recovered, order)
+	case isCancelledStatus(dest.Status):
+		// The maker refunds the source escrow; nothing is left for the relayer
+		order.Status = OrderStatusCancelled
+		r.Completed = append(r.Completed, order)
+	default:
+		// A withdrawn destination escrow has revealed the secret, which the
+		// order manager picks up to claim the source escrow
+		order.Status = OrderStatusActive
+		r.Recovered = append(r.Recovered, order)
+	}
+}
+
+// normalizeHash lowercases a hex hashlock and strips its 0x prefix
+func normalizeHash(hash string) string {
+	return strings.TrimPrefix(strings.ToLower(hash), "0x")
+}
+
+// isWithdrawnStatus reports whether an on-chain escrow status means its funds
+// were claimed
+func isWithdrawnStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "withdrawn", "claimed", string(OrderStatusCompleted):
+		return true
+	default:
+		return false
+	}
+}
+
+// isCancelledStatus reports whether an on-chain escrow status means its funds
+// were returned
+func isCancelledStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "cancelled", "canceled", "refunded":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,119 @@
+package order_manager
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReconcile(t *testing.T) {
+	deployedAt := time.Unix(1700000000, 0)
+
+	cronosSources := []*Order{
+		// Destination escrow deployed on Ethereum and still locked
+		{ID: "c-active", Type: OrderTypeCronosToEthereum, Status: "active", SecretHash: "0xAA", SourceEscrowAddr: "crc1a"},
+		// Destination escrow never deployed
+		{ID: "c-new", Type: OrderTypeCronosToEthereum, Status: "active", SecretHash: "0xbb", SourceEscrowAddr: "crc1b"},
+		// Already claimed by the relayer
+		{ID: "c-done", Type: OrderTypeCronosToEthereum, Status: "withdrawn", SecretHash: "0xcc", SourceEscrowAddr: "crc1c"},
+	}
+	ethereumEscrows := []*Order{
+		{ID: "e-dest", Status: "Withdrawn", SecretHash: "0xaa", SourceEscrowAddr: "0xdest", Timelock: 500, CreatedAt: deployedAt},
+		{ID: "e-active", Type: OrderTypeEthereumToCronos, Status: "Active", SecretHash: "0xdd", SourceEscrowAddr: "0xsrc"},
+		{ID: "e-refunded", Type: OrderTypeEthereumToCronos, Status: "Cancelled", SecretHash: "0xee", SourceEscrowAddr: "0xsrc2"},
+	}
+	cronosDestinations := []Escrow{
+		{Chain: "cronos", Address: "crc1dest", SecretHash: "dd", Status: "active", Timelock: 400},
+		{Chain: "cronos", Address: "crc1orphan", SecretHash: "0xff", Status: "active"},
+	}
+
+	result := Reconcile(cronosSources, ethereumEscrows, cronosDestinations)
+
+	recovered := make(map[string]*Order)
+	for _, order := range result.Recovered {
+		recovered[order.ID] = order
+	}
+	if len(recovered) != 3 {
+		t.Fatalf("expected 3 recovered orders, got %v", result.Recovered)
+	}
+
+	if order := recovered["c-active"]; order == nil || order.Status != OrderStatusActive ||
+		order.DestEscrowAddr != "0xdest" || order.DestTimelock != 500 ||
+		order.DestDeployedAt != uint64(deployedAt.Unix()) {
+		t.Fatalf("unexpected recovered Cronos order: %+v", order)
+	}
+	if order := recovered["c-new"]; order == nil || order.Status != OrderStatusPending || order.DestEscrowAddr != "" {
+		t.Fatalf("expected order without destination escrow to be pending, got %+v", order)
+	}
+	if order := recovered["e-active"]; order == nil || order.Status != OrderStatusActive ||
+		order.DestEscrowAddr != "crc1dest" || order.DestTimelock != 400 {
+		t.Fatalf("unexpected recovered Ethereum order: %+v", order)
+	}
+	if _, ok := recovered["e-dest"]; ok {
+		t.Fatal("destination escrow must not be recovered as an order")
+	}
+
+	completed := make(map[string]OrderStatus)
+	for _, order := range result.Completed {
+		completed[order.ID] = order.Status
+	}
+	if len(completed) != 2 || completed["c-done"] != OrderStatusCompleted || completed["e-refunded"] != OrderStatusCancelled {
+		t.Fatalf("unexpected completed orders: %v", completed)
+	}
+
+	if len(result.Orphaned) != 1 || result.Orphaned[0].Address != "crc1orphan" {
+		t.Fatalf("expected crc1orphan to be orphaned, got %v", result.Orphaned)
+	}
+}
+
+func TestMergeOrdersKeepsStoredOrders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.json")
+	if err := SaveOrders(path, []*Order{{ID: "order-1", Secret: "known"}}); err != nil {
+		t.Fatalf("failed to save orders: %v", err)
+	}
+
+	added, err := MergeOrders(path, []*Order{{ID: "order-1"}, {ID: "order-2"}})
+	if err != nil {
+		t.Fatalf("failed to merge orders: %v", err)
+	}
+	if added != 1 {
+		t.Fatalf("expected 1 added order, got %d", added)
+	}
+
+	orders, err := LoadOrders(path)
+	if err != nil {
+		t.Fatalf("failed to load orders: %v", err)
+	}
+	if len(orders) != 2 || orders[0].ID != "order-1" || orders[0].Secret != "known" || orders[1].ID != "order-2" {
+		t.Fatalf("unexpected merged orders: %+v", orders)
+	}
+}
+
+func TestRestoreOrdersRequeuesPendingOrders(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	if err := SaveOrders(om.config.Relayer.OrderStorePath, []*Order{
+		{ID: "active", Status: OrderStatusActive},
+		{ID: "pending", Status: OrderStatusPending},
+	}); err != nil {
+		t.Fatalf("failed to save orders: %v", err)
+	}
+
+	if err := om.restoreOrders(); err != nil {
+		t.Fatalf("failed to restore orders: %v", err)
+	}
+
+	if _, ok := om.GetOrder("active"); !ok {
+		t.Fatal("active order should be restored directly")
+	}
+	if _, ok := om.GetOrder("pending"); ok {
+		t.Fatal("pending order should wait for new order handling")
+	}
+	select {
+	case order := <-om.newOrdersChan:
+		if order.ID != "pending" {
+			t.Fatalf("expected pending order to be queued, got %s", order.ID)
+		}
+	default:
+		t.Fatal("pending order was not queued")
+	}
+}
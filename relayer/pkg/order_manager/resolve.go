@@ -0,0 +1,80 @@
+package order_manager
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// ErrOrderNotResolvable is returned when an order's state doesn't allow an
+// operator to resolve it by hand
+var ErrOrderNotResolvable = errors.New("order cannot be resolved")
+
+// ForceCompleteOrder marks an order completed on an operator's request, after
+// its swap was settled outside the relayer's automation. The order stops
+// being tracked, and the intervention is recorded in its history with the
+// operator and reason
+func (om *OrderManager) ForceCompleteOrder(orderID, reason, operator string) error {
+	return om.resolveOrder(orderID, OrderStatusCompleted, reason, operator)
+}
+
+// ForceFailOrder marks an order failed on an operator's request, so the
+// relayer stops acting on it. Like other failed orders it stays tracked for
+// reporting, and the intervention is recorded in its history with the
+// operator and reason
+func (om *OrderManager) ForceFailOrder(orderID, reason, operator string) error {
+	if reason == "" {
+		return fmt.Errorf("%w: a reason is required to fail an order", ErrOrderNotResolvable)
+	}
+	return om.resolveOrder(orderID, OrderStatusFailed, reason, operator)
+}
+
+// resolveOrder moves a tracked order to status on an operator's request
+func (om *OrderManager) resolveOrder(orderID string, status OrderStatus, reason, operator string) error {
+	om.ordersMutex.Lock()
+	defer om.ordersMutex.Unlock()
+
+	order, exists := om.trackedOrder(orderID)
+	if !exists {
+		if _, queued := om.queuedOrders[orderID]; queued {
+			return fmt.Errorf("%w: order is still queued", ErrOrderNotResolvable)
+		}
+		return ErrOrderNotFound
+	}
+	if isFinished(order) || order.Status == status {
+		return fmt.Errorf("%w: order is already %s", ErrOrderNotResolvable, order.Status)
+	}
+
+	from := order.Status
+	note := fmt.Sprintf("%s by operator %s", resolutionVerb(status), operator)
+	if reason != "" {
+		note += ": " + reason
+	}
+	if status == OrderStatusFailed {
+		order.LastError = reason
+	}
+	om.setStatus(order, status, note, "")
+	switch status {
+	case OrderStatusCompleted:
+		om.retireOrder(order)
+	case OrderStatusFailed:
+		om.setAsideFailedOrder(order)
+	}
+
+	om.logger.Warn("Order resolved manually",
+		zap.String("order_id", orderID),
+		zap.String("from", string(from)),
+		zap.String("to", string(status)),
+		zap.String("operator", operator),
+		zap.String("reason", reason))
+	return nil
+}
+
+// resolutionVerb describes a manual move to status in an order's history
+func resolutionVerb(status OrderStatus) string {
+	if status == OrderStatusCompleted {
+		return "force-completed"
+	}
+	return "force-failed"
+}
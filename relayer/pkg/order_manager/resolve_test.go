@@ -0,0 +1,90 @@
+package order_manager
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestForceCompleteOrder(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	om.config.Relayer.MaxOrderHistory = 10
+	order := newMatchedOrder("order-1")
+	om.trackOrder(order)
+
+	if err := om.ForceCompleteOrder(order.ID, "settled by hand", "alice"); err != nil {
+		t.Fatalf("expected order to be completed, got %v", err)
+	}
+
+	if order.Status != OrderStatusCompleted {
+		t.Fatalf("expected completed status, got %s", order.Status)
+	}
+	if _, tracked := om.GetOrder(order.ID); tracked {
+		t.Fatal("completed order should no longer be tracked")
+	}
+	if finished := <-om.completedOrders; finished != order {
+		t.Fatalf("expected completed order to be reported, got %+v", finished)
+	}
+
+	last := order.History[len(order.History)-1]
+	if last.From != OrderStatusMatched || last.To != OrderStatusCompleted {
+		t.Fatalf("expected a matched to completed transition, got %+v", last)
+	}
+	if last.Note != "force-completed by operator alice: settled by hand" || last.Timestamp.IsZero() {
+		t.Fatalf("expected the intervention to be recorded, got %+v", last)
+	}
+}
+
+func TestForceFailOrder(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+	om.config.Relayer.MaxOrderHistory = 10
+	order := newMatchedOrder("order-1")
+	om.trackOrder(order)
+
+	if err := om.ForceFailOrder(order.ID, "", "alice"); !errors.Is(err, ErrOrderNotResolvable) {
+		t.Fatalf("expected a reason to be required, got %v", err)
+	}
+
+	if err := om.ForceFailOrder(order.ID, "escrow drained", "alice"); err != nil {
+		t.Fatalf("expected order to be failed, got %v", err)
+	}
+	if order.Status != OrderStatusFailed || order.LastError != "escrow drained" {
+		t.Fatalf("expected failed status with the reason, got %s: %q", order.Status, order.LastError)
+	}
+	// Failed orders stay tracked for reporting
+	if _, tracked := om.GetOrder(order.ID); !tracked {
+		t.Fatal("failed order should still be tracked")
+	}
+	last := order.History[len(order.History)-1]
+	if last.To != OrderStatusFailed || !strings.Contains(last.Note, "force-failed by operator alice") {
+		t.Fatalf("expected the intervention to be recorded, got %+v", last)
+	}
+
+	if err := om.ForceFailOrder(order.ID, "again", "alice"); !errors.Is(err, ErrOrderNotResolvable) {
+		t.Fatalf("expected a failed order not to be failed twice, got %v", err)
+	}
+
+	// A failed order can still be completed once it is settled by hand
+	if err := om.ForceCompleteOrder(order.ID, "refunded maker", "bob"); err != nil {
+		t.Fatalf("expected failed order to be completed, got %v", err)
+	}
+}
+
+func TestForceResolveOrderRejected(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+
+	if err := om.ForceCompleteOrder("missing", "", "alice"); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("expected unknown order to be reported, got %v", err)
+	}
+
+	om.AddOrder(&Order{ID: "queued"})
+	if err := om.ForceFailOrder("queued", "stuck", "alice"); !errors.Is(err, ErrOrderNotResolvable) {
+		t.Fatalf("expected queued order to be rejected, got %v", err)
+	}
+
+	cancelled := &Order{ID: "cancelled", Status: OrderStatusCancelled}
+	om.trackOrder(cancelled)
+	if err := om.ForceCompleteOrder(cancelled.ID, "", "alice"); !errors.Is(err, ErrOrderNotResolvable) {
+		t.Fatalf("expected finished order to be rejected, got %v", err)
+	}
+}
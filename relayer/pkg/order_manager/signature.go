@@ -0,0 +1,195 @@
+package order_manager
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrInvalidSignature is returned for submitted orders whose signature
+// doesn't prove the maker signed them
+var ErrInvalidSignature = errors.New("invalid order signature")
+
+// OrderSignature is a maker's hex-encoded signature over an order's digest.
+// Orders from Ethereum carry a 65-byte recoverable signature of the digest as
+// an EIP-191 personal message. Orders from Cronos carry a 64-byte secp256k1
+// signature of the digest along with the signer's compressed public key,
+// which Cosmos signatures don't embed
+type OrderSignature struct {
+	Signature string `json:"signature"`
+	PubKey    string `json:"pub_key,omitempty"`
+}
+
+// signedOrder is the part of an order its maker signs. Its fields encode in
+// declaration order, so the JSON encoding is canonical
+type signedOrder struct {
+	SourceChain      string      `json:"source_chain"`
+	DestinationChain string      `json:"destination_chain"`
+	Maker            string      `json:"maker"`
+	Taker            string      `json:"taker"`
+	SecretHash       string      `json:"secret_hash"`
+	Timelock         uint64      `json:"timelock"`
+	SourceEscrowAddr string      `json:"source_escrow_addr"`
+	SourceAsset      signedAsset `json:"source_asset"`
+	DestinationAsset signedAsset `json:"destination_asset"`
+}
+
+type signedAsset struct {
+	Symbol   string `json:"symbol"`
+	Address  string `json:"address"`
+	Amount   string `json:"amount"`
+	Decimals int    `json:"decimals"`
+}
+
+// OrderDigest returns the SHA256 digest of the canonical JSON encoding of the
+// order fields a maker signs
+func OrderDigest(order *Order) []byte {
+	bz, err := json.Marshal(signedOrder{
+		SourceChain:      order.SourceChain,
+		DestinationChain: order.DestinationChain,
+		Maker:            order.Maker,
+		Taker:            order.Taker,
+		SecretHash:       normalizeHash(order.SecretHash),
+		Timelock:         order.Timelock,
+		SourceEscrowAddr: order.SourceEscrowAddr,
+		SourceAsset:      newSignedAsset(order.SourceAsset),
+		DestinationAsset: newSignedAsset(order.DestinationAsset),
+	})
+	if err != nil {
+		// Only strings and integers are encoded
+		panic(fmt.Sprintf("failed to encode order: %v", err))
+	}
+
+	digest := sha256.Sum256(bz)
+	return digest[:]
+}
+
+func newSignedAsset(asset AssetInfo) signedAsset {
+	amount := "0"
+	if asset.Amount != nil {
+		amount = asset.Amount.String()
+	}
+	return signedAsset{
+		Symbol:   asset.Symbol,
+		Address:  asset.Address,
+		Amount:   amount,
+		Decimals: asset.Decimals,
+	}
+}
+
+// VerifyOrderSignature checks that sig was made by the order's maker over the
+// order's digest, using the signature scheme of the order's source chain
+func VerifyOrderSignature(order *Order, sig OrderSignature) error {
+	signature, err := decodeHex(sig.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature: %v", ErrInvalidSignature, err)
+	}
+	digest := OrderDigest(order)
+
+	switch order.SourceChain {
+	case "ethereum":
+		return verifyEthereumSignature(digest, order.Maker, signature)
+	case "cronos":
+		pubKey, err := decodeHex(sig.PubKey)
+		if err != nil {
+			return fmt.Errorf("%w: malformed public key: %v", ErrInvalidSignature, err)
+		}
+		return verifyCosmosSignature(digest, order.Maker, signature, pubKey)
+	default:
+		return fmt.Errorf("%w: unsupported source chain %q", ErrInvalidSignature, order.SourceChain)
+	}
+}
+
+// verifyEthereumSignature recovers the signer of a personal message signature
+// over digest and checks it is maker. V may be 0/1 or 27/28
+func verifyEthereumSignature(digest []byte, maker string, signature []byte) error {
+	if len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: signature is %d bytes, expected %d", ErrInvalidSignature, len(signature), crypto.SignatureLength)
+	}
+	makerAddr, err := evmAddress(maker)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	sig := bytes.Clone(signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(accounts.TextHash(digest), sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	if signer := crypto.PubkeyToAddress(*pubKey); signer != makerAddr {
+		return fmt.Errorf("%w: signed by %s, not maker %s", ErrInvalidSignature, signer.Hex(), makerAddr.Hex())
+	}
+	return nil
+}
+
+// verifyCosmosSignature checks that pubKey belongs to maker and signed digest
+func verifyCosmosSignature(digest []byte, maker string, signature, pubKey []byte) error {
+	if len(pubKey) != secp256k1.PubKeySize {
+		return fmt.Errorf("%w: public key is %d bytes, expected %d", ErrInvalidSignature, len(pubKey), secp256k1.PubKeySize)
+	}
+	_, makerAddr, err := bech32.DecodeAndConvert(maker)
+	if err != nil {
+		return fmt.Errorf("%w: invalid maker %q: %v", ErrInvalidSignature, maker, err)
+	}
+
+	key := &secp256k1.PubKey{Key: pubKey}
+	if !bytes.Equal(key.Address(), makerAddr) {
+		return fmt.Errorf("%w: public key does not belong to maker %s", ErrInvalidSignature, maker)
+	}
+	if !key.VerifySignature(digest, signature) {
+		return fmt.Errorf("%w: signature does not match order", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// decodeHex decodes a hex string with an optional 0x prefix
+func decodeHex(s string) ([]byte, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty value")
+	}
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+// SubmitOrder queues an order submitted by its maker once sig proves the
+// maker signed it. The relayer sets the order's ID, status and timestamps,
+// derives its type from the source chain and drops any secret it carries
+func (om *OrderManager) SubmitOrder(order *Order, sig OrderSignature) error {
+	if err := VerifyOrderSignature(order, sig); err != nil {
+		return err
+	}
+
+	switch order.SourceChain {
+	case "cronos":
+		order.Type = OrderTypeCronosToEthereum
+	case "ethereum":
+		order.Type = OrderTypeEthereumToCronos
+	}
+
+	now := time.Now()
+	order.ID = OrderID(order.SecretHash, order.SourceChain, order.DestinationChain)
+	order.Status = OrderStatusPending
+	order.Secret = ""
+	order.History = nil
+	order.CreatedAt = now
+	order.UpdatedAt = now
+	if order.ExpiresAt.IsZero() {
+		order.ExpiresAt = time.Unix(int64(order.Timelock), 0)
+	}
+
+	om.AddOrder(order)
+	return nil
+}
@@ -0,0 +1,158 @@
+package order_manager
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func newSubmittedOrder(sourceChain, maker string) *Order {
+	return &Order{
+		SourceChain:      sourceChain,
+		DestinationChain: "ethereum",
+		Maker:            maker,
+		SecretHash:       "0x9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658",
+		Timelock:         1700000000,
+		SourceEscrowAddr: "escrow",
+		SourceAsset:      AssetInfo{Symbol: "CRO", Amount: big.NewInt(1000), Decimals: 18},
+		DestinationAsset: AssetInfo{Symbol: "ETH", Amount: big.NewInt(5), Decimals: 18},
+	}
+}
+
+// signEthereum signs an order from maker key as an Ethereum wallet would
+func signEthereum(t *testing.T, order *Order, key []byte) OrderSignature {
+	t.Helper()
+
+	priv, err := crypto.ToECDSA(key)
+	if err != nil {
+		t.Fatalf("failed to load key: %v", err)
+	}
+	sig, err := crypto.Sign(accounts.TextHash(OrderDigest(order)), priv)
+	if err != nil {
+		t.Fatalf("failed to sign order: %v", err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return OrderSignature{Signature: "0x" + hex.EncodeToString(sig)}
+}
+
+// signCosmos signs an order with key as a Cosmos wallet would
+func signCosmos(t *testing.T, order *Order, key *secp256k1.PrivKey) OrderSignature {
+	t.Helper()
+
+	sig, err := key.Sign(OrderDigest(order))
+	if err != nil {
+		t.Fatalf("failed to sign order: %v", err)
+	}
+	return OrderSignature{
+		Signature: hex.EncodeToString(sig),
+		PubKey:    hex.EncodeToString(key.PubKey().Bytes()),
+	}
+}
+
+func TestVerifyOrderSignatureEthereum(t *testing.T) {
+	makerKey := crypto.Keccak256([]byte("maker"))
+	otherKey := crypto.Keccak256([]byte("other"))
+	priv, _ := crypto.ToECDSA(makerKey)
+	maker := crypto.PubkeyToAddress(priv.PublicKey).Hex()
+
+	order := newSubmittedOrder("ethereum", maker)
+	if err := VerifyOrderSignature(order, signEthereum(t, order, makerKey)); err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+
+	if err := VerifyOrderSignature(order, signEthereum(t, order, otherKey)); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected wrong signer to be rejected, got %v", err)
+	}
+
+	sig := signEthereum(t, order, makerKey)
+	order.DestinationAsset.Amount = big.NewInt(6)
+	if err := VerifyOrderSignature(order, sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected tampered order to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyOrderSignatureCosmos(t *testing.T) {
+	makerKey := secp256k1.GenPrivKey()
+	otherKey := secp256k1.GenPrivKey()
+	maker, err := bech32.ConvertAndEncode("crc", makerKey.PubKey().Address())
+	if err != nil {
+		t.Fatalf("failed to encode maker: %v", err)
+	}
+
+	order := newSubmittedOrder("cronos", maker)
+	if err := VerifyOrderSignature(order, signCosmos(t, order, makerKey)); err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+
+	if err := VerifyOrderSignature(order, signCosmos(t, order, otherKey)); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected wrong signer to be rejected, got %v", err)
+	}
+
+	// A signature by another key can't be passed off with the maker's key
+	forged := signCosmos(t, order, otherKey)
+	forged.PubKey = hex.EncodeToString(makerKey.PubKey().Bytes())
+	if err := VerifyOrderSignature(order, forged); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected forged signature to be rejected, got %v", err)
+	}
+
+	sig := signCosmos(t, order, makerKey)
+	order.Timelock++
+	if err := VerifyOrderSignature(order, sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected tampered order to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyOrderSignatureMalformed(t *testing.T) {
+	order := newSubmittedOrder("ethereum", "0x1111111111111111111111111111111111111111")
+	for _, sig := range []OrderSignature{
+		{},
+		{Signature: "zz"},
+		{Signature: "0x0102"},
+	} {
+		if err := VerifyOrderSignature(order, sig); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("expected %+v to be rejected, got %v", sig, err)
+		}
+	}
+
+	order.SourceChain = "osmosis"
+	if err := VerifyOrderSignature(order, OrderSignature{Signature: "01"}); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected unsupported chain to be rejected, got %v", err)
+	}
+}
+
+func TestSubmitOrder(t *testing.T) {
+	om := newTestOrderManager(t, nil)
+	makerKey := crypto.Keccak256([]byte("maker"))
+	priv, _ := crypto.ToECDSA(makerKey)
+
+	order := newSubmittedOrder("ethereum", crypto.PubkeyToAddress(priv.PublicKey).Hex())
+	order.DestinationChain = "cronos"
+	sig := signEthereum(t, order, makerKey)
+
+	tampered := *order
+	tampered.Maker = "0x1111111111111111111111111111111111111111"
+	if err := om.SubmitOrder(&tampered, sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected tampered order to be rejected, got %v", err)
+	}
+	if queued := len(om.newOrdersChan); queued != 0 {
+		t.Fatalf("expected rejected order not to be queued, got %d queued", queued)
+	}
+
+	order.Secret = "leaked"
+	if err := om.SubmitOrder(order, sig); err != nil {
+		t.Fatalf("expected order to be accepted, got %v", err)
+	}
+	queued := <-om.newOrdersChan
+	if queued.ID != OrderID(order.SecretHash, "ethereum", "cronos") {
+		t.Fatalf("expected canonical order ID, got %s", queued.ID)
+	}
+	if queued.Type != OrderTypeEthereumToCronos || queued.Status != OrderStatusPending || queued.Secret != "" {
+		t.Fatalf("unexpected submitted order: %+v", queued)
+	}
+}
@@ -0,0 +1,62 @@
+package order_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+)
+
+// completeIfSpent handles a source escrow withdrawal that failed with
+// withdrawErr. If the withdrawal reverted because the escrow was already
+// withdrawn, a counterparty revealed the secret and claimed it first, which
+// settles the swap, so the order is completed instead of being retried. It
+// reports whether the order was completed
+func (om *OrderManager) completeIfSpent(ctx context.Context, order *Order, withdrawErr error) bool {
+	if !errors.Is(withdrawErr, chain_errors.ErrReverted) {
+		return false
+	}
+
+	withdrawn, err := om.sourceEscrowWithdrawn(ctx, order)
+	if err != nil {
+		om.logger.Warn("Failed to check whether source escrow was already withdrawn",
+			zap.String("order_id", order.ID),
+			zap.String("escrow", order.SourceEscrowAddr),
+			zap.Error(err))
+		return false
+	}
+	if !withdrawn {
+		return false
+	}
+
+	om.SetStatus(order, OrderStatusCompleted, "source escrow already withdrawn by counterparty", "")
+	om.secretManager.Forget(order.SecretHash)
+
+	om.logger.Info("Source escrow was withdrawn by a counterparty first",
+		zap.String("order_id", order.ID),
+		zap.String("escrow", order.SourceEscrowAddr),
+		zap.NamedError("withdraw_error", withdrawErr))
+	return true
+}
+
+// sourceEscrowWithdrawn reports whether an order's source escrow has been
+// withdrawn: a Cronos escrow whose status is completed, or an Ethereum escrow
+// with a withdrawal on record
+func (om *OrderManager) sourceEscrowWithdrawn(ctx context.Context, order *Order) (bool, error) {
+	if order.Type == OrderTypeCronosToEthereum {
+		status, err := om.cronosClient.GetEscrowStatus(ctx, order.SourceEscrowAddr)
+		if err != nil {
+			return false, fmt.Errorf("failed to get source escrow status: %w", err)
+		}
+		return OrderStatus(status) == OrderStatusCompleted, nil
+	}
+
+	withdrawals, err := om.ethereumClient.GetRevealedSecrets(ctx, order.SourceEscrowAddr, order.SourceBlock)
+	if err != nil {
+		return false, fmt.Errorf("failed to get source escrow withdrawals: %w", err)
+	}
+	return len(withdrawals) > 0, nil
+}
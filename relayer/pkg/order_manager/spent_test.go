@@ -0,0 +1,97 @@
+package order_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+)
+
+// frontRunCronosClient fails withdrawals with withdrawErr and reports the
+// escrow as status
+type frontRunCronosClient struct {
+	recordingCronosClient
+	withdrawErr  error
+	status       string
+	statusChecks int
+}
+
+func (c *frontRunCronosClient) WithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string) (string, error) {
+	return "", c.withdrawErr
+}
+
+func (c *frontRunCronosClient) PartialWithdrawFromEscrow(ctx context.Context, escrowAddr string, secret string, amount string) (string, error) {
+	return "", c.withdrawErr
+}
+
+func (c *frontRunCronosClient) GetEscrowStatus(ctx context.Context, escrowAddr string) (string, error) {
+	c.statusChecks++
+	return c.status, nil
+}
+
+func TestExecuteSwapCompletesFrontRunOrder(t *testing.T) {
+	reverted := chain_errors.Classify(fmt.Errorf("execute wasm contract failed: escrow already withdrawn"))
+
+	for _, tc := range []struct {
+		name         string
+		partial      bool
+		withdrawErr  error
+		status       string
+		wantErr      bool
+		wantStatus   OrderStatus
+		statusChecks int
+	}{
+		{
+			name:         "withdrawn by counterparty",
+			withdrawErr:  reverted,
+			status:       "completed",
+			wantStatus:   OrderStatusCompleted,
+			statusChecks: 1,
+		},
+		{
+			name:         "partial fill withdrawn by counterparty",
+			partial:      true,
+			withdrawErr:  reverted,
+			status:       "completed",
+			wantStatus:   OrderStatusCompleted,
+			statusChecks: 1,
+		},
+		{
+			name:         "reverted with escrow still active",
+			withdrawErr:  reverted,
+			status:       "active",
+			wantErr:      true,
+			wantStatus:   OrderStatusMatched,
+			statusChecks: 1,
+		},
+		{
+			name:        "not a revert",
+			withdrawErr: chain_errors.Classify(errors.New("dial tcp: connection refused")),
+			status:      "completed",
+			wantErr:     true,
+			wantStatus:  OrderStatusMatched,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &frontRunCronosClient{withdrawErr: tc.withdrawErr, status: tc.status}
+			om := newTestOrderManager(t, client)
+			order := newMatchedOrder("order-1")
+			if tc.partial {
+				order = newPartialFillOrder("order-1")
+			}
+
+			err := om.executeSwap(context.Background(), order)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if order.Status != tc.wantStatus {
+				t.Fatalf("expected status %s, got %s", tc.wantStatus, order.Status)
+			}
+			if client.statusChecks != tc.statusChecks {
+				t.Fatalf("expected %d escrow status checks, got %d", tc.statusChecks, client.statusChecks)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package order_manager
+
+import "sync"
+
+// statusIndex buckets tracked orders by status so callers interested in one
+// status don't scan every order. Status changes of tracked orders must go
+// through setStatus so the buckets follow them. It has its own lock, so
+// readers don't contend ordersMutex
+type statusIndex struct {
+	mutex    sync.RWMutex
+	orders   map[string]*Order
+	byStatus map[OrderStatus]map[string]*Order
+}
+
+func newStatusIndex() *statusIndex {
+	return &statusIndex{
+		orders:   make(map[string]*Order),
+		byStatus: make(map[OrderStatus]map[string]*Order),
+	}
+}
+
+// add tracks order under its current status, replacing any order tracked
+// under the same ID
+func (idx *statusIndex) add(order *Order) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	if existing, ok := idx.orders[order.ID]; ok {
+		idx.unbucket(existing.ID, existing.Status)
+	}
+	idx.orders[order.ID] = order
+	idx.bucket(order)
+}
+
+// remove stops tracking the order with the given ID
+func (idx *statusIndex) remove(id string) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	if existing, ok := idx.orders[id]; ok {
+		idx.unbucket(id, existing.Status)
+		delete(idx.orders, id)
+	}
+}
+
+// setStatus sets the order's status, moving it to the new bucket if it is
+// tracked. Orders that aren't tracked yet are only updated
+func (idx *statusIndex) setStatus(order *Order, status OrderStatus) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	tracked := idx.orders[order.ID] == order
+	if tracked {
+		idx.unbucket(order.ID, order.Status)
+	}
+	order.Status = status
+	if tracked {
+		idx.bucket(order)
+	}
+}
+
+// withStatus returns the tracked orders with the given status
+func (idx *statusIndex) withStatus(status OrderStatus) []*Order {
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	bucket := idx.byStatus[status]
+	orders := make([]*Order, 0, len(bucket))
+	for _, order := range bucket {
+		orders = append(orders, order)
+	}
+	return orders
+}
+
+// bucket adds order to its status bucket. The caller must hold mutex
+func (idx *statusIndex) bucket(order *Order) {
+	bucket, ok := idx.byStatus[order.Status]
+	if !ok {
+		bucket = make(map[string]*Order)
+		idx.byStatus[order.Status] = bucket
+	}
+	bucket[order.ID] = order
+}
+
+// unbucket removes an order from a status bucket, dropping the bucket once
+// it is empty. The caller must hold mutex
+func (idx *statusIndex) unbucket(id string, status OrderStatus) {
+	bucket := idx.byStatus[status]
+	delete(bucket, id)
+	if len(bucket) == 0 {
+		delete(idx.byStatus, status)
+	}
+}
@@ -0,0 +1,145 @@
+package order_manager
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"go.uber.org/zap"
+)
+
+func orderIDs(orders []*Order) []string {
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestStatusIndexFollowsTransitions(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+
+	first := &Order{ID: "order-1", Status: OrderStatusActive}
+	second := &Order{ID: "order-2", Status: OrderStatusActive}
+	om.ordersMutex.Lock()
+	om.trackOrder(first)
+	om.trackOrder(second)
+	om.ordersMutex.Unlock()
+
+	if ids := orderIDs(om.GetOrdersByStatus(OrderStatusActive)); fmt.Sprint(ids) != "[order-1 order-2]" {
+		t.Fatalf("expected both orders active, got %v", ids)
+	}
+
+	om.SetStatus(first, OrderStatusMatched, "matched in test", "")
+	if ids := orderIDs(om.GetOrdersByStatus(OrderStatusActive)); fmt.Sprint(ids) != "[order-2]" {
+		t.Fatalf("expected only order-2 active, got %v", ids)
+	}
+	if ids := orderIDs(om.GetOrdersByStatus(OrderStatusMatched)); fmt.Sprint(ids) != "[order-1]" {
+		t.Fatalf("expected order-1 matched, got %v", ids)
+	}
+
+	om.SetStatus(first, OrderStatusCompleted, "", "")
+	om.ordersMutex.Lock()
+	om.retireOrder(first)
+	om.ordersMutex.Unlock()
+	if orders := om.GetOrdersByStatus(OrderStatusCompleted); len(orders) != 0 {
+		t.Fatalf("expected retired order to leave the index, got %v", orderIDs(orders))
+	}
+}
+
+func TestStatusIndexIgnoresUntrackedOrders(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+
+	// Orders being handled before they are tracked still change status
+	order := &Order{ID: "order-1", Status: OrderStatusPending}
+	om.SetStatus(order, OrderStatusActive, "", "")
+	if order.Status != OrderStatusActive {
+		t.Fatalf("expected status %s, got %s", OrderStatusActive, order.Status)
+	}
+	if orders := om.GetOrdersByStatus(OrderStatusActive); len(orders) != 0 {
+		t.Fatalf("expected untracked order to stay out of the index, got %v", orderIDs(orders))
+	}
+
+	if !om.QuarantineOrder(&Order{ID: "order-2", Status: OrderStatusPending}, "unsupported asset") {
+		t.Fatal("expected order to be quarantined")
+	}
+	if ids := orderIDs(om.GetOrdersByStatus(OrderStatusFailed)); fmt.Sprint(ids) != "[order-2]" {
+		t.Fatalf("expected quarantined order to be indexed as failed, got %v", ids)
+	}
+}
+
+func TestStatusIndexConcurrentTransitions(t *testing.T) {
+	om := newTestOrderManager(t, &slowCronosClient{})
+
+	orders := make([]*Order, 100)
+	om.ordersMutex.Lock()
+	for i := range orders {
+		orders[i] = &Order{ID: fmt.Sprintf("order-%d", i), Status: OrderStatusActive}
+		om.trackOrder(orders[i])
+	}
+	om.ordersMutex.Unlock()
+
+	var wg sync.WaitGroup
+	for _, order := range orders {
+		wg.Add(1)
+		go func(order *Order) {
+			defer wg.Done()
+			for _, status := range []OrderStatus{OrderStatusMatched, OrderStatusExpired, OrderStatusActive, OrderStatusMatched} {
+				om.SetStatus(order, status, "", "")
+				om.GetOrdersByStatus(OrderStatusActive)
+			}
+		}(order)
+	}
+	wg.Wait()
+
+	if active := om.GetOrdersByStatus(OrderStatusActive); len(active) != 0 {
+		t.Fatalf("expected no active orders, got %d", len(active))
+	}
+	if matched := om.GetOrdersByStatus(OrderStatusMatched); len(matched) != len(orders) {
+		t.Fatalf("expected %d matched orders, got %d", len(orders), len(matched))
+	}
+}
+
+// newBenchmarkOrderManager tracks 10k orders, a tenth of them active and the
+// rest waiting to be cancelled
+func newBenchmarkOrderManager(b *testing.B) *OrderManager {
+	b.Helper()
+
+	om := NewOrderManager(&config.Config{}, &slowCronosClient{}, nil, zap.NewNop())
+	om.ordersMutex.Lock()
+	for i := 0; i < 10000; i++ {
+		status := OrderStatusExpired
+		if i%10 == 0 {
+			status = OrderStatusActive
+		}
+		om.trackOrder(&Order{ID: fmt.Sprintf("order-%d", i), Status: status})
+	}
+	om.ordersMutex.Unlock()
+	return om
+}
+
+func BenchmarkActiveOrdersScan(b *testing.B) {
+	om := newBenchmarkOrderManager(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var active []*Order
+		for _, order := range om.GetActiveOrders() {
+			if order.Status == OrderStatusActive {
+				active = append(active, order)
+			}
+		}
+	}
+}
+
+func BenchmarkActiveOrdersIndexed(b *testing.B) {
+	om := newBenchmarkOrderManager(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		om.GetOrdersByStatus(OrderStatusActive)
+	}
+}
@@ -0,0 +1,84 @@
+package order_manager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SaveOrders writes orders to path as JSON, replacing any previous snapshot.
+// The file holds swap secrets, so it is only readable by the owner
+func SaveOrders(path string, orders []*Order) error {
+	data, err := json.MarshalIndent(orders, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal orders: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create order store directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated store
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write order store: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace order store: %w", err)
+	}
+
+	return nil
+}
+
+// LoadOrders reads orders previously written by SaveOrders. A missing file
+// yields no orders
+func LoadOrders(path string) ([]*Order, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read order store: %w", err)
+	}
+
+	var orders []*Order
+	if err := json.Unmarshal(data, &orders); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal order store: %w", err)
+	}
+
+	return orders, nil
+}
+
+// MergeOrders adds orders to the snapshot at path, keeping any order already
+// stored under the same ID, and returns how many were added
+func MergeOrders(path string, orders []*Order) (int, error) {
+	stored, err := LoadOrders(path)
+	if err != nil {
+		return 0, err
+	}
+
+	known := make(map[string]bool, len(stored))
+	for _, order := range stored {
+		known[order.ID] = true
+	}
+
+	added := 0
+	for _, order := range orders {
+		if known[order.ID] {
+			continue
+		}
+		known[order.ID] = true
+		stored = append(stored, order)
+		added++
+	}
+
+	if added == 0 {
+		return 0, nil
+	}
+	if err := SaveOrders(path, stored); err != nil {
+		return 0, err
+	}
+	return added, nil
+}
@@ -0,0 +1,84 @@
+// Package price_oracle fetches market prices the relayer checks auction
+// prices against
+package price_oracle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PriceDecimals is the fixed-point precision of oracle prices: a price of
+// 2.5 quote units per base unit is 2.5 * 10^PriceDecimals
+const PriceDecimals = 18
+
+// defaultTimeout bounds a price request when no timeout is configured
+const defaultTimeout = 5 * time.Second
+
+// maxResponseBytes bounds the body of a price response
+const maxResponseBytes = 1 << 16
+
+// PriceOracle returns the market price of a pair such as "CRO/USDC", in whole
+// units of the second asset per whole unit of the first, scaled by
+// 10^PriceDecimals
+type PriceOracle interface {
+	GetPrice(pair string) (*big.Int, error)
+}
+
+// HTTPOracle reads prices from an HTTP endpoint. It sends
+// GET <endpoint>?pair=<pair> and expects {"price": "<decimal integer>"} in
+// return, the price scaled by 10^PriceDecimals
+type HTTPOracle struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPOracle returns an oracle reading prices from endpoint, giving up on
+// a request after timeout
+func NewHTTPOracle(endpoint string, timeout time.Duration) *HTTPOracle {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &HTTPOracle{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// GetPrice fetches the current price of pair
+func (o *HTTPOracle) GetPrice(pair string) (*big.Int, error) {
+	endpoint, err := url.Parse(o.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price oracle endpoint: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("pair", pair)
+	endpoint.RawQuery = query.Encode()
+
+	resp, err := o.client.Get(endpoint.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch price of %s: %w", pair, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("price oracle returned %s for %s", resp.Status, pair)
+	}
+
+	var body struct {
+		Price string `json:"price"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode price of %s: %w", pair, err)
+	}
+
+	price, ok := new(big.Int).SetString(body.Price, 10)
+	if !ok || price.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid price %q for %s", body.Price, pair)
+	}
+	return price, nil
+}
@@ -0,0 +1,40 @@
+package price_oracle
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPOracleGetPrice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("pair") {
+		case "CRO/USDC":
+			fmt.Fprint(w, `{"price":"2500000000000000000"}`)
+		case "ETH/USDC":
+			fmt.Fprint(w, `{"price":"-1"}`)
+		case "BAD/JSON":
+			fmt.Fprint(w, `{"price":`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	oracle := NewHTTPOracle(server.URL, 0)
+
+	price, err := oracle.GetPrice("CRO/USDC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price.String() != "2500000000000000000" {
+		t.Fatalf("expected 2.5 scaled by 10^18, got %s", price)
+	}
+
+	for _, pair := range []string{"ETH/USDC", "BAD/JSON", "UNKNOWN/PAIR"} {
+		if _, err := oracle.GetPrice(pair); err == nil {
+			t.Fatalf("expected an error for %s", pair)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package rpc_retry
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+)
+
+// ErrCircuitOpen is returned without calling the node while its breaker is
+// open. It wraps chain_errors.ErrRPCUnavailable, so callers treat it as
+// transient
+var ErrCircuitOpen = fmt.Errorf("circuit breaker open: %w", chain_errors.ErrRPCUnavailable)
+
+// breakerMetrics publishes the state of each chain's breaker, keyed by name
+var breakerMetrics = expvar.NewMap("relayer_rpc_breaker")
+
+// State of a circuit breaker
+type State int
+
+const (
+	// StateClosed lets every call through
+	StateClosed State = iota
+	// StateOpen rejects calls until the cool-down has passed
+	StateOpen
+	// StateHalfOpen lets a single trial call through to probe the node
+	StateHalfOpen
+)
+
+// String returns the state's name
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half_open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// Breaker stops calls to a node after threshold consecutive transient
+// failures. Once cooldown has passed a single trial call is let through,
+// closing the breaker if it succeeds and reopening it if it fails. A nil or
+// zero-threshold Breaker never opens
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	logger    *zap.Logger
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+	// probing is set while the half-open trial call is in flight
+	probing bool
+}
+
+// NewBreaker creates a breaker for the named chain and publishes its state
+func NewBreaker(name string, threshold int, cooldown time.Duration, logger *zap.Logger) *Breaker {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	b := &Breaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		logger:    logger,
+		now:       time.Now,
+	}
+	breakerMetrics.Set(name, expvar.Func(func() interface{} {
+		return b.State().String()
+	}))
+	return b
+}
+
+// Name returns the chain the breaker guards
+func (b *Breaker) Name() string {
+	if b == nil {
+		return ""
+	}
+	return b.name
+}
+
+// State returns the breaker's current state
+func (b *Breaker) State() State {
+	if b == nil {
+		return StateClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state()
+}
+
+// Allow reports whether a call may go to the node, returning ErrCircuitOpen
+// if not. In the half-open state only the first caller is let through
+func (b *Breaker) Allow() error {
+	if b == nil || b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state() {
+	case StateOpen:
+		return ErrCircuitOpen
+	case StateHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Record updates the breaker with the outcome of a call. Only errors showing
+// the node is unreachable count as failures; any answer from the node,
+// including a rejection, counts as success
+func (b *Breaker) Record(err error) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	// A cancelled call says nothing about the node
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if !isNodeFailure(err) {
+		if b.open {
+			b.logger.Info("RPC circuit breaker closed", zap.String("chain", b.name))
+		}
+		b.failures = 0
+		b.open = false
+		return
+	}
+
+	b.failures++
+	if b.open || b.failures >= b.threshold {
+		if !b.open {
+			b.logger.Warn("RPC circuit breaker opened",
+				zap.String("chain", b.name),
+				zap.Int("consecutive_failures", b.failures),
+				zap.Duration("cooldown", b.cooldown),
+				zap.Error(err))
+		}
+		b.open = true
+		b.openedAt = b.now()
+	}
+}
+
+// state returns the current state, with the lock held
+func (b *Breaker) state() State {
+	if !b.open {
+		return StateClosed
+	}
+	if b.now().Sub(b.openedAt) < b.cooldown {
+		return StateOpen
+	}
+	return StateHalfOpen
+}
+
+// isNodeFailure reports whether err means the node didn't answer
+func isNodeFailure(err error) bool {
+	return errors.Is(err, chain_errors.ErrRPCUnavailable) || errors.Is(err, context.DeadlineExceeded)
+}
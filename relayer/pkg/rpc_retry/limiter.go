@@ -0,0 +1,84 @@
+package rpc_retry
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket spacing calls to a node out to a steady rate. The
+// bucket holds a second's worth of tokens, so short bursts pass unthrottled.
+// A nil Limiter never waits
+type Limiter struct {
+	interval time.Duration
+	burst    int
+	now      func() time.Time
+
+	mu sync.Mutex
+	// tat is the theoretical arrival time of the next call at the steady rate
+	tat time.Time
+}
+
+// NewLimiter creates a limiter allowing perSecond calls per second. It
+// returns nil, disabling the limit, if perSecond isn't positive
+func NewLimiter(perSecond float64) *Limiter {
+	if perSecond <= 0 {
+		return nil
+	}
+
+	burst := int(math.Floor(perSecond))
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+		burst:    burst,
+		now:      time.Now,
+	}
+}
+
+// Wait blocks until a token is available or ctx is done. A call abandoned
+// because ctx is done gives its token back
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes the next token and returns how long to wait for it
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if l.tat.Before(now) {
+		l.tat = now
+	}
+	l.tat = l.tat.Add(l.interval)
+	return l.tat.Sub(now) - time.Duration(l.burst)*l.interval
+}
+
+// cancel returns a reserved token to the bucket
+func (l *Limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tat = l.tat.Add(-l.interval)
+}
@@ -0,0 +1,94 @@
+package rpc_retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+)
+
+func TestLimiterSpreadsBurst(t *testing.T) {
+	// 50 calls per second with a burst of 50, so 60 calls need at least
+	// 10 intervals of 20ms
+	l := NewLimiter(50)
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 60; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Fatalf("expected the burst to be spread over at least 200ms, took %s", elapsed)
+	}
+}
+
+func TestLimiterWaitCancelled(t *testing.T) {
+	l := NewLimiter(1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("expected the first token to be available, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("cancellation did not interrupt the wait, took %s", elapsed)
+	}
+
+	// The abandoned token is given back, so the next call waits for the
+	// original slot rather than a later one
+	l.mu.Lock()
+	tat := l.tat
+	l.mu.Unlock()
+	if wait := time.Until(tat); wait > time.Second {
+		t.Fatalf("expected the cancelled token to be returned, next slot in %s", wait)
+	}
+}
+
+func TestNewLimiterDisabled(t *testing.T) {
+	if l := NewLimiter(0); l != nil {
+		t.Fatal("expected a zero rate to disable the limiter")
+	}
+
+	var l *Limiter
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("expected a nil limiter not to wait, got %v", err)
+	}
+}
+
+func TestRetrierRateLimitsAttempts(t *testing.T) {
+	r := New("limit-attempts", config.RPCRetryConfig{MaxAttempts: 1}, 20, nil)
+
+	calls := 0
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		if err := r.Do(context.Background(), func(ctx context.Context) error {
+			calls++
+			return nil
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if calls != 25 {
+		t.Fatalf("expected 25 calls, got %d", calls)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("expected calls beyond the burst to be throttled, took %s", elapsed)
+	}
+}
@@ -0,0 +1,156 @@
+package rpc_retry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+)
+
+// Retrier retries transient RPC failures with exponential backoff, guards the
+// node with a circuit breaker and rate limits every attempt. A nil Retrier
+// runs each call once
+type Retrier struct {
+	attempts       int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	breaker        *Breaker
+	limiter        *Limiter
+
+	// sleep waits between attempts, replaced in tests
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// New creates a retrier for the named chain's node, allowing at most
+// maxPerSecond calls per second; 0 disables the limit
+func New(name string, cfg config.RPCRetryConfig, maxPerSecond float64, logger *zap.Logger) *Retrier {
+	attempts := cfg.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return &Retrier{
+		attempts:       attempts,
+		initialBackoff: cfg.InitialBackoff,
+		maxBackoff:     cfg.MaxBackoff,
+		breaker:        NewBreaker(name, cfg.BreakerThreshold, cfg.BreakerCooldown, logger),
+		limiter:        NewLimiter(maxPerSecond),
+		sleep:          sleep,
+	}
+}
+
+// Breaker returns the retrier's circuit breaker
+func (r *Retrier) Breaker() *Breaker {
+	if r == nil {
+		return nil
+	}
+	return r.breaker
+}
+
+// Do runs op until it succeeds, fails with a non-transient error or runs out
+// of attempts. It fails fast with ErrCircuitOpen while the breaker is open
+func (r *Retrier) Do(ctx context.Context, op func(ctx context.Context) error) error {
+	_, err := Call(ctx, r, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, op(ctx)
+	})
+	return err
+}
+
+// Once runs op a single time through the breaker. It is meant for calls that
+// must not be repeated blindly, such as broadcasting a transaction
+func (r *Retrier) Once(ctx context.Context, op func(ctx context.Context) error) error {
+	if r == nil {
+		return op(ctx)
+	}
+	if err := r.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	if err := r.breaker.Allow(); err != nil {
+		return err
+	}
+
+	err := chain_errors.Classify(op(ctx))
+	r.breaker.Record(err)
+	return err
+}
+
+// Call runs op like Retrier.Do and returns its result
+func Call[T any](ctx context.Context, r *Retrier, op func(ctx context.Context) (T, error)) (T, error) {
+	if r == nil {
+		return op(ctx)
+	}
+
+	var (
+		result T
+		err    error
+	)
+	for attempt := 1; ; attempt++ {
+		if waitErr := r.limiter.Wait(ctx); waitErr != nil {
+			if err != nil {
+				return result, err
+			}
+			return result, waitErr
+		}
+		if allowErr := r.breaker.Allow(); allowErr != nil {
+			if err != nil {
+				return result, fmt.Errorf("%w (last error: %v)", allowErr, err)
+			}
+			return result, allowErr
+		}
+
+		result, err = op(ctx)
+		err = chain_errors.Classify(err)
+		r.breaker.Record(err)
+
+		if err == nil || !chain_errors.IsTransient(err) {
+			return result, err
+		}
+		if attempt >= r.attempts {
+			if attempt > 1 {
+				return result, fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+			}
+			return result, err
+		}
+
+		if sleepErr := r.sleep(ctx, r.backoff(attempt)); sleepErr != nil {
+			return result, err
+		}
+	}
+}
+
+// backoff returns the delay after the given failed attempt, doubling from the
+// initial backoff up to the maximum
+func (r *Retrier) backoff(attempt int) time.Duration {
+	delay := r.initialBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if r.maxBackoff > 0 && delay >= r.maxBackoff {
+			return r.maxBackoff
+		}
+	}
+	if r.maxBackoff > 0 && delay > r.maxBackoff {
+		return r.maxBackoff
+	}
+	return delay
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,175 @@
+package rpc_retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/chain_errors"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+)
+
+var errUnavailable = errors.New("dial tcp: connection refused")
+
+// newTestRetrier returns a retrier that records its backoff delays instead of
+// sleeping
+func newTestRetrier(name string, cfg config.RPCRetryConfig) (*Retrier, *[]time.Duration) {
+	var delays []time.Duration
+	r := New(name, cfg, 0, nil)
+	r.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return r, &delays
+}
+
+func TestRetrySucceedsAfterTransientFailures(t *testing.T) {
+	r, delays := newTestRetrier("retry-success", config.RPCRetryConfig{
+		MaxAttempts:    4,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     150 * time.Millisecond,
+	})
+
+	calls := 0
+	result, err := Call(context.Background(), r, func(ctx context.Context) (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, errUnavailable
+		}
+		return 42, nil
+	})
+	if err != nil || result != 42 {
+		t.Fatalf("expected 42, got %d, %v", result, err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+
+	expected := []time.Duration{100 * time.Millisecond, 150 * time.Millisecond}
+	if len(*delays) != len(expected) || (*delays)[0] != expected[0] || (*delays)[1] != expected[1] {
+		t.Fatalf("expected backoff %v, got %v", expected, *delays)
+	}
+}
+
+func TestRetryExhaustion(t *testing.T) {
+	r, _ := newTestRetrier("retry-exhaustion", config.RPCRetryConfig{MaxAttempts: 3})
+
+	calls := 0
+	err := r.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		return errUnavailable
+	})
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+	if !errors.Is(err, chain_errors.ErrRPCUnavailable) || !errors.Is(err, errUnavailable) {
+		t.Fatalf("expected the classified last error, got %v", err)
+	}
+}
+
+func TestRetrySkipsPermanentErrors(t *testing.T) {
+	r, _ := newTestRetrier("retry-permanent", config.RPCRetryConfig{MaxAttempts: 3})
+
+	calls := 0
+	err := r.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		return errors.New("execution reverted: escrow expired")
+	})
+	if calls != 1 || !errors.Is(err, chain_errors.ErrReverted) {
+		t.Fatalf("expected a single reverted call, got %d calls and %v", calls, err)
+	}
+}
+
+func TestNilRetrierCallsOnce(t *testing.T) {
+	var r *Retrier
+
+	calls := 0
+	err := r.Do(context.Background(), func(ctx context.Context) error {
+		calls++
+		return errUnavailable
+	})
+	if calls != 1 || err != errUnavailable {
+		t.Fatalf("expected one unwrapped call, got %d calls and %v", calls, err)
+	}
+	if r.Breaker().State() != StateClosed {
+		t.Fatal("expected a nil breaker to stay closed")
+	}
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	r, _ := newTestRetrier("breaker-transitions", config.RPCRetryConfig{
+		MaxAttempts:      1,
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Minute,
+	})
+	b := r.Breaker()
+	b.now = func() time.Time { return now }
+
+	calls := 0
+	failing := func(ctx context.Context) error {
+		calls++
+		return errUnavailable
+	}
+	succeeding := func(ctx context.Context) error {
+		calls++
+		return nil
+	}
+
+	// Consecutive node failures open the breaker
+	for i := 0; i < 2; i++ {
+		if err := r.Do(context.Background(), failing); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d rejected before the threshold", i)
+		}
+	}
+	if b.State() != StateOpen {
+		t.Fatalf("expected open breaker, got %s", b.State())
+	}
+	if got := breakerMetrics.Get("breaker-transitions").String(); got != `"open"` {
+		t.Fatalf("expected published state open, got %s", got)
+	}
+
+	// Calls are rejected without reaching the node during the cool-down
+	if err := r.Do(context.Background(), succeeding); !errors.Is(err, ErrCircuitOpen) || calls != 2 {
+		t.Fatalf("expected rejection while open, got %v after %d calls", err, calls)
+	}
+	if !chain_errors.IsTransient(ErrCircuitOpen) {
+		t.Fatal("expected an open circuit to be transient")
+	}
+
+	// After the cool-down one trial is let through; a failure reopens
+	now = now.Add(time.Minute)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("expected half-open breaker, got %s", b.State())
+	}
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected trial call to be allowed, got %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected a second concurrent trial to be rejected, got %v", err)
+	}
+	b.Record(chain_errors.Classify(errUnavailable))
+	if b.State() != StateOpen {
+		t.Fatalf("expected failed trial to reopen the breaker, got %s", b.State())
+	}
+
+	// A successful trial closes it
+	now = now.Add(time.Minute)
+	if err := r.Do(context.Background(), succeeding); err != nil {
+		t.Fatalf("expected trial call to succeed, got %v", err)
+	}
+	if b.State() != StateClosed {
+		t.Fatalf("expected closed breaker, got %s", b.State())
+	}
+
+	// Errors returned by a reachable node don't count as failures
+	r.Do(context.Background(), failing)
+	r.Do(context.Background(), func(ctx context.Context) error {
+		return errors.New("execution reverted")
+	})
+	r.Do(context.Background(), failing)
+	if b.State() != StateClosed {
+		t.Fatalf("expected non-consecutive failures to keep the breaker closed, got %s", b.State())
+	}
+}
@@ -0,0 +1,162 @@
+package secret_manager
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SecretSize is the size in bytes of generated swap secrets
+const SecretSize = 32
+
+// SecretManager generates swap secrets and tracks them by hashlock until they
+// are revealed on-chain
+type SecretManager struct {
+	logger *zap.Logger
+
+	secrets      map[string]*SecretRecord
+	secretsMutex sync.RWMutex
+}
+
+// SecretRecord holds a secret and its revelation state
+type SecretRecord struct {
+	OrderID  string `json:"order_id,omitempty"`
+	HashLock string `json:"hash_lock"`
+	// Secret is never logged; it is only handed out through GetSecret
+	Secret       string     `json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+	RevealedAt   *time.Time `json:"revealed_at,omitempty"`
+	RevealTxHash string     `json:"reveal_tx_hash,omitempty"`
+}
+
+// NewSecretManager creates a new secret manager
+func NewSecretManager(logger *zap.Logger) *SecretManager {
+	return &SecretManager{
+		logger:  logger,
+		secrets: make(map[string]*SecretRecord),
+	}
+}
+
+// GenerateSecret creates a random 32-byte secret for a maker-initiated order
+// and returns its record, including the SHA256 hashlock
+func (sm *SecretManager) GenerateSecret(orderID string) (*SecretRecord, error) {
+	secret := make([]byte, SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	record := &SecretRecord{
+		OrderID:   orderID,
+		HashLock:  HashSecret(secret),
+		Secret:    hex.EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}
+
+	sm.secretsMutex.Lock()
+	sm.secrets[record.HashLock] = record
+	sm.secretsMutex.Unlock()
+
+	sm.logger.Info("Generated secret for order",
+		zap.String("order_id", orderID),
+		zap.String("hash_lock", record.HashLock))
+
+	return record, nil
+}
+
+// GetSecret returns the secret for a hashlock, if known
+func (sm *SecretManager) GetSecret(hashLock string) (string, bool) {
+	sm.secretsMutex.RLock()
+	defer sm.secretsMutex.RUnlock()
+
+	record, exists := sm.secrets[normalizeHashLock(hashLock)]
+	if !exists {
+		return "", false
+	}
+	return record.Secret, true
+}
+
+// IsRevealed reports whether the secret for a hashlock has been seen on-chain
+func (sm *SecretManager) IsRevealed(hashLock string) bool {
+	sm.secretsMutex.RLock()
+	defer sm.secretsMutex.RUnlock()
+
+	record, exists := sm.secrets[normalizeHashLock(hashLock)]
+	return exists && record.RevealedAt != nil
+}
+
+// RecordRevealedSecret records a secret revealed by a counterparty withdrawal.
+// The secret is only accepted if it hashes to the expected hashlock.
+func (sm *SecretManager) RecordRevealedSecret(hashLock string, secret string, txHash string) error {
+	secretBytes, err := DecodeSecret(secret)
+	if err != nil {
+		return err
+	}
+
+	key := normalizeHashLock(hashLock)
+	if HashSecret(secretBytes) != key {
+		return fmt.Errorf("revealed secret does not match hashlock %s", hashLock)
+	}
+
+	now := time.Now()
+
+	sm.secretsMutex.Lock()
+	defer sm.secretsMutex.Unlock()
+
+	record, exists := sm.secrets[key]
+	if !exists {
+		record = &SecretRecord{
+			HashLock:  key,
+			CreatedAt: now,
+		}
+		sm.secrets[key] = record
+	}
+	record.Secret = hex.EncodeToString(secretBytes)
+	if record.RevealedAt == nil {
+		record.RevealedAt = &now
+		record.RevealTxHash = txHash
+	}
+
+	sm.logger.Info("Recorded revealed secret",
+		zap.String("hash_lock", key),
+		zap.String("tx_hash", txHash))
+
+	return nil
+}
+
+// Forget removes a secret once both legs of the swap are settled
+func (sm *SecretManager) Forget(hashLock string) {
+	sm.secretsMutex.Lock()
+	defer sm.secretsMutex.Unlock()
+
+	delete(sm.secrets, normalizeHashLock(hashLock))
+}
+
+// HashSecret returns the hex-encoded SHA256 hashlock of a secret
+func HashSecret(secret []byte) string {
+	hash := sha256.Sum256(secret)
+	return hex.EncodeToString(hash[:])
+}
+
+// DecodeSecret decodes a hex-encoded secret with an optional 0x prefix
+func DecodeSecret(secret string) ([]byte, error) {
+	secretBytes, err := hex.DecodeString(strings.TrimPrefix(secret, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret encoding: %w", err)
+	}
+	if len(secretBytes) != SecretSize {
+		return nil, fmt.Errorf("secret must be %d bytes, got %d", SecretSize, len(secretBytes))
+	}
+	return secretBytes, nil
+}
+
+// normalizeHashLock converts a hashlock into the lowercase, unprefixed hex
+// form used as the map key
+func normalizeHashLock(hashLock string) string {
+	return strings.ToLower(strings.TrimPrefix(hashLock, "0x"))
+}
@@ -0,0 +1,71 @@
+package secret_manager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestGenerateSecret(t *testing.T) {
+	sm := NewSecretManager(zap.NewNop())
+
+	record, err := sm.GenerateSecret("order-1")
+	if err != nil {
+		t.Fatalf("failed to generate secret: %v", err)
+	}
+
+	secret, err := hex.DecodeString(record.Secret)
+	if err != nil {
+		t.Fatalf("secret is not hex: %v", err)
+	}
+	if len(secret) != SecretSize {
+		t.Fatalf("expected %d byte secret, got %d", SecretSize, len(secret))
+	}
+
+	hash := sha256.Sum256(secret)
+	if record.HashLock != hex.EncodeToString(hash[:]) {
+		t.Fatalf("hashlock %s does not match sha256 of secret", record.HashLock)
+	}
+
+	got, ok := sm.GetSecret("0x" + record.HashLock)
+	if !ok || got != record.Secret {
+		t.Fatal("expected secret to be retrievable by 0x-prefixed hashlock")
+	}
+	if sm.IsRevealed(record.HashLock) {
+		t.Fatal("secret should not be revealed yet")
+	}
+}
+
+func TestRecordRevealedSecret(t *testing.T) {
+	sm := NewSecretManager(zap.NewNop())
+
+	secret := make([]byte, SecretSize)
+	secret[0] = 0x42
+	hashLock := "0x" + HashSecret(secret)
+
+	if err := sm.RecordRevealedSecret(hashLock, "0x"+hex.EncodeToString(make([]byte, SecretSize)), "0xabc"); err == nil {
+		t.Fatal("expected mismatched secret to be rejected")
+	}
+	if _, ok := sm.GetSecret(hashLock); ok {
+		t.Fatal("mismatched secret must not be stored")
+	}
+
+	if err := sm.RecordRevealedSecret(hashLock, hex.EncodeToString(secret), "0xabc"); err != nil {
+		t.Fatalf("failed to record revealed secret: %v", err)
+	}
+	if !sm.IsRevealed(hashLock) {
+		t.Fatal("expected secret to be marked revealed")
+	}
+
+	got, ok := sm.GetSecret(hashLock)
+	if !ok || got != hex.EncodeToString(secret) {
+		t.Fatal("expected revealed secret to be retrievable")
+	}
+
+	sm.Forget(hashLock)
+	if _, ok := sm.GetSecret(hashLock); ok {
+		t.Fatal("expected secret to be forgotten")
+	}
+}
@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+)
+
+// SignatureHeader carries the hex-encoded HMAC-SHA256 of the request body,
+// keyed with the configured webhook secret
+const SignatureHeader = "X-Relayer-Signature"
+
+// requestTimeout bounds a single delivery attempt
+const requestTimeout = 10 * time.Second
+
+// Notifier POSTs JSON payloads to the configured webhook URL in the
+// background, retrying failed deliveries with exponential backoff. A nil
+// Notifier drops every payload
+type Notifier struct {
+	url           string
+	secret        string
+	maxAttempts   int
+	retryInterval time.Duration
+	client        *http.Client
+	logger        *zap.Logger
+
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// New creates a notifier from the relayer config, or returns nil when no
+// webhook URL is configured
+func New(cfg *config.RelayerConfig, logger *zap.Logger) *Notifier {
+	if cfg.WebhookURL == "" {
+		return nil
+	}
+
+	maxAttempts := cfg.WebhookMaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	return &Notifier{
+		url:           cfg.WebhookURL,
+		secret:        cfg.WebhookSecret,
+		maxAttempts:   maxAttempts,
+		retryInterval: cfg.WebhookRetryInterval,
+		client:        &http.Client{Timeout: requestTimeout},
+		logger:        logger,
+		done:          make(chan struct{}),
+	}
+}
+
+// Send encodes payload right away and delivers it in the background
+func (n *Notifier) Send(payload interface{}) error {
+	if n == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		n.deliver(body)
+	}()
+	return nil
+}
+
+// Close stops retrying and waits for deliveries in flight to finish
+func (n *Notifier) Close() {
+	if n == nil {
+		return
+	}
+
+	n.closeOnce.Do(func() { close(n.done) })
+	n.wg.Wait()
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of body keyed with secret
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver posts body until it is accepted, rejected permanently or out of
+// attempts
+func (n *Notifier) deliver(body []byte) {
+	delay := n.retryInterval
+	for attempt := 1; ; attempt++ {
+		retry, err := n.post(body)
+		if err == nil {
+			return
+		}
+
+		if !retry || attempt >= n.maxAttempts {
+			n.logger.Error("Failed to deliver webhook",
+				zap.String("url", n.url),
+				zap.Int("attempts", attempt),
+				zap.Error(err))
+			return
+		}
+
+		n.logger.Warn("Webhook delivery failed, retrying",
+			zap.String("url", n.url),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", delay),
+			zap.Error(err))
+
+		select {
+		case <-n.done:
+			n.logger.Warn("Dropping webhook on shutdown", zap.String("url", n.url), zap.Error(err))
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post makes one delivery attempt. retry reports whether a failure may
+// succeed later: network errors, rate limiting and server errors
+func (n *Notifier) post(body []byte) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(body, n.secret))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+}
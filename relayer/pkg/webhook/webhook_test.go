@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+)
+
+// recordingServer answers webhook requests with the queued status codes,
+// then 200, and records the bodies and signatures it receives
+type recordingServer struct {
+	mu         sync.Mutex
+	statuses   []int
+	bodies     [][]byte
+	signatures []string
+}
+
+func (s *recordingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bodies = append(s.bodies, body)
+	s.signatures = append(s.signatures, r.Header.Get(SignatureHeader))
+
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestNotifier(t *testing.T, statuses ...int) (*Notifier, *recordingServer) {
+	t.Helper()
+
+	recorder := &recordingServer{statuses: statuses}
+	server := httptest.NewServer(recorder)
+	t.Cleanup(server.Close)
+
+	n := New(&config.RelayerConfig{
+		WebhookURL:           server.URL,
+		WebhookSecret:        "webhook-secret",
+		WebhookMaxAttempts:   3,
+		WebhookRetryInterval: time.Millisecond,
+	}, zap.NewNop())
+	return n, recorder
+}
+
+func TestNotifierDeliversSignedPayload(t *testing.T) {
+	n, recorder := newTestNotifier(t)
+
+	if err := n.Send(map[string]string{"event": "order.expired"}); err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+	n.Close()
+
+	if len(recorder.bodies) != 1 {
+		t.Fatalf("expected one delivery, got %d", len(recorder.bodies))
+	}
+	if string(recorder.bodies[0]) != `{"event":"order.expired"}` {
+		t.Fatalf("unexpected body %s", recorder.bodies[0])
+	}
+	if want := Sign(recorder.bodies[0], "webhook-secret"); recorder.signatures[0] != want {
+		t.Fatalf("expected signature %s, got %s", want, recorder.signatures[0])
+	}
+	if Sign(recorder.bodies[0], "other-secret") == recorder.signatures[0] {
+		t.Fatal("expected the signature to depend on the secret")
+	}
+}
+
+func TestNotifierRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		statuses []int
+		want     int
+	}{
+		{"server errors are retried", []int{http.StatusBadGateway, http.StatusTooManyRequests}, 3},
+		{"attempts are bounded", []int{500, 500, 500, 500}, 3},
+		{"client errors are not retried", []int{http.StatusBadRequest}, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n, recorder := newTestNotifier(t, tc.statuses...)
+
+			if err := n.Send(map[string]string{"event": "order.failed"}); err != nil {
+				t.Fatalf("failed to send: %v", err)
+			}
+			// Close would cut the retries short
+			n.wg.Wait()
+
+			if len(recorder.bodies) != tc.want {
+				t.Fatalf("expected %d attempts, got %d", tc.want, len(recorder.bodies))
+			}
+		})
+	}
+}
+
+func TestNilNotifier(t *testing.T) {
+	n := New(&config.RelayerConfig{}, zap.NewNop())
+	if n != nil {
+		t.Fatal("expected no notifier without a webhook URL")
+	}
+	if err := n.Send("ignored"); err != nil {
+		t.Fatalf("expected a nil notifier to drop payloads, got %v", err)
+	}
+	n.Close()
+}
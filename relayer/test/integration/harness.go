@@ -0,0 +1,364 @@
+//go:build integration
+
+// Package integration drives swaps end to end against a local anvil node
+// standing in for Ethereum and a wasmd chain standing in for Cronos. The
+// chains are started with docker compose; see README.md
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/config"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/ethereum_client"
+)
+
+// Defaults match docker-compose.yml and wasmd-init.sh
+const (
+	defaultEthRPC        = "http://localhost:8545"
+	defaultWasmdRPC      = "http://localhost:26657"
+	defaultWasmdChainID  = "localwasm"
+	defaultEVMArtifacts  = "../../../evm/artifacts/contracts/escrow"
+	defaultWasmArtifacts = "../../../cronos-contracts/artifacts"
+
+	// Well-known anvil account #0, used as the relayer's Ethereum key
+	relayerEthKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+	// wasmd-init.sh funds the relayer and maker accounts derived from this
+	// mnemonic at indexes 0 and 1
+	testMnemonic = "test test test test test test test test test test test junk"
+
+	// Denom the maker swaps on wasmd
+	swapDenom = "uswap"
+
+	txTimeout = time.Minute
+)
+
+// harness holds the clients and contracts of a freshly deployed pair of
+// chains
+type harness struct {
+	cfg *config.Config
+
+	relayerCronos *cronos_client.Client
+	makerCronos   *cronos_client.Client
+	relayerEth    *ethereum_client.Client
+
+	eth    *ethclient.Client
+	ethRPC *rpc.Client
+	comet  *rpchttp.HTTP
+}
+
+// newHarness connects to the local chains, deploys the escrow contracts on
+// both and returns a relayer configuration pointing at them. The test is
+// skipped if either chain is unreachable
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	ethEndpoint := env("INTEGRATION_ETH_RPC", defaultEthRPC)
+	wasmdEndpoint := env("INTEGRATION_WASMD_RPC", defaultWasmdRPC)
+
+	ethRPC, err := rpc.DialContext(ctx, ethEndpoint)
+	if err != nil {
+		t.Fatalf("failed to dial anvil: %v", err)
+	}
+	eth := ethclient.NewClient(ethRPC)
+	if _, err := eth.ChainID(ctx); err != nil {
+		t.Skipf("anvil is not reachable at %s: %v", ethEndpoint, err)
+	}
+
+	comet, err := rpchttp.New(wasmdEndpoint, "/websocket")
+	if err != nil {
+		t.Fatalf("failed to create wasmd RPC client: %v", err)
+	}
+	if _, err := comet.Status(ctx); err != nil {
+		t.Skipf("wasmd is not reachable at %s: %v", wasmdEndpoint, err)
+	}
+
+	cfg := &config.Config{
+		Cronos: config.ChainConfig{
+			ChainID:       env("INTEGRATION_WASMD_CHAIN_ID", defaultWasmdChainID),
+			RPCEndpoint:   wasmdEndpoint,
+			GasPrice:      "0.025stake",
+			GasLimit:      20000000,
+			Mnemonic:      testMnemonic,
+			HDPath:        "m/44'/118'/0'/0/0",
+			Bech32Prefix:  "wasm",
+			WaitForTx:     true,
+			TxWaitTimeout: txTimeout,
+		},
+		Ethereum: config.ChainConfig{
+			ChainID:     "31337",
+			RPCEndpoint: ethEndpoint,
+			PrivateKey:  relayerEthKey,
+			TxType:      config.TxTypeDynamic,
+		},
+		Relayer: config.RelayerConfig{
+			BlockPollInterval:    time.Second,
+			EventPollInterval:    time.Second,
+			OrderUpdateInterval:  time.Second,
+			MaxRetries:           3,
+			RetryInterval:        time.Second,
+			TransactionTimeout:   txTimeout,
+			TimelockSafetyMargin: 10 * time.Minute,
+			BatchSize:            10,
+			LogScanBatchSize:     1000,
+			OrderStorePath:       filepath.Join(t.TempDir(), "orders.json"),
+			MaxOrderHistory:      50,
+		},
+	}
+
+	logger := zaptest.NewLogger(t)
+
+	h := &harness{cfg: cfg, eth: eth, ethRPC: ethRPC, comet: comet}
+
+	h.relayerCronos, err = cronos_client.NewClient(&cfg.Cronos, &cfg.Relayer, logger.Named("relayer_cronos"))
+	if err != nil {
+		t.Fatalf("failed to create relayer Cronos client: %v", err)
+	}
+
+	makerCfg := cfg.Cronos
+	makerCfg.HDPath = "m/44'/118'/0'/0/1"
+	h.makerCronos, err = cronos_client.NewClient(&makerCfg, &cfg.Relayer, logger.Named("maker_cronos"))
+	if err != nil {
+		t.Fatalf("failed to create maker Cronos client: %v", err)
+	}
+
+	if err := h.deployEthereumContracts(ctx); err != nil {
+		t.Fatalf("failed to deploy Ethereum contracts: %v", err)
+	}
+	if err := h.deployCronosContracts(ctx); err != nil {
+		t.Fatalf("failed to deploy Cronos contracts: %v", err)
+	}
+
+	h.relayerEth, err = ethereum_client.NewClient(&cfg.Ethereum, &cfg.Contracts.Ethereum, &cfg.Relayer, logger.Named("relayer_ethereum"))
+	if err != nil {
+		t.Fatalf("failed to create relayer Ethereum client: %v", err)
+	}
+
+	return h
+}
+
+// deployEthereumContracts deploys the escrow factory and a resolver owned by
+// the relayer. The resolver is deployed without a limit order protocol or
+// IBC handler, which Cronos to Ethereum swaps don't use
+func (h *harness) deployEthereumContracts(ctx context.Context) error {
+	key, err := crypto.HexToECDSA(relayerEthKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse relayer key: %w", err)
+	}
+	chainID, err := h.eth.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		return fmt.Errorf("failed to create transactor: %w", err)
+	}
+	auth.Context = ctx
+	owner := auth.From
+
+	factory, err := h.deployEVMContract(ctx, auth, "EscrowFactory", owner)
+	if err != nil {
+		return err
+	}
+	resolver, err := h.deployEVMContract(ctx, auth, "Resolver", factory, common.Address{}, common.Address{}, owner)
+	if err != nil {
+		return err
+	}
+
+	h.cfg.Contracts.Ethereum.EscrowFactory = factory.Hex()
+	h.cfg.Contracts.Ethereum.Resolver = resolver.Hex()
+	return nil
+}
+
+// deployEVMContract deploys the Hardhat artifact of contract name and waits
+// for it to be mined
+func (h *harness) deployEVMContract(ctx context.Context, auth *bind.TransactOpts, name string, params ...interface{}) (common.Address, error) {
+	path := filepath.Join(env("INTEGRATION_EVM_ARTIFACTS", defaultEVMArtifacts), name+".sol", name+".json")
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read %s artifact: %w", name, err)
+	}
+
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode string          `json:"bytecode"`
+	}
+	if err := json.Unmarshal(bz, &artifact); err != nil {
+		return common.Address{}, fmt.Errorf("failed to decode %s artifact: %w", name, err)
+	}
+	contractABI, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to parse %s ABI: %w", name, err)
+	}
+	bytecode, err := hexutil.Decode(artifact.Bytecode)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to decode %s bytecode: %w", name, err)
+	}
+
+	addr, tx, _, err := bind.DeployContract(auth, contractABI, bytecode, h.eth, params...)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to deploy %s: %w", name, err)
+	}
+	if _, err := bind.WaitDeployed(ctx, h.eth, tx); err != nil {
+		return common.Address{}, fmt.Errorf("failed to wait for %s deployment: %w", name, err)
+	}
+	return addr, nil
+}
+
+// deployCronosContracts stores the escrow contract codes and instantiates the
+// factory with the relayer as its owner
+func (h *harness) deployCronosContracts(ctx context.Context) error {
+	sourceCodeID, err := h.storeCode(ctx, "source_escrow")
+	if err != nil {
+		return err
+	}
+	destinationCodeID, err := h.storeCode(ctx, "destination_escrow")
+	if err != nil {
+		return err
+	}
+	factoryCodeID, err := h.storeCode(ctx, "escrow_factory")
+	if err != nil {
+		return err
+	}
+
+	owner := h.relayerCronos.Address().String()
+	factory, err := h.relayerCronos.InstantiateEscrow(ctx, factoryCodeID, map[string]interface{}{
+		"owner":                      owner,
+		"source_escrow_code_id":      sourceCodeID,
+		"destination_escrow_code_id": destinationCodeID,
+	}, "escrow_factory", owner)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate escrow factory: %w", err)
+	}
+
+	h.cfg.Contracts.Cronos.EscrowFactory = factory
+	h.cfg.Contracts.Cronos.SourceEscrowCodeID = sourceCodeID
+	h.cfg.Contracts.Cronos.DestinationEscrowCodeID = destinationCodeID
+	return nil
+}
+
+// storeCode uploads the optimized wasm of crate and returns its code ID
+func (h *harness) storeCode(ctx context.Context, crate string) (uint64, error) {
+	path := filepath.Join(env("INTEGRATION_WASM_ARTIFACTS", defaultWasmArtifacts), crate+".wasm")
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s code: %w", crate, err)
+	}
+
+	txHash, err := h.relayerCronos.BroadcastTx(ctx, &wasmtypes.MsgStoreCode{
+		Sender:       h.relayerCronos.Address().String(),
+		WASMByteCode: code,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to store %s code: %w", crate, err)
+	}
+	result, err := h.relayerCronos.WaitForTx(ctx, txHash, txTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to wait for %s code: %w", crate, err)
+	}
+
+	for _, event := range result.Events {
+		if event.Type != wasmtypes.EventTypeStoreCode {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == wasmtypes.AttributeKeyCodeID {
+				return strconv.ParseUint(attr.Value, 10, 64)
+			}
+		}
+	}
+	return 0, fmt.Errorf("no code ID in store code transaction %s", txHash)
+}
+
+// cronosBalance returns the bank balance of addr in denom
+func (h *harness) cronosBalance(ctx context.Context, addr sdk.AccAddress, denom string) (*big.Int, error) {
+	req := &banktypes.QueryBalanceRequest{Address: addr.String(), Denom: denom}
+	bz, err := req.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal balance query: %w", err)
+	}
+
+	res, err := h.comet.ABCIQuery(ctx, "/cosmos.bank.v1beta1.Query/Balance", bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balance: %w", err)
+	}
+	if res.Response.Code != 0 {
+		return nil, fmt.Errorf("balance query failed: %s", res.Response.Log)
+	}
+
+	var balance banktypes.QueryBalanceResponse
+	if err := balance.Unmarshal(res.Response.Value); err != nil {
+		return nil, fmt.Errorf("failed to decode balance: %w", err)
+	}
+	return balance.Balance.Amount.BigInt(), nil
+}
+
+// sendAs sends a transaction from addr without its key, using anvil's account
+// impersonation, and waits for its receipt
+func (h *harness) sendAs(ctx context.Context, from, to common.Address, data []byte) (*types.Receipt, error) {
+	if err := h.ethRPC.CallContext(ctx, nil, "anvil_impersonateAccount", from); err != nil {
+		return nil, fmt.Errorf("failed to impersonate %s: %w", from, err)
+	}
+
+	var txHash common.Hash
+	err := h.ethRPC.CallContext(ctx, &txHash, "eth_sendTransaction", map[string]interface{}{
+		"from": from,
+		"to":   to,
+		"data": hexutil.Bytes(data),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction from %s: %w", from, err)
+	}
+
+	receipt, err := h.relayerEth.WaitForTransaction(ctx, txHash.Hex(), txTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s from %s reverted", txHash, from)
+	}
+	return receipt, nil
+}
+
+// fund sets the ETH balance of addr
+func (h *harness) fund(ctx context.Context, addr common.Address, amount *big.Int) error {
+	if err := h.ethRPC.CallContext(ctx, nil, "anvil_setBalance", addr, (*hexutil.Big)(amount)); err != nil {
+		return fmt.Errorf("failed to fund %s: %w", addr, err)
+	}
+	return nil
+}
+
+// env returns the environment variable key, or fallback if it is unset
+func env(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
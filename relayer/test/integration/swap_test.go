@@ -0,0 +1,245 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/manus-ai/cronos-eth-bridge/pkg/cronos_client"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/order_manager"
+	"github.com/manus-ai/cronos-eth-bridge/pkg/secret_manager"
+)
+
+// makerEscrowABI holds the Ethereum escrow functions the maker calls to
+// claim the destination leg
+const makerEscrowABI = `[
+	{"type":"function","name":"confirmSourceEscrow","inputs":[{"name":"srcTxHash","type":"string"},{"name":"srcBlockHeight","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"function","name":"withdraw","inputs":[{"name":"secret","type":"bytes32"}],"outputs":[],"stateMutability":"nonpayable"}
+]`
+
+// TestCronosToEthereumSwap locks uswap in a Cronos source escrow, lets the
+// order manager deploy the Ethereum destination escrow, has the maker claim
+// it with the secret and waits for the relayer to claim the source escrow
+// with the revealed secret
+func TestCronosToEthereumSwap(t *testing.T) {
+	h := newHarness(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	srcAmount := big.NewInt(1000000)
+	dstAmount := big.NewInt(1e15)
+
+	var secret [secret_manager.SecretSize]byte
+	if _, err := rand.Read(secret[:]); err != nil {
+		t.Fatalf("failed to generate secret: %v", err)
+	}
+	secretHash := secret_manager.HashSecret(secret[:])
+	timelock := uint64(time.Now().Add(time.Hour).Unix())
+
+	maker := h.makerCronos.Address()
+	relayer := h.relayerCronos.Address()
+	factory := h.cfg.Contracts.Cronos.EscrowFactory
+
+	// The maker opens and funds the source escrow with the relayer as taker
+	_, err := h.makerCronos.CreateSourceEscrow(ctx, factory, cronos_client.CreateEscrowParams{
+		Maker:      maker.String(),
+		Taker:      relayer.String(),
+		SecretHash: secretHash,
+		Timelock:   timelock,
+		DstChainID: "ethereum",
+		DstAsset:   "ETH",
+		DstAmount:  dstAmount.String(),
+		Label:      "integration_" + secretHash[:8],
+	})
+	if err != nil {
+		t.Fatalf("failed to create source escrow: %v", err)
+	}
+
+	escrows, err := h.relayerCronos.GetEscrowOrders(ctx, factory, "", 100)
+	if err != nil {
+		t.Fatalf("failed to list source escrows: %v", err)
+	}
+	var sourceEscrow string
+	for _, escrow := range escrows {
+		if strings.EqualFold(escrow.SecretHash, secretHash) {
+			sourceEscrow = escrow.Address
+		}
+	}
+	if sourceEscrow == "" {
+		t.Fatalf("source escrow for hashlock %s not listed by the factory", secretHash)
+	}
+
+	_, err = h.makerCronos.ExecuteContract(ctx, sourceEscrow, map[string]interface{}{"deposit": map[string]interface{}{}},
+		sdk.NewCoins(sdk.NewCoin(swapDenom, sdk.NewIntFromBigInt(srcAmount))))
+	if err != nil {
+		t.Fatalf("failed to deposit into source escrow: %v", err)
+	}
+
+	relayerBefore, err := h.cronosBalance(ctx, relayer, swapDenom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The relayer picks the order up and deploys the destination escrow
+	om := order_manager.NewOrderManager(h.cfg, h.relayerCronos, h.relayerEth, zaptest.NewLogger(t).Named("order_manager"))
+	if err := om.Start(ctx); err != nil {
+		t.Fatalf("failed to start order manager: %v", err)
+	}
+	defer om.Stop(context.Background())
+
+	now := time.Now()
+	order := &order_manager.Order{
+		ID:               order_manager.OrderID(secretHash, "cronos", "ethereum"),
+		Type:             order_manager.OrderTypeCronosToEthereum,
+		Status:           order_manager.OrderStatusPending,
+		SourceChain:      "cronos",
+		DestinationChain: "ethereum",
+		Maker:            maker.String(),
+		Taker:            relayer.String(),
+		SecretHash:       secretHash,
+		Timelock:         timelock,
+		SourceEscrowAddr: sourceEscrow,
+		SourceAsset:      order_manager.AssetInfo{Symbol: swapDenom, Amount: srcAmount, Decimals: 6},
+		DestinationAsset: order_manager.AssetInfo{Symbol: "ETH", Amount: dstAmount, Decimals: 18},
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		ExpiresAt:        time.Unix(int64(timelock), 0),
+	}
+	om.AddOrder(order)
+
+	var deployTx string
+	waitFor(t, ctx, "destination escrow deployment", func() bool {
+		history, _ := om.GetOrderHistory(order.ID)
+		for _, transition := range history {
+			if transition.To == order_manager.OrderStatusActive {
+				deployTx = transition.TxHash
+				return true
+			}
+		}
+		return false
+	})
+
+	// Link the destination escrow to the order the way the Ethereum scanner
+	// does
+	receipt, err := h.relayerEth.WaitForTransaction(ctx, deployTx, txTimeout)
+	if err != nil {
+		t.Fatalf("failed to wait for destination escrow deployment: %v", err)
+	}
+	block := receipt.BlockNumber.Uint64()
+	ethEscrows, err := h.relayerEth.GetEscrowOrders(ctx, h.cfg.Contracts.Ethereum.EscrowFactory, block, block)
+	if err != nil {
+		t.Fatalf("failed to list destination escrows: %v", err)
+	}
+	var destEscrow common.Address
+	for _, escrow := range ethEscrows {
+		if !strings.EqualFold(strings.TrimPrefix(escrow.SecretHash, "0x"), secretHash) {
+			continue
+		}
+		destEscrow = common.HexToAddress(escrow.EscrowAddress)
+		merged := om.MergeOrder(&order_manager.Order{
+			ID:               order_manager.OrderID(escrow.SecretHash, "ethereum", "cronos"),
+			Type:             order_manager.OrderTypeEthereumToCronos,
+			SourceChain:      "ethereum",
+			DestinationChain: "cronos",
+			SecretHash:       escrow.SecretHash,
+			Timelock:         escrow.Timelock,
+			SourceEscrowAddr: escrow.EscrowAddress,
+			CreatedAt:        time.Unix(int64(escrow.CreatedAt), 0),
+		})
+		if !merged {
+			t.Fatalf("destination escrow %s was not merged into order %s", escrow.EscrowAddress, order.ID)
+		}
+	}
+	if destEscrow == (common.Address{}) {
+		t.Fatalf("no destination escrow with hashlock %s created in block %d", secretHash, block)
+	}
+
+	// The maker claims the destination escrow, revealing the secret
+	escrowABI, err := abi.JSON(strings.NewReader(makerEscrowABI))
+	if err != nil {
+		t.Fatalf("failed to parse escrow ABI: %v", err)
+	}
+	makerEth := common.BytesToAddress(maker)
+	if err := h.fund(ctx, makerEth, big.NewInt(1e18)); err != nil {
+		t.Fatal(err)
+	}
+
+	confirm, err := escrowABI.Pack("confirmSourceEscrow", sourceEscrow, big.NewInt(0))
+	if err != nil {
+		t.Fatalf("failed to pack confirmSourceEscrow: %v", err)
+	}
+	if _, err := h.sendAs(ctx, makerEth, destEscrow, confirm); err != nil {
+		t.Fatalf("failed to confirm source escrow: %v", err)
+	}
+
+	makerBefore, err := h.eth.BalanceAt(ctx, makerEth, nil)
+	if err != nil {
+		t.Fatalf("failed to get maker balance: %v", err)
+	}
+
+	withdraw, err := escrowABI.Pack("withdraw", secret)
+	if err != nil {
+		t.Fatalf("failed to pack withdraw: %v", err)
+	}
+	withdrawReceipt, err := h.sendAs(ctx, makerEth, destEscrow, withdraw)
+	if err != nil {
+		t.Fatalf("failed to withdraw from destination escrow: %v", err)
+	}
+
+	// The relayer reads the secret off Ethereum and claims the source escrow
+	waitFor(t, ctx, "source escrow withdrawal", func() bool {
+		status, err := h.relayerCronos.GetEscrowStatus(ctx, sourceEscrow)
+		return err == nil && status == "completed"
+	})
+	waitFor(t, ctx, "order completion", func() bool {
+		_, tracked := om.GetOrder(order.ID)
+		return !tracked
+	})
+
+	makerAfter, err := h.eth.BalanceAt(ctx, makerEth, nil)
+	if err != nil {
+		t.Fatalf("failed to get maker balance: %v", err)
+	}
+	gasCost := new(big.Int).Mul(new(big.Int).SetUint64(withdrawReceipt.GasUsed), withdrawReceipt.EffectiveGasPrice)
+	received := new(big.Int).Sub(makerAfter, makerBefore)
+	received.Add(received, gasCost)
+	if received.Cmp(dstAmount) != 0 {
+		t.Fatalf("maker received %s wei on Ethereum, want %s", received, dstAmount)
+	}
+
+	relayerAfter, err := h.cronosBalance(ctx, relayer, swapDenom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := new(big.Int).Sub(relayerAfter, relayerBefore); got.Cmp(srcAmount) != 0 {
+		t.Fatalf("relayer received %s%s on Cronos, want %s%s", got, swapDenom, srcAmount, swapDenom)
+	}
+}
+
+// waitFor polls cond every second until it holds, failing the test if ctx
+// expires first
+func waitFor(t *testing.T, ctx context.Context, what string, cond func() bool) {
+	t.Helper()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for !cond() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for %s", what)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,33 @@
+#!/bin/sh
+# Initializes a single-validator wasmd chain for the integration tests and
+# starts it. The relayer and maker accounts are derived from MNEMONIC at
+# address indexes 0 and 1, matching the harness
+set -e
+
+CHAIN_ID=${CHAIN_ID:-localwasm}
+HOME_DIR=/root/.wasmd
+MNEMONIC="test test test test test test test test test test test junk"
+KEYRING="--keyring-backend test --home $HOME_DIR"
+
+if [ ! -f "$HOME_DIR/config/genesis.json" ]; then
+  wasmd init integration --chain-id "$CHAIN_ID" --home "$HOME_DIR" >/dev/null 2>&1
+
+  wasmd keys add validator $KEYRING >/dev/null 2>&1
+  echo "$MNEMONIC" | wasmd keys add relayer --recover --index 0 $KEYRING >/dev/null
+  echo "$MNEMONIC" | wasmd keys add maker --recover --index 1 $KEYRING >/dev/null
+
+  # The maker swaps uswap; stake pays for gas
+  wasmd genesis add-genesis-account validator 1000000000000stake $KEYRING
+  wasmd genesis add-genesis-account relayer 1000000000000stake $KEYRING
+  wasmd genesis add-genesis-account maker 1000000000000stake,1000000000uswap $KEYRING
+
+  wasmd genesis gentx validator 1000000000stake --chain-id "$CHAIN_ID" $KEYRING
+  wasmd genesis collect-gentxs --home "$HOME_DIR" >/dev/null 2>&1
+
+  # One second blocks keep the tests quick
+  sed -i 's/^timeout_commit = .*/timeout_commit = "1s"/' "$HOME_DIR/config/config.toml"
+fi
+
+exec wasmd start --home "$HOME_DIR" \
+  --rpc.laddr tcp://0.0.0.0:26657 \
+  --minimum-gas-prices 0stake
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	sdkmath "cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GasSimulator estimates the gas a transaction uses, scaled by the factory's
+// gas adjustment.
+type GasSimulator func(clientCtx client.Context, txf tx.Factory, msgs ...sdk.Msg) (uint64, error)
+
+// SimulateGas estimates gas by simulating the transaction on the node.
+func SimulateGas(clientCtx client.Context, txf tx.Factory, msgs ...sdk.Msg) (uint64, error) {
+	_, adjusted, err := tx.CalculateGas(clientCtx, txf, msgs...)
+	return adjusted, err
+}
+
+// EstimateFee returns the fee a transaction with the given gas limit pays:
+// the --fees set on the factory, or else the gas limit priced at its
+// --gas-prices.
+func EstimateFee(txf tx.Factory, gas uint64) sdk.Coins {
+	if !txf.Fees().IsZero() {
+		return txf.Fees()
+	}
+
+	fees := sdk.NewCoins()
+	for _, price := range txf.GasPrices() {
+		fee := price.Amount.MulInt(sdkmath.NewIntFromUint64(gas)).Ceil().RoundInt()
+		fees = fees.Add(sdk.NewCoin(price.Denom, fee))
+	}
+	return fees
+}
+
+// BroadcastWithFeeEstimate simulates the transaction, prints its estimated
+// gas and fee to stderr and then signs and broadcasts it. With --gas auto the
+// simulated gas, adjusted by --gas-adjustment, becomes the gas limit. With
+// --dry-run nothing is broadcast, and with --generate-only or --offline the
+// transaction is generated as usual without simulating it.
+func BroadcastWithFeeEstimate(cmd *cobra.Command, clientCtx client.Context, simulate GasSimulator, msgs ...sdk.Msg) error {
+	txf, err := tx.NewFactoryCLI(clientCtx, cmd.Flags())
+	if err != nil {
+		return err
+	}
+	if clientCtx.GenerateOnly || clientCtx.Offline {
+		return tx.GenerateOrBroadcastTxWithFactory(clientCtx, txf, msgs...)
+	}
+
+	txf, err = txf.Prepare(clientCtx)
+	if err != nil {
+		return err
+	}
+
+	gas, err := simulate(clientCtx, txf, msgs...)
+	if err != nil {
+		return fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+	if txf.SimulateAndExecute() {
+		txf = txf.WithGas(gas)
+	}
+
+	fee := EstimateFee(txf, txf.Gas())
+	feeText := fee.String()
+	if fee.IsZero() {
+		feeText = "none"
+	}
+	out := cmd.ErrOrStderr()
+	fmt.Fprintf(out, "estimated gas: %d (gas adjustment %g)\n", gas, txf.GasAdjustment())
+	fmt.Fprintf(out, "gas limit: %d\n", txf.Gas())
+	fmt.Fprintf(out, "estimated fee: %s\n", feeText)
+
+	if clientCtx.Simulate {
+		return nil
+	}
+	return tx.BroadcastTx(clientCtx, txf.WithSimulateAndExecute(false), msgs...)
+}
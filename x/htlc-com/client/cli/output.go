@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+// HTLCOutput is the JSON form HTLC queries print with --output json. Its
+// schema is kept stable for scripts, independent of the query responses:
+//
+//	id        HTLC ID
+//	sender    bech32 address that locked the coins
+//	receiver  bech32 address that can claim them
+//	amount    locked coins sorted by denom, amounts as decimal strings
+//	hashlock  hex-encoded hash lock
+//	timelock  Unix time in seconds after which the HTLC can be refunded
+//	claimed   whether the HTLC has been claimed
+//	refunded  whether the HTLC has been refunded
+//	client_id the sender's order id, omitted when the HTLC has none
+//	splits    receivers paid a share on claim, each with its share as
+//	          amount is, omitted when the HTLC pays the receiver alone
+type HTLCOutput struct {
+	ID       uint64        `json:"id"`
+	Sender   string        `json:"sender"`
+	Receiver string        `json:"receiver"`
+	Amount   []CoinOutput  `json:"amount"`
+	HashLock string        `json:"hashlock"`
+	TimeLock int64         `json:"timelock"`
+	Claimed  bool          `json:"claimed"`
+	Refunded bool          `json:"refunded"`
+	ClientId string        `json:"client_id,omitempty"`
+	Splits   []SplitOutput `json:"splits,omitempty"`
+}
+
+// SplitOutput is a split in HTLCOutput.
+type SplitOutput struct {
+	Receiver string       `json:"receiver"`
+	Share    []CoinOutput `json:"share"`
+}
+
+// CoinOutput is a coin in HTLCOutput.
+type CoinOutput struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// HTLCListOutput is the JSON form list-htlcs prints with --output json.
+// next_key is the base64 key of the next page, empty on the last page.
+type HTLCListOutput struct {
+	HTLCs   []HTLCOutput `json:"htlcs"`
+	NextKey []byte       `json:"next_key"`
+	Total   uint64       `json:"total"`
+}
+
+// NewHTLCOutput returns the JSON form of htlc.
+func NewHTLCOutput(htlc types.HTLC) HTLCOutput {
+	var splits []SplitOutput
+	for _, split := range htlc.Splits {
+		splits = append(splits, SplitOutput{Receiver: split.Receiver.String(), Share: coinsOutput(split.Share)})
+	}
+
+	return HTLCOutput{
+		ID:       htlc.Id,
+		Sender:   htlc.Sender.String(),
+		Receiver: htlc.Receiver.String(),
+		Amount:   coinsOutput(htlc.Amount),
+		HashLock: hex.EncodeToString(htlc.HashLock),
+		TimeLock: htlc.TimeLock.Unix(),
+		Claimed:  htlc.Claimed,
+		Refunded: htlc.Refunded,
+		ClientId: htlc.ClientId,
+		Splits:   splits,
+	}
+}
+
+// coinsOutput returns coins sorted by denom in their JSON form.
+func coinsOutput(coins sdk.Coins) []CoinOutput {
+	coins = sdk.NewCoins(coins...)
+	out := make([]CoinOutput, len(coins))
+	for i, coin := range coins {
+		out[i] = CoinOutput{Denom: coin.Denom, Amount: coin.Amount.String()}
+	}
+	return out
+}
+
+// NewHTLCListOutput returns the JSON form of a page of HTLCs.
+func NewHTLCListOutput(htlcs []types.HTLC, pagination *query.PageResponse) HTLCListOutput {
+	out := HTLCListOutput{HTLCs: make([]HTLCOutput, len(htlcs))}
+	for i, htlc := range htlcs {
+		out.HTLCs[i] = NewHTLCOutput(htlc)
+	}
+	if pagination != nil {
+		out.NextKey = pagination.NextKey
+		out.Total = pagination.Total
+	}
+	return out
+}
+
+// PrintOutput prints out with --output json and the query response res
+// otherwise.
+func PrintOutput(clientCtx client.Context, res proto.Message, out interface{}) error {
+	if clientCtx.OutputFormat != flags.OutputFormatJSON {
+		return clientCtx.PrintProto(res)
+	}
+
+	bz, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	return clientCtx.PrintRaw(bz)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
 	"github.com/spf13/cobra"
@@ -12,6 +13,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 )
 
+// FlagStatus filters listed HTLCs by status.
+const FlagStatus = "status"
+
 func GetQueryCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:                        types.ModuleName,
@@ -23,6 +27,12 @@ func GetQueryCmd() *cobra.Command {
 
 	cmd.AddCommand(CmdListHTLCs())
 	cmd.AddCommand(CmdShowHTLC())
+	cmd.AddCommand(CmdShowHTLCByHashLock())
+	cmd.AddCommand(CmdCurrentPrice())
+	cmd.AddCommand(CmdTotalLocked())
+	cmd.AddCommand(CmdHTLCActions())
+	cmd.AddCommand(CmdPreimage())
+	cmd.AddCommand(CmdExpiringHTLCs())
 
 	return cmd
 }
@@ -31,25 +41,46 @@ func CmdListHTLCs() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list-htlcs",
 		Short: "List all HTLCs",
-		Long:  "List all HTLCs in the network",
+		Long:  "List the HTLCs in the network a page at a time, optionally only those with the given --status or --client-id",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
 
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			req := &types.QueryListHTLCsRequest{Pagination: pageReq}
+			req.ClientId, err = cmd.Flags().GetString(FlagClientId)
+			if err != nil {
+				return err
+			}
+			if name, _ := cmd.Flags().GetString(FlagStatus); name != "" {
+				status, err := types.ParseHTLCStatus(name)
+				if err != nil {
+					return err
+				}
+				req.Status = status
+			}
+
 			queryClient := types.NewQueryClient(clientCtx)
 
-			res, err := queryClient.HTLCs(context.Background(), &types.QueryListHTLCsRequest{})
+			res, err := queryClient.HTLCs(context.Background(), req)
 			if err != nil {
 				return err
 			}
 
-			return clientCtx.PrintProto(res)
+			return PrintOutput(clientCtx, res, NewHTLCListOutput(res.HTLCs, res.Pagination))
 		},
 	}
 
+	cmd.Flags().String(FlagStatus, "", "Only list HTLCs with this status: active, claimed, refunded or expired")
+	cmd.Flags().String(FlagClientId, "", "Only list HTLCs created with this client order id")
 	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "htlcs")
 
 	return cmd
 }
@@ -78,6 +109,206 @@ func CmdShowHTLC() *cobra.Command {
 				return err
 			}
 
+			return PrintOutput(clientCtx, res, NewHTLCOutput(res.HTLC))
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdShowHTLCByHashLock() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-htlc-by-hashlock [hashlock]",
+		Short: "Show a HTLC by hash lock",
+		Long:  "Show details of the first active HTLC locked with the given hex-encoded hash lock",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			hashLock, err := ParseHashLock(args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.HTLCByHashLock(context.Background(), &types.QueryHTLCByHashLockRequest{HashLock: hashLock})
+			if err != nil {
+				return err
+			}
+
+			return PrintOutput(clientCtx, res, NewHTLCOutput(res.HTLC))
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdCurrentPrice() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "current-price [id]",
+		Short: "Show the current Dutch auction price of a HTLC",
+		Long:  "Show the Dutch auction price of a specific HTLC at the latest block time",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.CurrentPrice(context.Background(), &types.QueryCurrentPriceRequest{Id: id})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdTotalLocked() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "total-locked",
+		Short: "Show the total value locked in HTLCs",
+		Long:  "Show the coins locked in all active HTLCs, per denom",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.TotalLocked(context.Background(), &types.QueryTotalLockedRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdPreimage() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preimage [id]",
+		Short: "Show the preimage revealed by claiming a HTLC",
+		Long:  "Show the secret revealed by claiming a specific HTLC, or by claiming the latest part of a Merkle HTLC. It fails until the HTLC has been claimed",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.Preimage(context.Background(), &types.QueryPreimageRequest{Id: id})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdHTLCActions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "htlc-actions [address] [id]...",
+		Short: "Show which HTLCs an address can claim or refund",
+		Long:  fmt.Sprintf("Show whether the address can claim or refund each of up to %d HTLCs at the latest block time, and why not when it can do neither", types.MaxHTLCActionsIds),
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			ids := make([]uint64, 0, len(args)-1)
+			for _, arg := range args[1:] {
+				id, err := strconv.ParseUint(arg, 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid htlc id %q: %w", arg, err)
+				}
+				ids = append(ids, id)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.HTLCActions(context.Background(), &types.QueryHTLCActionsRequest{Address: args[0], Ids: ids})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdExpiringHTLCs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "expiring-htlcs [duration]",
+		Short: "List the HTLCs expiring within a duration",
+		Long:  fmt.Sprintf("List the unsettled HTLCs whose time lock comes before the latest block time plus the duration, such as 10m or 1h, the earliest first and at most %d of them. HTLCs already expired but not yet refunded are included", types.MaxExpiringHTLCs),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			within, err := time.ParseDuration(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid duration %q: %w", args[0], err)
+			}
+			if within < 0 {
+				return fmt.Errorf("duration must not be negative, got %s", within)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.ExpiringHTLCs(context.Background(), &types.QueryExpiringHTLCsRequest{Within: within})
+			if err != nil {
+				return err
+			}
+
 			return clientCtx.PrintProto(res)
 		},
 	}
@@ -1,10 +1,20 @@
 package cli_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/client/cli"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 )
 
 func TestQueryCmds(t *testing.T) {
@@ -12,8 +22,88 @@ func TestQueryCmds(t *testing.T) {
 	listCmd := cli.CmdListHTLCs()
 	require.NotNil(t, listCmd)
 	require.Equal(t, "list-htlcs", listCmd.Use)
+	require.NotNil(t, listCmd.Flags().Lookup(cli.FlagStatus))
+	require.NotNil(t, listCmd.Flags().Lookup(flags.FlagLimit))
+	require.NotNil(t, listCmd.Flags().Lookup(flags.FlagPageKey))
 
 	showCmd := cli.CmdShowHTLC()
 	require.NotNil(t, showCmd)
 	require.Equal(t, "show-htlc", showCmd.Use)
+
+	showByHashLockCmd := cli.CmdShowHTLCByHashLock()
+	require.NotNil(t, showByHashLockCmd)
+	require.Equal(t, "show-htlc-by-hashlock", showByHashLockCmd.Name())
+
+	currentPriceCmd := cli.CmdCurrentPrice()
+	require.NotNil(t, currentPriceCmd)
+	require.Equal(t, "current-price", currentPriceCmd.Name())
+
+	totalLockedCmd := cli.CmdTotalLocked()
+	require.NotNil(t, totalLockedCmd)
+	require.Equal(t, "total-locked", totalLockedCmd.Name())
+
+	actionsCmd := cli.CmdHTLCActions()
+	require.NotNil(t, actionsCmd)
+	require.Equal(t, "htlc-actions", actionsCmd.Name())
+	require.Error(t, actionsCmd.Args(actionsCmd, []string{"cro1receiver"}))
+
+	preimageCmd := cli.CmdPreimage()
+	require.NotNil(t, preimageCmd)
+	require.Equal(t, "preimage", preimageCmd.Name())
+
+	expiringCmd := cli.CmdExpiringHTLCs()
+	require.NotNil(t, expiringCmd)
+	require.Equal(t, "expiring-htlcs", expiringCmd.Name())
+	require.Error(t, expiringCmd.Args(expiringCmd, nil))
+}
+
+func TestHTLCOutputJSON(t *testing.T) {
+	sender := sdk.AccAddress([]byte("sender______________"))
+	receiver := sdk.AccAddress([]byte("receiver____________"))
+	htlc := types.HTLC{
+		Id:       7,
+		Sender:   sender,
+		Receiver: receiver,
+		Amount:   sdk.Coins{sdk.NewInt64Coin("uatom", 5), sdk.NewInt64Coin("stake", 1000)},
+		HashLock: []byte{0xab, 0xcd},
+		TimeLock: time.Unix(1700000000, 0).UTC(),
+		Claimed:  true,
+	}
+
+	var buf bytes.Buffer
+	clientCtx := client.Context{}.WithOutput(&buf).WithOutputFormat(flags.OutputFormatJSON)
+	require.NoError(t, cli.PrintOutput(clientCtx, &types.QueryGetHTLCResponse{HTLC: htlc}, cli.NewHTLCOutput(htlc)))
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+	require.Equal(t, map[string]interface{}{
+		"id":       float64(7),
+		"sender":   sender.String(),
+		"receiver": receiver.String(),
+		"amount": []interface{}{
+			map[string]interface{}{"denom": "stake", "amount": "1000"},
+			map[string]interface{}{"denom": "uatom", "amount": "5"},
+		},
+		"hashlock": "abcd",
+		"timelock": float64(1700000000),
+		"claimed":  true,
+		"refunded": false,
+	}, fields)
+
+	buf.Reset()
+	list := cli.NewHTLCListOutput([]types.HTLC{htlc}, &query.PageResponse{NextKey: []byte{1}, Total: 3})
+	require.NoError(t, cli.PrintOutput(clientCtx, &types.QueryListHTLCsResponse{HTLCs: []types.HTLC{htlc}}, list))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+	require.Len(t, fields["htlcs"], 1)
+	require.Equal(t, "AQ==", fields["next_key"])
+	require.Equal(t, float64(3), fields["total"])
+}
+
+func TestQueryCmdsOutputFlag(t *testing.T) {
+	// Text stays the default; json switches to the flat schema
+	for _, cmd := range []*cobra.Command{cli.CmdListHTLCs(), cli.CmdShowHTLC(), cli.CmdShowHTLCByHashLock()} {
+		flag := cmd.Flags().Lookup(flags.FlagOutput)
+		require.NotNil(t, flag, cmd.Name())
+		require.Equal(t, flags.OutputFormatText, flag.DefValue, cmd.Name())
+	}
 }
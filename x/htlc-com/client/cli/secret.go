@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// FlagSecret derives the hash lock from a given secret instead of a
+	// random one.
+	FlagSecret = "secret"
+	// FlagOutputFile writes the secret to a file.
+	FlagOutputFile = "output-file"
+)
+
+// secretSize is the length of generated secrets in bytes.
+const secretSize = 32
+
+// CmdGenSecret generates an HTLC secret and its hash lock. It runs offline.
+func CmdGenSecret() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen-secret",
+		Short: "Generate a secret and its SHA256 hash lock",
+		Long: `Generate a random 32-byte secret and print it hex encoded together with its
+SHA256 hash lock, ready to pass to create-htlc and claim-htlc.
+
+Flags:
+  --secret       Derive the hash lock from this hex-encoded secret instead
+  --output-file  Also write the hex-encoded secret to this file, readable only
+                 by the current user. An existing file is never overwritten
+  --parts        Generate a secret per part of a Merkle HTLC, printing each
+                 with its Merkle proof and the Merkle root as the hash lock
+		
+Example:
+  gen-secret
+  gen-secret --output-file ./swap.secret
+  gen-secret --secret 0xabcdef1234567890...
+  gen-secret --parts 4 --output-file ./swap.secrets`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			given, err := cmd.Flags().GetString(FlagSecret)
+			if err != nil {
+				return err
+			}
+			parts, err := cmd.Flags().GetUint32(FlagParts)
+			if err != nil {
+				return err
+			}
+			if parts > 0 {
+				if given != "" {
+					return fmt.Errorf("--%s cannot be combined with --%s", FlagSecret, FlagParts)
+				}
+				return genMerkleSecrets(cmd, parts)
+			}
+
+			var secret []byte
+			if given != "" {
+				secret, err = ParsePreimage(given)
+				if err != nil {
+					return err
+				}
+			} else {
+				secret = make([]byte, secretSize)
+				if _, err := rand.Read(secret); err != nil {
+					return fmt.Errorf("failed to generate secret: %w", err)
+				}
+			}
+
+			encoded := hex.EncodeToString(secret)
+			hashLock := sha256.Sum256(secret)
+
+			outputFile, err := cmd.Flags().GetString(FlagOutputFile)
+			if err != nil {
+				return err
+			}
+			if outputFile != "" {
+				if err := writeSecretFile(outputFile, encoded); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "secret: %s\n", encoded)
+			fmt.Fprintf(out, "hashlock: %s\n", hex.EncodeToString(hashLock[:]))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagSecret, "", "Hex-encoded secret to derive the hash lock from instead of generating one")
+	cmd.Flags().String(FlagOutputFile, "", "File to write the hex-encoded secret to, created with 0600 permissions")
+	cmd.Flags().Uint32(FlagParts, 0, "Number of parts of a Merkle HTLC to generate secrets for")
+
+	return cmd
+}
+
+// genMerkleSecrets generates a secret per part of a Merkle HTLC and prints
+// the secrets with their proofs and the root to lock the HTLC with.
+func genMerkleSecrets(cmd *cobra.Command, parts uint32) error {
+	if parts < 2 || parts > types.MaxHTLCParts {
+		return fmt.Errorf("parts must be between 2 and %d", types.MaxHTLCParts)
+	}
+
+	secrets := make([]string, parts)
+	leaves := make([][]byte, parts)
+	for i := range secrets {
+		secret := make([]byte, secretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return fmt.Errorf("failed to generate secret: %w", err)
+		}
+		secretHash := sha256.Sum256(secret)
+		secrets[i] = hex.EncodeToString(secret)
+		leaves[i] = types.MerkleLeaf(uint32(i), secretHash[:])
+	}
+
+	outputFile, err := cmd.Flags().GetString(FlagOutputFile)
+	if err != nil {
+		return err
+	}
+	if outputFile != "" {
+		if err := writeSecretFile(outputFile, strings.Join(secrets, "\n")); err != nil {
+			return err
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	for i, secret := range secrets {
+		proof := types.MerkleProof(leaves, uint32(i))
+		encoded := make([]string, len(proof))
+		for j, hash := range proof {
+			encoded[j] = hex.EncodeToString(hash)
+		}
+		fmt.Fprintf(out, "secret %d: %s\n", i, secret)
+		fmt.Fprintf(out, "proof %d: %s\n", i, strings.Join(encoded, ","))
+	}
+	fmt.Fprintf(out, "hashlock: %s\n", hex.EncodeToString(types.MerkleRoot(leaves)))
+	return nil
+}
+
+// writeSecretFile creates path readable only by the owner and writes the
+// secret to it, refusing to replace an existing file.
+func writeSecretFile(path, secret string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create secret file: %w", err)
+	}
+	if _, err := fmt.Fprintln(f, secret); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write secret file: %w", err)
+	}
+	return f.Close()
+}
@@ -1,8 +1,13 @@
 package cli
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
@@ -10,10 +15,27 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
-	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+const (
+	// FlagPayoutAddress redirects the coins of a claimed HTLC.
+	FlagPayoutAddress = "payout-address"
+	// FlagParts splits a new HTLC into parts claimed with their own secrets.
+	FlagParts = "parts"
+	// FlagPart is the part of a Merkle HTLC to claim up to.
+	FlagPart = "part"
+	// FlagProof is the Merkle proof of the claimed part.
+	FlagProof = "proof"
+	// FlagClientId tags a new HTLC with the sender's own order id, or
+	// filters listed HTLCs by it.
+	FlagClientId = "client-id"
+	// FlagSplit pays a share of a new HTLC to another receiver on claim.
+	FlagSplit = "split"
+	// FlagAllowPartial lets a batch claim succeed with some claims failing.
+	FlagAllowPartial = "allow-partial"
+)
+
 func GetTxCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:                        types.ModuleName,
@@ -24,8 +46,13 @@ func GetTxCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(CmdCreateHTLC())
+	cmd.AddCommand(CmdBatchCreateHTLC())
 	cmd.AddCommand(CmdClaimHTLC())
+	cmd.AddCommand(CmdBatchClaimHTLC())
+	cmd.AddCommand(CmdRevealSecret())
 	cmd.AddCommand(CmdRefundHTLC())
+	cmd.AddCommand(CmdUpdateHTLC())
+	cmd.AddCommand(CmdGenSecret())
 
 	return cmd
 }
@@ -42,8 +69,17 @@ Arguments:
   [hashlock]  The SHA256 hash of the preimage (32 bytes in hex)
   [timelock]  The Unix timestamp when the HTLC expires and can be refunded
 		
+Flags:
+  --parts      Split the HTLC into this many parts, each claimed with its own
+               secret; [hashlock] is then the Merkle root from gen-secret --parts
+  --client-id  Tag the HTLC with your own order id, at most 128 bytes
+  --split      Pay a share to a receiver on claim, as receiver=coins; repeat it
+               for every receiver, the shares adding up to [amount]
+		
 Example:
-  create-htlc cosmos1... 1000stake 0x1234567890abcdef... 1620000000`,
+  create-htlc cosmos1... 1000stake 0x1234567890abcdef... 1620000000
+  create-htlc cosmos1... 1000stake 0x1234567890abcdef... 1620000000 --parts 4
+  create-htlc cosmos1... 1000stake 0x1234567890abcdef... 1620000000 --split cosmos1a...=600stake --split cosmos1b...=400stake`,
 		Args: cobra.ExactArgs(4),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
@@ -61,11 +97,9 @@ Example:
 				return err
 			}
 
-			hashLock := []byte(args[2])
-
-			// Validate hashLock length
-			if len(hashLock) != 32 {
-				return fmt.Errorf("hashLock must be 32 bytes (SHA256 hash)")
+			hashLock, err := ParseHashLock(args[2])
+			if err != nil {
+				return err
 			}
 
 			timeLock, err := strconv.ParseInt(args[3], 10, 64)
@@ -80,11 +114,84 @@ Example:
 			}
 
 			msg := types.NewMsgCreateHTLC(clientCtx.GetFromAddress(), receiver, amount, hashLock, timeLock)
+			msg.Parts, err = cmd.Flags().GetUint32(FlagParts)
+			if err != nil {
+				return err
+			}
+			msg.ClientId, err = cmd.Flags().GetString(FlagClientId)
+			if err != nil {
+				return err
+			}
+			splits, err := cmd.Flags().GetStringArray(FlagSplit)
+			if err != nil {
+				return err
+			}
+			for _, split := range splits {
+				parsed, err := ParseSplit(split)
+				if err != nil {
+					return err
+				}
+				msg.Splits = append(msg.Splits, parsed)
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return BroadcastWithFeeEstimate(cmd, clientCtx, SimulateGas, msg)
+		},
+	}
+
+	cmd.Flags().Uint32(FlagParts, 0, "Number of parts the HTLC is claimed in, each with its own secret")
+	cmd.Flags().String(FlagClientId, "", "Client order id to tag the HTLC with")
+	cmd.Flags().StringArray(FlagSplit, nil, "Share paid to a receiver on claim, as receiver=coins (repeatable)")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdBatchCreateHTLC() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-create-htlc [htlcs-file]",
+		Short: "Create several HTLCs atomically",
+		Long: `Create several HTLCs in a single message. Either every HTLC is created or,
+if any of them fails, none is.
+
+Arguments:
+  [htlcs-file]  A JSON file holding a list of HTLCs with the same fields as create-htlc
+
+Example:
+  batch-create-htlc htlcs.json
+
+where htlcs.json contains:
+  [
+    {"receiver": "cosmos1...", "amount": "1000stake", "hash_lock": "0x1234...", "time_lock": 1620000000},
+    {"receiver": "cosmos1...", "amount": "500stake", "hash_lock": "0xabcd...", "time_lock": 1620003600}
+  ]`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			htlcs, err := ParseBatchHTLCFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			now := time.Now().Unix()
+			for i, htlc := range htlcs {
+				if htlc.TimeLock <= now {
+					return fmt.Errorf("htlc %d: timeLock must be in the future", i)
+				}
+			}
+
+			msg := types.NewMsgBatchCreateHTLC(clientCtx.GetFromAddress(), htlcs)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+			return BroadcastWithFeeEstimate(cmd, clientCtx, SimulateGas, msg)
 		},
 	}
 
@@ -101,10 +208,18 @@ func CmdClaimHTLC() *cobra.Command {
 		
 Arguments:
   [htlc-id]   The ID of the HTLC to claim
-  [preimage]  The preimage that matches the hash lock of the HTLC
+  [preimage]  The hex-encoded preimage that matches the hash lock of the HTLC
+		
+Flags:
+  --payout-address  Send the claimed coins to this address instead of the receiver
+  --part            Claim a Merkle HTLC up to and including this part, using
+                    the part's secret as [preimage]
+  --proof           The comma-separated hex-encoded Merkle proof of the part
 		
 Example:
-  claim-htlc 1 0xabcdef1234567890...`,
+  claim-htlc 1 0xabcdef1234567890...
+  claim-htlc 1 0xabcdef1234567890... --payout-address cosmos1...
+  claim-htlc 1 0xabcdef1234567890... --part 2 --proof 0x1234...,0x5678...`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
@@ -117,19 +232,145 @@ Example:
 				return err
 			}
 
-			preimage := []byte(args[1])
-
-			// Validate preimage is not empty
-			if len(preimage) == 0 {
-				return fmt.Errorf("preimage cannot be empty")
+			preimage, err := ParsePreimage(args[1])
+			if err != nil {
+				return err
 			}
 
 			msg := types.NewMsgClaimHTLC(clientCtx.GetFromAddress(), htlcId, preimage)
+
+			payout, err := cmd.Flags().GetString(FlagPayoutAddress)
+			if err != nil {
+				return err
+			}
+			if payout != "" {
+				msg.PayoutAddress, err = sdk.AccAddressFromBech32(payout)
+				if err != nil {
+					return fmt.Errorf("invalid payout address: %w", err)
+				}
+			}
+
+			msg.Part, err = cmd.Flags().GetUint32(FlagPart)
+			if err != nil {
+				return err
+			}
+			proof, err := cmd.Flags().GetString(FlagProof)
+			if err != nil {
+				return err
+			}
+			if proof != "" {
+				msg.Proof, err = ParseMerkleProof(proof)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return BroadcastWithFeeEstimate(cmd, clientCtx, SimulateGas, msg)
+		},
+	}
+
+	cmd.Flags().String(FlagPayoutAddress, "", "Address to send the claimed coins to instead of the receiver")
+	cmd.Flags().Uint32(FlagPart, 0, "Part of a Merkle HTLC to claim up to and including")
+	cmd.Flags().String(FlagProof, "", "Comma-separated hex-encoded Merkle proof of the claimed part")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdBatchClaimHTLC() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-claim-htlc [htlc-id:preimage]...",
+		Short: "Claim several HTLCs with one message",
+		Long: `Claim several HTLCs in a single message, each with the preimage that matches
+its hash lock. Either every HTLC is claimed or, if any claim fails, none is.
+With --allow-partial the failing claims are reported in the response instead
+and the others are still claimed. Merkle HTLCs are claimed with claim-htlc.
+
+Arguments:
+  [htlc-id:preimage]  An HTLC ID and its hex-encoded preimage
+
+Flags:
+  --allow-partial  Claim the valid HTLCs even if other claims fail
+
+Example:
+  batch-claim-htlc 1:0xabcdef... 2:0x123456...
+  batch-claim-htlc 1:0xabcdef... 2:0x123456... --allow-partial`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			claims := make([]types.HTLCClaim, len(args))
+			for i, arg := range args {
+				claims[i], err = ParseHTLCClaim(arg)
+				if err != nil {
+					return err
+				}
+			}
+
+			allowPartial, err := cmd.Flags().GetBool(FlagAllowPartial)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBatchClaimHTLC(clientCtx.GetFromAddress(), claims, allowPartial)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+			return BroadcastWithFeeEstimate(cmd, clientCtx, SimulateGas, msg)
+		},
+	}
+
+	cmd.Flags().Bool(FlagAllowPartial, false, "Claim the valid HTLCs even if other claims fail")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func CmdRevealSecret() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reveal-secret [htlc-id] [secret]",
+		Short: "Reveal the secret of an HTLC without claiming it",
+		Long: `Publish the secret of an HTLC on-chain without claiming its coins, so the
+counterparty can complete its own leg of the swap. The receiver can still
+claim the HTLC afterwards.
+		
+Arguments:
+  [htlc-id]  The ID of the HTLC
+  [secret]   The hex-encoded preimage that matches the hash lock of the HTLC
+		
+Example:
+  reveal-secret 1 0xabcdef1234567890...`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			htlcId, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			secret, err := ParsePreimage(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevealSecret(clientCtx.GetFromAddress(), htlcId, secret)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return BroadcastWithFeeEstimate(cmd, clientCtx, SimulateGas, msg)
 		},
 	}
 
@@ -166,7 +407,7 @@ Example:
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+			return BroadcastWithFeeEstimate(cmd, clientCtx, SimulateGas, msg)
 		},
 	}
 
@@ -174,3 +415,183 @@ Example:
 
 	return cmd
 }
+
+func CmdUpdateHTLC() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-htlc [htlc-id] [new-timelock]",
+		Short: "Extend the time lock of an HTLC",
+		Long: `Extend the time lock of an HTLC you created, before it expires.
+		
+Arguments:
+  [htlc-id]       The ID of the HTLC to update
+  [new-timelock]  The new Unix timestamp, later than the current time lock
+		
+Example:
+  update-htlc 1 1620003600`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			htlcId, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			newTimeLock, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			// Validate newTimeLock is in the future
+			if newTimeLock <= time.Now().Unix() {
+				return fmt.Errorf("new timeLock must be in the future")
+			}
+
+			msg := types.NewMsgUpdateHTLC(clientCtx.GetFromAddress(), htlcId, newTimeLock)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return BroadcastWithFeeEstimate(cmd, clientCtx, SimulateGas, msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// ParseHashLock decodes a hex-encoded SHA256 hash lock, with or without a 0x
+// prefix.
+func ParseHashLock(arg string) ([]byte, error) {
+	hashLock, err := decodeHex(arg)
+	if err != nil {
+		return nil, fmt.Errorf("hashlock must be hex encoded: %w", err)
+	}
+	if len(hashLock) != sha256.Size {
+		return nil, fmt.Errorf("hashlock must be %d bytes (SHA256 hash), got %d", sha256.Size, len(hashLock))
+	}
+	return hashLock, nil
+}
+
+// ParseSplit parses a --split value of the form receiver=coins.
+func ParseSplit(arg string) (types.Split, error) {
+	receiver, share, ok := strings.Cut(arg, "=")
+	if !ok {
+		return types.Split{}, fmt.Errorf("split %q must be receiver=coins", arg)
+	}
+
+	address, err := sdk.AccAddressFromBech32(strings.TrimSpace(receiver))
+	if err != nil {
+		return types.Split{}, fmt.Errorf("split %q: %w", arg, err)
+	}
+	coins, err := sdk.ParseCoinsNormalized(strings.TrimSpace(share))
+	if err != nil {
+		return types.Split{}, fmt.Errorf("split %q: %w", arg, err)
+	}
+	return types.Split{Receiver: address, Share: coins}, nil
+}
+
+// batchHTLCEntry is one HTLC in a batch-create-htlc file.
+type batchHTLCEntry struct {
+	Receiver string `json:"receiver"`
+	Amount   string `json:"amount"`
+	HashLock string `json:"hash_lock"`
+	TimeLock int64  `json:"time_lock"`
+}
+
+// ParseBatchHTLCFile reads the HTLCs of a batch-create-htlc JSON file.
+func ParseBatchHTLCFile(path string) ([]types.HTLCParams, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []batchHTLCEntry
+	if err := json.Unmarshal(bz, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s lists no htlcs", path)
+	}
+
+	htlcs := make([]types.HTLCParams, len(entries))
+	for i, entry := range entries {
+		receiver, err := sdk.AccAddressFromBech32(entry.Receiver)
+		if err != nil {
+			return nil, fmt.Errorf("htlc %d: %w", i, err)
+		}
+		amount, err := sdk.ParseCoinsNormalized(entry.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("htlc %d: %w", i, err)
+		}
+		hashLock, err := ParseHashLock(entry.HashLock)
+		if err != nil {
+			return nil, fmt.Errorf("htlc %d: %w", i, err)
+		}
+
+		htlcs[i] = types.HTLCParams{
+			Receiver: receiver,
+			Amount:   amount,
+			HashLock: hashLock,
+			TimeLock: entry.TimeLock,
+		}
+	}
+	return htlcs, nil
+}
+
+// ParsePreimage decodes a hex-encoded preimage, with or without a 0x prefix.
+func ParsePreimage(arg string) ([]byte, error) {
+	preimage, err := decodeHex(arg)
+	if err != nil {
+		return nil, fmt.Errorf("preimage must be hex encoded: %w", err)
+	}
+	if len(preimage) == 0 {
+		return nil, fmt.Errorf("preimage cannot be empty")
+	}
+	return preimage, nil
+}
+
+// ParseHTLCClaim parses a batch-claim-htlc argument of the form
+// htlc-id:preimage, the preimage being hex encoded.
+func ParseHTLCClaim(arg string) (types.HTLCClaim, error) {
+	id, preimage, ok := strings.Cut(arg, ":")
+	if !ok {
+		return types.HTLCClaim{}, fmt.Errorf("claim %q must be htlc-id:preimage", arg)
+	}
+
+	htlcId, err := strconv.ParseUint(strings.TrimSpace(id), 10, 64)
+	if err != nil {
+		return types.HTLCClaim{}, fmt.Errorf("claim %q: invalid htlc id: %w", arg, err)
+	}
+	secret, err := ParsePreimage(strings.TrimSpace(preimage))
+	if err != nil {
+		return types.HTLCClaim{}, fmt.Errorf("claim %q: %w", arg, err)
+	}
+	return types.HTLCClaim{HTLCId: htlcId, Preimage: secret}, nil
+}
+
+// ParseMerkleProof decodes a comma-separated list of hex-encoded proof
+// hashes, each with or without a 0x prefix.
+func ParseMerkleProof(arg string) ([][]byte, error) {
+	var proof [][]byte
+	for i, hash := range strings.Split(arg, ",") {
+		bz, err := decodeHex(strings.TrimSpace(hash))
+		if err != nil {
+			return nil, fmt.Errorf("proof hash %d must be hex encoded: %w", i, err)
+		}
+		if len(bz) != sha256.Size {
+			return nil, fmt.Errorf("proof hash %d must be %d bytes, got %d", i, sha256.Size, len(bz))
+		}
+		proof = append(proof, bz)
+	}
+	return proof, nil
+}
+
+func decodeHex(arg string) ([]byte, error) {
+	arg = strings.TrimPrefix(strings.TrimPrefix(arg, "0x"), "0X")
+	return hex.DecodeString(arg)
+}
@@ -1,10 +1,24 @@
 package cli_test
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/client/cli"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func TestTxCmds(t *testing.T) {
@@ -17,7 +31,288 @@ func TestTxCmds(t *testing.T) {
 	require.NotNil(t, claimCmd)
 	require.Equal(t, "claim-htlc", claimCmd.Use)
 
+	revealCmd := cli.CmdRevealSecret()
+	require.NotNil(t, revealCmd)
+	require.Equal(t, "reveal-secret", revealCmd.Name())
+
 	refundCmd := cli.CmdRefundHTLC()
 	require.NotNil(t, refundCmd)
 	require.Equal(t, "refund-htlc", refundCmd.Use)
+
+	batchCmd := cli.CmdBatchCreateHTLC()
+	require.NotNil(t, batchCmd)
+	require.Equal(t, "batch-create-htlc", batchCmd.Name())
+
+	batchClaimCmd := cli.CmdBatchClaimHTLC()
+	require.NotNil(t, batchClaimCmd)
+	require.Equal(t, "batch-claim-htlc", batchClaimCmd.Name())
+	require.NotNil(t, batchClaimCmd.Flags().Lookup(cli.FlagAllowPartial))
+
+	updateCmd := cli.CmdUpdateHTLC()
+	require.NotNil(t, updateCmd)
+	require.Equal(t, "update-htlc", updateCmd.Name())
+}
+
+func TestParseHashLock(t *testing.T) {
+	hash := sha256.Sum256([]byte("secret"))
+	encoded := hex.EncodeToString(hash[:])
+
+	for _, tc := range []struct {
+		desc  string
+		arg   string
+		valid bool
+	}{
+		{"hex", encoded, true},
+		{"0x prefixed hex", "0x" + encoded, true},
+		{"too short", "0x" + encoded[:62], false},
+		{"too long", encoded + "00", false},
+		{"32 raw bytes", string(hash[:16]) + "0123456789abcdef", false},
+		{"not hex", "0x" + encoded[:62] + "zz", false},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			hashLock, err := cli.ParseHashLock(tc.arg)
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, hash[:], hashLock)
+		})
+	}
+}
+
+func TestParsePreimage(t *testing.T) {
+	preimage, err := cli.ParsePreimage("0x736563726574")
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), preimage)
+
+	_, err = cli.ParsePreimage("0x")
+	require.Error(t, err)
+
+	_, err = cli.ParsePreimage("secret")
+	require.Error(t, err)
+}
+
+// genSecret runs gen-secret with args and returns the printed secret and hash
+// lock
+func genSecret(t *testing.T, args ...string) (secret, hashLock []byte) {
+	t.Helper()
+
+	cmd := cli.CmdGenSecret()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	secret, err := hex.DecodeString(strings.TrimPrefix(lines[0], "secret: "))
+	require.NoError(t, err)
+	hashLock, err = hex.DecodeString(strings.TrimPrefix(lines[1], "hashlock: "))
+	require.NoError(t, err)
+	return secret, hashLock
+}
+
+func TestGenSecret(t *testing.T) {
+	secret, hashLock := genSecret(t)
+	require.Len(t, secret, 32)
+	expected := sha256.Sum256(secret)
+	require.Equal(t, expected[:], hashLock)
+
+	// every run draws a new secret
+	other, _ := genSecret(t)
+	require.NotEqual(t, secret, other)
+
+	// a given secret is hashed instead
+	secret, hashLock = genSecret(t, "--secret", "0x736563726574")
+	require.Equal(t, []byte("secret"), secret)
+	expected = sha256.Sum256([]byte("secret"))
+	require.Equal(t, expected[:], hashLock)
+}
+
+func TestGenSecretOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "swap.secret")
+
+	secret, _ := genSecret(t, "--output-file", path)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(secret), strings.TrimSpace(string(contents)))
+
+	// an existing secret file is never overwritten
+	cmd := cli.CmdGenSecret()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--output-file", path})
+	require.Error(t, cmd.Execute())
+}
+
+func TestGenSecretParts(t *testing.T) {
+	cmd := cli.CmdGenSecret()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--parts", "3"})
+	require.NoError(t, cmd.Execute())
+
+	// A secret and proof per part, then the root
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 7)
+	root, err := hex.DecodeString(strings.TrimPrefix(lines[6], "hashlock: "))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		secret, err := cli.ParsePreimage(strings.TrimPrefix(lines[2*i], fmt.Sprintf("secret %d: ", i)))
+		require.NoError(t, err)
+		proof, err := cli.ParseMerkleProof(strings.TrimPrefix(lines[2*i+1], fmt.Sprintf("proof %d: ", i)))
+		require.NoError(t, err)
+
+		secretHash := sha256.Sum256(secret)
+		require.True(t, types.VerifyMerkleProof(root, types.MerkleLeaf(uint32(i), secretHash[:]), uint32(i), 3, proof), "part %d", i)
+	}
+
+	cmd = cli.CmdGenSecret()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--parts", "3", "--secret", "0x736563726574"})
+	require.Error(t, cmd.Execute())
+}
+
+func TestParseMerkleProof(t *testing.T) {
+	first := sha256.Sum256([]byte("first"))
+	second := sha256.Sum256([]byte("second"))
+
+	proof, err := cli.ParseMerkleProof("0x" + hex.EncodeToString(first[:]) + ", " + hex.EncodeToString(second[:]))
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{first[:], second[:]}, proof)
+
+	_, err = cli.ParseMerkleProof(hex.EncodeToString(first[:]) + ",0x1234")
+	require.Error(t, err)
+
+	_, err = cli.ParseMerkleProof("not-hex")
+	require.Error(t, err)
+}
+
+func TestParseBatchHTLCFile(t *testing.T) {
+	receiver := sdk.AccAddress([]byte("receiver____________"))
+	hash := sha256.Sum256([]byte("secret"))
+
+	write := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "htlcs.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	path := write(t, `[
+		{"receiver": "`+receiver.String()+`", "amount": "1000stake", "hash_lock": "0x`+hex.EncodeToString(hash[:])+`", "time_lock": 1620000000},
+		{"receiver": "`+receiver.String()+`", "amount": "500stake", "hash_lock": "`+hex.EncodeToString(hash[:])+`", "time_lock": 1620003600}
+	]`)
+	htlcs, err := cli.ParseBatchHTLCFile(path)
+	require.NoError(t, err)
+	require.Len(t, htlcs, 2)
+	require.Equal(t, receiver, htlcs[0].Receiver)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 500)), htlcs[1].Amount)
+	require.Equal(t, hash[:], htlcs[1].HashLock)
+	require.Equal(t, int64(1620003600), htlcs[1].TimeLock)
+
+	for _, content := range []string{
+		`[]`,
+		`{"receiver": "` + receiver.String() + `"}`,
+		`[{"receiver": "invalid", "amount": "1000stake", "hash_lock": "0x` + hex.EncodeToString(hash[:]) + `", "time_lock": 1620000000}]`,
+		`[{"receiver": "` + receiver.String() + `", "amount": "1000stake", "hash_lock": "0x1234", "time_lock": 1620000000}]`,
+	} {
+		_, err := cli.ParseBatchHTLCFile(write(t, content))
+		require.Error(t, err, content)
+	}
+}
+
+func TestParseSplit(t *testing.T) {
+	receiver := sdk.AccAddress([]byte("receiver____________"))
+
+	split, err := cli.ParseSplit(receiver.String() + "=600stake")
+	require.NoError(t, err)
+	require.Equal(t, receiver, split.Receiver)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 600)), split.Share)
+
+	for _, arg := range []string{
+		receiver.String(),
+		"invalid=600stake",
+		receiver.String() + "=lots",
+	} {
+		_, err := cli.ParseSplit(arg)
+		require.Error(t, err, arg)
+	}
+}
+
+func TestParseHTLCClaim(t *testing.T) {
+	claim, err := cli.ParseHTLCClaim("7:0x736563726574")
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), claim.HTLCId)
+	require.Equal(t, []byte("secret"), claim.Preimage)
+
+	for _, arg := range []string{
+		"7",
+		"seven:0x736563726574",
+		"7:0x",
+		"7:secret",
+	} {
+		_, err := cli.ParseHTLCClaim(arg)
+		require.Error(t, err, arg)
+	}
+}
+
+// refunder signs the transactions of the fee estimation tests
+var refunder = sdk.AccAddress([]byte("refunder____________"))
+
+func newFeeEstimateCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{Use: "test"}
+	flags.AddTxFlagsToCmd(cmd)
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func TestBroadcastWithFeeEstimateDryRun(t *testing.T) {
+	cmd := newFeeEstimateCmd(t, "--gas", "auto", "--gas-adjustment", "1.5", "--gas-prices", "0.025stake")
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+
+	clientCtx := client.Context{}.
+		WithAccountRetriever(client.MockAccountRetriever{ReturnAccNum: 1, ReturnAccSeq: 1}).
+		WithSimulation(true)
+	msg := types.NewMsgRefundHTLC(refunder, 1)
+
+	var simulated []sdk.Msg
+	simulate := func(_ client.Context, txf tx.Factory, msgs ...sdk.Msg) (uint64, error) {
+		require.Equal(t, 1.5, txf.GasAdjustment())
+		simulated = msgs
+		return 120000, nil
+	}
+
+	require.NoError(t, cli.BroadcastWithFeeEstimate(cmd, clientCtx, simulate, msg))
+	require.Equal(t, []sdk.Msg{msg}, simulated)
+	require.Contains(t, stderr.String(), "estimated gas: 120000 (gas adjustment 1.5)")
+	require.Contains(t, stderr.String(), "gas limit: 120000")
+	require.Contains(t, stderr.String(), "estimated fee: 3000stake")
+}
+
+func TestBroadcastWithFeeEstimateFixedFees(t *testing.T) {
+	cmd := newFeeEstimateCmd(t, "--gas", "200000", "--fees", "500stake")
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+
+	clientCtx := client.Context{}.
+		WithAccountRetriever(client.MockAccountRetriever{ReturnAccNum: 1, ReturnAccSeq: 1}).
+		WithSimulation(true)
+	simulate := func(client.Context, tx.Factory, ...sdk.Msg) (uint64, error) {
+		return 80000, nil
+	}
+
+	// A fixed gas limit is kept, and the given fees are what is paid
+	require.NoError(t, cli.BroadcastWithFeeEstimate(cmd, clientCtx, simulate, types.NewMsgRefundHTLC(refunder, 1)))
+	require.Contains(t, stderr.String(), "estimated gas: 80000")
+	require.Contains(t, stderr.String(), "gas limit: 200000")
+	require.Contains(t, stderr.String(), "estimated fee: 500stake")
 }
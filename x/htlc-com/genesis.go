@@ -0,0 +1,44 @@
+package htlc
+
+import (
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// InitGenesis initializes the module's state from a genesis state. It panics
+// unless the module account is registered without permissions and blocked
+// from receiving transfers.
+func InitGenesis(ctx sdk.Context, k keeper.Keeper, genState types.GenesisState) {
+	if err := k.ValidateModuleAccount(ctx); err != nil {
+		panic(err)
+	}
+
+	if err := k.SetParams(ctx, genState.Params); err != nil {
+		panic(err)
+	}
+
+	for _, htlc := range genState.HTLCs {
+		k.SetHTLC(ctx, htlc)
+	}
+
+	// Ids start at 1, so a zero next id means none was exported
+	if genState.NextId > 0 {
+		k.SetNextHTLCId(ctx, genState.NextId)
+	}
+}
+
+// ExportGenesis returns the module's exported genesis state.
+func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
+	htlcs := k.GetAllHTLCs(ctx)
+	if htlcs == nil {
+		htlcs = []types.HTLC{}
+	}
+
+	return &types.GenesisState{
+		HTLCs:  htlcs,
+		NextId: k.GetNextHTLCId(ctx),
+		Params: k.GetParams(ctx),
+	}
+}
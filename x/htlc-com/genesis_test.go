@@ -0,0 +1,112 @@
+package htlc_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	htlc "github.com/crypto-org-chain/cronos/v2/x/htlc"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	simappparams "cosmossdk.io/simapp/params"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// nopAccountKeeper registers the htlc module account without permissions
+type nopAccountKeeper struct{}
+
+func (nopAccountKeeper) GetModuleAddress(moduleName string) sdk.AccAddress {
+	return authtypes.NewModuleAddress(moduleName)
+}
+
+func (nopAccountKeeper) GetModuleAccount(ctx context.Context, moduleName string) sdk.ModuleAccountI {
+	return authtypes.NewEmptyModuleAccount(moduleName)
+}
+
+// nopBankKeeper accepts every transfer and blocks every address
+type nopBankKeeper struct{}
+
+func (nopBankKeeper) BlockedAddr(addr sdk.AccAddress) bool {
+	return true
+}
+
+func (nopBankKeeper) SendCoinsFromModuleToAccount(ctx context.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	return nil
+}
+
+func (nopBankKeeper) SendCoinsFromAccountToModule(ctx context.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error {
+	return nil
+}
+
+func (nopBankKeeper) GetAllBalances(ctx context.Context, addr sdk.AccAddress) sdk.Coins {
+	return sdk.NewCoins()
+}
+
+func setupKeeper(t *testing.T) (keeper.Keeper, sdk.Context) {
+	t.Helper()
+
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("test"))
+	cdc := simappparams.MakeTestEncodingConfig().Codec
+
+	k := keeper.NewKeeper(cdc, storeKey, nopAccountKeeper{}, nopBankKeeper{}, authtypes.NewModuleAddress(govtypes.ModuleName).String())
+	return k, ctx.WithBlockTime(time.Unix(1700000000, 0))
+}
+
+func TestGenesisRoundTrip(t *testing.T) {
+	k, ctx := setupKeeper(t)
+
+	sender := sdk.AccAddress([]byte("sender______________"))
+	receiver := sdk.AccAddress([]byte("receiver____________"))
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	for _, preimage := range []string{"first", "second", "third"} {
+		hashLock := sha256.Sum256([]byte(preimage))
+		_, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLock[:], timeLock)
+		require.NoError(t, err)
+	}
+
+	exported := htlc.ExportGenesis(ctx, k)
+	require.Len(t, exported.HTLCs, 3)
+	require.Equal(t, uint64(4), exported.NextId)
+	require.NoError(t, exported.Validate())
+
+	imported, importedCtx := setupKeeper(t)
+	htlc.InitGenesis(importedCtx, imported, *exported)
+	require.Equal(t, exported, htlc.ExportGenesis(importedCtx, imported))
+
+	// new HTLCs don't reuse imported ids
+	hashLock := sha256.Sum256([]byte("fourth"))
+	id, err := imported.CreateHTLC(importedCtx, sender, receiver, amount, hashLock[:], timeLock)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), id)
+}
+
+// unblockedBankKeeper lets anyone send to the module account
+type unblockedBankKeeper struct {
+	nopBankKeeper
+}
+
+func (unblockedBankKeeper) BlockedAddr(addr sdk.AccAddress) bool {
+	return false
+}
+
+func TestInitGenesisRequiresBlockedModuleAccount(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("test"))
+	cdc := simappparams.MakeTestEncodingConfig().Codec
+
+	k := keeper.NewKeeper(cdc, storeKey, nopAccountKeeper{}, unblockedBankKeeper{}, authtypes.NewModuleAddress(govtypes.ModuleName).String())
+	require.Panics(t, func() {
+		htlc.InitGenesis(ctx, k, *types.DefaultGenesis())
+	})
+}
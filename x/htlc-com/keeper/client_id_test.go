@@ -0,0 +1,94 @@
+package keeper_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestCreateHTLCWithClientId(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	id, err := k.CreateHTLCWithClientId(ctx, sender, receiver, amount, hashLockOf([]byte("secret")), timeLock, 0, "order-1")
+	require.NoError(t, err)
+
+	htlc, found := k.GetHTLC(ctx, id)
+	require.True(t, found)
+	require.Equal(t, "order-1", htlc.ClientId)
+	require.Equal(t, "order-1", eventAttributes(ctx, keeper.EventTypeCreateHTLC)[keeper.AttributeKeyClientId])
+
+	// Without a client id the attribute is left out
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	_, err = k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("other")), timeLock)
+	require.NoError(t, err)
+	require.NotContains(t, eventAttributes(ctx, keeper.EventTypeCreateHTLC), keeper.AttributeKeyClientId)
+
+	_, err = k.CreateHTLCWithClientId(ctx, sender, receiver, amount, hashLockOf([]byte("long")), timeLock, 0,
+		strings.Repeat("x", types.MaxClientIdLength+1))
+	require.ErrorIs(t, err, types.ErrInvalidClientId)
+
+	// Merkle HTLCs carry a client id too, and their parts are still checked
+	_, leaves := merkleSecrets(4)
+	id, err = k.CreateHTLCWithClientId(ctx, sender, receiver, amount, types.MerkleRoot(leaves), timeLock, 4, "order-2")
+	require.NoError(t, err)
+	htlc, _ = k.GetHTLC(ctx, id)
+	require.Equal(t, uint32(4), htlc.Parts)
+	require.Equal(t, "order-2", htlc.ClientId)
+
+	_, err = k.CreateHTLCWithClientId(ctx, sender, receiver, amount, types.MerkleRoot(leaves), timeLock, 1, "order-3")
+	require.ErrorIs(t, err, types.ErrInvalidParts)
+}
+
+func TestQueryHTLCsByClientId(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	create := func(secret, clientId string) uint64 {
+		id, err := k.CreateHTLCWithClientId(ctx, sender, receiver, amount, hashLockOf([]byte(secret)), timeLock, 0, clientId)
+		require.NoError(t, err)
+		return id
+	}
+
+	first := create("a", "order-1")
+	create("b", "order-10")
+	create("c", "")
+	claimed := create("d", "order-1")
+	require.NoError(t, k.ClaimHTLC(ctx, claimed, []byte("d"), receiver))
+
+	queryServer := keeper.NewQueryServerImpl(k)
+	ids := func(req *types.QueryListHTLCsRequest) []uint64 {
+		res, err := queryServer.HTLCs(sdk.WrapSDKContext(ctx), req)
+		require.NoError(t, err)
+
+		var ids []uint64
+		for _, htlc := range res.HTLCs {
+			ids = append(ids, htlc.Id)
+		}
+		return ids
+	}
+
+	// "order-1" doesn't match "order-10"
+	require.Equal(t, []uint64{first, claimed}, ids(&types.QueryListHTLCsRequest{ClientId: "order-1"}))
+	require.Equal(t, []uint64{claimed}, ids(&types.QueryListHTLCsRequest{ClientId: "order-1", Status: types.HTLCStatusClaimed}))
+	require.Empty(t, ids(&types.QueryListHTLCsRequest{ClientId: "order"}))
+	require.Len(t, ids(&types.QueryListHTLCsRequest{}), 4)
+
+	// Deleting an HTLC removes its index entry
+	k.DeleteHTLC(ctx, first)
+	require.Equal(t, []uint64{claimed}, ids(&types.QueryListHTLCsRequest{ClientId: "order-1"}))
+
+	_, err := queryServer.HTLCs(sdk.WrapSDKContext(ctx), &types.QueryListHTLCsRequest{
+		ClientId: strings.Repeat("x", types.MaxClientIdLength+1),
+	})
+	require.ErrorIs(t, err, types.ErrInvalidClientId)
+}
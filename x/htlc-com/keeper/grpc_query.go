@@ -2,13 +2,22 @@ package keeper
 
 import (
 	"context"
+	"crypto/sha256"
+	"time"
 
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
 
+	"cosmossdk.io/store/prefix"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
+
+type queryServer struct {
 	Keeper
 }
 
@@ -25,18 +34,199 @@ func (q queryServer) HTLC(c context.Context, req *types.QueryGetHTLCRequest) (*t
 	return &types.QueryGetHTLCResponse{HTLC: htlc}, nil
 }
 
+// HTLCs lists a page of stored HTLCs in ID order, optionally only those in
+// the requested status or created with the requested client id. Expiry is
+// judged against the current block time. A client id filter pages through
+// the client id index instead of every HTLC.
 func (q queryServer) HTLCs(c context.Context, req *types.QueryListHTLCsRequest) (*types.QueryListHTLCsResponse, error) {
+	status := types.HTLCStatusUnspecified
+	var (
+		clientId string
+		pageReq  *query.PageRequest
+	)
+	if req != nil {
+		status = req.Status
+		clientId = req.ClientId
+		pageReq = req.Pagination
+	}
+	if !status.IsValid() {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid htlc status %s", status)
+	}
+	if err := types.ValidateClientId(clientId); err != nil {
+		return nil, err
+	}
+
 	ctx := sdk.UnwrapSDKContext(c)
-	store := ctx.KVStore(q.storeKey)
-	iterator := sdk.KVStorePrefixIterator(store, []byte(types.KeyPrefixHTLC))
-	defer iterator.Close()
+	store := prefix.NewStore(ctx.KVStore(q.storeKey), []byte(types.KeyPrefixHTLC))
+	if clientId != "" {
+		store = prefix.NewStore(ctx.KVStore(q.storeKey), types.GetHTLCByClientIdPrefix(clientId))
+	}
 
 	var htlcs []types.HTLC
-	for ; iterator.Valid(); iterator.Next() {
+	pageRes, err := query.FilteredPaginate(store, htlcsPageRequest(pageReq), func(_, value []byte, accumulate bool) (bool, error) {
 		var htlc types.HTLC
-		q.cdc.MustUnmarshal(iterator.Value(), &htlc)
-		htlcs = append(htlcs, htlc)
+		if clientId != "" {
+			// Index entries hold the HTLC ID
+			var found bool
+			if htlc, found = q.GetHTLC(ctx, sdk.BigEndianToUint64(value)); !found {
+				return false, nil
+			}
+		} else if err := q.cdc.Unmarshal(value, &htlc); err != nil {
+			return false, err
+		}
+		if status != types.HTLCStatusUnspecified && htlc.StatusAt(ctx.BlockTime()) != status {
+			return false, nil
+		}
+		if accumulate {
+			htlcs = append(htlcs, htlc)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+
+	return &types.QueryListHTLCsResponse{HTLCs: htlcs, Pagination: pageRes}, nil
+}
+
+// htlcsPageRequest applies the default page size to a request that doesn't
+// set one and caps the page size of one that does.
+func htlcsPageRequest(req *query.PageRequest) *query.PageRequest {
+	pageReq := &query.PageRequest{}
+	if req != nil {
+		*pageReq = *req
+	}
+	if pageReq.Limit == 0 {
+		pageReq.Limit = types.DefaultHTLCsPageLimit
+	}
+	if pageReq.Limit > types.MaxHTLCsPageLimit {
+		pageReq.Limit = types.MaxHTLCsPageLimit
+	}
+	return pageReq
+}
+
+func (q queryServer) HTLCByHashLock(c context.Context, req *types.QueryHTLCByHashLockRequest) (*types.QueryHTLCByHashLockResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	// Every HTLC is indexed by a SHA-256 hash, so any other length can't match
+	if len(req.HashLock) != sha256.Size {
+		return nil, status.Errorf(codes.InvalidArgument, "hashlock must be %d bytes, got %d", sha256.Size, len(req.HashLock))
 	}
 
-	return &types.QueryListHTLCsResponse{HTLCs: htlcs}, nil
+	ctx := sdk.UnwrapSDKContext(c)
+	htlc, found := q.GetHTLCByHashLock(ctx, req.HashLock)
+	if !found {
+		return nil, types.ErrHTLCNotFound
+	}
+	return &types.QueryHTLCByHashLockResponse{HTLC: htlc}, nil
+}
+
+func (q queryServer) CurrentPrice(c context.Context, req *types.QueryCurrentPriceRequest) (*types.QueryCurrentPriceResponse, error) {
+	if req == nil {
+		return nil, types.ErrHTLCNotFound
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	price, err := q.Keeper.CurrentPrice(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCurrentPriceResponse{Price: price}, nil
+}
+
+// Preimage returns the secret revealed by claiming an HTLC.
+func (q queryServer) Preimage(c context.Context, req *types.QueryPreimageRequest) (*types.QueryPreimageResponse, error) {
+	if req == nil {
+		return nil, types.ErrHTLCNotFound
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	preimage, err := q.Keeper.Preimage(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryPreimageResponse{Preimage: preimage}, nil
+}
+
+// TotalLocked returns the coins locked in active HTLCs.
+func (q queryServer) TotalLocked(c context.Context, _ *types.QueryTotalLockedRequest) (*types.QueryTotalLockedResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	return &types.QueryTotalLockedResponse{Amount: q.Keeper.TotalLocked(ctx)}, nil
+}
+
+// ExpiringHTLCs lists the unsettled HTLCs whose time lock comes before the
+// current block time plus the requested window, the earliest first. HTLCs
+// already expired but not yet refunded are included.
+func (q queryServer) ExpiringHTLCs(c context.Context, req *types.QueryExpiringHTLCsRequest) (*types.QueryExpiringHTLCsResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	if req.Within < 0 {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "window must not be negative, got %s", req.Within)
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	htlcs := q.htlcsExpiringBefore(ctx, ctx.BlockTime().Add(req.Within), types.MaxExpiringHTLCs)
+	return &types.QueryExpiringHTLCsResponse{HTLCs: htlcs}, nil
+}
+
+// HTLCActions reports, for each requested HTLC, whether the address can claim
+// or refund it at the current block time, following the rules ClaimHTLC and
+// RefundHTLC enforce. Unknown ids are reported with a reason rather than
+// failing the query.
+func (q queryServer) HTLCActions(c context.Context, req *types.QueryHTLCActionsRequest) (*types.QueryHTLCActionsResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+	address, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid address: %s", err)
+	}
+	if len(req.Ids) == 0 || len(req.Ids) > types.MaxHTLCActionsIds {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "must ask about between 1 and %d htlcs, got %d", types.MaxHTLCActionsIds, len(req.Ids))
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	actions := make([]types.HTLCActions, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		htlc, found := q.GetHTLC(ctx, id)
+		if !found {
+			actions = append(actions, types.HTLCActions{Id: id, Reason: types.ErrHTLCNotFound.Error()})
+			continue
+		}
+		actions = append(actions, htlcActions(htlc, address, ctx.BlockTime()))
+	}
+	return &types.QueryHTLCActionsResponse{Actions: actions}, nil
+}
+
+// htlcActions checks what address can do with htlc at blockTime. The
+// receiver can claim up to and including the time lock and the sender can
+// refund from the time lock on.
+func htlcActions(htlc types.HTLC, address sdk.AccAddress, blockTime time.Time) types.HTLCActions {
+	actions := types.HTLCActions{Id: htlc.Id}
+	switch {
+	case htlc.Claimed:
+		actions.Reason = types.ErrHTLCClaimed.Error()
+		return actions
+	case htlc.Refunded:
+		actions.Reason = types.ErrHTLCRefunded.Error()
+		return actions
+	}
+
+	isReceiver := address.Equals(htlc.Receiver)
+	isSender := address.Equals(htlc.Sender)
+	actions.CanClaim = isReceiver && !blockTime.After(htlc.TimeLock)
+	actions.CanRefund = isSender && !blockTime.Before(htlc.TimeLock)
+
+	switch {
+	case actions.CanClaim || actions.CanRefund:
+	case isReceiver:
+		actions.Reason = types.ErrHTLCExpired.Error()
+	case isSender:
+		actions.Reason = types.ErrHTLCNotExpired.Error()
+	default:
+		actions.Reason = "address is neither the sender nor the receiver"
+	}
+	return actions
 }
@@ -0,0 +1,346 @@
+package keeper_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQueryHTLCsByStatus(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	soon := ctx.BlockTime().Add(time.Minute).Unix()
+	later := ctx.BlockTime().Add(time.Hour).Unix()
+
+	active, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("active")), later)
+	require.NoError(t, err)
+
+	claimed, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("claimed")), later)
+	require.NoError(t, err)
+	require.NoError(t, k.ClaimHTLC(ctx, claimed, []byte("claimed"), receiver))
+
+	refunded, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("refunded")), soon)
+	require.NoError(t, err)
+	require.NoError(t, k.RefundHTLC(ctx.WithBlockTime(time.Unix(soon, 0)), refunded, sender))
+
+	expired, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("expired")), soon)
+	require.NoError(t, err)
+
+	// query after the short time lock passed but before the long one did
+	queryCtx := sdk.WrapSDKContext(ctx.WithBlockTime(time.Unix(soon+1, 0)))
+	queryServer := keeper.NewQueryServerImpl(k)
+
+	for _, tc := range []struct {
+		status types.HTLCStatus
+		ids    []uint64
+	}{
+		{status: types.HTLCStatusUnspecified, ids: []uint64{active, claimed, refunded, expired}},
+		{status: types.HTLCStatusActive, ids: []uint64{active}},
+		{status: types.HTLCStatusClaimed, ids: []uint64{claimed}},
+		{status: types.HTLCStatusRefunded, ids: []uint64{refunded}},
+		{status: types.HTLCStatusExpired, ids: []uint64{expired}},
+	} {
+		t.Run(tc.status.String(), func(t *testing.T) {
+			res, err := queryServer.HTLCs(queryCtx, &types.QueryListHTLCsRequest{Status: tc.status})
+			require.NoError(t, err)
+
+			var ids []uint64
+			for _, htlc := range res.HTLCs {
+				ids = append(ids, htlc.Id)
+			}
+			require.Equal(t, tc.ids, ids)
+		})
+	}
+
+	// an HTLC can still be claimed at its time lock, so it isn't expired yet
+	res, err := queryServer.HTLCs(sdk.WrapSDKContext(ctx.WithBlockTime(time.Unix(soon, 0))), &types.QueryListHTLCsRequest{Status: types.HTLCStatusExpired})
+	require.NoError(t, err)
+	require.Empty(t, res.HTLCs)
+
+	_, err = queryServer.HTLCs(queryCtx, &types.QueryListHTLCsRequest{Status: types.HTLCStatus(99)})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+}
+
+func TestQueryHTLCsPaginates(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	const total = 1000
+	for i := 0; i < total; i++ {
+		_, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte(fmt.Sprintf("htlc-%d", i))), timeLock)
+		require.NoError(t, err)
+	}
+
+	queryCtx := sdk.WrapSDKContext(ctx)
+	queryServer := keeper.NewQueryServerImpl(k)
+
+	// walk the HTLCs a page at a time; no page holds more than was asked for
+	var ids []uint64
+	var nextKey []byte
+	pages := 0
+	for {
+		res, err := queryServer.HTLCs(queryCtx, &types.QueryListHTLCsRequest{
+			Pagination: &query.PageRequest{Key: nextKey, Limit: 300},
+		})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(res.HTLCs), 300)
+		pages++
+
+		for _, htlc := range res.HTLCs {
+			ids = append(ids, htlc.Id)
+		}
+		nextKey = res.Pagination.NextKey
+		if len(nextKey) == 0 {
+			break
+		}
+	}
+	require.Equal(t, 4, pages)
+	require.Len(t, ids, total)
+	for i, id := range ids {
+		require.Equal(t, uint64(i+1), id)
+	}
+
+	// without a page size the default applies
+	res, err := queryServer.HTLCs(queryCtx, &types.QueryListHTLCsRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.HTLCs, types.DefaultHTLCsPageLimit)
+	require.NotEmpty(t, res.Pagination.NextKey)
+
+	// larger pages are capped
+	k2, ctx2, _ := setupKeeper(t)
+	for i := 0; i < types.MaxHTLCsPageLimit+1; i++ {
+		_, err := k2.CreateHTLC(ctx2, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 1)), hashLockOf([]byte(fmt.Sprintf("capped-%d", i))), timeLock)
+		require.NoError(t, err)
+	}
+	res, err = keeper.NewQueryServerImpl(k2).HTLCs(sdk.WrapSDKContext(ctx2), &types.QueryListHTLCsRequest{
+		Pagination: &query.PageRequest{Limit: 5000},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.HTLCs, types.MaxHTLCsPageLimit)
+	require.NotEmpty(t, res.Pagination.NextKey)
+}
+
+func TestQueryHTLCsPaginatesByStatus(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	// every third HTLC is claimed
+	var active []uint64
+	for i := 0; i < 30; i++ {
+		preimage := []byte(fmt.Sprintf("htlc-%d", i))
+		id, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf(preimage), timeLock)
+		require.NoError(t, err)
+		if i%3 == 0 {
+			require.NoError(t, k.ClaimHTLC(ctx, id, preimage, receiver))
+			continue
+		}
+		active = append(active, id)
+	}
+
+	queryServer := keeper.NewQueryServerImpl(k)
+	var ids []uint64
+	var nextKey []byte
+	for {
+		res, err := queryServer.HTLCs(sdk.WrapSDKContext(ctx), &types.QueryListHTLCsRequest{
+			Status:     types.HTLCStatusActive,
+			Pagination: &query.PageRequest{Key: nextKey, Limit: 7},
+		})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(res.HTLCs), 7)
+
+		for _, htlc := range res.HTLCs {
+			ids = append(ids, htlc.Id)
+		}
+		nextKey = res.Pagination.NextKey
+		if len(nextKey) == 0 {
+			break
+		}
+	}
+	require.Equal(t, active, ids)
+}
+
+func TestQueryHTLCActions(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+	stranger := sdk.AccAddress([]byte("stranger____________"))
+
+	open, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("open")), timeLock)
+	require.NoError(t, err)
+
+	claimed, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("claimed")), timeLock)
+	require.NoError(t, err)
+	require.NoError(t, k.ClaimHTLC(ctx, claimed, []byte("claimed"), receiver))
+
+	refunded, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("refunded")), timeLock)
+	require.NoError(t, err)
+	require.NoError(t, k.RefundHTLC(ctx.WithBlockTime(time.Unix(timeLock, 0)), refunded, sender))
+
+	queryServer := keeper.NewQueryServerImpl(k)
+	before := ctx.BlockTime()
+	atTimeLock := time.Unix(timeLock, 0)
+	after := atTimeLock.Add(time.Second)
+
+	for _, tc := range []struct {
+		name      string
+		address   sdk.AccAddress
+		id        uint64
+		blockTime time.Time
+		want      types.HTLCActions
+	}{
+		{name: "receiver before time lock", address: receiver, id: open, blockTime: before, want: types.HTLCActions{CanClaim: true}},
+		{name: "receiver at time lock", address: receiver, id: open, blockTime: atTimeLock, want: types.HTLCActions{CanClaim: true}},
+		{name: "receiver after time lock", address: receiver, id: open, blockTime: after, want: types.HTLCActions{Reason: types.ErrHTLCExpired.Error()}},
+		{name: "sender before time lock", address: sender, id: open, blockTime: before, want: types.HTLCActions{Reason: types.ErrHTLCNotExpired.Error()}},
+		{name: "sender at time lock", address: sender, id: open, blockTime: atTimeLock, want: types.HTLCActions{CanRefund: true}},
+		{name: "sender after time lock", address: sender, id: open, blockTime: after, want: types.HTLCActions{CanRefund: true}},
+		{name: "stranger", address: stranger, id: open, blockTime: before, want: types.HTLCActions{Reason: "address is neither the sender nor the receiver"}},
+		{name: "claimed", address: receiver, id: claimed, blockTime: before, want: types.HTLCActions{Reason: types.ErrHTLCClaimed.Error()}},
+		{name: "refunded", address: sender, id: refunded, blockTime: after, want: types.HTLCActions{Reason: types.ErrHTLCRefunded.Error()}},
+		{name: "not found", address: receiver, id: 99, blockTime: before, want: types.HTLCActions{Reason: types.ErrHTLCNotFound.Error()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := queryServer.HTLCActions(sdk.WrapSDKContext(ctx.WithBlockTime(tc.blockTime)), &types.QueryHTLCActionsRequest{
+				Address: tc.address.String(),
+				Ids:     []uint64{tc.id},
+			})
+			require.NoError(t, err)
+
+			tc.want.Id = tc.id
+			require.Equal(t, []types.HTLCActions{tc.want}, res.Actions)
+		})
+	}
+
+	// several ids are answered in request order
+	res, err := queryServer.HTLCActions(sdk.WrapSDKContext(ctx), &types.QueryHTLCActionsRequest{Address: receiver.String(), Ids: []uint64{claimed, open}})
+	require.NoError(t, err)
+	require.Len(t, res.Actions, 2)
+	require.Equal(t, claimed, res.Actions[0].Id)
+	require.Equal(t, open, res.Actions[1].Id)
+}
+
+func TestQueryHTLCActionsInvalidRequest(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	queryServer := keeper.NewQueryServerImpl(k)
+	goCtx := sdk.WrapSDKContext(ctx)
+
+	_, err := queryServer.HTLCActions(goCtx, &types.QueryHTLCActionsRequest{Address: "not-an-address", Ids: []uint64{1}})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+
+	_, err = queryServer.HTLCActions(goCtx, &types.QueryHTLCActionsRequest{Address: receiver.String()})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	_, err = queryServer.HTLCActions(goCtx, &types.QueryHTLCActionsRequest{Address: receiver.String(), Ids: make([]uint64, types.MaxHTLCActionsIds+1)})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+}
+
+func TestQueryHTLCByHashLock(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	queryServer := keeper.NewQueryServerImpl(k)
+	goCtx := sdk.WrapSDKContext(ctx)
+
+	hashLock := hashLockOf([]byte("secret"))
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLock, ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+
+	res, err := queryServer.HTLCByHashLock(goCtx, &types.QueryHTLCByHashLockRequest{HashLock: hashLock})
+	require.NoError(t, err)
+	require.Equal(t, id, res.HTLC.Id)
+
+	// a truncated hashlock is rejected rather than looked up
+	_, err = queryServer.HTLCByHashLock(goCtx, &types.QueryHTLCByHashLockRequest{HashLock: hashLock[:16]})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = queryServer.HTLCByHashLock(goCtx, &types.QueryHTLCByHashLockRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = queryServer.HTLCByHashLock(goCtx, nil)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestQueryExpiringHTLCs(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	queryServer := keeper.NewQueryServerImpl(k)
+
+	later, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("later")), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+	soon, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("soon")), ctx.BlockTime().Add(time.Minute).Unix())
+	require.NoError(t, err)
+
+	res, err := queryServer.ExpiringHTLCs(sdk.WrapSDKContext(ctx), &types.QueryExpiringHTLCsRequest{Within: 10 * time.Minute})
+	require.NoError(t, err)
+	require.Len(t, res.HTLCs, 1)
+	require.Equal(t, soon, res.HTLCs[0].Id)
+
+	// The window starts at the block time, so expired HTLCs are included
+	laterCtx := ctx.WithBlockTime(ctx.BlockTime().Add(30 * time.Minute))
+	res, err = queryServer.ExpiringHTLCs(sdk.WrapSDKContext(laterCtx), &types.QueryExpiringHTLCsRequest{Within: time.Hour})
+	require.NoError(t, err)
+	require.Len(t, res.HTLCs, 2)
+	require.Equal(t, []uint64{soon, later}, []uint64{res.HTLCs[0].Id, res.HTLCs[1].Id})
+
+	_, err = queryServer.ExpiringHTLCs(sdk.WrapSDKContext(ctx), &types.QueryExpiringHTLCsRequest{Within: -time.Minute})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+	_, err = queryServer.ExpiringHTLCs(sdk.WrapSDKContext(ctx), nil)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+}
+
+func TestQueryPreimage(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	queryServer := keeper.NewQueryServerImpl(k)
+	preimage := []byte("secret")
+
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf(preimage), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+
+	// nothing is revealed before the claim
+	_, err = queryServer.Preimage(sdk.WrapSDKContext(ctx), &types.QueryPreimageRequest{Id: id})
+	require.ErrorIs(t, err, types.ErrPreimageNotRevealed)
+
+	require.NoError(t, k.ClaimHTLC(ctx, id, preimage, receiver))
+	res, err := queryServer.Preimage(sdk.WrapSDKContext(ctx), &types.QueryPreimageRequest{Id: id})
+	require.NoError(t, err)
+	require.Equal(t, preimage, res.Preimage)
+
+	_, err = queryServer.Preimage(sdk.WrapSDKContext(ctx), &types.QueryPreimageRequest{Id: id + 1})
+	require.ErrorIs(t, err, types.ErrHTLCNotFound)
+}
+
+func TestQueryPreimageRefunded(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf([]byte("secret")), timeLock)
+	require.NoError(t, err)
+	require.NoError(t, k.RefundHTLC(ctx.WithBlockTime(time.Unix(timeLock, 0)), id, sender))
+
+	_, err = k.Preimage(ctx, id)
+	require.ErrorIs(t, err, types.ErrPreimageNotRevealed)
+}
+
+func TestQueryPreimageMerkle(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	id, secrets, leaves := createMerkleHTLC(t, k, ctx, 1000, 3)
+
+	// each claimed part reveals its own secret
+	for i := 0; i < 2; i++ {
+		require.NoError(t, k.ClaimHTLCPart(ctx, id, uint32(i), secrets[i], types.MerkleProof(leaves, uint32(i)), receiver, nil))
+		preimage, err := k.Preimage(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, secrets[i], preimage)
+	}
+}
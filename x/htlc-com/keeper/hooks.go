@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SetHooks sets the hooks notified of HTLC lifecycle changes. It may only be
+// called once, while wiring the app; combine several with
+// types.NewMultiHTLCHooks.
+func (k *Keeper) SetHooks(hooks types.HTLCHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set htlc hooks twice")
+	}
+	k.hooks = hooks
+	return k
+}
+
+func (k Keeper) afterHTLCCreated(ctx sdk.Context, htlc types.HTLC) error {
+	if k.hooks == nil {
+		return nil
+	}
+	return k.hooks.AfterHTLCCreated(ctx, htlc)
+}
+
+func (k Keeper) afterHTLCClaimed(ctx sdk.Context, htlc types.HTLC, preimage []byte) error {
+	if k.hooks == nil {
+		return nil
+	}
+	return k.hooks.AfterHTLCClaimed(ctx, htlc, preimage)
+}
+
+func (k Keeper) afterHTLCRefunded(ctx sdk.Context, htlc types.HTLC) error {
+	if k.hooks == nil {
+		return nil
+	}
+	return k.hooks.AfterHTLCRefunded(ctx, htlc)
+}
@@ -0,0 +1,132 @@
+package keeper_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// hookCall is a call recorded by recordingHooks
+type hookCall struct {
+	hook     string
+	id       uint64
+	preimage []byte
+}
+
+// recordingHooks records every hook call and fails with err if set
+type recordingHooks struct {
+	calls []hookCall
+	err   error
+}
+
+func (h *recordingHooks) AfterHTLCCreated(ctx context.Context, htlc types.HTLC) error {
+	h.calls = append(h.calls, hookCall{hook: "created", id: htlc.Id})
+	return h.err
+}
+
+func (h *recordingHooks) AfterHTLCClaimed(ctx context.Context, htlc types.HTLC, preimage []byte) error {
+	h.calls = append(h.calls, hookCall{hook: "claimed", id: htlc.Id, preimage: preimage})
+	return h.err
+}
+
+func (h *recordingHooks) AfterHTLCRefunded(ctx context.Context, htlc types.HTLC) error {
+	h.calls = append(h.calls, hookCall{hook: "refunded", id: htlc.Id})
+	return h.err
+}
+
+func TestHTLCHooks(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	hooks := &recordingHooks{}
+	k.SetHooks(types.NewMultiHTLCHooks(nil, hooks))
+
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	claimedID, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("claimed")), timeLock)
+	require.NoError(t, err)
+	refundedID, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("refunded")), timeLock)
+	require.NoError(t, err)
+
+	require.NoError(t, k.ClaimHTLC(ctx, claimedID, []byte("claimed"), receiver))
+	require.NoError(t, k.RefundHTLC(ctx.WithBlockTime(ctx.BlockTime().Add(2*time.Hour)), refundedID, sender))
+
+	require.Equal(t, []hookCall{
+		{hook: "created", id: claimedID},
+		{hook: "created", id: refundedID},
+		{hook: "claimed", id: claimedID, preimage: []byte("claimed")},
+		{hook: "refunded", id: refundedID},
+	}, hooks.calls)
+
+	// Automatic refunds and Merkle part claims notify the hooks too
+	hooks.calls = nil
+	expiringID, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("expiring")), timeLock)
+	require.NoError(t, err)
+	merkleID, secrets, leaves := createMerkleHTLC(t, k, ctx, 1000, 2)
+	require.NoError(t, k.ClaimHTLCPart(ctx, merkleID, 0, secrets[0], types.MerkleProof(leaves, 0), receiver, nil))
+	require.Equal(t, []uint64{expiringID, merkleID}, k.RefundExpiredHTLCs(ctx.WithBlockTime(ctx.BlockTime().Add(2*time.Hour))))
+
+	require.Equal(t, []hookCall{
+		{hook: "created", id: expiringID},
+		{hook: "created", id: merkleID},
+		{hook: "claimed", id: merkleID, preimage: secrets[0]},
+		{hook: "refunded", id: expiringID},
+		{hook: "refunded", id: merkleID},
+	}, hooks.calls)
+}
+
+func TestHTLCHooksErrorAborts(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	hookErr := errors.New("hook failed")
+	k.SetHooks(&recordingHooks{err: hookErr})
+
+	_, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		hashLockOf([]byte("secret")), ctx.BlockTime().Add(time.Hour).Unix())
+	require.ErrorIs(t, err, hookErr)
+}
+
+func TestHTLCHooksErrorSkipsAutomaticRefund(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	hooks := &recordingHooks{}
+	k.SetHooks(hooks)
+
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		hashLockOf([]byte("secret")), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+
+	// the failed refund is rolled back and not retried in the next block
+	hooks.err = errors.New("hook failed")
+	expiredCtx := ctx.WithBlockTime(ctx.BlockTime().Add(2 * time.Hour))
+	require.Empty(t, k.RefundExpiredHTLCs(expiredCtx))
+	htlc, found := k.GetHTLC(ctx, id)
+	require.True(t, found)
+	require.False(t, htlc.Refunded)
+
+	hooks.calls = nil
+	require.Empty(t, k.RefundExpiredHTLCs(expiredCtx.WithBlockTime(expiredCtx.BlockTime().Add(time.Minute))))
+	require.Empty(t, hooks.calls)
+
+	// the sender can still refund it
+	hooks.err = nil
+	require.NoError(t, k.RefundHTLC(expiredCtx, id, sender))
+}
+
+func TestSetHooksTwicePanics(t *testing.T) {
+	k, _, _ := setupKeeper(t)
+	k.SetHooks(&recordingHooks{})
+	require.Panics(t, func() { k.SetHooks(&recordingHooks{}) })
+}
+
+func TestKeeperWithoutHooks(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		hashLockOf([]byte("secret")), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+	require.NoError(t, k.ClaimHTLC(ctx, id, []byte("secret"), receiver))
+}
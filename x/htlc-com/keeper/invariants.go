@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// RegisterInvariants registers all htlc module invariants.
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "module-balance", ModuleBalanceInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "module-account", ModuleAccountInvariant(k))
+}
+
+// ModuleAccountInvariant checks that the module account still meets
+// ValidateModuleAccount, which InitGenesis only checks once.
+func ModuleAccountInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		err := k.ValidateModuleAccount(ctx)
+		msg := "\tmodule account is registered, has no permissions and is blocked\n"
+		if err != nil {
+			msg = fmt.Sprintf("\t%s\n", err)
+		}
+		return sdk.FormatInvariant(types.ModuleName, "module-account", msg), err != nil
+	}
+}
+
+// ModuleBalanceInvariant checks that the module account holds exactly the
+// coins locked in unsettled HTLCs, less the parts of Merkle HTLCs already
+// claimed.
+func ModuleBalanceInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			locked sdk.Coins
+			active int
+		)
+		for _, htlc := range k.GetAllHTLCs(ctx) {
+			if htlc.Claimed || htlc.Refunded {
+				continue
+			}
+			locked = locked.Add(htlc.Unclaimed()...)
+			active++
+		}
+
+		balance := k.bankKeeper.GetAllBalances(ctx, authtypes.NewModuleAddress(types.ModuleName))
+		// IsEqual panics on differing denoms, so compare both ways instead
+		broken := !balance.IsAllGTE(locked) || !locked.IsAllGTE(balance)
+
+		return sdk.FormatInvariant(types.ModuleName, "module-balance", fmt.Sprintf(
+			"\tsum of %d unsettled htlcs: %s\n\tmodule account balance: %s\n",
+			active, locked, balance,
+		)), broken
+	}
+}
@@ -0,0 +1,75 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestModuleBalanceInvariant(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+	invariant := keeper.ModuleBalanceInvariant(k)
+
+	// an empty module holds nothing
+	_, broken := invariant(ctx)
+	require.False(t, broken)
+
+	preimage := []byte("secret")
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+	claimedID, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf(preimage), timeLock)
+	require.NoError(t, err)
+	activeID, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 40)), hashLockOf([]byte("other")), timeLock)
+	require.NoError(t, err)
+
+	// settled HTLCs no longer count towards the locked coins
+	require.NoError(t, k.ClaimHTLC(ctx, claimedID, preimage, receiver))
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	// coins leaving the module without settling an HTLC break the invariant
+	bankKeeper.modules[types.ModuleName] = sdk.NewCoins(sdk.NewInt64Coin("stake", 30))
+	msg, broken := invariant(ctx)
+	require.True(t, broken)
+	require.Contains(t, msg, "module-balance")
+	require.Contains(t, msg, "40stake")
+	require.Contains(t, msg, "30stake")
+
+	// so does an HTLC marked settled without paying out
+	bankKeeper.modules[types.ModuleName] = sdk.NewCoins(sdk.NewInt64Coin("stake", 40))
+	_, broken = invariant(ctx)
+	require.False(t, broken)
+
+	htlc, found := k.GetHTLC(ctx, activeID)
+	require.True(t, found)
+	htlc.Refunded = true
+	k.SetHTLC(ctx, htlc)
+
+	_, broken = invariant(ctx)
+	require.True(t, broken)
+}
+
+func TestModuleBalanceInvariantOtherDenom(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+	invariant := keeper.ModuleBalanceInvariant(k)
+
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+	_, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 40)), hashLockOf([]byte("secret")), timeLock)
+	require.NoError(t, err)
+
+	// a denom no HTLC locks breaks the invariant rather than panicking
+	bankKeeper.modules[types.ModuleName] = sdk.NewCoins(sdk.NewInt64Coin("atom", 40))
+	msg, broken := invariant(ctx)
+	require.True(t, broken)
+	require.Contains(t, msg, "40stake")
+	require.Contains(t, msg, "40atom")
+
+	// as do coins of another denom sent on top of the locked ones
+	bankKeeper.modules[types.ModuleName] = sdk.NewCoins(sdk.NewInt64Coin("stake", 40), sdk.NewInt64Coin("atom", 1))
+	_, broken = invariant(ctx)
+	require.True(t, broken)
+}
@@ -4,13 +4,18 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
 
-	sdk "github.com/cosmos/cosmos-sdk/types"
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
 	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // Event types
@@ -18,26 +23,61 @@ const (
 	EventTypeCreateHTLC = "create_htlc"
 	EventTypeClaimHTLC  = "claim_htlc"
 	EventTypeRefundHTLC = "refund_htlc"
+	EventTypeUpdateHTLC = "update_htlc"
+	// EventTypeRevealSecret is emitted when an HTLC's secret is revealed
+	// without claiming it
+	EventTypeRevealSecret = "reveal_secret"
+	// EventTypeSplitTransfer is emitted for each receiver a split HTLC pays
+	EventTypeSplitTransfer = "htlc_split_transfer"
 
-	AttributeKeySender    = "sender"
-	AttributeKeyReceiver  = "receiver"
-	AttributeKeyHTLCID    = "htlc_id"
-	AttributeKeyAmount    = "amount"
+	AttributeKeySender   = "sender"
+	AttributeKeyReceiver = "receiver"
+	AttributeKeyHTLCID   = "htlc_id"
+	AttributeKeyAmount   = "amount"
 	AttributeKeyHashLock = "hash_lock"
-	AttributeKeyTimeLock  = "time_lock"
+	// AttributeKeyTimeLock is the time lock in Unix seconds, as a decimal
+	// integer. AttributeKeyTimeLockUTC is the same time in RFC 3339 UTC for
+	// people reading events.
+	AttributeKeyTimeLock      = "time_lock"
+	AttributeKeyTimeLockUTC   = "time_lock_utc"
+	AttributeKeyPreimage      = "preimage"
+	AttributeKeyPayoutAddress = "payout_address"
+	AttributeKeyParts         = "parts"
+	AttributeKeyPart          = "part"
+	AttributeKeyClientId      = "client_id"
+	AttributeKeySplits        = "splits"
 )
 
 type Keeper struct {
-	storeKey   storetypes.StoreKey
-	cdc        codec.BinaryCodec
-	bankKeeper types.BankKeeper
+	storeKey      storetypes.StoreKey
+	cdc           codec.BinaryCodec
+	accountKeeper types.AccountKeeper
+	bankKeeper    types.BankKeeper
+
+	// authority is the address allowed to execute MsgUpdateParams, normally
+	// the gov module account
+	authority string
+
+	// hooks are notified of HTLC lifecycle changes; nil if none are set
+	hooks types.HTLCHooks
 }
 
-func NewKeeper(cdc codec.BinaryCodec, storeKey storetypes.StoreKey, bankKeeper types.BankKeeper) Keeper {
+// NewKeeper panics unless the HTLC module account is registered with the
+// account keeper, which escrows the locked coins.
+func NewKeeper(cdc codec.BinaryCodec, storeKey storetypes.StoreKey, accountKeeper types.AccountKeeper, bankKeeper types.BankKeeper, authority string) Keeper {
+	if _, err := sdk.AccAddressFromBech32(authority); err != nil {
+		panic(err)
+	}
+	if addr := accountKeeper.GetModuleAddress(types.ModuleName); addr == nil {
+		panic(fmt.Sprintf("%s module account has not been set", types.ModuleName))
+	}
+
 	return Keeper{
-		storeKey:   storeKey,
-		cdc:        cdc,
-		bankKeeper: bankKeeper,
+		storeKey:      storeKey,
+		cdc:           cdc,
+		accountKeeper: accountKeeper,
+		bankKeeper:    bankKeeper,
+		authority:     authority,
 	}
 }
 
@@ -54,22 +94,117 @@ func (k Keeper) GetHTLC(ctx sdk.Context, id uint64) (types.HTLC, bool) {
 
 func (k Keeper) SetHTLC(ctx sdk.Context, htlc types.HTLC) {
 	store := ctx.KVStore(k.storeKey)
+
+	// The time lock index entry moves when the time lock is extended and is
+	// dropped once the HTLC is settled. An HTLC whose automatic refund failed
+	// is indexed by its time lock again once it is stored
+	locked := sdk.NewCoins()
+	if existing, found := k.GetHTLC(ctx, htlc.Id); found {
+		store.Delete(types.GetHTLCByTimeLockKey(existing.TimeLock.Unix(), existing.Id))
+		store.Delete(types.GetHTLCRefundFailedKey(existing.Id))
+		locked = lockedAmount(existing)
+	}
+	k.updateTotalLocked(ctx, lockedAmount(htlc), locked)
+
 	bz := k.cdc.MustMarshal(&htlc)
 	store.Set(types.GetHTLCKey(htlc.Id), bz)
+	store.Set(types.GetHTLCByHashLockKey(htlc.HashLock, htlc.Id), sdk.Uint64ToBigEndian(htlc.Id))
+	if htlc.ClientId != "" {
+		store.Set(types.GetHTLCByClientIdKey(htlc.ClientId, htlc.Id), sdk.Uint64ToBigEndian(htlc.Id))
+	}
+	if !htlc.Claimed && !htlc.Refunded {
+		store.Set(types.GetHTLCByTimeLockKey(htlc.TimeLock.Unix(), htlc.Id), sdk.Uint64ToBigEndian(htlc.Id))
+	}
 }
 
 func (k Keeper) DeleteHTLC(ctx sdk.Context, id uint64) {
 	store := ctx.KVStore(k.storeKey)
+	if htlc, found := k.GetHTLC(ctx, id); found {
+		store.Delete(types.GetHTLCByHashLockKey(htlc.HashLock, id))
+		store.Delete(types.GetHTLCByTimeLockKey(htlc.TimeLock.Unix(), id))
+		store.Delete(types.GetHTLCRefundFailedKey(id))
+		if htlc.ClientId != "" {
+			store.Delete(types.GetHTLCByClientIdKey(htlc.ClientId, id))
+		}
+		k.updateTotalLocked(ctx, nil, lockedAmount(htlc))
+	}
 	store.Delete(types.GetHTLCKey(id))
 }
 
+// GetHTLCByHashLock returns the first active HTLC locked with the given hashlock.
+// If every HTLC with that hashlock is already settled, the oldest one is returned.
+func (k Keeper) GetHTLCByHashLock(ctx sdk.Context, hashLock []byte) (types.HTLC, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetHTLCByHashLockPrefix(hashLock))
+	defer iterator.Close()
+
+	var (
+		first types.HTLC
+		found bool
+	)
+	for ; iterator.Valid(); iterator.Next() {
+		htlc, ok := k.GetHTLC(ctx, sdk.BigEndianToUint64(iterator.Value()))
+		if !ok {
+			continue
+		}
+		if !htlc.Claimed && !htlc.Refunded {
+			return htlc, true
+		}
+		if !found {
+			first, found = htlc, true
+		}
+	}
+
+	return first, found
+}
+
 func (k Keeper) CreateHTLC(ctx sdk.Context, sender, receiver sdk.AccAddress, amount sdk.Coins, hashLock []byte, timeLock int64) (uint64, error) {
+	return k.createHTLC(ctx, sender, receiver, amount, hashLock, timeLock, 0, "", nil, types.HTLC{})
+}
+
+// CreateHTLCWithClientId creates an HTLC like CreateHTLC, or like
+// CreateMerkleHTLC when parts is set, tagged with the sender's clientId so it
+// can be looked up by it.
+func (k Keeper) CreateHTLCWithClientId(ctx sdk.Context, sender, receiver sdk.AccAddress, amount sdk.Coins, hashLock []byte, timeLock int64, parts uint32, clientId string) (uint64, error) {
+	if parts > 0 {
+		if err := validateMerkleParts(amount, parts); err != nil {
+			return 0, err
+		}
+	}
+	return k.createHTLC(ctx, sender, receiver, amount, hashLock, timeLock, parts, clientId, nil, types.HTLC{})
+}
+
+// createHTLC locks amount from sender in a new HTLC, claimed in the given
+// number of parts or in full when parts is zero. A claim in full pays out to
+// splits when they are set. The HTLC is priced by a Dutch auction starting
+// at the block time when auction holds an initial price.
+func (k Keeper) createHTLC(ctx sdk.Context, sender, receiver sdk.AccAddress, amount sdk.Coins, hashLock []byte, timeLock int64, parts uint32, clientId string, splits []types.Split, auction types.HTLC) (uint64, error) {
 	if len(hashLock) != sha256.Size {
 		return 0, types.ErrInvalidHashLock
 	}
+	if err := types.ValidateClientId(clientId); err != nil {
+		return 0, err
+	}
 	if timeLock <= ctx.BlockTime().Unix() {
 		return 0, types.ErrInvalidTimeLock
 	}
+	// Checked here as well as in ValidateBasic, since keeper callers skip
+	// it. An empty amount would lock nothing yet still be claimable
+	if len(amount) == 0 || !amount.IsAllPositive() {
+		return 0, errorsmod.Wrapf(types.ErrInvalidAmount, "amount must be positive, got %q", amount)
+	}
+	if len(splits) > 0 && parts > 0 {
+		return 0, errorsmod.Wrap(types.ErrInvalidSplits, "an htlc claimed in parts cannot be split")
+	}
+	if err := types.ValidateSplits(amount, splits); err != nil {
+		return 0, err
+	}
+	if err := auction.ValidateAuction(); err != nil {
+		return 0, errorsmod.Wrap(types.ErrInvalidAuction, err.Error())
+	}
+	if err := k.validateLock(ctx, amount, timeLock); err != nil {
+		return 0, err
+	}
 
 	// send coins from sender to module account to lock
 	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, amount); err != nil {
@@ -86,28 +221,122 @@ func (k Keeper) CreateHTLC(ctx sdk.Context, sender, receiver sdk.AccAddress, amo
 		TimeLock: time.Unix(timeLock, 0),
 		Claimed:  false,
 		Refunded: false,
+		Parts:    parts,
+		ClientId: clientId,
+		Splits:   splits,
+	}
+	if auction.IsDutchAuction() {
+		htlc.InitialPrice = auction.InitialPrice
+		htlc.MinPrice = auction.MinPrice
+		htlc.DecayRate = auction.DecayRate
+		htlc.StartTime = ctx.BlockTime()
 	}
 
 	k.SetHTLC(ctx, htlc)
 	k.IncrementNextHTLCId(ctx)
 
 	// Emit event
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			EventTypeCreateHTLC,
-			sdk.NewAttribute(AttributeKeySender, sender.String()),
-			sdk.NewAttribute(AttributeKeyReceiver, receiver.String()),
-			sdk.NewAttribute(AttributeKeyHTLCID, fmt.Sprintf("%d", id)),
-			sdk.NewAttribute(AttributeKeyAmount, amount.String()),
-			sdk.NewAttribute(AttributeKeyHashLock, fmt.Sprintf("%x", hashLock)),
-			sdk.NewAttribute(AttributeKeyTimeLock, time.Unix(timeLock, 0).String()),
-		),
+	event := sdk.NewEvent(
+		EventTypeCreateHTLC,
+		sdk.NewAttribute(AttributeKeySender, sender.String()),
+		sdk.NewAttribute(AttributeKeyReceiver, receiver.String()),
+		sdk.NewAttribute(AttributeKeyHTLCID, fmt.Sprintf("%d", id)),
+		sdk.NewAttribute(AttributeKeyAmount, amount.String()),
+		sdk.NewAttribute(AttributeKeyHashLock, fmt.Sprintf("%x", hashLock)),
+		sdk.NewAttribute(AttributeKeyTimeLock, fmt.Sprintf("%d", timeLock)),
+		sdk.NewAttribute(AttributeKeyTimeLockUTC, formatTimeLock(htlc.TimeLock)),
 	)
+	if parts > 0 {
+		event = event.AppendAttributes(sdk.NewAttribute(AttributeKeyParts, fmt.Sprintf("%d", parts)))
+	}
+	if clientId != "" {
+		event = event.AppendAttributes(sdk.NewAttribute(AttributeKeyClientId, clientId))
+	}
+	if len(splits) > 0 {
+		event = event.AppendAttributes(sdk.NewAttribute(AttributeKeySplits, fmt.Sprintf("%d", len(splits))))
+	}
+	ctx.EventManager().EmitEvent(event)
+
+	if err := k.afterHTLCCreated(ctx, htlc); err != nil {
+		return 0, err
+	}
 
 	return id, nil
 }
 
+// BatchCreateHTLC creates an HTLC from sender for every leg and returns their
+// ids in order. The legs are created in a cached context that is only written
+// once all of them succeed, so a failing leg leaves no HTLC, index entry or
+// transfer behind.
+func (k Keeper) BatchCreateHTLC(ctx sdk.Context, sender sdk.AccAddress, htlcs []types.HTLCParams) ([]uint64, error) {
+	cacheCtx, write := ctx.CacheContext()
+
+	ids := make([]uint64, 0, len(htlcs))
+	for i, htlc := range htlcs {
+		id, err := k.CreateHTLC(cacheCtx, sender, htlc.Receiver, htlc.Amount, htlc.HashLock, htlc.TimeLock)
+		if err != nil {
+			return nil, errorsmod.Wrapf(err, "htlc %d", i)
+		}
+		ids = append(ids, id)
+	}
+
+	write()
+	return ids, nil
+}
+
+// BatchClaimHTLC claims every HTLC in claims for claimer and returns the
+// result of each claim in order. An atomic batch is claimed in a cached
+// context that is only written once every claim succeeds, so a failing claim
+// fails the whole batch and leaves no HTLC claimed. With allowPartial each
+// claim gets its own cached context instead: a failing claim only records
+// its error in its result, and the other claims still go through.
+func (k Keeper) BatchClaimHTLC(ctx sdk.Context, claimer sdk.AccAddress, claims []types.HTLCClaim, allowPartial bool) ([]types.ClaimResult, error) {
+	batchCtx, writeBatch := ctx.CacheContext()
+
+	results := make([]types.ClaimResult, 0, len(claims))
+	for i, claim := range claims {
+		claimCtx, writeClaim := batchCtx.CacheContext()
+
+		result := types.ClaimResult{HTLCId: claim.HTLCId}
+		amount, err := k.claimForBatch(claimCtx, claim, claimer)
+		switch {
+		case err == nil:
+			writeClaim()
+			result.Amount = amount
+		case allowPartial:
+			result.Error = err.Error()
+		default:
+			return nil, errorsmod.Wrapf(err, "claim %d", i)
+		}
+		results = append(results, result)
+	}
+
+	writeBatch()
+	return results, nil
+}
+
+// claimForBatch claims a whole HTLC of a batch and returns the coins it
+// released.
+func (k Keeper) claimForBatch(ctx sdk.Context, claim types.HTLCClaim, claimer sdk.AccAddress) (sdk.Coins, error) {
+	htlc, found := k.GetHTLC(ctx, claim.HTLCId)
+	if !found {
+		return nil, types.ErrHTLCNotFound
+	}
+	if err := k.ClaimHTLC(ctx, claim.HTLCId, claim.Preimage, claimer); err != nil {
+		return nil, err
+	}
+	return htlc.Unclaimed(), nil
+}
+
 func (k Keeper) ClaimHTLC(ctx sdk.Context, id uint64, preimage []byte, claimer sdk.AccAddress) error {
+	return k.ClaimHTLCTo(ctx, id, preimage, claimer, nil)
+}
+
+// ClaimHTLCTo claims an HTLC like ClaimHTLC but pays the coins out to payout,
+// falling back to the receiver when payout is empty. Only the receiver may
+// claim either way. A split HTLC pays each split receiver its share instead,
+// so it cannot be claimed to a payout address.
+func (k Keeper) ClaimHTLCTo(ctx sdk.Context, id uint64, preimage []byte, claimer, payout sdk.AccAddress) error {
 	htlc, found := k.GetHTLC(ctx, id)
 	if !found {
 		return types.ErrHTLCNotFound
@@ -118,6 +347,9 @@ func (k Keeper) ClaimHTLC(ctx sdk.Context, id uint64, preimage []byte, claimer s
 	if htlc.Refunded {
 		return types.ErrHTLCRefunded
 	}
+	if htlc.IsMerkle() {
+		return errorsmod.Wrap(types.ErrInvalidPreimage, "htlc is claimed in parts with a merkle proof")
+	}
 	if !bytes.Equal(sha256.Sum256(preimage)[:], htlc.HashLock) {
 		return types.ErrInvalidPreimage
 	}
@@ -127,28 +359,97 @@ func (k Keeper) ClaimHTLC(ctx sdk.Context, id uint64, preimage []byte, claimer s
 	if ctx.BlockTime().After(htlc.TimeLock) {
 		return types.ErrHTLCExpired
 	}
+	if htlc.IsSplit() && !payout.Empty() {
+		return errorsmod.Wrap(types.ErrInvalidSplits, "a split htlc pays out to its split receivers")
+	}
+
+	if payout.Empty() {
+		payout = htlc.Receiver
+	}
 
 	htlc.Claimed = true
+	htlc.Preimage = preimage
 	k.SetHTLC(ctx, htlc)
 
-	// transfer coins to receiver
-	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.Receiver, htlc.Amount); err != nil {
+	// transfer coins to the payout address, or to each split receiver
+	if htlc.IsSplit() {
+		if err := k.paySplits(ctx, htlc); err != nil {
+			return err
+		}
+	} else if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, payout, htlc.Amount); err != nil {
 		return err
 	}
 
 	// Emit event
+	event := sdk.NewEvent(
+		EventTypeClaimHTLC,
+		sdk.NewAttribute(AttributeKeyHTLCID, fmt.Sprintf("%d", id)),
+		sdk.NewAttribute(AttributeKeyReceiver, claimer.String()),
+		sdk.NewAttribute(AttributeKeyAmount, htlc.Amount.String()),
+		sdk.NewAttribute(AttributeKeyPreimage, hex.EncodeToString(preimage)),
+	)
+	if !htlc.IsSplit() {
+		event = event.AppendAttributes(sdk.NewAttribute(AttributeKeyPayoutAddress, payout.String()))
+	}
+	ctx.EventManager().EmitEvent(event)
+
+	return k.afterHTLCClaimed(ctx, htlc, preimage)
+}
+
+// RevealSecret checks secret against the hash lock of an HTLC and stores it
+// on the HTLC, where Preimage returns it, without claiming the HTLC: no coins
+// move and the receiver can still claim it before the time lock. Anyone
+// holding the secret may reveal it, but only once.
+func (k Keeper) RevealSecret(ctx sdk.Context, id uint64, secret []byte, revealer sdk.AccAddress) error {
+	htlc, found := k.GetHTLC(ctx, id)
+	if !found {
+		return types.ErrHTLCNotFound
+	}
+	if htlc.Claimed {
+		return types.ErrHTLCClaimed
+	}
+	if htlc.Refunded {
+		return types.ErrHTLCRefunded
+	}
+	if htlc.IsMerkle() {
+		return errorsmod.Wrap(types.ErrInvalidPreimage, "htlc is claimed in parts with a merkle proof")
+	}
+	if len(htlc.Preimage) > 0 {
+		return types.ErrSecretRevealed
+	}
+	if !bytes.Equal(sha256.Sum256(secret)[:], htlc.HashLock) {
+		return types.ErrInvalidPreimage
+	}
+
+	htlc.Preimage = secret
+	k.SetHTLC(ctx, htlc)
+
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
-			EventTypeClaimHTLC,
+			EventTypeRevealSecret,
 			sdk.NewAttribute(AttributeKeyHTLCID, fmt.Sprintf("%d", id)),
-			sdk.NewAttribute(AttributeKeyReceiver, claimer.String()),
-			sdk.NewAttribute(AttributeKeyAmount, htlc.Amount.String()),
+			sdk.NewAttribute(AttributeKeySender, revealer.String()),
+			sdk.NewAttribute(AttributeKeyPreimage, hex.EncodeToString(secret)),
 		),
 	)
 
 	return nil
 }
 
+// Preimage returns the secret revealed by claiming an HTLC, by claiming the
+// latest part of a Merkle HTLC or by MsgRevealSecret, so the counterparty can
+// complete its own leg of the swap.
+func (k Keeper) Preimage(ctx sdk.Context, id uint64) ([]byte, error) {
+	htlc, found := k.GetHTLC(ctx, id)
+	if !found {
+		return nil, types.ErrHTLCNotFound
+	}
+	if len(htlc.Preimage) == 0 {
+		return nil, types.ErrPreimageNotRevealed
+	}
+	return htlc.Preimage, nil
+}
+
 func (k Keeper) RefundHTLC(ctx sdk.Context, id uint64, refunder sdk.AccAddress) error {
 	htlc, found := k.GetHTLC(ctx, id)
 	if !found {
@@ -167,11 +468,19 @@ func (k Keeper) RefundHTLC(ctx sdk.Context, id uint64, refunder sdk.AccAddress)
 		return types.ErrHTLCNotExpired
 	}
 
+	return k.refund(ctx, htlc)
+}
+
+// refund marks an HTLC refunded and returns its unclaimed coins to the
+// sender.
+func (k Keeper) refund(ctx sdk.Context, htlc types.HTLC) error {
+	amount := htlc.Unclaimed()
+
 	htlc.Refunded = true
 	k.SetHTLC(ctx, htlc)
 
 	// refund coins to sender
-	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.Sender, htlc.Amount); err != nil {
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.Sender, amount); err != nil {
 		return err
 	}
 
@@ -179,28 +488,189 @@ func (k Keeper) RefundHTLC(ctx sdk.Context, id uint64, refunder sdk.AccAddress)
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			EventTypeRefundHTLC,
+			sdk.NewAttribute(AttributeKeyHTLCID, fmt.Sprintf("%d", htlc.Id)),
+			sdk.NewAttribute(AttributeKeySender, htlc.Sender.String()),
+			sdk.NewAttribute(AttributeKeyReceiver, htlc.Receiver.String()),
+			sdk.NewAttribute(AttributeKeyAmount, amount.String()),
+		),
+	)
+
+	return k.afterHTLCRefunded(ctx, htlc)
+}
+
+// RefundExpiredHTLCs refunds the unsettled HTLCs whose time lock has passed
+// at the current block time, earliest first, and returns their ids. Only the
+// due part of the time lock index is scanned, and at most MaxRefundsPerBlock
+// HTLCs are visited; the rest are left for the next block. A failed refund is
+// rolled back and the HTLC is moved from the time lock index to the failed
+// refunds, so it is not retried every block but can still be refunded by its
+// sender.
+func (k Keeper) RefundExpiredHTLCs(ctx sdk.Context) []uint64 {
+	limit := k.GetParams(ctx).MaxRefundsPerBlock
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(
+		[]byte(types.KeyPrefixHTLCByTimeLock),
+		types.GetHTLCByTimeLockPrefix(ctx.BlockTime().Unix()+1),
+	)
+
+	var due []uint64
+	for ; iterator.Valid(); iterator.Next() {
+		if limit > 0 && uint64(len(due)) >= limit {
+			break
+		}
+		due = append(due, sdk.BigEndianToUint64(iterator.Value()))
+	}
+	iterator.Close()
+
+	var refunded []uint64
+	for _, id := range due {
+		htlc, found := k.GetHTLC(ctx, id)
+		if !found || htlc.Claimed || htlc.Refunded {
+			continue
+		}
+
+		cacheCtx, write := ctx.CacheContext()
+		if err := k.refund(cacheCtx, htlc); err != nil {
+			ctx.Logger().Error("failed to refund expired htlc", "module", types.ModuleName, "htlc_id", id, "err", err)
+			store.Delete(types.GetHTLCByTimeLockKey(htlc.TimeLock.Unix(), id))
+			store.Set(types.GetHTLCRefundFailedKey(id), sdk.Uint64ToBigEndian(id))
+			continue
+		}
+		write()
+		refunded = append(refunded, id)
+	}
+
+	return refunded
+}
+
+// GetHTLCsExpiringBefore returns the unsettled HTLCs whose time lock is
+// before t, ordered by time lock and then by id. HTLCs already past their
+// time lock and waiting to be refunded are included. Only the due part of
+// the time lock index is scanned.
+func (k Keeper) GetHTLCsExpiringBefore(ctx sdk.Context, t time.Time) []types.HTLC {
+	return k.htlcsExpiringBefore(ctx, t, 0)
+}
+
+// htlcsExpiringBefore is GetHTLCsExpiringBefore returning at most limit
+// HTLCs, or all of them when limit is 0.
+func (k Keeper) htlcsExpiringBefore(ctx sdk.Context, t time.Time, limit int) []types.HTLC {
+	store := ctx.KVStore(k.storeKey)
+	// Time locks are whole seconds, so the index is scanned up to the second
+	// of t and the boundary second is checked against t itself
+	iterator := store.Iterator(
+		[]byte(types.KeyPrefixHTLCByTimeLock),
+		types.GetHTLCByTimeLockPrefix(t.Unix()+1),
+	)
+	defer iterator.Close()
+
+	var htlcs []types.HTLC
+	for ; iterator.Valid(); iterator.Next() {
+		if limit > 0 && len(htlcs) >= limit {
+			break
+		}
+		htlc, found := k.GetHTLC(ctx, sdk.BigEndianToUint64(iterator.Value()))
+		if !found || htlc.Claimed || htlc.Refunded || !htlc.TimeLock.Before(t) {
+			continue
+		}
+		htlcs = append(htlcs, htlc)
+	}
+	return htlcs
+}
+
+// UpdateHTLC extends the time lock of an unsettled, unexpired HTLC. Only the
+// original sender may extend it, and the time lock can never be shortened.
+func (k Keeper) UpdateHTLC(ctx sdk.Context, id uint64, sender sdk.AccAddress, newTimeLock int64) error {
+	htlc, found := k.GetHTLC(ctx, id)
+	if !found {
+		return types.ErrHTLCNotFound
+	}
+	if htlc.Claimed {
+		return types.ErrHTLCClaimed
+	}
+	if htlc.Refunded {
+		return types.ErrHTLCRefunded
+	}
+	if !sender.Equals(htlc.Sender) {
+		return types.ErrUnauthorizedUpdater
+	}
+	if ctx.BlockTime().After(htlc.TimeLock) {
+		return types.ErrHTLCExpired
+	}
+	if newTimeLock <= htlc.TimeLock.Unix() || newTimeLock <= ctx.BlockTime().Unix() {
+		return types.ErrInvalidTimeLock
+	}
+	if err := k.validateTimeLock(ctx, k.GetParams(ctx), newTimeLock); err != nil {
+		return err
+	}
+
+	htlc.TimeLock = time.Unix(newTimeLock, 0)
+	k.SetHTLC(ctx, htlc)
+
+	// Emit event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			EventTypeUpdateHTLC,
 			sdk.NewAttribute(AttributeKeyHTLCID, fmt.Sprintf("%d", id)),
-			sdk.NewAttribute(AttributeKeySender, refunder.String()),
-			sdk.NewAttribute(AttributeKeyAmount, htlc.Amount.String()),
+			sdk.NewAttribute(AttributeKeySender, sender.String()),
+			sdk.NewAttribute(AttributeKeyTimeLock, fmt.Sprintf("%d", newTimeLock)),
+			sdk.NewAttribute(AttributeKeyTimeLockUTC, formatTimeLock(htlc.TimeLock)),
 		),
 	)
 
 	return nil
 }
 
+// formatTimeLock renders a time lock for the human-readable event attribute.
+func formatTimeLock(timeLock time.Time) string {
+	return timeLock.UTC().Format(time.RFC3339)
+}
+
+// CurrentPrice returns the Dutch auction price of an HTLC at the current block time.
+func (k Keeper) CurrentPrice(ctx sdk.Context, id uint64) (sdkmath.Int, error) {
+	htlc, found := k.GetHTLC(ctx, id)
+	if !found {
+		return sdkmath.Int{}, types.ErrHTLCNotFound
+	}
+	if !htlc.IsDutchAuction() {
+		return sdkmath.Int{}, types.ErrNotDutchAuction
+	}
+
+	return htlc.PriceAt(ctx.BlockTime()), nil
+}
+
 func (k Keeper) GetNextHTLCId(ctx sdk.Context) uint64 {
 	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(types.KeyNextHTLCId)
+	bz := store.Get([]byte(types.KeyNextHTLCId))
 	if bz == nil {
 		return 1
 	}
 	return binary.BigEndian.Uint64(bz)
 }
 
-func (k Keeper) IncrementNextHTLCId(ctx sdk.Context) {
+// SetNextHTLCId sets the id the next created HTLC will get.
+func (k Keeper) SetNextHTLCId(ctx sdk.Context, id uint64) {
 	store := ctx.KVStore(k.storeKey)
-	id := k.GetNextHTLCId(ctx) + 1
 	bz := make([]byte, 8)
 	binary.BigEndian.PutUint64(bz, id)
-	store.Set(types.KeyNextHTLCId, bz)
+	store.Set([]byte(types.KeyNextHTLCId), bz)
+}
+
+func (k Keeper) IncrementNextHTLCId(ctx sdk.Context) {
+	k.SetNextHTLCId(ctx, k.GetNextHTLCId(ctx)+1)
+}
+
+// GetAllHTLCs returns every stored HTLC ordered by id.
+func (k Keeper) GetAllHTLCs(ctx sdk.Context) []types.HTLC {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, []byte(types.KeyPrefixHTLC))
+	defer iterator.Close()
+
+	var htlcs []types.HTLC
+	for ; iterator.Valid(); iterator.Next() {
+		var htlc types.HTLC
+		k.cdc.MustUnmarshal(iterator.Value(), &htlc)
+		htlcs = append(htlcs, htlc)
+	}
+	return htlcs
 }
@@ -1,15 +1,731 @@
 package keeper_test
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
 	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+	simappparams "cosmossdk.io/simapp/params"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
+// mockAccountKeeper holds the registered module accounts
+type mockAccountKeeper struct {
+	accounts map[string]sdk.ModuleAccountI
+}
+
+func newMockAccountKeeper(accounts ...sdk.ModuleAccountI) *mockAccountKeeper {
+	m := &mockAccountKeeper{accounts: make(map[string]sdk.ModuleAccountI)}
+	for _, acc := range accounts {
+		m.accounts[acc.GetName()] = acc
+	}
+	return m
+}
+
+func (m *mockAccountKeeper) GetModuleAddress(moduleName string) sdk.AccAddress {
+	if acc, ok := m.accounts[moduleName]; ok {
+		return acc.GetAddress()
+	}
+	return nil
+}
+
+func (m *mockAccountKeeper) GetModuleAccount(ctx context.Context, moduleName string) sdk.ModuleAccountI {
+	return m.accounts[moduleName]
+}
+
+// mockBankKeeper tracks account and module balances in memory
+type mockBankKeeper struct {
+	balances map[string]sdk.Coins
+	modules  map[string]sdk.Coins
+	blocked  map[string]bool
+}
+
+func newMockBankKeeper() *mockBankKeeper {
+	return &mockBankKeeper{
+		balances: make(map[string]sdk.Coins),
+		modules:  make(map[string]sdk.Coins),
+		blocked: map[string]bool{
+			authtypes.NewModuleAddress(types.ModuleName).String(): true,
+		},
+	}
+}
+
+func (m *mockBankKeeper) BlockedAddr(addr sdk.AccAddress) bool {
+	return m.blocked[addr.String()]
+}
+
+func (m *mockBankKeeper) SendCoinsFromAccountToModule(ctx context.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error {
+	balance, hasNeg := m.balances[senderAddr.String()].SafeSub(amt...)
+	if hasNeg {
+		return sdkerrors.ErrInsufficientFunds
+	}
+	m.balances[senderAddr.String()] = balance
+	m.modules[recipientModule] = m.modules[recipientModule].Add(amt...)
+	return nil
+}
+
+func (m *mockBankKeeper) SendCoinsFromModuleToAccount(ctx context.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	balance, hasNeg := m.modules[senderModule].SafeSub(amt...)
+	if hasNeg {
+		return sdkerrors.ErrInsufficientFunds
+	}
+	m.modules[senderModule] = balance
+	m.balances[recipientAddr.String()] = m.balances[recipientAddr.String()].Add(amt...)
+	return nil
+}
+
+func (m *mockBankKeeper) GetAllBalances(ctx context.Context, addr sdk.AccAddress) sdk.Coins {
+	for module, coins := range m.modules {
+		if authtypes.NewModuleAddress(module).Equals(addr) {
+			return coins
+		}
+	}
+	return m.balances[addr.String()]
+}
+
+var (
+	sender   = sdk.AccAddress([]byte("sender______________"))
+	receiver = sdk.AccAddress([]byte("receiver____________"))
+
+	authority = authtypes.NewModuleAddress(govtypes.ModuleName).String()
+)
+
+func setupKeeper(t *testing.T) (keeper.Keeper, sdk.Context, *mockBankKeeper) {
+	t.Helper()
+
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("test"))
+	cdc := simappparams.MakeTestEncodingConfig().Codec
+
+	bankKeeper := newMockBankKeeper()
+	bankKeeper.balances[sender.String()] = sdk.NewCoins(sdk.NewInt64Coin("stake", 1000000))
+
+	k := keeper.NewKeeper(cdc, storeKey, newMockAccountKeeper(authtypes.NewEmptyModuleAccount(types.ModuleName)), bankKeeper, authority)
+	return k, ctx.WithBlockTime(time.Unix(1700000000, 0)), bankKeeper
+}
+
+func hashLockOf(preimage []byte) []byte {
+	hash := sha256.Sum256(preimage)
+	return hash[:]
+}
+
 func TestHTLC(t *testing.T) {
 	// TODO: Add tests for the keeper functions
 	// This is a placeholder for future tests
 	require.True(t, true)
 }
+
+func TestHTLCByHashLockIndex(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	preimage := []byte("secret")
+	hashLock := hashLockOf(preimage)
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	_, found := k.GetHTLCByHashLock(ctx, hashLock)
+	require.False(t, found)
+
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLock, timeLock)
+	require.NoError(t, err)
+
+	htlc, found := k.GetHTLCByHashLock(ctx, hashLock)
+	require.True(t, found)
+	require.Equal(t, id, htlc.Id)
+
+	// a second HTLC with the same hashlock doesn't shadow the first active one
+	dupID, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 50)), hashLock, timeLock)
+	require.NoError(t, err)
+
+	htlc, found = k.GetHTLCByHashLock(ctx, hashLock)
+	require.True(t, found)
+	require.Equal(t, id, htlc.Id)
+
+	// once the first is settled, the next active one is returned
+	require.NoError(t, k.ClaimHTLC(ctx, id, preimage, receiver))
+	htlc, found = k.GetHTLCByHashLock(ctx, hashLock)
+	require.True(t, found)
+	require.Equal(t, dupID, htlc.Id)
+
+	// deleting removes the index entry
+	k.DeleteHTLC(ctx, dupID)
+	htlc, found = k.GetHTLCByHashLock(ctx, hashLock)
+	require.True(t, found)
+	require.Equal(t, id, htlc.Id)
+
+	k.DeleteHTLC(ctx, id)
+	_, found = k.GetHTLCByHashLock(ctx, hashLock)
+	require.False(t, found)
+}
+
+// eventAttributes returns the attributes of the last emitted event of the given type
+func eventAttributes(ctx sdk.Context, eventType string) map[string]string {
+	attrs := make(map[string]string)
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != eventType {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			attrs[attr.Key] = attr.Value
+		}
+	}
+	return attrs
+}
+
+func countEvents(ctx sdk.Context, eventType string) int {
+	count := 0
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCreateHTLCEmitsUnixTimeLock(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+	_, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf([]byte("secret")), timeLock)
+	require.NoError(t, err)
+
+	attrs := eventAttributes(ctx, keeper.EventTypeCreateHTLC)
+	emitted, err := strconv.ParseInt(attrs[keeper.AttributeKeyTimeLock], 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, timeLock, emitted)
+	require.Equal(t, time.Unix(timeLock, 0).UTC().Format(time.RFC3339), attrs[keeper.AttributeKeyTimeLockUTC])
+}
+
+func TestCreateHTLCRejectsNonPositiveAmount(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+	bankKeeper.balances[sender.String()] = sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))
+
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+	for name, amount := range map[string]sdk.Coins{
+		"nil":      nil,
+		"empty":    {},
+		"zero":     {sdk.NewInt64Coin("stake", 0)},
+		"negative": {sdk.Coin{Denom: "stake", Amount: sdkmath.NewInt(-1)}},
+		"mixed":    {sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("uatom", 0)},
+	} {
+		_, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte(name)), timeLock)
+		require.ErrorIs(t, err, types.ErrInvalidAmount, name)
+	}
+
+	// nothing was locked, and no HTLC can be claimed
+	require.Empty(t, bankKeeper.modules[types.ModuleName])
+	require.Empty(t, k.GetAllHTLCs(ctx))
+
+	_, err := k.CreateMerkleHTLC(ctx, sender, receiver, sdk.Coins{}, hashLockOf([]byte("merkle")), 2, timeLock)
+	require.ErrorIs(t, err, types.ErrInvalidAmount)
+}
+
+func TestClaimHTLCEmitsPreimage(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	preimage := []byte("secret")
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf(preimage), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, k.ClaimHTLC(ctx, id, preimage, receiver))
+
+	attrs := eventAttributes(ctx, keeper.EventTypeClaimHTLC)
+	require.Equal(t, "1", attrs[keeper.AttributeKeyHTLCID])
+	require.Equal(t, receiver.String(), attrs[keeper.AttributeKeyReceiver])
+	require.Equal(t, hex.EncodeToString(preimage), attrs[keeper.AttributeKeyPreimage])
+}
+
+func TestClaimHTLCPayout(t *testing.T) {
+	payout := sdk.AccAddress([]byte("payout______________"))
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+
+	for _, tc := range []struct {
+		name      string
+		payout    sdk.AccAddress
+		paidTo    sdk.AccAddress
+		notPaidTo sdk.AccAddress
+	}{
+		{name: "default to receiver", payout: nil, paidTo: receiver, notPaidTo: payout},
+		{name: "redirect to payout address", payout: payout, paidTo: payout, notPaidTo: receiver},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx, bankKeeper := setupKeeper(t)
+
+			preimage := []byte("secret")
+			id, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf(preimage), ctx.BlockTime().Add(time.Hour).Unix())
+			require.NoError(t, err)
+
+			ctx = ctx.WithEventManager(sdk.NewEventManager())
+			require.NoError(t, k.ClaimHTLCTo(ctx, id, preimage, receiver, tc.payout))
+
+			require.Equal(t, amount, bankKeeper.balances[tc.paidTo.String()])
+			require.True(t, bankKeeper.balances[tc.notPaidTo.String()].IsZero())
+			require.True(t, bankKeeper.modules[types.ModuleName].IsZero())
+
+			attrs := eventAttributes(ctx, keeper.EventTypeClaimHTLC)
+			require.Equal(t, receiver.String(), attrs[keeper.AttributeKeyReceiver])
+			require.Equal(t, tc.paidTo.String(), attrs[keeper.AttributeKeyPayoutAddress])
+		})
+	}
+}
+
+func TestClaimHTLCPayoutRequiresReceiver(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+
+	preimage := []byte("secret")
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf(preimage), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+
+	// Knowing the preimage is not enough to redirect the funds
+	err = k.ClaimHTLCTo(ctx, id, preimage, sender, sender)
+	require.ErrorIs(t, err, types.ErrUnauthorizedClaimer)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), bankKeeper.modules[types.ModuleName])
+}
+
+func TestRefundHTLCEmitsReceiver(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf([]byte("secret")), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(2 * time.Hour)).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, k.RefundHTLC(ctx, id, sender))
+
+	attrs := eventAttributes(ctx, keeper.EventTypeRefundHTLC)
+	require.Equal(t, "1", attrs[keeper.AttributeKeyHTLCID])
+	require.Equal(t, sender.String(), attrs[keeper.AttributeKeySender])
+	require.Equal(t, receiver.String(), attrs[keeper.AttributeKeyReceiver])
+}
+
+func TestUpdateHTLC(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	preimage := []byte("secret")
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+
+	id, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf(preimage), timeLock)
+	require.NoError(t, err)
+
+	// only the sender can extend
+	require.ErrorIs(t, k.UpdateHTLC(ctx, id, receiver, timeLock+60), types.ErrUnauthorizedUpdater)
+
+	// the time lock can't be shortened or kept
+	require.ErrorIs(t, k.UpdateHTLC(ctx, id, sender, timeLock), types.ErrInvalidTimeLock)
+	require.ErrorIs(t, k.UpdateHTLC(ctx, id, sender, timeLock-60), types.ErrInvalidTimeLock)
+
+	require.ErrorIs(t, k.UpdateHTLC(ctx, id+1, sender, timeLock+60), types.ErrHTLCNotFound)
+
+	require.NoError(t, k.UpdateHTLC(ctx, id, sender, timeLock+60))
+	htlc, found := k.GetHTLC(ctx, id)
+	require.True(t, found)
+	require.Equal(t, timeLock+60, htlc.TimeLock.Unix())
+
+	attrs := eventAttributes(ctx, keeper.EventTypeUpdateHTLC)
+	require.Equal(t, sender.String(), attrs[keeper.AttributeKeySender])
+	require.Equal(t, strconv.FormatInt(timeLock+60, 10), attrs[keeper.AttributeKeyTimeLock])
+	require.Equal(t, htlc.TimeLock.UTC().Format(time.RFC3339), attrs[keeper.AttributeKeyTimeLockUTC])
+
+	// expired HTLCs can't be extended
+	expiredCtx := ctx.WithBlockTime(htlc.TimeLock.Add(time.Second))
+	require.ErrorIs(t, k.UpdateHTLC(expiredCtx, id, sender, timeLock+3600), types.ErrHTLCExpired)
+
+	// nor can settled ones
+	require.NoError(t, k.ClaimHTLC(ctx, id, preimage, receiver))
+	require.ErrorIs(t, k.UpdateHTLC(ctx, id, sender, timeLock+3600), types.ErrHTLCClaimed)
+
+	refundID, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("other")), timeLock)
+	require.NoError(t, err)
+	require.NoError(t, k.RefundHTLC(ctx.WithBlockTime(time.Unix(timeLock, 0)), refundID, sender))
+	require.ErrorIs(t, k.UpdateHTLC(ctx, refundID, sender, timeLock+3600), types.ErrHTLCRefunded)
+}
+
+func TestCreateHTLCParams(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+	bankKeeper.balances[sender.String()] = sdk.NewCoins(sdk.NewInt64Coin("stake", 1000), sdk.NewInt64Coin("uatom", 1000))
+
+	require.NoError(t, k.SetParams(ctx, types.NewParams([]string{"stake"}, sdkmath.NewInt(10), 3600, 0)))
+
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	// allowed denom within the limits
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 10)), hashLockOf([]byte("allowed")), timeLock)
+	require.NoError(t, err)
+
+	// disallowed denom, alone or next to an allowed one
+	_, err = k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("uatom", 100)), hashLockOf([]byte("uatom")), timeLock)
+	require.ErrorIs(t, err, types.ErrDenomNotAllowed)
+	_, err = k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("uatom", 100)), hashLockOf([]byte("mixed")), timeLock)
+	require.ErrorIs(t, err, types.ErrDenomNotAllowed)
+
+	// below the minimum amount
+	_, err = k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 9)), hashLockOf([]byte("small")), timeLock)
+	require.ErrorIs(t, err, types.ErrAmountTooSmall)
+
+	// time lock beyond the maximum
+	_, err = k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf([]byte("long")), timeLock+1)
+	require.ErrorIs(t, err, types.ErrInvalidTimeLock)
+
+	// rejected HTLCs lock nothing
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 10)), bankKeeper.modules[types.ModuleName])
+
+	// nor can the maximum be bypassed by extending the time lock
+	require.ErrorIs(t, k.UpdateHTLC(ctx, id, sender, timeLock+1), types.ErrInvalidTimeLock)
+	require.NoError(t, k.UpdateHTLC(ctx.WithBlockTime(ctx.BlockTime().Add(time.Minute)), id, sender, timeLock+60))
+}
+
+func TestCurrentPrice(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	timeLock := ctx.BlockTime().Add(2 * time.Hour).Unix()
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+
+	id, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("secret")), timeLock)
+	require.NoError(t, err)
+
+	// fixed-price HTLCs have no auction price
+	_, err = k.CurrentPrice(ctx, id)
+	require.ErrorIs(t, err, types.ErrNotDutchAuction)
+
+	_, err = k.CurrentPrice(ctx, id+1)
+	require.ErrorIs(t, err, types.ErrHTLCNotFound)
+
+	htlc, found := k.GetHTLC(ctx, id)
+	require.True(t, found)
+	htlc.InitialPrice = sdkmath.NewInt(1000)
+	htlc.MinPrice = sdkmath.NewInt(400)
+	htlc.DecayRate = sdkmath.NewInt(10)
+	htlc.StartTime = ctx.BlockTime()
+	k.SetHTLC(ctx, htlc)
+
+	for _, tc := range []struct {
+		name    string
+		elapsed time.Duration
+		want    int64
+	}{
+		{"before start", -time.Minute, 1000},
+		{"at start", 0, 1000},
+		{"mid decay", 30 * time.Second, 700},
+		{"at minimum", time.Minute, 400},
+		{"past duration", time.Hour, 400},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			price, err := k.CurrentPrice(ctx.WithBlockTime(htlc.StartTime.Add(tc.elapsed)), id)
+			require.NoError(t, err)
+			require.Equal(t, sdkmath.NewInt(tc.want), price)
+		})
+	}
+}
+
+func TestRefundExpiredHTLCs(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	soon := ctx.BlockTime().Add(time.Hour).Unix()
+	later := ctx.BlockTime().Add(2 * time.Hour).Unix()
+
+	expiring, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("expiring")), soon)
+	require.NoError(t, err)
+	claimed, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("claimed")), soon)
+	require.NoError(t, err)
+	extended, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("extended")), soon)
+	require.NoError(t, err)
+	pending, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("pending")), later)
+	require.NoError(t, err)
+
+	require.NoError(t, k.ClaimHTLC(ctx, claimed, []byte("claimed"), receiver))
+	require.NoError(t, k.UpdateHTLC(ctx, extended, sender, later))
+
+	// nothing is due before the time lock
+	require.Empty(t, k.RefundExpiredHTLCs(ctx.WithBlockTime(time.Unix(soon-1, 0))))
+
+	expiredCtx := ctx.WithBlockTime(time.Unix(soon, 0)).WithEventManager(sdk.NewEventManager())
+	require.Equal(t, []uint64{expiring}, k.RefundExpiredHTLCs(expiredCtx))
+
+	htlc, found := k.GetHTLC(ctx, expiring)
+	require.True(t, found)
+	require.True(t, htlc.Refunded)
+	require.Equal(t, sender.String(), eventAttributes(expiredCtx, keeper.EventTypeRefundHTLC)[keeper.AttributeKeySender])
+
+	// the refund fires exactly once
+	require.Empty(t, k.RefundExpiredHTLCs(expiredCtx.WithBlockTime(time.Unix(soon+60, 0))))
+	require.Equal(t, int64(1000000-400+100), bankKeeper.balances[sender.String()].AmountOf("stake").Int64())
+
+	// extended HTLCs are refunded at their new time lock
+	laterCtx := ctx.WithBlockTime(time.Unix(later, 0))
+	require.ElementsMatch(t, []uint64{extended, pending}, k.RefundExpiredHTLCs(laterCtx))
+	require.Empty(t, k.RefundExpiredHTLCs(laterCtx))
+}
+
+func TestRefundExpiredHTLCsLimit(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	params := types.DefaultParams()
+	params.MaxRefundsPerBlock = 2
+	require.NoError(t, k.SetParams(ctx, params))
+
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	start := ctx.BlockTime()
+
+	var ids []uint64
+	for i, preimage := range []string{"first", "second", "third"} {
+		id, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte(preimage)), start.Add(time.Duration(i+1)*time.Minute).Unix())
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	// the earliest time locks are refunded first, the rest in the next block
+	expiredCtx := ctx.WithBlockTime(start.Add(time.Hour))
+	require.Equal(t, ids[:2], k.RefundExpiredHTLCs(expiredCtx))
+	require.Equal(t, ids[2:], k.RefundExpiredHTLCs(expiredCtx))
+	require.Empty(t, k.RefundExpiredHTLCs(expiredCtx))
+}
+
+func TestGetHTLCsExpiringBefore(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	start := ctx.BlockTime()
+
+	// Created out of time lock order, so the result order comes from the index
+	third, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("third")), start.Add(30*time.Minute).Unix())
+	require.NoError(t, err)
+	first, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("first")), start.Add(10*time.Minute).Unix())
+	require.NoError(t, err)
+	second, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("second")), start.Add(20*time.Minute).Unix())
+	require.NoError(t, err)
+	claimed, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("claimed")), start.Add(15*time.Minute).Unix())
+	require.NoError(t, err)
+	refunded, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("refunded")), start.Add(5*time.Minute).Unix())
+	require.NoError(t, err)
+	_, err = k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf([]byte("later")), start.Add(2*time.Hour).Unix())
+	require.NoError(t, err)
+
+	require.NoError(t, k.ClaimHTLC(ctx, claimed, []byte("claimed"), receiver))
+	require.NoError(t, k.RefundHTLC(ctx.WithBlockTime(start.Add(5*time.Minute)), refunded, sender))
+
+	ids := func(htlcs []types.HTLC) []uint64 {
+		var ids []uint64
+		for _, htlc := range htlcs {
+			ids = append(ids, htlc.Id)
+		}
+		return ids
+	}
+
+	require.Empty(t, k.GetHTLCsExpiringBefore(ctx, start.Add(10*time.Minute)))
+	require.Equal(t, []uint64{first}, ids(k.GetHTLCsExpiringBefore(ctx, start.Add(10*time.Minute+time.Nanosecond))))
+	require.Equal(t, []uint64{first, second}, ids(k.GetHTLCsExpiringBefore(ctx, start.Add(25*time.Minute))))
+	require.Equal(t, []uint64{first, second, third}, ids(k.GetHTLCsExpiringBefore(ctx, start.Add(time.Hour))))
+
+	// An extended HTLC moves in the index
+	require.NoError(t, k.UpdateHTLC(ctx, first, sender, start.Add(40*time.Minute).Unix()))
+	require.Equal(t, []uint64{second, third, first}, ids(k.GetHTLCsExpiringBefore(ctx, start.Add(time.Hour))))
+}
+
+func TestBatchCreateHTLC(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	ids, err := k.BatchCreateHTLC(ctx, sender, []types.HTLCParams{
+		{Receiver: receiver, Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), HashLock: hashLockOf([]byte("leg-1")), TimeLock: timeLock},
+		{Receiver: receiver, Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 200)), HashLock: hashLockOf([]byte("leg-2")), TimeLock: timeLock},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2}, ids)
+
+	htlc, found := k.GetHTLC(ctx, ids[1])
+	require.True(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 200)), htlc.Amount)
+	require.Equal(t, 2, countEvents(ctx, keeper.EventTypeCreateHTLC))
+}
+
+func TestBatchCreateHTLCRollsBackOnFailure(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+	nextID := k.GetNextHTLCId(ctx)
+
+	// the second leg's time lock has already passed
+	_, err := k.BatchCreateHTLC(ctx, sender, []types.HTLCParams{
+		{Receiver: receiver, Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), HashLock: hashLockOf([]byte("leg-1")), TimeLock: timeLock},
+		{Receiver: receiver, Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 200)), HashLock: hashLockOf([]byte("leg-2")), TimeLock: ctx.BlockTime().Unix() - 1},
+	})
+	require.ErrorIs(t, err, types.ErrInvalidTimeLock)
+
+	// the first leg was created in the cached context only
+	require.Empty(t, k.GetAllHTLCs(ctx))
+	require.Equal(t, nextID, k.GetNextHTLCId(ctx))
+	_, found := k.GetHTLCByHashLock(ctx, hashLockOf([]byte("leg-1")))
+	require.False(t, found)
+	require.Zero(t, countEvents(ctx, keeper.EventTypeCreateHTLC))
+}
+
+// createClaimableHTLCs creates an HTLC of 100 stake for receiver per secret
+// and returns their ids.
+func createClaimableHTLCs(t *testing.T, k keeper.Keeper, ctx sdk.Context, secrets ...string) []uint64 {
+	t.Helper()
+
+	ids := make([]uint64, len(secrets))
+	for i, secret := range secrets {
+		id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf([]byte(secret)), ctx.BlockTime().Add(time.Hour).Unix())
+		require.NoError(t, err)
+		ids[i] = id
+	}
+	return ids
+}
+
+func TestBatchClaimHTLC(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+	ids := createClaimableHTLCs(t, k, ctx, "first", "second", "third")
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+	results, err := k.BatchClaimHTLC(ctx, receiver, []types.HTLCClaim{
+		{HTLCId: ids[0], Preimage: []byte("first")},
+		{HTLCId: ids[1], Preimage: []byte("second")},
+		{HTLCId: ids[2], Preimage: []byte("third")},
+	}, false)
+	require.NoError(t, err)
+
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	require.Equal(t, []types.ClaimResult{
+		{HTLCId: ids[0], Amount: amount},
+		{HTLCId: ids[1], Amount: amount},
+		{HTLCId: ids[2], Amount: amount},
+	}, results)
+	for _, id := range ids {
+		htlc, found := k.GetHTLC(ctx, id)
+		require.True(t, found)
+		require.True(t, htlc.Claimed)
+	}
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 300)), bankKeeper.balances[receiver.String()])
+	require.Equal(t, 3, countEvents(ctx, keeper.EventTypeClaimHTLC))
+}
+
+func TestBatchClaimHTLCRollsBackOnFailure(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	ids := createClaimableHTLCs(t, k, ctx, "first", "second")
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+	// the second claim has the wrong preimage
+	_, err := k.BatchClaimHTLC(ctx, receiver, []types.HTLCClaim{
+		{HTLCId: ids[0], Preimage: []byte("first")},
+		{HTLCId: ids[1], Preimage: []byte("wrong")},
+	}, false)
+	require.ErrorIs(t, err, types.ErrInvalidPreimage)
+	require.ErrorContains(t, err, "claim 1")
+
+	// the first claim was made in the cached context only
+	htlc, found := k.GetHTLC(ctx, ids[0])
+	require.True(t, found)
+	require.False(t, htlc.Claimed)
+	require.Zero(t, countEvents(ctx, keeper.EventTypeClaimHTLC))
+}
+
+func TestBatchClaimHTLCAllowPartial(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+	ids := createClaimableHTLCs(t, k, ctx, "first", "second", "third")
+	require.NoError(t, k.ClaimHTLC(ctx, ids[2], []byte("third"), receiver))
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+	results, err := k.BatchClaimHTLC(ctx, receiver, []types.HTLCClaim{
+		{HTLCId: ids[0], Preimage: []byte("first")},
+		{HTLCId: ids[1], Preimage: []byte("wrong")},
+		{HTLCId: ids[2], Preimage: []byte("third")},
+		{HTLCId: 99, Preimage: []byte("missing")},
+	}, true)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	require.Equal(t, types.ClaimResult{HTLCId: ids[0], Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 100))}, results[0])
+	require.Contains(t, results[1].Error, types.ErrInvalidPreimage.Error())
+	require.Contains(t, results[2].Error, types.ErrHTLCClaimed.Error())
+	require.Contains(t, results[3].Error, types.ErrHTLCNotFound.Error())
+	for _, result := range results[1:] {
+		require.True(t, result.Amount.IsZero())
+	}
+
+	htlc, found := k.GetHTLC(ctx, ids[0])
+	require.True(t, found)
+	require.True(t, htlc.Claimed)
+	htlc, found = k.GetHTLC(ctx, ids[1])
+	require.True(t, found)
+	require.False(t, htlc.Claimed)
+
+	// only the valid claim paid out, on top of the earlier single claim
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 200)), bankKeeper.balances[receiver.String()])
+	require.Equal(t, 1, countEvents(ctx, keeper.EventTypeClaimHTLC))
+}
+
+func TestRevealSecret(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	secret := []byte("secret")
+	id, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf(secret), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, k.RevealSecret(ctx, id, secret, sender))
+
+	attrs := eventAttributes(ctx, keeper.EventTypeRevealSecret)
+	require.Equal(t, "1", attrs[keeper.AttributeKeyHTLCID])
+	require.Equal(t, sender.String(), attrs[keeper.AttributeKeySender])
+	require.Equal(t, hex.EncodeToString(secret), attrs[keeper.AttributeKeyPreimage])
+
+	// the secret is published but nothing is claimed or transferred
+	revealed, err := k.Preimage(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, secret, revealed)
+	htlc, found := k.GetHTLC(ctx, id)
+	require.True(t, found)
+	require.False(t, htlc.Claimed)
+	require.Equal(t, amount, bankKeeper.modules[types.ModuleName])
+	require.True(t, bankKeeper.balances[receiver.String()].IsZero())
+
+	// the secret is revealed only once
+	require.ErrorIs(t, k.RevealSecret(ctx, id, secret, receiver), types.ErrSecretRevealed)
+
+	// and the receiver can still claim
+	require.NoError(t, k.ClaimHTLC(ctx, id, secret, receiver))
+	require.Equal(t, amount, bankKeeper.balances[receiver.String()])
+	require.True(t, bankKeeper.modules[types.ModuleName].IsZero())
+}
+
+func TestRevealSecretInvalid(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+	secret := []byte("secret")
+	id, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf(secret), timeLock)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, k.RevealSecret(ctx, id, []byte("wrong"), sender), types.ErrInvalidPreimage)
+	require.ErrorIs(t, k.RevealSecret(ctx, id+1, secret, sender), types.ErrHTLCNotFound)
+
+	// a wrong secret stores nothing
+	_, err = k.Preimage(ctx, id)
+	require.ErrorIs(t, err, types.ErrPreimageNotRevealed)
+
+	// settled HTLCs can't be revealed
+	require.NoError(t, k.ClaimHTLC(ctx, id, secret, receiver))
+	require.ErrorIs(t, k.RevealSecret(ctx, id, secret, sender), types.ErrHTLCClaimed)
+
+	refundSecret := []byte("refund")
+	refundID, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf(refundSecret), timeLock)
+	require.NoError(t, err)
+	require.NoError(t, k.RefundHTLC(ctx.WithBlockTime(time.Unix(timeLock, 0)), refundID, sender))
+	require.ErrorIs(t, k.RevealSecret(ctx, refundID, refundSecret, sender), types.ErrHTLCRefunded)
+}
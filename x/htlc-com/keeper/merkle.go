@@ -0,0 +1,118 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CreateMerkleHTLC locks amount like CreateHTLC in an HTLC that the receiver
+// claims in parts equal parts, each with its own secret. merkleRoot is the
+// root of the tree over the secrets, built as described in types.MerkleLeaf.
+// Every locked coin must have at least one unit per part.
+func (k Keeper) CreateMerkleHTLC(ctx sdk.Context, sender, receiver sdk.AccAddress, amount sdk.Coins, merkleRoot []byte, parts uint32, timeLock int64) (uint64, error) {
+	if err := validateMerkleParts(amount, parts); err != nil {
+		return 0, err
+	}
+
+	return k.createHTLC(ctx, sender, receiver, amount, merkleRoot, timeLock, parts, "", nil, types.HTLC{})
+}
+
+// validateMerkleParts checks that amount can be split into parts parts.
+func validateMerkleParts(amount sdk.Coins, parts uint32) error {
+	if parts < 2 || parts > types.MaxHTLCParts {
+		return errorsmod.Wrapf(types.ErrInvalidParts, "parts must be between 2 and %d, got %d", types.MaxHTLCParts, parts)
+	}
+	for _, coin := range amount {
+		if coin.Amount.LT(sdkmath.NewInt(int64(parts))) {
+			return errorsmod.Wrapf(types.ErrAmountTooSmall, "%s cannot be split into %d parts", coin, parts)
+		}
+	}
+	return nil
+}
+
+// CalculateClaimAmount checks that preimage is the secret of part index of a
+// Merkle HTLC, proven against its root by proof, and returns the coins the
+// claim releases: the parts up to and including index that have not been
+// claimed yet. Parts must be claimed in order, so an index at or below the
+// last claimed part is rejected.
+func (k Keeper) CalculateClaimAmount(htlc types.HTLC, index uint32, preimage []byte, proof [][]byte) (sdk.Coins, error) {
+	if !htlc.IsMerkle() {
+		return nil, types.ErrNotMerkleHTLC
+	}
+	if index >= htlc.Parts {
+		return nil, errorsmod.Wrapf(types.ErrInvalidMerkleProof, "part %d is out of range for %d parts", index, htlc.Parts)
+	}
+	if index < htlc.ClaimedParts {
+		return nil, errorsmod.Wrapf(types.ErrPartClaimed, "part %d, %d of %d parts claimed", index, htlc.ClaimedParts, htlc.Parts)
+	}
+
+	secretHash := sha256.Sum256(preimage)
+	leaf := types.MerkleLeaf(index, secretHash[:])
+	if !types.VerifyMerkleProof(htlc.HashLock, leaf, index, htlc.Parts, proof) {
+		return nil, types.ErrInvalidMerkleProof
+	}
+
+	return htlc.PartsAmount(index + 1).Sub(htlc.PartsAmount(htlc.ClaimedParts)...), nil
+}
+
+// ClaimHTLCPart claims a Merkle HTLC up to and including part index, paying
+// the released coins to payout, or to the receiver when payout is empty. The
+// HTLC is settled once its last part is claimed.
+func (k Keeper) ClaimHTLCPart(ctx sdk.Context, id uint64, index uint32, preimage []byte, proof [][]byte, claimer, payout sdk.AccAddress) error {
+	htlc, found := k.GetHTLC(ctx, id)
+	if !found {
+		return types.ErrHTLCNotFound
+	}
+	if htlc.Claimed {
+		return types.ErrHTLCClaimed
+	}
+	if htlc.Refunded {
+		return types.ErrHTLCRefunded
+	}
+	if !claimer.Equals(htlc.Receiver) {
+		return types.ErrUnauthorizedClaimer
+	}
+	if ctx.BlockTime().After(htlc.TimeLock) {
+		return types.ErrHTLCExpired
+	}
+
+	amount, err := k.CalculateClaimAmount(htlc, index, preimage, proof)
+	if err != nil {
+		return err
+	}
+
+	if payout.Empty() {
+		payout = htlc.Receiver
+	}
+
+	htlc.ClaimedParts = index + 1
+	htlc.Claimed = htlc.ClaimedParts == htlc.Parts
+	htlc.Preimage = preimage
+	k.SetHTLC(ctx, htlc)
+
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, payout, amount); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			EventTypeClaimHTLC,
+			sdk.NewAttribute(AttributeKeyHTLCID, fmt.Sprintf("%d", id)),
+			sdk.NewAttribute(AttributeKeyReceiver, claimer.String()),
+			sdk.NewAttribute(AttributeKeyAmount, amount.String()),
+			sdk.NewAttribute(AttributeKeyPreimage, hex.EncodeToString(preimage)),
+			sdk.NewAttribute(AttributeKeyPayoutAddress, payout.String()),
+			sdk.NewAttribute(AttributeKeyPart, fmt.Sprintf("%d", index)),
+		),
+	)
+
+	return k.afterHTLCClaimed(ctx, htlc, preimage)
+}
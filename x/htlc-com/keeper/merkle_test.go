@@ -0,0 +1,156 @@
+package keeper_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// merkleSecrets returns a secret per part and the tree's leaves
+func merkleSecrets(parts int) ([][]byte, [][]byte) {
+	secrets := make([][]byte, parts)
+	leaves := make([][]byte, parts)
+	for i := range secrets {
+		secrets[i] = []byte(fmt.Sprintf("part-secret-%d", i))
+		leaves[i] = types.MerkleLeaf(uint32(i), hashLockOf(secrets[i]))
+	}
+	return secrets, leaves
+}
+
+func createMerkleHTLC(t *testing.T, k keeper.Keeper, ctx sdk.Context, amount int64, parts int) (uint64, [][]byte, [][]byte) {
+	t.Helper()
+
+	secrets, leaves := merkleSecrets(parts)
+	id, err := k.CreateMerkleHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", amount)),
+		types.MerkleRoot(leaves), uint32(parts), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+	return id, secrets, leaves
+}
+
+func TestCalculateClaimAmount(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	id, secrets, leaves := createMerkleHTLC(t, k, ctx, 1000, 4)
+	htlc, found := k.GetHTLC(ctx, id)
+	require.True(t, found)
+
+	// Each part is a quarter of the amount, cumulatively
+	for i, expected := range []int64{250, 500, 750, 1000} {
+		amount, err := k.CalculateClaimAmount(htlc, uint32(i), secrets[i], types.MerkleProof(leaves, uint32(i)))
+		require.NoError(t, err)
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", expected)), amount, "part %d", i)
+	}
+
+	// Once a part is claimed only the later parts are left
+	htlc.ClaimedParts = 2
+	amount, err := k.CalculateClaimAmount(htlc, 3, secrets[3], types.MerkleProof(leaves, 3))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 500)), amount)
+
+	_, err = k.CalculateClaimAmount(htlc, 1, secrets[1], types.MerkleProof(leaves, 1))
+	require.ErrorIs(t, err, types.ErrPartClaimed)
+
+	// A secret must be proven at its own index
+	_, err = k.CalculateClaimAmount(htlc, 3, secrets[2], types.MerkleProof(leaves, 2))
+	require.ErrorIs(t, err, types.ErrInvalidMerkleProof)
+	_, err = k.CalculateClaimAmount(htlc, 3, []byte("wrong"), types.MerkleProof(leaves, 3))
+	require.ErrorIs(t, err, types.ErrInvalidMerkleProof)
+	_, err = k.CalculateClaimAmount(htlc, 4, secrets[3], types.MerkleProof(leaves, 3))
+	require.ErrorIs(t, err, types.ErrInvalidMerkleProof)
+
+	_, err = k.CalculateClaimAmount(types.HTLC{Amount: htlc.Amount}, 0, secrets[0], nil)
+	require.ErrorIs(t, err, types.ErrNotMerkleHTLC)
+}
+
+func TestClaimHTLCPart(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+
+	// 1000 stake in 3 parts doesn't split evenly
+	id, secrets, leaves := createMerkleHTLC(t, k, ctx, 1000, 3)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, k.ClaimHTLCPart(ctx, id, 0, secrets[0], types.MerkleProof(leaves, 0), receiver, nil))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 333)), bankKeeper.balances[receiver.String()])
+
+	attrs := eventAttributes(ctx, keeper.EventTypeClaimHTLC)
+	require.Equal(t, "333stake", attrs[keeper.AttributeKeyAmount])
+	require.Equal(t, "0", attrs[keeper.AttributeKeyPart])
+
+	htlc, _ := k.GetHTLC(ctx, id)
+	require.False(t, htlc.Claimed)
+	require.Equal(t, uint32(1), htlc.ClaimedParts)
+
+	// A claimed part can't be claimed again
+	err := k.ClaimHTLCPart(ctx, id, 0, secrets[0], types.MerkleProof(leaves, 0), receiver, nil)
+	require.ErrorIs(t, err, types.ErrPartClaimed)
+
+	// Only the receiver can claim, and the full-claim path is closed
+	err = k.ClaimHTLCPart(ctx, id, 1, secrets[1], types.MerkleProof(leaves, 1), sender, nil)
+	require.ErrorIs(t, err, types.ErrUnauthorizedClaimer)
+	err = k.ClaimHTLC(ctx, id, secrets[2], receiver)
+	require.ErrorIs(t, err, types.ErrInvalidPreimage)
+
+	// Claiming the last part releases the rest, rounding included, and
+	// settles the HTLC
+	require.NoError(t, k.ClaimHTLCPart(ctx, id, 2, secrets[2], types.MerkleProof(leaves, 2), receiver, nil))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)), bankKeeper.balances[receiver.String()])
+	require.True(t, bankKeeper.modules[types.ModuleName].IsZero())
+
+	htlc, _ = k.GetHTLC(ctx, id)
+	require.True(t, htlc.Claimed)
+	require.Empty(t, k.RefundExpiredHTLCs(ctx.WithBlockTime(ctx.BlockTime().Add(2*time.Hour))))
+
+	err = k.ClaimHTLCPart(ctx, id, 2, secrets[2], types.MerkleProof(leaves, 2), receiver, nil)
+	require.ErrorIs(t, err, types.ErrHTLCClaimed)
+}
+
+func TestRefundPartiallyClaimedHTLC(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+
+	id, secrets, leaves := createMerkleHTLC(t, k, ctx, 1000, 5)
+	require.NoError(t, k.ClaimHTLCPart(ctx, id, 1, secrets[1], types.MerkleProof(leaves, 1), receiver, nil))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 400)), bankKeeper.balances[receiver.String()])
+
+	msg, broken := keeper.ModuleBalanceInvariant(k)(ctx)
+	require.False(t, broken, msg)
+
+	// Only the unclaimed parts go back to the sender
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(2 * time.Hour)).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, k.RefundHTLC(ctx, id, sender))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 999600)), bankKeeper.balances[sender.String()])
+	require.True(t, bankKeeper.modules[types.ModuleName].IsZero())
+	require.Equal(t, "600stake", eventAttributes(ctx, keeper.EventTypeRefundHTLC)[keeper.AttributeKeyAmount])
+
+	msg, broken = keeper.ModuleBalanceInvariant(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+func TestCreateMerkleHTLCValidatesParts(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	_, leaves := merkleSecrets(4)
+	root := types.MerkleRoot(leaves)
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))
+
+	for _, parts := range []uint32{0, 1, types.MaxHTLCParts + 1} {
+		_, err := k.CreateMerkleHTLC(ctx, sender, receiver, amount, root, parts, timeLock)
+		require.ErrorIs(t, err, types.ErrInvalidParts, "%d parts", parts)
+	}
+
+	// Every part must release at least one unit
+	_, err := k.CreateMerkleHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 3)), root, 4, timeLock)
+	require.ErrorIs(t, err, types.ErrAmountTooSmall)
+
+	id, err := k.CreateMerkleHTLC(ctx, sender, receiver, amount, root, 4, timeLock)
+	require.NoError(t, err)
+	htlc, _ := k.GetHTLC(ctx, id)
+	require.Equal(t, uint32(4), htlc.Parts)
+	require.Equal(t, root, htlc.HashLock)
+}
@@ -0,0 +1,27 @@
+package keeper
+
+import (
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ValidateModuleAccount checks that the HTLC escrow account is set up the way
+// the locked-fund accounting relies on: it is a registered module account
+// without permissions, so it can neither mint nor burn, and the bank keeper
+// blocks it from receiving transfers that bypass the module.
+func (k Keeper) ValidateModuleAccount(ctx sdk.Context) error {
+	acc := k.accountKeeper.GetModuleAccount(ctx, types.ModuleName)
+	if acc == nil {
+		return errorsmod.Wrapf(types.ErrInvalidModuleAccount, "%s module account is not registered", types.ModuleName)
+	}
+	if perms := acc.GetPermissions(); len(perms) > 0 {
+		return errorsmod.Wrapf(types.ErrInvalidModuleAccount, "%s module account must have no permissions, has %v", types.ModuleName, perms)
+	}
+	if !k.bankKeeper.BlockedAddr(acc.GetAddress()) {
+		return errorsmod.Wrapf(types.ErrInvalidModuleAccount, "%s module account must be blocked from receiving transfers", types.ModuleName)
+	}
+	return nil
+}
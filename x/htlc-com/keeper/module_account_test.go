@@ -0,0 +1,62 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	simappparams "cosmossdk.io/simapp/params"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+func TestValidateModuleAccount(t *testing.T) {
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+
+	for _, tc := range []struct {
+		name    string
+		account sdk.ModuleAccountI
+		blocked bool
+		err     bool
+	}{
+		{name: "registered and blocked", account: authtypes.NewEmptyModuleAccount(types.ModuleName), blocked: true},
+		{name: "not blocked", account: authtypes.NewEmptyModuleAccount(types.ModuleName), err: true},
+		{name: "minter", account: authtypes.NewEmptyModuleAccount(types.ModuleName, authtypes.Minter), blocked: true, err: true},
+		{name: "burner", account: authtypes.NewEmptyModuleAccount(types.ModuleName, authtypes.Burner), blocked: true, err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+			ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("test")).WithBlockTime(time.Unix(1700000000, 0))
+			cdc := simappparams.MakeTestEncodingConfig().Codec
+
+			bankKeeper := newMockBankKeeper()
+			bankKeeper.blocked[moduleAddr.String()] = tc.blocked
+			k := keeper.NewKeeper(cdc, storeKey, newMockAccountKeeper(tc.account), bankKeeper, authority)
+
+			err := k.ValidateModuleAccount(ctx)
+			_, broken := keeper.ModuleAccountInvariant(k)(ctx)
+			if tc.err {
+				require.ErrorIs(t, err, types.ErrInvalidModuleAccount)
+				require.True(t, broken)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, broken)
+		})
+	}
+}
+
+func TestNewKeeperRequiresModuleAccount(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	cdc := simappparams.MakeTestEncodingConfig().Codec
+
+	require.Panics(t, func() {
+		keeper.NewKeeper(cdc, storeKey, newMockAccountKeeper(), newMockBankKeeper(), authority)
+	})
+}
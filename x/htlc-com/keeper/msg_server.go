@@ -5,7 +5,10 @@ import (
 
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
 
+	errorsmod "cosmossdk.io/errors"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
 type msgServer struct {
@@ -19,7 +22,12 @@ func NewMsgServerImpl(k Keeper) types.MsgServer {
 func (k msgServer) CreateHTLC(goCtx context.Context, msg *types.MsgCreateHTLC) (*types.MsgCreateHTLCResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	id, err := k.CreateHTLC(ctx, msg.Sender, msg.Receiver, msg.Amount, msg.HashLock, msg.TimeLock)
+	if msg.Parts > 0 {
+		if err := validateMerkleParts(msg.Amount, msg.Parts); err != nil {
+			return nil, err
+		}
+	}
+	id, err := k.createHTLC(ctx, msg.Sender, msg.Receiver, msg.Amount, msg.HashLock, msg.TimeLock, msg.Parts, msg.ClientId, msg.Splits, msg.Auction())
 	if err != nil {
 		return nil, err
 	}
@@ -27,15 +35,67 @@ func (k msgServer) CreateHTLC(goCtx context.Context, msg *types.MsgCreateHTLC) (
 	return &types.MsgCreateHTLCResponse{Id: id}, nil
 }
 
+func (k msgServer) BatchCreateHTLC(goCtx context.Context, msg *types.MsgBatchCreateHTLC) (*types.MsgBatchCreateHTLCResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	ids, err := k.Keeper.BatchCreateHTLC(ctx, msg.Sender, msg.HTLCs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgBatchCreateHTLCResponse{Ids: ids}, nil
+}
+
+func (k msgServer) BatchClaimHTLC(goCtx context.Context, msg *types.MsgBatchClaimHTLC) (*types.MsgBatchClaimHTLCResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	results, err := k.Keeper.BatchClaimHTLC(ctx, msg.Claimer, msg.Claims, msg.AllowPartial)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgBatchClaimHTLCResponse{Results: results}, nil
+}
+
 func (k msgServer) ClaimHTLC(goCtx context.Context, msg *types.MsgClaimHTLC) (*types.MsgClaimHTLCResponse, error) {
 	ctx := sdk.UnwrapSDKContext(goCtx)
 
-	err := k.ClaimHTLC(ctx, msg.HTLCId, msg.Preimage, msg.Claimer)
+	htlc, found := k.GetHTLC(ctx, msg.HTLCId)
+	if !found {
+		return nil, types.ErrHTLCNotFound
+	}
+
+	var err error
+	if msg.IsPartClaim() {
+		err = k.ClaimHTLCPart(ctx, msg.HTLCId, msg.Part, msg.Preimage, msg.Proof, msg.Claimer, msg.PayoutAddress)
+	} else {
+		err = k.ClaimHTLCTo(ctx, msg.HTLCId, msg.Preimage, msg.Claimer, msg.PayoutAddress)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.MsgClaimHTLCResponse{}, nil
+	// The claim released whatever the HTLC stopped locking
+	remaining := sdk.NewCoins()
+	if claimed, found := k.GetHTLC(ctx, msg.HTLCId); found && !claimed.Claimed {
+		remaining = claimed.Unclaimed()
+	}
+
+	return &types.MsgClaimHTLCResponse{
+		Amount:    htlc.Unclaimed().Sub(remaining...),
+		Remaining: remaining,
+	}, nil
+}
+
+func (k msgServer) RevealSecret(goCtx context.Context, msg *types.MsgRevealSecret) (*types.MsgRevealSecretResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	err := k.Keeper.RevealSecret(ctx, msg.HTLCId, msg.Secret, msg.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRevealSecretResponse{}, nil
 }
 
 func (k msgServer) RefundHTLC(goCtx context.Context, msg *types.MsgRefundHTLC) (*types.MsgRefundHTLCResponse, error) {
@@ -48,3 +108,27 @@ func (k msgServer) RefundHTLC(goCtx context.Context, msg *types.MsgRefundHTLC) (
 
 	return &types.MsgRefundHTLCResponse{}, nil
 }
+
+func (k msgServer) UpdateHTLC(goCtx context.Context, msg *types.MsgUpdateHTLC) (*types.MsgUpdateHTLCResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	err := k.Keeper.UpdateHTLC(ctx, msg.HTLCId, msg.Sender, msg.NewTimeLock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateHTLCResponse{}, nil
+}
+
+func (k msgServer) UpdateParams(goCtx context.Context, msg *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	if msg.Authority != k.authority {
+		return nil, errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", k.authority, msg.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := k.SetParams(ctx, msg.Params); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
@@ -2,9 +2,16 @@ package keeper_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
 	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
 func TestMsgServer(t *testing.T) {
@@ -12,3 +19,130 @@ func TestMsgServer(t *testing.T) {
 	// This is a placeholder for future tests
 	require.True(t, true)
 }
+
+func TestMsgUpdateParams(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	params := types.NewParams([]string{"stake"}, sdkmath.NewInt(10), 3600, 0)
+
+	// only the authority can update the params
+	_, err := msgServer.UpdateParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateParams(sender.String(), params))
+	require.ErrorIs(t, err, govtypes.ErrInvalidSigner)
+	require.Equal(t, types.DefaultParams(), k.GetParams(ctx))
+
+	_, err = msgServer.UpdateParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateParams(authority, params))
+	require.NoError(t, err)
+	require.Equal(t, params, k.GetParams(ctx))
+
+	// invalid params are rejected
+	params.MaxTimeLockSeconds = -1
+	_, err = msgServer.UpdateParams(sdk.WrapSDKContext(ctx), types.NewMsgUpdateParams(authority, params))
+	require.Error(t, err)
+}
+
+func TestMsgCreateHTLCAuction(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	queryServer := keeper.NewQueryServerImpl(k)
+
+	msg := types.NewMsgCreateHTLC(sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf([]byte("secret")), ctx.BlockTime().Add(time.Hour).Unix())
+	msg.InitialPrice = sdkmath.NewInt(1000)
+	msg.MinPrice = sdkmath.NewInt(400)
+	msg.DecayRate = sdkmath.NewInt(10)
+	res, err := msgServer.CreateHTLC(sdk.WrapSDKContext(ctx), msg)
+	require.NoError(t, err)
+
+	// the auction starts at the block the HTLC is created in
+	htlc, found := k.GetHTLC(ctx, res.Id)
+	require.True(t, found)
+	require.True(t, htlc.IsDutchAuction())
+	require.Equal(t, ctx.BlockTime(), htlc.StartTime)
+
+	price, err := queryServer.CurrentPrice(sdk.WrapSDKContext(ctx.WithBlockTime(ctx.BlockTime().Add(30*time.Second))), &types.QueryCurrentPriceRequest{Id: res.Id})
+	require.NoError(t, err)
+	require.Equal(t, sdkmath.NewInt(700), price.Price)
+
+	// invalid prices are rejected even without ValidateBasic
+	msg = types.NewMsgCreateHTLC(sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf([]byte("other")), ctx.BlockTime().Add(time.Hour).Unix())
+	msg.InitialPrice = sdkmath.NewInt(400)
+	msg.MinPrice = sdkmath.NewInt(1000)
+	_, err = msgServer.CreateHTLC(sdk.WrapSDKContext(ctx), msg)
+	require.ErrorIs(t, err, types.ErrInvalidAuction)
+	require.Len(t, k.GetAllHTLCs(ctx), 1)
+}
+
+func TestMsgBatchCreateHTLC(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	res, err := msgServer.BatchCreateHTLC(sdk.WrapSDKContext(ctx), types.NewMsgBatchCreateHTLC(sender, []types.HTLCParams{
+		{Receiver: receiver, Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), HashLock: hashLockOf([]byte("leg-1")), TimeLock: timeLock},
+		{Receiver: receiver, Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 200)), HashLock: hashLockOf([]byte("leg-2")), TimeLock: timeLock},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2}, res.Ids)
+	require.Len(t, k.GetAllHTLCs(ctx), 2)
+}
+
+func TestMsgBatchClaimHTLC(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	ids := createClaimableHTLCs(t, k, ctx, "first", "second")
+	claims := []types.HTLCClaim{
+		{HTLCId: ids[0], Preimage: []byte("first")},
+		{HTLCId: ids[1], Preimage: []byte("wrong")},
+	}
+
+	// an atomic batch fails as a whole
+	_, err := msgServer.BatchClaimHTLC(sdk.WrapSDKContext(ctx), types.NewMsgBatchClaimHTLC(receiver, claims, false))
+	require.ErrorIs(t, err, types.ErrInvalidPreimage)
+
+	res, err := msgServer.BatchClaimHTLC(sdk.WrapSDKContext(ctx), types.NewMsgBatchClaimHTLC(receiver, claims, true))
+	require.NoError(t, err)
+	require.Len(t, res.Results, 2)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), res.Results[0].Amount)
+	require.Empty(t, res.Results[0].Error)
+	require.Equal(t, ids[1], res.Results[1].HTLCId)
+	require.NotEmpty(t, res.Results[1].Error)
+}
+
+func TestMsgClaimHTLCReturnsAmount(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+
+	preimage := []byte("secret")
+	id, err := k.CreateHTLC(ctx, sender, receiver, amount, hashLockOf(preimage), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+
+	res, err := msgServer.ClaimHTLC(sdk.WrapSDKContext(ctx), types.NewMsgClaimHTLC(receiver, id, preimage))
+	require.NoError(t, err)
+	require.Equal(t, amount, res.Amount)
+	require.True(t, res.Remaining.IsZero())
+
+	_, err = msgServer.ClaimHTLC(sdk.WrapSDKContext(ctx), types.NewMsgClaimHTLC(receiver, id+1, preimage))
+	require.ErrorIs(t, err, types.ErrHTLCNotFound)
+}
+
+func TestMsgClaimHTLCPartReturnsRemaining(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	msgServer := keeper.NewMsgServerImpl(k)
+
+	// 1000 stake in 3 parts doesn't split evenly
+	id, secrets, leaves := createMerkleHTLC(t, k, ctx, 1000, 3)
+
+	for i, expected := range []struct {
+		amount    int64
+		remaining int64
+	}{{333, 667}, {333, 334}, {334, 0}} {
+		msg := types.NewMsgClaimHTLC(receiver, id, secrets[i])
+		msg.Part = uint32(i)
+		msg.Proof = types.MerkleProof(leaves, uint32(i))
+
+		res, err := msgServer.ClaimHTLC(sdk.WrapSDKContext(ctx), msg)
+		require.NoError(t, err)
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", expected.amount)), res.Amount, "part %d", i)
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", expected.remaining)), res.Remaining, "part %d", i)
+	}
+}
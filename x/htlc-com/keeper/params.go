@@ -0,0 +1,65 @@
+package keeper
+
+import (
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetAuthority returns the address allowed to update the module params.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// GetParams returns the module params, or the defaults if none were set.
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get([]byte(types.KeyParams))
+	if bz == nil {
+		return types.DefaultParams()
+	}
+	var params types.Params
+	k.cdc.MustUnmarshal(bz, &params)
+	return params
+}
+
+// SetParams validates and stores the module params.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&params)
+	store.Set([]byte(types.KeyParams), bz)
+
+	return nil
+}
+
+// validateLock checks the coins and time lock of a new HTLC against the
+// module params.
+func (k Keeper) validateLock(ctx sdk.Context, amount sdk.Coins, timeLock int64) error {
+	params := k.GetParams(ctx)
+
+	for _, coin := range amount {
+		if !params.IsDenomAllowed(coin.Denom) {
+			return errorsmod.Wrapf(types.ErrDenomNotAllowed, "%s cannot be locked", coin.Denom)
+		}
+		if !params.MinAmount.IsNil() && coin.Amount.LT(params.MinAmount) {
+			return errorsmod.Wrapf(types.ErrAmountTooSmall, "%s is less than the minimum of %s", coin, params.MinAmount)
+		}
+	}
+
+	return k.validateTimeLock(ctx, params, timeLock)
+}
+
+// validateTimeLock checks a time lock is no further past the block time than
+// the params allow.
+func (k Keeper) validateTimeLock(ctx sdk.Context, params types.Params, timeLock int64) error {
+	if params.MaxTimeLockSeconds > 0 && timeLock-ctx.BlockTime().Unix() > params.MaxTimeLockSeconds {
+		return errorsmod.Wrapf(types.ErrInvalidTimeLock, "time lock is more than %d seconds after the block time", params.MaxTimeLockSeconds)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CreateSplitHTLC creates an HTLC like CreateHTLCWithClientId that the
+// receiver claims once, with the preimage of hashLock, but that pays each
+// split receiver its share. The shares must add up to amount.
+func (k Keeper) CreateSplitHTLC(ctx sdk.Context, sender, receiver sdk.AccAddress, amount sdk.Coins, hashLock []byte, timeLock int64, splits []types.Split, clientId string) (uint64, error) {
+	return k.createHTLC(ctx, sender, receiver, amount, hashLock, timeLock, 0, clientId, splits, types.HTLC{})
+}
+
+// paySplits sends each split receiver of a claimed HTLC its share, emitting
+// a split transfer event for each.
+func (k Keeper) paySplits(ctx sdk.Context, htlc types.HTLC) error {
+	for _, split := range htlc.Splits {
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, split.Receiver, split.Share); err != nil {
+			return err
+		}
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				EventTypeSplitTransfer,
+				sdk.NewAttribute(AttributeKeyHTLCID, fmt.Sprintf("%d", htlc.Id)),
+				sdk.NewAttribute(AttributeKeyReceiver, split.Receiver.String()),
+				sdk.NewAttribute(AttributeKeyAmount, split.Share.String()),
+			),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	maker       = sdk.AccAddress([]byte("maker_______________"))
+	feeReceiver = sdk.AccAddress([]byte("fee_receiver________"))
+)
+
+func TestClaimSplitHTLC(t *testing.T) {
+	k, ctx, bank := setupKeeper(t)
+	preimage := []byte("secret")
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	splits := []types.Split{
+		{Receiver: maker, Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 50))},
+		{Receiver: feeReceiver, Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 50))},
+	}
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	id, err := k.CreateSplitHTLC(ctx, sender, receiver, amount, hashLockOf(preimage), ctx.BlockTime().Add(time.Hour).Unix(), splits, "")
+	require.NoError(t, err)
+	require.Equal(t, "2", eventAttributes(ctx, keeper.EventTypeCreateHTLC)[keeper.AttributeKeySplits])
+
+	htlc, found := k.GetHTLC(ctx, id)
+	require.True(t, found)
+	require.Equal(t, splits, htlc.Splits)
+
+	// Split HTLCs pay their split receivers, not a payout address
+	err = k.ClaimHTLCTo(ctx, id, preimage, receiver, sender)
+	require.ErrorIs(t, err, types.ErrInvalidSplits)
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, k.ClaimHTLC(ctx, id, preimage, receiver))
+
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 50)), bank.balances[maker.String()])
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 50)), bank.balances[feeReceiver.String()])
+	require.True(t, bank.balances[receiver.String()].IsZero())
+	require.True(t, bank.modules[types.ModuleName].IsZero())
+
+	var transfers []map[string]string
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != keeper.EventTypeSplitTransfer {
+			continue
+		}
+		attrs := make(map[string]string)
+		for _, attr := range event.Attributes {
+			attrs[attr.Key] = attr.Value
+		}
+		transfers = append(transfers, attrs)
+	}
+	require.Len(t, transfers, 2)
+	for i, split := range splits {
+		require.Equal(t, split.Receiver.String(), transfers[i][keeper.AttributeKeyReceiver])
+		require.Equal(t, "50stake", transfers[i][keeper.AttributeKeyAmount])
+	}
+
+	claim := eventAttributes(ctx, keeper.EventTypeClaimHTLC)
+	require.Equal(t, "100stake", claim[keeper.AttributeKeyAmount])
+	require.NotContains(t, claim, keeper.AttributeKeyPayoutAddress)
+}
+
+func TestCreateSplitHTLCRejectsInvalidSplits(t *testing.T) {
+	k, ctx, bank := setupKeeper(t)
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+	balance := bank.balances[sender.String()]
+
+	for name, splits := range map[string][]types.Split{
+		"short of the amount": {
+			{Receiver: maker, Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 50))},
+			{Receiver: feeReceiver, Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 40))},
+		},
+		"over the amount": {
+			{Receiver: maker, Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 60))},
+			{Receiver: feeReceiver, Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 50))},
+		},
+		"other denom": {
+			{Receiver: maker, Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 50))},
+			{Receiver: feeReceiver, Share: sdk.NewCoins(sdk.NewInt64Coin("atom", 50))},
+		},
+		"duplicate receiver": {
+			{Receiver: maker, Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 50))},
+			{Receiver: maker, Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 50))},
+		},
+		"empty share": {
+			{Receiver: maker, Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 100))},
+			{Receiver: feeReceiver},
+		},
+	} {
+		_, err := k.CreateSplitHTLC(ctx, sender, receiver, amount, hashLockOf([]byte(name)), timeLock, splits, "")
+		require.ErrorIs(t, err, types.ErrInvalidSplits, name)
+	}
+
+	// Nothing was locked
+	require.Equal(t, balance, bank.balances[sender.String()])
+	require.Empty(t, k.GetAllHTLCs(ctx))
+}
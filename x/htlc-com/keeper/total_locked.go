@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+
+	sdkmath "cosmossdk.io/math"
+	"cosmossdk.io/store/prefix"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TotalLocked returns the coins locked in active HTLCs, i.e. neither claimed
+// nor refunded nor past their time lock, less the parts of Merkle HTLCs
+// already claimed. The running total kept up to date by SetHTLC still holds
+// the expired HTLCs until they are refunded, so they are subtracted from it:
+// only the due part of the time lock index and the HTLCs whose automatic
+// refund failed are walked.
+func (k Keeper) TotalLocked(ctx sdk.Context) sdk.Coins {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), []byte(types.KeyPrefixTotalLocked))
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+
+	total := sdk.NewCoins()
+	for ; iterator.Valid(); iterator.Next() {
+		var amount sdkmath.Int
+		if err := amount.Unmarshal(iterator.Value()); err != nil {
+			panic(err)
+		}
+		total = total.Add(sdk.NewCoin(string(iterator.Key()), amount))
+	}
+
+	for _, htlc := range k.GetHTLCsExpiringBefore(ctx, ctx.BlockTime()) {
+		total = total.Sub(htlc.Unclaimed()...)
+	}
+	for _, htlc := range k.failedRefunds(ctx, ctx.BlockTime()) {
+		total = total.Sub(htlc.Unclaimed()...)
+	}
+	return total
+}
+
+// failedRefunds returns the unsettled HTLCs whose automatic refund failed and
+// whose time lock is before t.
+func (k Keeper) failedRefunds(ctx sdk.Context, t time.Time) []types.HTLC {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), []byte(types.KeyPrefixHTLCRefundFailed))
+	iterator := store.Iterator(nil, nil)
+	defer iterator.Close()
+
+	var htlcs []types.HTLC
+	for ; iterator.Valid(); iterator.Next() {
+		htlc, found := k.GetHTLC(ctx, sdk.BigEndianToUint64(iterator.Value()))
+		if !found || htlc.Claimed || htlc.Refunded || !htlc.TimeLock.Before(t) {
+			continue
+		}
+		htlcs = append(htlcs, htlc)
+	}
+	return htlcs
+}
+
+// updateTotalLocked adds added to and removes removed from the running total
+// of locked coins. Denoms whose total drops to zero are deleted.
+func (k Keeper) updateTotalLocked(ctx sdk.Context, added, removed sdk.Coins) {
+	// IsEqual panics on differing denoms, so compare both ways instead
+	if added.IsAllGTE(removed) && removed.IsAllGTE(added) {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	denoms := make(map[string]struct{}, len(added)+len(removed))
+	for _, coin := range added.Add(removed...) {
+		denoms[coin.Denom] = struct{}{}
+	}
+
+	for denom := range denoms {
+		key := types.GetTotalLockedKey(denom)
+
+		total := sdkmath.ZeroInt()
+		if bz := store.Get(key); bz != nil {
+			if err := total.Unmarshal(bz); err != nil {
+				panic(err)
+			}
+		}
+		total = total.Add(added.AmountOf(denom)).Sub(removed.AmountOf(denom))
+
+		if total.IsZero() {
+			store.Delete(key)
+			continue
+		}
+		bz, err := total.Marshal()
+		if err != nil {
+			panic(err)
+		}
+		store.Set(key, bz)
+	}
+}
+
+// lockedAmount returns the coins an HTLC holds in the module account.
+func lockedAmount(htlc types.HTLC) sdk.Coins {
+	if htlc.Claimed || htlc.Refunded {
+		return sdk.NewCoins()
+	}
+	return htlc.Unclaimed()
+}
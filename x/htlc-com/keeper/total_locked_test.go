@@ -0,0 +1,111 @@
+package keeper_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/keeper"
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// requireTotalLocked checks the running total against the sum over the
+// HTLCs active at the block time and the query
+func requireTotalLocked(t *testing.T, k keeper.Keeper, ctx sdk.Context, expected sdk.Coins) {
+	t.Helper()
+
+	summed := sdk.NewCoins()
+	for _, htlc := range k.GetAllHTLCs(ctx) {
+		if htlc.StatusAt(ctx.BlockTime()) == types.HTLCStatusActive {
+			summed = summed.Add(htlc.Unclaimed()...)
+		}
+	}
+	require.Equal(t, expected, summed, "sum over active htlcs")
+	require.Equal(t, expected, k.TotalLocked(ctx), "running total")
+
+	res, err := keeper.NewQueryServerImpl(k).TotalLocked(ctx, &types.QueryTotalLockedRequest{})
+	require.NoError(t, err)
+	require.Equal(t, expected, res.Amount, "query")
+}
+
+func TestTotalLocked(t *testing.T) {
+	k, ctx, bankKeeper := setupKeeper(t)
+	bankKeeper.balances[sender.String()] = bankKeeper.balances[sender.String()].Add(sdk.NewInt64Coin("uatom", 500))
+	timeLock := ctx.BlockTime().Add(time.Hour).Unix()
+
+	requireTotalLocked(t, k, ctx, sdk.NewCoins())
+
+	claimedID, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), hashLockOf([]byte("claimed")), timeLock)
+	require.NoError(t, err)
+	refundedID, err := k.CreateHTLC(ctx, sender, receiver,
+		sdk.NewCoins(sdk.NewInt64Coin("stake", 50), sdk.NewInt64Coin("uatom", 500)), hashLockOf([]byte("refunded")), timeLock)
+	require.NoError(t, err)
+	merkleID, secrets, leaves := createMerkleHTLC(t, k, ctx, 1000, 4)
+	requireTotalLocked(t, k, ctx, sdk.NewCoins(sdk.NewInt64Coin("stake", 1150), sdk.NewInt64Coin("uatom", 500)))
+
+	// Extending a time lock leaves the total alone
+	require.NoError(t, k.UpdateHTLC(ctx, refundedID, sender, timeLock+60))
+	requireTotalLocked(t, k, ctx, sdk.NewCoins(sdk.NewInt64Coin("stake", 1150), sdk.NewInt64Coin("uatom", 500)))
+
+	require.NoError(t, k.ClaimHTLC(ctx, claimedID, []byte("claimed"), receiver))
+	requireTotalLocked(t, k, ctx, sdk.NewCoins(sdk.NewInt64Coin("stake", 1050), sdk.NewInt64Coin("uatom", 500)))
+
+	// Claiming up to the second part of four releases half the Merkle HTLC
+	require.NoError(t, k.ClaimHTLCPart(ctx, merkleID, 1, secrets[1], types.MerkleProof(leaves, 1), receiver, nil))
+	requireTotalLocked(t, k, ctx, sdk.NewCoins(sdk.NewInt64Coin("stake", 550), sdk.NewInt64Coin("uatom", 500)))
+
+	// HTLCs past their time lock no longer count, even before they are
+	// refunded
+	expired := ctx.WithBlockTime(ctx.BlockTime().Add(2 * time.Hour))
+	requireTotalLocked(t, k, expired, sdk.NewCoins())
+
+	// A denom leaves the total once nothing of it is locked
+	require.NoError(t, k.RefundHTLC(expired, refundedID, sender))
+	requireTotalLocked(t, k, ctx, sdk.NewCoins(sdk.NewInt64Coin("stake", 500)))
+
+	require.Equal(t, []uint64{merkleID}, k.RefundExpiredHTLCs(expired))
+	requireTotalLocked(t, k, ctx, sdk.NewCoins())
+}
+
+func TestTotalLockedFailedRefund(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+	hooks := &recordingHooks{}
+	k.SetHooks(hooks)
+
+	soonID, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		hashLockOf([]byte("soon")), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+	_, err = k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 40)),
+		hashLockOf([]byte("later")), ctx.BlockTime().Add(3*time.Hour).Unix())
+	require.NoError(t, err)
+
+	// An HTLC whose automatic refund failed leaves the time lock index but
+	// still doesn't count
+	hooks.err = errors.New("hook failed")
+	expired := ctx.WithBlockTime(ctx.BlockTime().Add(2 * time.Hour))
+	require.Empty(t, k.RefundExpiredHTLCs(expired))
+	require.Empty(t, k.GetHTLCsExpiringBefore(expired, expired.BlockTime()))
+	requireTotalLocked(t, k, expired, sdk.NewCoins(sdk.NewInt64Coin("stake", 40)))
+	requireTotalLocked(t, k, ctx, sdk.NewCoins(sdk.NewInt64Coin("stake", 140)))
+
+	// Its sender refunding it settles the running total
+	hooks.err = nil
+	require.NoError(t, k.RefundHTLC(expired, soonID, sender))
+	requireTotalLocked(t, k, expired, sdk.NewCoins(sdk.NewInt64Coin("stake", 40)))
+	requireTotalLocked(t, k, ctx, sdk.NewCoins(sdk.NewInt64Coin("stake", 40)))
+}
+
+func TestTotalLockedDeleteHTLC(t *testing.T) {
+	k, ctx, _ := setupKeeper(t)
+
+	id, err := k.CreateHTLC(ctx, sender, receiver, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		hashLockOf([]byte("secret")), ctx.BlockTime().Add(time.Hour).Unix())
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), k.TotalLocked(ctx))
+
+	k.DeleteHTLC(ctx, id)
+	require.True(t, k.TotalLocked(ctx).IsZero())
+}
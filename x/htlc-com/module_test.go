@@ -0,0 +1,64 @@
+package htlc_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	htlc "github.com/crypto-org-chain/cronos/v2/x/htlc"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/types/module"
+)
+
+// queryRoutes are the gateway routes of every htlc query, including the
+// filtered HTLC listings.
+var queryRoutes = []string{
+	"/cronos/htlc/v1/htlcs/1",
+	"/cronos/htlc/v1/htlcs",
+	"/cronos/htlc/v1/htlcs?status=active",
+	"/cronos/htlc/v1/htlcs?client_id=order-1",
+	"/cronos/htlc/v1/hashlocks/00",
+	"/cronos/htlc/v1/htlcs/1/price",
+	"/cronos/htlc/v1/htlcs/1/preimage",
+	"/cronos/htlc/v1/total_locked",
+	"/cronos/htlc/v1/expiring_htlcs?within=1h",
+	"/cronos/htlc/v1/htlc_actions/cosmos1receiver?ids=1",
+}
+
+// requireRoutesRegistered checks that mux serves every query route. Without
+// a node behind the client context the queries themselves fail, but an
+// unregistered route is not found.
+func requireRoutesRegistered(t *testing.T, mux *runtime.ServeMux) {
+	t.Helper()
+
+	for _, route := range queryRoutes {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, route, nil))
+		require.NotEqual(t, http.StatusNotFound, rec.Code, route)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cronos/htlc/v1/unknown", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRegisterGRPCGatewayRoutes(t *testing.T) {
+	var basic module.AppModuleBasic = htlc.NewAppModuleBasic()
+
+	mux := runtime.NewServeMux()
+	require.NotPanics(t, func() {
+		basic.RegisterGRPCGatewayRoutes(client.Context{}, mux)
+	})
+	requireRoutesRegistered(t, mux)
+
+	// the module basic manager registers every module's routes the same way
+	manager := module.NewBasicManager(htlc.NewAppModuleBasic())
+	mux = runtime.NewServeMux()
+	require.NotPanics(t, func() {
+		manager.RegisterGRPCGatewayRoutes(client.Context{}, mux)
+	})
+	requireRoutesRegistered(t, mux)
+}
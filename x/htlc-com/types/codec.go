@@ -14,7 +14,12 @@ var ModuleCdc = codec.NewLegacyAmino()
 func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgCreateHTLC{}, "htlc/CreateHTLC", nil)
 	cdc.RegisterConcrete(&MsgClaimHTLC{}, "htlc/ClaimHTLC", nil)
+	cdc.RegisterConcrete(&MsgRevealSecret{}, "htlc/RevealSecret", nil)
 	cdc.RegisterConcrete(&MsgRefundHTLC{}, "htlc/RefundHTLC", nil)
+	cdc.RegisterConcrete(&MsgUpdateHTLC{}, "htlc/UpdateHTLC", nil)
+	cdc.RegisterConcrete(&MsgUpdateParams{}, "htlc/UpdateParams", nil)
+	cdc.RegisterConcrete(&MsgBatchCreateHTLC{}, "htlc/BatchCreateHTLC", nil)
+	cdc.RegisterConcrete(&MsgBatchClaimHTLC{}, "htlc/BatchClaimHTLC", nil)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
@@ -22,7 +27,12 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		(*sdk.Msg)(nil),
 		&MsgCreateHTLC{},
 		&MsgClaimHTLC{},
+		&MsgRevealSecret{},
 		&MsgRefundHTLC{},
+		&MsgUpdateHTLC{},
+		&MsgUpdateParams{},
+		&MsgBatchCreateHTLC{},
+		&MsgBatchClaimHTLC{},
 	)
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
 }
@@ -15,4 +15,19 @@ var (
 	ErrHTLCNotExpired       = sdkerrors.Register(ModuleName, 8, "htlc not expired")
 	ErrUnauthorizedRefunder = sdkerrors.Register(ModuleName, 9, "unauthorized refunder")
 	ErrHTLCExpired          = sdkerrors.Register(ModuleName, 10, "htlc expired")
+	ErrUnauthorizedUpdater  = sdkerrors.Register(ModuleName, 11, "unauthorized updater")
+	ErrNotDutchAuction      = sdkerrors.Register(ModuleName, 12, "htlc is not a dutch auction")
+	ErrDenomNotAllowed      = sdkerrors.Register(ModuleName, 13, "denom not allowed")
+	ErrAmountTooSmall       = sdkerrors.Register(ModuleName, 14, "amount below minimum")
+	ErrInvalidParts         = sdkerrors.Register(ModuleName, 15, "invalid number of htlc parts")
+	ErrNotMerkleHTLC        = sdkerrors.Register(ModuleName, 16, "htlc is not claimed in parts")
+	ErrInvalidMerkleProof   = sdkerrors.Register(ModuleName, 17, "invalid merkle proof")
+	ErrPartClaimed          = sdkerrors.Register(ModuleName, 18, "htlc part already claimed")
+	ErrInvalidClientId      = sdkerrors.Register(ModuleName, 19, "invalid client id")
+	ErrPreimageNotRevealed  = sdkerrors.Register(ModuleName, 20, "preimage not revealed")
+	ErrInvalidAmount        = sdkerrors.Register(ModuleName, 21, "invalid htlc amount")
+	ErrInvalidSplits        = sdkerrors.Register(ModuleName, 22, "invalid htlc splits")
+	ErrSecretRevealed       = sdkerrors.Register(ModuleName, 23, "htlc secret already revealed")
+	ErrInvalidModuleAccount = sdkerrors.Register(ModuleName, 24, "invalid htlc module account")
+	ErrInvalidAuction       = sdkerrors.Register(ModuleName, 25, "invalid dutch auction prices")
 )
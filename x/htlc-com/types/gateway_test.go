@@ -0,0 +1,161 @@
+package types_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// recordingQueryClient records the request of every query it answers.
+type recordingQueryClient struct {
+	types.QueryClient
+	requests []interface{}
+}
+
+func (c *recordingQueryClient) HTLC(ctx context.Context, in *types.QueryGetHTLCRequest, opts ...grpc.CallOption) (*types.QueryGetHTLCResponse, error) {
+	c.requests = append(c.requests, in)
+	return &types.QueryGetHTLCResponse{HTLC: types.HTLC{Id: in.Id}}, nil
+}
+
+func (c *recordingQueryClient) HTLCs(ctx context.Context, in *types.QueryListHTLCsRequest, opts ...grpc.CallOption) (*types.QueryListHTLCsResponse, error) {
+	c.requests = append(c.requests, in)
+	return &types.QueryListHTLCsResponse{}, nil
+}
+
+func (c *recordingQueryClient) HTLCByHashLock(ctx context.Context, in *types.QueryHTLCByHashLockRequest, opts ...grpc.CallOption) (*types.QueryHTLCByHashLockResponse, error) {
+	c.requests = append(c.requests, in)
+	return &types.QueryHTLCByHashLockResponse{}, nil
+}
+
+func (c *recordingQueryClient) CurrentPrice(ctx context.Context, in *types.QueryCurrentPriceRequest, opts ...grpc.CallOption) (*types.QueryCurrentPriceResponse, error) {
+	c.requests = append(c.requests, in)
+	return &types.QueryCurrentPriceResponse{}, nil
+}
+
+func (c *recordingQueryClient) TotalLocked(ctx context.Context, in *types.QueryTotalLockedRequest, opts ...grpc.CallOption) (*types.QueryTotalLockedResponse, error) {
+	c.requests = append(c.requests, in)
+	return &types.QueryTotalLockedResponse{}, nil
+}
+
+func (c *recordingQueryClient) HTLCActions(ctx context.Context, in *types.QueryHTLCActionsRequest, opts ...grpc.CallOption) (*types.QueryHTLCActionsResponse, error) {
+	c.requests = append(c.requests, in)
+	return &types.QueryHTLCActionsResponse{}, nil
+}
+
+func (c *recordingQueryClient) Preimage(ctx context.Context, in *types.QueryPreimageRequest, opts ...grpc.CallOption) (*types.QueryPreimageResponse, error) {
+	c.requests = append(c.requests, in)
+	return &types.QueryPreimageResponse{}, nil
+}
+
+func (c *recordingQueryClient) ExpiringHTLCs(ctx context.Context, in *types.QueryExpiringHTLCsRequest, opts ...grpc.CallOption) (*types.QueryExpiringHTLCsResponse, error) {
+	c.requests = append(c.requests, in)
+	return &types.QueryExpiringHTLCsResponse{}, nil
+}
+
+func TestRegisterQueryHandlerClient(t *testing.T) {
+	client := &recordingQueryClient{}
+	mux := runtime.NewServeMux()
+	require.NoError(t, types.RegisterQueryHandlerClient(context.Background(), mux, client))
+
+	hashLock := make([]byte, 32)
+	hashLock[0] = 0xab
+
+	tests := []struct {
+		path    string
+		request interface{}
+	}{
+		{
+			path:    "/cronos/htlc/v1/htlcs/7",
+			request: &types.QueryGetHTLCRequest{Id: 7},
+		},
+		{
+			path:    "/cronos/htlc/v1/htlcs",
+			request: &types.QueryListHTLCsRequest{},
+		},
+		{
+			// the filtered listings
+			path:    "/cronos/htlc/v1/htlcs?status=expired&pagination.limit=5",
+			request: &types.QueryListHTLCsRequest{Status: types.HTLCStatusExpired, Pagination: &query.PageRequest{Limit: 5}},
+		},
+		{
+			path:    "/cronos/htlc/v1/htlcs?client_id=order-1",
+			request: &types.QueryListHTLCsRequest{ClientId: "order-1"},
+		},
+		{
+			path:    "/cronos/htlc/v1/hashlocks/ab" + strings.Repeat("00", 31),
+			request: &types.QueryHTLCByHashLockRequest{HashLock: hashLock},
+		},
+		{
+			path:    "/cronos/htlc/v1/htlcs/7/price",
+			request: &types.QueryCurrentPriceRequest{Id: 7},
+		},
+		{
+			path:    "/cronos/htlc/v1/htlcs/7/preimage",
+			request: &types.QueryPreimageRequest{Id: 7},
+		},
+		{
+			path:    "/cronos/htlc/v1/total_locked",
+			request: &types.QueryTotalLockedRequest{},
+		},
+		{
+			path:    "/cronos/htlc/v1/expiring_htlcs?within=1h",
+			request: &types.QueryExpiringHTLCsRequest{Within: time.Hour},
+		},
+		{
+			path:    "/cronos/htlc/v1/htlc_actions/cosmos1receiver?ids=1&ids=2",
+			request: &types.QueryHTLCActionsRequest{Address: "cosmos1receiver", Ids: []uint64{1, 2}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			client.requests = nil
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			require.Equal(t, []interface{}{tc.request}, client.requests)
+		})
+	}
+
+	// the response is the query's, encoded as JSON
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cronos/htlc/v1/htlcs/7", nil))
+	var res types.QueryGetHTLCResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Equal(t, uint64(7), res.HTLC.Id)
+}
+
+func TestRegisterQueryHandlerClientInvalidParams(t *testing.T) {
+	client := &recordingQueryClient{}
+	mux := runtime.NewServeMux()
+	require.NoError(t, types.RegisterQueryHandlerClient(context.Background(), mux, client))
+
+	for _, path := range []string{
+		"/cronos/htlc/v1/htlcs/seven",
+		"/cronos/htlc/v1/htlcs?status=pending",
+		"/cronos/htlc/v1/htlcs?pagination.limit=-1",
+		"/cronos/htlc/v1/hashlocks/not-hex",
+		"/cronos/htlc/v1/expiring_htlcs?within=soon",
+		"/cronos/htlc/v1/htlc_actions/cosmos1receiver?ids=one",
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code, path)
+	}
+	require.Empty(t, client.requests)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cronos/htlc/v1/unknown", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
 	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
 )
 
 func TestGenesisState_Validate(t *testing.T) {
@@ -34,9 +36,57 @@ func TestGenesisState_Validate(t *testing.T) {
 						Refunded: false,
 					},
 				},
+				NextId: 2,
 			},
 			valid: true,
 		},
+		{
+			desc: "next id not past imported htlcs",
+			genState: &types.GenesisState{
+				HTLCs:  []types.HTLC{{Id: 1}, {Id: 5}},
+				NextId: 5,
+			},
+			valid: false,
+		},
+		{
+			desc: "missing next id",
+			genState: &types.GenesisState{
+				HTLCs: []types.HTLC{{Id: 1}},
+			},
+			valid: false,
+		},
+		{
+			desc: "invalid params",
+			genState: &types.GenesisState{
+				NextId: 1,
+				Params: types.Params{AllowedDenoms: []string{"stake", "stake"}},
+			},
+			valid: false,
+		},
+		{
+			desc: "negative decay rate",
+			genState: &types.GenesisState{
+				HTLCs:  []types.HTLC{{Id: 1, InitialPrice: sdkmath.NewInt(1000), DecayRate: sdkmath.NewInt(-1)}},
+				NextId: 2,
+			},
+			valid: false,
+		},
+		{
+			desc: "initial price below min price",
+			genState: &types.GenesisState{
+				HTLCs:  []types.HTLC{{Id: 1, InitialPrice: sdkmath.NewInt(400), MinPrice: sdkmath.NewInt(1000)}},
+				NextId: 2,
+			},
+			valid: false,
+		},
+		{
+			desc: "duplicate htlc id",
+			genState: &types.GenesisState{
+				HTLCs:  []types.HTLC{{Id: 1}, {Id: 1}},
+				NextId: 2,
+			},
+			valid: false,
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			err := tc.genState.Validate()
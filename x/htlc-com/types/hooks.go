@@ -0,0 +1,66 @@
+package types
+
+import (
+	"context"
+)
+
+// HTLCHooks lets other modules, such as a DEX or an IBC middleware, react to
+// HTLCs being settled. The hooks run after the HTLC and its transfer are
+// written, within the same transaction, so an error aborts the message.
+type HTLCHooks interface {
+	// AfterHTLCCreated is called once an HTLC has locked its coins.
+	AfterHTLCCreated(ctx context.Context, htlc HTLC) error
+	// AfterHTLCClaimed is called with the revealed preimage once an HTLC is
+	// claimed. A Merkle HTLC calls it for every claim of its parts.
+	AfterHTLCClaimed(ctx context.Context, htlc HTLC, preimage []byte) error
+	// AfterHTLCRefunded is called once an HTLC is refunded to its sender,
+	// either on request or automatically after its time lock.
+	AfterHTLCRefunded(ctx context.Context, htlc HTLC) error
+}
+
+var _ HTLCHooks = MultiHTLCHooks{}
+
+// MultiHTLCHooks combines several HTLCHooks, calling them in order and
+// stopping at the first error. Nil entries are skipped.
+type MultiHTLCHooks []HTLCHooks
+
+// NewMultiHTLCHooks returns hooks calling each of hooks in order.
+func NewMultiHTLCHooks(hooks ...HTLCHooks) MultiHTLCHooks {
+	return hooks
+}
+
+func (h MultiHTLCHooks) AfterHTLCCreated(ctx context.Context, htlc HTLC) error {
+	for _, hook := range h {
+		if hook == nil {
+			continue
+		}
+		if err := hook.AfterHTLCCreated(ctx, htlc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h MultiHTLCHooks) AfterHTLCClaimed(ctx context.Context, htlc HTLC, preimage []byte) error {
+	for _, hook := range h {
+		if hook == nil {
+			continue
+		}
+		if err := hook.AfterHTLCClaimed(ctx, htlc, preimage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h MultiHTLCHooks) AfterHTLCRefunded(ctx context.Context, htlc HTLC) error {
+	for _, hook := range h {
+		if hook == nil {
+			continue
+		}
+		if err := hook.AfterHTLCRefunded(ctx, htlc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,5 +1,10 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+)
+
 const (
 	ModuleName = "htlc"
 	StoreKey   = ModuleName
@@ -11,4 +16,76 @@ const (
 
 	// KeyNextHTLCId is the key for storing the next HTLC ID
 	KeyNextHTLCId = "next_htlc_id"
+
+	// KeyPrefixHTLCByHashLock is the prefix for the hashlock -> HTLC ID index
+	KeyPrefixHTLCByHashLock = "htlc_by_hashlock/"
+
+	// KeyPrefixHTLCByTimeLock is the prefix for the time lock -> HTLC ID index
+	// of unsettled HTLCs
+	KeyPrefixHTLCByTimeLock = "htlc_by_timelock/"
+
+	// KeyParams is the key for storing the module params
+	KeyParams = "params"
+
+	// KeyPrefixHTLCByClientId is the prefix for the client id -> HTLC ID
+	// index of HTLCs created with a client id
+	KeyPrefixHTLCByClientId = "htlc_by_client_id/"
+
+	// KeyPrefixTotalLocked is the prefix for the running total of coins
+	// locked in active HTLCs, per denom
+	KeyPrefixTotalLocked = "total_locked/"
+
+	// KeyPrefixHTLCRefundFailed is the prefix for the index of expired HTLCs
+	// whose automatic refund failed, which are no longer in the time lock index
+	KeyPrefixHTLCRefundFailed = "htlc_refund_failed/"
 )
+
+// GetHTLCKey returns the store key of an HTLC
+func GetHTLCKey(id uint64) []byte {
+	return append([]byte(KeyPrefixHTLC), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetHTLCByHashLockPrefix returns the index prefix of all HTLCs sharing a hashlock
+func GetHTLCByHashLockPrefix(hashLock []byte) []byte {
+	return append([]byte(KeyPrefixHTLCByHashLock), hashLock...)
+}
+
+// GetHTLCByHashLockKey returns the index key of an HTLC under its hashlock.
+// The ID suffix keeps duplicate hashlocks distinct and ordered by creation.
+func GetHTLCByHashLockKey(hashLock []byte, id uint64) []byte {
+	return append(GetHTLCByHashLockPrefix(hashLock), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetHTLCByTimeLockPrefix returns the index prefix of all HTLCs expiring at
+// the given unix time. Big-endian encoding keeps the index ordered by time.
+func GetHTLCByTimeLockPrefix(timeLock int64) []byte {
+	return append([]byte(KeyPrefixHTLCByTimeLock), sdk.Uint64ToBigEndian(uint64(timeLock))...)
+}
+
+// GetHTLCByTimeLockKey returns the index key of an HTLC under its time lock.
+func GetHTLCByTimeLockKey(timeLock int64, id uint64) []byte {
+	return append(GetHTLCByTimeLockPrefix(timeLock), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetHTLCByClientIdPrefix returns the index prefix of all HTLCs created with
+// clientId. The client id is length-prefixed so that one id is never a prefix
+// of another.
+func GetHTLCByClientIdPrefix(clientId string) []byte {
+	return append([]byte(KeyPrefixHTLCByClientId), address.MustLengthPrefix([]byte(clientId))...)
+}
+
+// GetHTLCByClientIdKey returns the index key of an HTLC under its client id.
+func GetHTLCByClientIdKey(clientId string, id uint64) []byte {
+	return append(GetHTLCByClientIdPrefix(clientId), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetTotalLockedKey returns the store key of the total locked in a denom.
+func GetTotalLockedKey(denom string) []byte {
+	return append([]byte(KeyPrefixTotalLocked), denom...)
+}
+
+// GetHTLCRefundFailedKey returns the index key of an HTLC whose automatic
+// refund failed.
+func GetHTLCRefundFailedKey(id uint64) []byte {
+	return append([]byte(KeyPrefixHTLCRefundFailed), sdk.Uint64ToBigEndian(id)...)
+}
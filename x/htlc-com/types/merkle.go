@@ -0,0 +1,161 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxHTLCParts is the most parts a Merkle HTLC may be claimed in.
+const MaxHTLCParts = 1024
+
+// Merkle tree domain separators, so a leaf can never be passed off as an
+// inner node or the other way around.
+const (
+	merkleLeafPrefix byte = 0x00
+	merkleNodePrefix byte = 0x01
+)
+
+// A Merkle HTLC is split into Parts equal parts, each unlocked by its own
+// secret, and its hash lock is the root of a Merkle tree over the secrets.
+// Leaf i commits to the secret of part i:
+//
+//	leaf(i) = sha256(0x00 || BigEndian(uint32(i)) || sha256(secret_i))
+//	node    = sha256(0x01 || left || right)
+//
+// The leaves are in part order. A level with an odd number of nodes pairs its
+// last node with itself, so every proof holds exactly MerkleProofLength(Parts)
+// sibling hashes, listed from the leaf up. Revealing secret i fills the HTLC
+// up to and including part i, i.e. Amount * (i+1) / Parts in total.
+
+// MerkleLeaf returns the leaf committing to the secret of part index, given
+// the secret's SHA256 hash.
+func MerkleLeaf(index uint32, secretHash []byte) []byte {
+	var bz [4]byte
+	binary.BigEndian.PutUint32(bz[:], index)
+
+	h := sha256.New()
+	h.Write([]byte{merkleLeafPrefix})
+	h.Write(bz[:])
+	h.Write(secretHash)
+	return h.Sum(nil)
+}
+
+// MerkleRoot returns the root of the tree over leaves.
+func MerkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		return nil
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		level = merkleParents(level)
+	}
+	return level[0]
+}
+
+// MerkleProof returns the sibling hashes proving the leaf at index is part of
+// the tree over leaves, from the leaf up.
+func MerkleProof(leaves [][]byte, index uint32) [][]byte {
+	if int(index) >= len(leaves) {
+		return nil
+	}
+
+	var proof [][]byte
+	level := leaves
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if int(sibling) >= len(level) {
+			sibling = index
+		}
+		proof = append(proof, level[sibling])
+		level = merkleParents(level)
+		index /= 2
+	}
+	return proof
+}
+
+// MerkleProofLength returns how many sibling hashes prove a leaf of a tree
+// over parts leaves.
+func MerkleProofLength(parts uint32) int {
+	length := 0
+	for width := parts; width > 1; width = (width + 1) / 2 {
+		length++
+	}
+	return length
+}
+
+// VerifyMerkleProof reports whether leaf is leaf index of the tree over parts
+// leaves with the given root. The proof must have exactly the length of such
+// a tree, so a leaf index is bound to one position.
+func VerifyMerkleProof(root, leaf []byte, index, parts uint32, proof [][]byte) bool {
+	if index >= parts || len(proof) != MerkleProofLength(parts) {
+		return false
+	}
+
+	hash := leaf
+	for _, sibling := range proof {
+		if len(sibling) != sha256.Size {
+			return false
+		}
+		if index%2 == 0 {
+			hash = merkleNode(hash, sibling)
+		} else {
+			hash = merkleNode(sibling, hash)
+		}
+		index /= 2
+	}
+	return bytes.Equal(hash, root)
+}
+
+// merkleParents hashes a level of the tree into the level above it.
+func merkleParents(level [][]byte) [][]byte {
+	parents := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		right := level[i]
+		if i+1 < len(level) {
+			right = level[i+1]
+		}
+		parents = append(parents, merkleNode(level[i], right))
+	}
+	return parents
+}
+
+func merkleNode(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{merkleNodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// IsMerkle reports whether the HTLC is claimed in parts against a Merkle root.
+func (h HTLC) IsMerkle() bool {
+	return h.Parts > 0
+}
+
+// PartsAmount returns the coins released once the first parts of the HTLC
+// are claimed. Each coin is split evenly, rounding down, so the last part
+// also releases whatever the rounding held back.
+func (h HTLC) PartsAmount(parts uint32) sdk.Coins {
+	if !h.IsMerkle() || parts == 0 {
+		return sdk.NewCoins()
+	}
+	if parts >= h.Parts {
+		return h.Amount
+	}
+
+	released := sdk.NewCoins()
+	for _, coin := range h.Amount {
+		amount := coin.Amount.MulRaw(int64(parts)).QuoRaw(int64(h.Parts))
+		released = released.Add(sdk.NewCoin(coin.Denom, amount))
+	}
+	return released
+}
+
+// Unclaimed returns the coins an unsettled HTLC still locks.
+func (h HTLC) Unclaimed() sdk.Coins {
+	return h.Amount.Sub(h.PartsAmount(h.ClaimedParts)...)
+}
@@ -0,0 +1,87 @@
+package types_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func merkleLeaves(parts int) [][]byte {
+	leaves := make([][]byte, parts)
+	for i := range leaves {
+		secretHash := sha256.Sum256([]byte(fmt.Sprintf("secret-%d", i)))
+		leaves[i] = types.MerkleLeaf(uint32(i), secretHash[:])
+	}
+	return leaves
+}
+
+func TestMerkleProof(t *testing.T) {
+	for _, parts := range []int{2, 3, 4, 5, 7, 8, 13} {
+		t.Run(fmt.Sprintf("%d parts", parts), func(t *testing.T) {
+			leaves := merkleLeaves(parts)
+			root := types.MerkleRoot(leaves)
+
+			for i, leaf := range leaves {
+				proof := types.MerkleProof(leaves, uint32(i))
+				require.Len(t, proof, types.MerkleProofLength(uint32(parts)))
+				require.True(t, types.VerifyMerkleProof(root, leaf, uint32(i), uint32(parts), proof), "leaf %d", i)
+
+				// The proof binds the leaf to its index
+				other := (i + 1) % parts
+				require.False(t, types.VerifyMerkleProof(root, leaf, uint32(other), uint32(parts), proof), "leaf %d as %d", i, other)
+				require.False(t, types.VerifyMerkleProof(root, leaves[other], uint32(i), uint32(parts), proof), "leaf %d at %d", other, i)
+			}
+		})
+	}
+}
+
+func TestVerifyMerkleProofRejectsMalformedProofs(t *testing.T) {
+	leaves := merkleLeaves(4)
+	root := types.MerkleRoot(leaves)
+	proof := types.MerkleProof(leaves, 1)
+
+	// Out of range index
+	require.False(t, types.VerifyMerkleProof(root, leaves[1], 4, 4, proof))
+
+	// Too short or too long, e.g. an inner node passed off as a leaf
+	require.False(t, types.VerifyMerkleProof(root, leaves[1], 1, 4, proof[:1]))
+	require.False(t, types.VerifyMerkleProof(root, leaves[1], 1, 4, append(proof, proof[0])))
+
+	// The same tree claimed to have another number of parts
+	require.False(t, types.VerifyMerkleProof(root, leaves[1], 1, 8, proof))
+
+	// A hash that is not 32 bytes
+	require.False(t, types.VerifyMerkleProof(root, leaves[1], 1, 4, [][]byte{proof[0], proof[1][:31]}))
+}
+
+func TestMerkleProofLength(t *testing.T) {
+	for parts, length := range map[uint32]int{0: 0, 1: 0, 2: 1, 3: 2, 4: 2, 5: 3, 8: 3, 9: 4, types.MaxHTLCParts: 10} {
+		require.Equal(t, length, types.MerkleProofLength(parts), "%d parts", parts)
+	}
+}
+
+func TestHTLCPartsAmount(t *testing.T) {
+	htlc := types.HTLC{
+		Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("atom", 10)),
+		Parts:  3,
+	}
+
+	require.True(t, htlc.PartsAmount(0).IsZero())
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 33), sdk.NewInt64Coin("atom", 3)), htlc.PartsAmount(1))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 66), sdk.NewInt64Coin("atom", 6)), htlc.PartsAmount(2))
+
+	// The last part releases what the rounding held back
+	require.Equal(t, htlc.Amount, htlc.PartsAmount(3))
+
+	htlc.ClaimedParts = 1
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 67), sdk.NewInt64Coin("atom", 7)), htlc.Unclaimed())
+
+	// An HTLC claimed in full has no parts
+	require.True(t, types.HTLC{Amount: htlc.Amount}.PartsAmount(1).IsZero())
+	require.Equal(t, htlc.Amount, types.HTLC{Amount: htlc.Amount}.Unclaimed())
+}
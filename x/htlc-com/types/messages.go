@@ -3,20 +3,38 @@ package types
 import (
 	"fmt"
 
+	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"google.golang.org/protobuf/proto"
 )
 
 const (
-	TypeMsgCreateHTLC = "create_htlc"
-	TypeMsgClaimHTLC  = "claim_htlc"
-	TypeMsgRefundHTLC = "refund_htlc"
+	TypeMsgCreateHTLC   = "create_htlc"
+	TypeMsgClaimHTLC    = "claim_htlc"
+	TypeMsgRevealSecret = "reveal_secret"
+	TypeMsgRefundHTLC   = "refund_htlc"
+	TypeMsgUpdateHTLC   = "update_htlc"
+	TypeMsgUpdateParams = "update_params"
+
+	TypeMsgBatchCreateHTLC = "batch_create_htlc"
+	TypeMsgBatchClaimHTLC  = "batch_claim_htlc"
 )
 
+// MaxBatchCreateHTLCs is the most HTLCs a single MsgBatchCreateHTLC may create.
+const MaxBatchCreateHTLCs = 100
+
+// MaxBatchClaimHTLCs is the most HTLCs a single MsgBatchClaimHTLC may claim.
+const MaxBatchClaimHTLCs = 100
+
 var (
 	_ sdk.Msg = &MsgCreateHTLC{}
 	_ sdk.Msg = &MsgClaimHTLC{}
+	_ sdk.Msg = &MsgRevealSecret{}
 	_ sdk.Msg = &MsgRefundHTLC{}
+	_ sdk.Msg = &MsgUpdateHTLC{}
+	_ sdk.Msg = &MsgUpdateParams{}
+	_ sdk.Msg = &MsgBatchCreateHTLC{}
+	_ sdk.Msg = &MsgBatchClaimHTLC{}
 )
 
 type MsgCreateHTLC struct {
@@ -25,6 +43,27 @@ type MsgCreateHTLC struct {
 	Amount   sdk.Coins      `json:"amount" yaml:"amount"`
 	HashLock []byte         `json:"hash_lock" yaml:"hash_lock"`
 	TimeLock int64          `json:"time_lock" yaml:"time_lock"` // unix timestamp
+	// Parts optionally splits the HTLC into parts claimed with their own
+	// secrets, HashLock then being the Merkle root over the secrets.
+	Parts uint32 `json:"parts,omitempty" yaml:"parts,omitempty"`
+	// ClientId optionally tags the HTLC with the sender's own order id.
+	ClientId string `json:"client_id,omitempty" yaml:"client_id,omitempty"`
+	// Splits optionally divides Amount among several receivers on claim.
+	Splits []Split `json:"splits,omitempty" yaml:"splits,omitempty"`
+	// InitialPrice, MinPrice and DecayRate optionally price the HTLC by a
+	// Dutch auction starting at the time of the block it is created in.
+	InitialPrice sdkmath.Int `json:"initial_price,omitempty" yaml:"initial_price,omitempty"`
+	MinPrice     sdkmath.Int `json:"min_price,omitempty" yaml:"min_price,omitempty"`
+	DecayRate    sdkmath.Int `json:"decay_rate,omitempty" yaml:"decay_rate,omitempty"`
+}
+
+// Auction returns the HTLC Dutch auction prices set on the message.
+func (msg *MsgCreateHTLC) Auction() HTLC {
+	return HTLC{
+		InitialPrice: msg.InitialPrice,
+		MinPrice:     msg.MinPrice,
+		DecayRate:    msg.DecayRate,
+	}
 }
 
 func NewMsgCreateHTLC(sender, receiver sdk.AccAddress, amount sdk.Coins, hashLock []byte, timeLock int64) *MsgCreateHTLC {
@@ -68,13 +107,32 @@ func (msg *MsgCreateHTLC) ValidateBasic() error {
 	if msg.TimeLock <= 0 {
 		return ErrInvalidTimeLock
 	}
-	return nil
+	if msg.Parts == 1 || msg.Parts > MaxHTLCParts {
+		return sdkerrors.Wrapf(ErrInvalidParts, "parts must be 0 or between 2 and %d", MaxHTLCParts)
+	}
+	if len(msg.Splits) > 0 && msg.Parts > 0 {
+		return sdkerrors.Wrap(ErrInvalidSplits, "an htlc claimed in parts cannot be split")
+	}
+	if err := ValidateSplits(msg.Amount, msg.Splits); err != nil {
+		return err
+	}
+	if err := msg.Auction().ValidateAuction(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidAuction, err.Error())
+	}
+	return ValidateClientId(msg.ClientId)
 }
 
 type MsgClaimHTLC struct {
 	Claimer  sdk.AccAddress `json:"claimer" yaml:"claimer"`
 	HTLCId   uint64         `json:"htlc_id" yaml:"htlc_id"`
 	Preimage []byte         `json:"preimage" yaml:"preimage"`
+	// PayoutAddress optionally receives the claimed coins instead of the
+	// HTLC receiver.
+	PayoutAddress sdk.AccAddress `json:"payout_address,omitempty" yaml:"payout_address,omitempty"`
+	// Part and Proof claim a Merkle HTLC up to and including Part, Proof
+	// being the sibling hashes from the part's leaf up to the root.
+	Part  uint32   `json:"part,omitempty" yaml:"part,omitempty"`
+	Proof [][]byte `json:"proof,omitempty" yaml:"proof,omitempty"`
 }
 
 func NewMsgClaimHTLC(claimer sdk.AccAddress, htlcId uint64, preimage []byte) *MsgClaimHTLC {
@@ -107,6 +165,69 @@ func (msg *MsgClaimHTLC) ValidateBasic() error {
 	if len(msg.Preimage) == 0 {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "preimage cannot be empty")
 	}
+	if !msg.PayoutAddress.Empty() {
+		if err := sdk.VerifyAddressFormat(msg.PayoutAddress); err != nil {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid payout address: %s", err)
+		}
+	}
+	if msg.Part > 0 && len(msg.Proof) == 0 {
+		return sdkerrors.Wrap(ErrInvalidMerkleProof, "a part can only be claimed with a proof")
+	}
+	if len(msg.Proof) > MerkleProofLength(MaxHTLCParts) {
+		return sdkerrors.Wrapf(ErrInvalidMerkleProof, "proof has more than %d hashes", MerkleProofLength(MaxHTLCParts))
+	}
+	for _, hash := range msg.Proof {
+		if len(hash) != 32 {
+			return sdkerrors.Wrap(ErrInvalidMerkleProof, "proof hashes must be 32 bytes")
+		}
+	}
+	return nil
+}
+
+// IsPartClaim reports whether the message claims a part of a Merkle HTLC.
+func (msg *MsgClaimHTLC) IsPartClaim() bool {
+	return len(msg.Proof) > 0
+}
+
+// MsgRevealSecret publishes the secret of an HTLC on-chain without claiming
+// it, so the counterparty can complete its own leg of the swap. Anyone
+// holding the secret may reveal it.
+type MsgRevealSecret struct {
+	Sender sdk.AccAddress `json:"sender" yaml:"sender"`
+	HTLCId uint64         `json:"htlc_id" yaml:"htlc_id"`
+	Secret []byte         `json:"secret" yaml:"secret"`
+}
+
+func NewMsgRevealSecret(sender sdk.AccAddress, htlcId uint64, secret []byte) *MsgRevealSecret {
+	return &MsgRevealSecret{
+		Sender: sender,
+		HTLCId: htlcId,
+		Secret: secret,
+	}
+}
+
+func (msg *MsgRevealSecret) Route() string { return ModuleName }
+func (msg *MsgRevealSecret) Type() string  { return TypeMsgRevealSecret }
+func (msg *MsgRevealSecret) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+func (msg *MsgRevealSecret) GetSignBytes() []byte {
+	bz, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+func (msg *MsgRevealSecret) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender cannot be empty")
+	}
+	if msg.HTLCId == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "htlc id cannot be zero")
+	}
+	if len(msg.Secret) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "secret cannot be empty")
+	}
 	return nil
 }
 
@@ -143,3 +264,243 @@ func (msg *MsgRefundHTLC) ValidateBasic() error {
 	}
 	return nil
 }
+
+type MsgUpdateHTLC struct {
+	Sender      sdk.AccAddress `json:"sender" yaml:"sender"`
+	HTLCId      uint64         `json:"htlc_id" yaml:"htlc_id"`
+	NewTimeLock int64          `json:"new_time_lock" yaml:"new_time_lock"` // unix timestamp
+}
+
+func NewMsgUpdateHTLC(sender sdk.AccAddress, htlcId uint64, newTimeLock int64) *MsgUpdateHTLC {
+	return &MsgUpdateHTLC{
+		Sender:      sender,
+		HTLCId:      htlcId,
+		NewTimeLock: newTimeLock,
+	}
+}
+
+func (msg *MsgUpdateHTLC) Route() string { return ModuleName }
+func (msg *MsgUpdateHTLC) Type() string  { return TypeMsgUpdateHTLC }
+func (msg *MsgUpdateHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+func (msg *MsgUpdateHTLC) GetSignBytes() []byte {
+	bz, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic only checks the new time lock is set; the keeper checks it
+// extends the current time lock and lies after the block time
+func (msg *MsgUpdateHTLC) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender cannot be empty")
+	}
+	if msg.HTLCId == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "htlc id cannot be zero")
+	}
+	if msg.NewTimeLock <= 0 {
+		return ErrInvalidTimeLock
+	}
+	return nil
+}
+
+// MsgUpdateParams replaces the module params. It must be signed by the
+// module authority, normally the gov module account.
+type MsgUpdateParams struct {
+	Authority string `json:"authority" yaml:"authority"`
+	Params    Params `json:"params" yaml:"params"`
+}
+
+func NewMsgUpdateParams(authority string, params Params) *MsgUpdateParams {
+	return &MsgUpdateParams{
+		Authority: authority,
+		Params:    params,
+	}
+}
+
+func (msg *MsgUpdateParams) Route() string { return ModuleName }
+func (msg *MsgUpdateParams) Type() string  { return TypeMsgUpdateParams }
+func (msg *MsgUpdateParams) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{authority}
+}
+func (msg *MsgUpdateParams) GetSignBytes() []byte {
+	bz, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic checks the authority address and params; the msg server
+// checks the authority is the module's
+func (msg *MsgUpdateParams) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid authority address: %s", err)
+	}
+	if err := msg.Params.Validate(); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+	}
+	return nil
+}
+
+// HTLCParams describes one HTLC created by a MsgBatchCreateHTLC.
+type HTLCParams struct {
+	Receiver sdk.AccAddress `json:"receiver" yaml:"receiver"`
+	Amount   sdk.Coins      `json:"amount" yaml:"amount"`
+	HashLock []byte         `json:"hash_lock" yaml:"hash_lock"`
+	TimeLock int64          `json:"time_lock" yaml:"time_lock"` // unix timestamp
+}
+
+// Validate runs the stateless checks of MsgCreateHTLC on a single leg.
+func (p HTLCParams) Validate() error {
+	if p.Receiver.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "receiver cannot be empty")
+	}
+	if !p.Amount.IsAllPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount must be positive")
+	}
+	if len(p.HashLock) != 32 {
+		return ErrInvalidHashLock
+	}
+	if p.TimeLock <= 0 {
+		return ErrInvalidTimeLock
+	}
+	return nil
+}
+
+// MsgBatchCreateHTLC creates several HTLCs from the same sender atomically:
+// either every HTLC is created or none is.
+type MsgBatchCreateHTLC struct {
+	Sender sdk.AccAddress `json:"sender" yaml:"sender"`
+	HTLCs  []HTLCParams   `json:"htlcs" yaml:"htlcs"`
+}
+
+func NewMsgBatchCreateHTLC(sender sdk.AccAddress, htlcs []HTLCParams) *MsgBatchCreateHTLC {
+	return &MsgBatchCreateHTLC{
+		Sender: sender,
+		HTLCs:  htlcs,
+	}
+}
+
+func (msg *MsgBatchCreateHTLC) Route() string { return ModuleName }
+func (msg *MsgBatchCreateHTLC) Type() string  { return TypeMsgBatchCreateHTLC }
+func (msg *MsgBatchCreateHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+func (msg *MsgBatchCreateHTLC) GetSignBytes() []byte {
+	bz, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic checks the sender, the batch size and every leg; the keeper
+// checks the time locks and params against the block.
+func (msg *MsgBatchCreateHTLC) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender cannot be empty")
+	}
+	if len(msg.HTLCs) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "htlcs cannot be empty")
+	}
+	if len(msg.HTLCs) > MaxBatchCreateHTLCs {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "at most %d htlcs per batch, got %d", MaxBatchCreateHTLCs, len(msg.HTLCs))
+	}
+	for i, htlc := range msg.HTLCs {
+		if err := htlc.Validate(); err != nil {
+			return sdkerrors.Wrapf(err, "htlc %d", i)
+		}
+	}
+	return nil
+}
+
+// HTLCClaim describes one HTLC claimed by a MsgBatchClaimHTLC.
+type HTLCClaim struct {
+	HTLCId   uint64 `json:"htlc_id" yaml:"htlc_id"`
+	Preimage []byte `json:"preimage" yaml:"preimage"`
+}
+
+// Validate runs the stateless checks of MsgClaimHTLC on a single claim.
+func (c HTLCClaim) Validate() error {
+	if c.HTLCId == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "htlc id cannot be zero")
+	}
+	if len(c.Preimage) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "preimage cannot be empty")
+	}
+	return nil
+}
+
+// ClaimResult reports the outcome of one claim of a MsgBatchClaimHTLC: the
+// coins it released, or why it failed when the batch allows partial success.
+type ClaimResult struct {
+	HTLCId uint64    `json:"htlc_id" yaml:"htlc_id"`
+	Amount sdk.Coins `json:"amount,omitempty" yaml:"amount,omitempty"`
+	Error  string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// MsgBatchClaimHTLC claims several HTLCs of the same receiver with one
+// message. By default the batch is atomic: either every HTLC is claimed or
+// none is. With AllowPartial set, failing claims are reported in the
+// response and the others are still claimed. Merkle HTLCs are claimed part
+// by part with MsgClaimHTLC instead.
+type MsgBatchClaimHTLC struct {
+	Claimer      sdk.AccAddress `json:"claimer" yaml:"claimer"`
+	Claims       []HTLCClaim    `json:"claims" yaml:"claims"`
+	AllowPartial bool           `json:"allow_partial,omitempty" yaml:"allow_partial,omitempty"`
+}
+
+func NewMsgBatchClaimHTLC(claimer sdk.AccAddress, claims []HTLCClaim, allowPartial bool) *MsgBatchClaimHTLC {
+	return &MsgBatchClaimHTLC{
+		Claimer:      claimer,
+		Claims:       claims,
+		AllowPartial: allowPartial,
+	}
+}
+
+func (msg *MsgBatchClaimHTLC) Route() string { return ModuleName }
+func (msg *MsgBatchClaimHTLC) Type() string  { return TypeMsgBatchClaimHTLC }
+func (msg *MsgBatchClaimHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Claimer}
+}
+func (msg *MsgBatchClaimHTLC) GetSignBytes() []byte {
+	bz, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic checks the claimer, the batch size and every claim, and that
+// no HTLC is claimed twice; the keeper checks the preimages against the
+// HTLCs.
+func (msg *MsgBatchClaimHTLC) ValidateBasic() error {
+	if msg.Claimer.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "claimer cannot be empty")
+	}
+	if len(msg.Claims) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "claims cannot be empty")
+	}
+	if len(msg.Claims) > MaxBatchClaimHTLCs {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "at most %d claims per batch, got %d", MaxBatchClaimHTLCs, len(msg.Claims))
+	}
+	seen := make(map[uint64]bool, len(msg.Claims))
+	for i, claim := range msg.Claims {
+		if err := claim.Validate(); err != nil {
+			return sdkerrors.Wrapf(err, "claim %d", i)
+		}
+		if seen[claim.HTLCId] {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "claim %d: htlc %d is claimed twice", i, claim.HTLCId)
+		}
+		seen[claim.HTLCId] = true
+	}
+	return nil
+}
@@ -1,11 +1,17 @@
 package types_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
 	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 func TestMsgCreateHTLC_ValidateBasic(t *testing.T) {
@@ -69,6 +75,152 @@ func TestMsgCreateHTLC_ValidateBasic(t *testing.T) {
 			},
 			err: types.ErrInvalidTimeLock,
 		},
+		{
+			name: "single part",
+			msg: types.MsgCreateHTLC{
+				Sender:   []byte("sender"),
+				Receiver: []byte("receiver"),
+				Amount:   nil,
+				HashLock: []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock: time.Now().Add(time.Hour).Unix(),
+				Parts:    1,
+			},
+			err: types.ErrInvalidParts,
+		},
+		{
+			name: "too many parts",
+			msg: types.MsgCreateHTLC{
+				Sender:   []byte("sender"),
+				Receiver: []byte("receiver"),
+				Amount:   nil,
+				HashLock: []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock: time.Now().Add(time.Hour).Unix(),
+				Parts:    types.MaxHTLCParts + 1,
+			},
+			err: types.ErrInvalidParts,
+		},
+		{
+			name: "client id too long",
+			msg: types.MsgCreateHTLC{
+				Sender:   []byte("sender"),
+				Receiver: []byte("receiver"),
+				Amount:   nil,
+				HashLock: []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock: time.Now().Add(time.Hour).Unix(),
+				ClientId: strings.Repeat("x", types.MaxClientIdLength+1),
+			},
+			err: types.ErrInvalidClientId,
+		},
+		{
+			name: "valid client id",
+			msg: types.MsgCreateHTLC{
+				Sender:   []byte("sender"),
+				Receiver: []byte("receiver"),
+				Amount:   nil,
+				HashLock: []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock: time.Now().Add(time.Hour).Unix(),
+				ClientId: strings.Repeat("x", types.MaxClientIdLength),
+			},
+			err: nil,
+		},
+		{
+			name: "splits not adding up to amount",
+			msg: types.MsgCreateHTLC{
+				Sender:   []byte("sender"),
+				Receiver: []byte("receiver"),
+				Amount:   sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+				HashLock: []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock: time.Now().Add(time.Hour).Unix(),
+				Splits: []types.Split{
+					{Receiver: []byte("receiver-a"), Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 60))},
+					{Receiver: []byte("receiver-b"), Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 30))},
+				},
+			},
+			err: types.ErrInvalidSplits,
+		},
+		{
+			name: "split merkle htlc",
+			msg: types.MsgCreateHTLC{
+				Sender:   []byte("sender"),
+				Receiver: []byte("receiver"),
+				Amount:   sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+				HashLock: []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock: time.Now().Add(time.Hour).Unix(),
+				Parts:    4,
+				Splits: []types.Split{
+					{Receiver: []byte("receiver-a"), Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 100))},
+				},
+			},
+			err: types.ErrInvalidSplits,
+		},
+		{
+			name: "valid split htlc",
+			msg: types.MsgCreateHTLC{
+				Sender:   []byte("sender"),
+				Receiver: []byte("receiver"),
+				Amount:   sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+				HashLock: []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock: time.Now().Add(time.Hour).Unix(),
+				Splits: []types.Split{
+					{Receiver: []byte("receiver-a"), Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 60))},
+					{Receiver: []byte("receiver-b"), Share: sdk.NewCoins(sdk.NewInt64Coin("stake", 40))},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "valid merkle htlc",
+			msg: types.MsgCreateHTLC{
+				Sender:   []byte("sender"),
+				Receiver: []byte("receiver"),
+				Amount:   nil,
+				HashLock: []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock: time.Now().Add(time.Hour).Unix(),
+				Parts:    4,
+			},
+			err: nil,
+		},
+		{
+			name: "auction starting below its min price",
+			msg: types.MsgCreateHTLC{
+				Sender:       []byte("sender"),
+				Receiver:     []byte("receiver"),
+				Amount:       sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+				HashLock:     []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock:     time.Now().Add(time.Hour).Unix(),
+				InitialPrice: sdkmath.NewInt(400),
+				MinPrice:     sdkmath.NewInt(1000),
+				DecayRate:    sdkmath.NewInt(10),
+			},
+			err: types.ErrInvalidAuction,
+		},
+		{
+			name: "auction with negative decay rate",
+			msg: types.MsgCreateHTLC{
+				Sender:       []byte("sender"),
+				Receiver:     []byte("receiver"),
+				Amount:       sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+				HashLock:     []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock:     time.Now().Add(time.Hour).Unix(),
+				InitialPrice: sdkmath.NewInt(1000),
+				DecayRate:    sdkmath.NewInt(-10),
+			},
+			err: types.ErrInvalidAuction,
+		},
+		{
+			name: "valid auction",
+			msg: types.MsgCreateHTLC{
+				Sender:       []byte("sender"),
+				Receiver:     []byte("receiver"),
+				Amount:       sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+				HashLock:     []byte("hashlockhashlockhashlockhashlock"),
+				TimeLock:     time.Now().Add(time.Hour).Unix(),
+				InitialPrice: sdkmath.NewInt(1000),
+				MinPrice:     sdkmath.NewInt(400),
+				DecayRate:    sdkmath.NewInt(10),
+			},
+			err: nil,
+		},
 		{
 			name: "valid message",
 			msg: types.MsgCreateHTLC{
@@ -127,6 +279,16 @@ func TestMsgClaimHTLC_ValidateBasic(t *testing.T) {
 			},
 			err: types.ErrInvalidPreimage,
 		},
+		{
+			name: "invalid payout address",
+			msg: types.MsgClaimHTLC{
+				Claimer:       []byte("claimer"),
+				HTLCId:        1,
+				Preimage:      []byte("preimage"),
+				PayoutAddress: make([]byte, 256),
+			},
+			err: sdkerrors.ErrInvalidAddress,
+		},
 		{
 			name: "valid message",
 			msg: types.MsgClaimHTLC{
@@ -136,6 +298,87 @@ func TestMsgClaimHTLC_ValidateBasic(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "part without proof",
+			msg: types.MsgClaimHTLC{
+				Claimer:  []byte("claimer"),
+				HTLCId:   1,
+				Preimage: []byte("preimage"),
+				Part:     2,
+			},
+			err: types.ErrInvalidMerkleProof,
+		},
+		{
+			name: "short proof hash",
+			msg: types.MsgClaimHTLC{
+				Claimer:  []byte("claimer"),
+				HTLCId:   1,
+				Preimage: []byte("preimage"),
+				Part:     1,
+				Proof:    [][]byte{[]byte("short")},
+			},
+			err: types.ErrInvalidMerkleProof,
+		},
+		{
+			name: "valid part claim",
+			msg: types.MsgClaimHTLC{
+				Claimer:  []byte("claimer"),
+				HTLCId:   1,
+				Preimage: []byte("preimage"),
+				Part:     1,
+				Proof:    [][]byte{[]byte("hashlockhashlockhashlockhashlock")},
+			},
+			err: nil,
+		},
+		{
+			name: "valid message with payout address",
+			msg: types.MsgClaimHTLC{
+				Claimer:       []byte("claimer"),
+				HTLCId:        1,
+				Preimage:      []byte("preimage"),
+				PayoutAddress: []byte("payout"),
+			},
+			err: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgRevealSecret_ValidateBasic(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  types.MsgRevealSecret
+		err  error
+	}{
+		{
+			name: "empty sender",
+			msg:  types.MsgRevealSecret{HTLCId: 1, Secret: []byte("secret")},
+			err:  sdkerrors.ErrInvalidAddress,
+		},
+		{
+			name: "zero htlc id",
+			msg:  types.MsgRevealSecret{Sender: []byte("sender"), Secret: []byte("secret")},
+			err:  sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "empty secret",
+			msg:  types.MsgRevealSecret{Sender: []byte("sender"), HTLCId: 1},
+			err:  sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "valid message",
+			msg:  types.MsgRevealSecret{Sender: []byte("sender"), HTLCId: 1, Secret: []byte("secret")},
+		},
 	}
 
 	for _, tt := range tests {
@@ -193,3 +436,227 @@ func TestMsgRefundHTLC_ValidateBasic(t *testing.T) {
 		})
 	}
 }
+
+func TestMsgUpdateHTLC_ValidateBasic(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  types.MsgUpdateHTLC
+		err  error
+	}{
+		{
+			name: "invalid sender",
+			msg: types.MsgUpdateHTLC{
+				Sender:      []byte{},
+				HTLCId:      1,
+				NewTimeLock: time.Now().Add(time.Hour).Unix(),
+			},
+			err: sdkerrors.ErrInvalidAddress,
+		},
+		{
+			name: "invalid htlc id",
+			msg: types.MsgUpdateHTLC{
+				Sender:      []byte("sender"),
+				HTLCId:      0,
+				NewTimeLock: time.Now().Add(time.Hour).Unix(),
+			},
+			err: sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "invalid time lock",
+			msg: types.MsgUpdateHTLC{
+				Sender:      []byte("sender"),
+				HTLCId:      1,
+				NewTimeLock: 0,
+			},
+			err: types.ErrInvalidTimeLock,
+		},
+		{
+			name: "valid message",
+			msg: types.MsgUpdateHTLC{
+				Sender:      []byte("sender"),
+				HTLCId:      1,
+				NewTimeLock: time.Now().Add(time.Hour).Unix(),
+			},
+			err: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUpdateParams_ValidateBasic(t *testing.T) {
+	authority := sdk.AccAddress([]byte("authority___________")).String()
+
+	tests := []struct {
+		name string
+		msg  types.MsgUpdateParams
+		err  error
+	}{
+		{
+			name: "invalid authority",
+			msg: types.MsgUpdateParams{
+				Authority: "invalid",
+				Params:    types.DefaultParams(),
+			},
+			err: sdkerrors.ErrInvalidAddress,
+		},
+		{
+			name: "invalid params",
+			msg: types.MsgUpdateParams{
+				Authority: authority,
+				Params:    types.NewParams([]string{"stake"}, sdkmath.NewInt(-1), 0, 0),
+			},
+			err: sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "valid message",
+			msg: types.MsgUpdateParams{
+				Authority: authority,
+				Params:    types.NewParams([]string{"stake"}, sdkmath.NewInt(10), 3600, 0),
+			},
+			err: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgBatchCreateHTLC_ValidateBasic(t *testing.T) {
+	leg := types.HTLCParams{
+		Receiver: []byte("receiver"),
+		Amount:   sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		HashLock: make([]byte, 32),
+		TimeLock: time.Now().Add(time.Hour).Unix(),
+	}
+	badLeg := leg
+	badLeg.HashLock = []byte("hashlock")
+
+	tooMany := make([]types.HTLCParams, types.MaxBatchCreateHTLCs+1)
+	for i := range tooMany {
+		tooMany[i] = leg
+	}
+
+	tests := []struct {
+		name string
+		msg  types.MsgBatchCreateHTLC
+		err  error
+	}{
+		{
+			name: "invalid sender",
+			msg:  types.MsgBatchCreateHTLC{Sender: []byte{}, HTLCs: []types.HTLCParams{leg}},
+			err:  sdkerrors.ErrInvalidAddress,
+		},
+		{
+			name: "empty batch",
+			msg:  types.MsgBatchCreateHTLC{Sender: []byte("sender")},
+			err:  sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "too many htlcs",
+			msg:  types.MsgBatchCreateHTLC{Sender: []byte("sender"), HTLCs: tooMany},
+			err:  sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "invalid leg",
+			msg:  types.MsgBatchCreateHTLC{Sender: []byte("sender"), HTLCs: []types.HTLCParams{leg, badLeg}},
+			err:  types.ErrInvalidHashLock,
+		},
+		{
+			name: "valid message",
+			msg:  types.MsgBatchCreateHTLC{Sender: []byte("sender"), HTLCs: []types.HTLCParams{leg, leg}},
+			err:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgBatchClaimHTLC_ValidateBasic(t *testing.T) {
+	claim := types.HTLCClaim{HTLCId: 1, Preimage: []byte("secret")}
+	other := types.HTLCClaim{HTLCId: 2, Preimage: []byte("other")}
+
+	tooMany := make([]types.HTLCClaim, types.MaxBatchClaimHTLCs+1)
+	for i := range tooMany {
+		tooMany[i] = types.HTLCClaim{HTLCId: uint64(i + 1), Preimage: []byte("secret")}
+	}
+
+	tests := []struct {
+		name string
+		msg  types.MsgBatchClaimHTLC
+		err  error
+	}{
+		{
+			name: "invalid claimer",
+			msg:  types.MsgBatchClaimHTLC{Claimer: []byte{}, Claims: []types.HTLCClaim{claim}},
+			err:  sdkerrors.ErrInvalidAddress,
+		},
+		{
+			name: "empty batch",
+			msg:  types.MsgBatchClaimHTLC{Claimer: []byte("claimer")},
+			err:  sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "too many claims",
+			msg:  types.MsgBatchClaimHTLC{Claimer: []byte("claimer"), Claims: tooMany},
+			err:  sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "zero htlc id",
+			msg:  types.MsgBatchClaimHTLC{Claimer: []byte("claimer"), Claims: []types.HTLCClaim{claim, {Preimage: []byte("secret")}}},
+			err:  sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "empty preimage",
+			msg:  types.MsgBatchClaimHTLC{Claimer: []byte("claimer"), Claims: []types.HTLCClaim{{HTLCId: 3}}},
+			err:  sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "duplicate htlc",
+			msg:  types.MsgBatchClaimHTLC{Claimer: []byte("claimer"), Claims: []types.HTLCClaim{claim, other, claim}},
+			err:  sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "valid message",
+			msg:  types.MsgBatchClaimHTLC{Claimer: []byte("claimer"), Claims: []types.HTLCClaim{claim, other}, AllowPartial: true},
+			err:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.ValidateBasic()
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
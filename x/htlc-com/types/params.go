@@ -0,0 +1,159 @@
+package types
+
+import (
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+var (
+	// KeyAllowedDenoms is the param store key of the lockable denoms.
+	KeyAllowedDenoms = []byte("AllowedDenoms")
+	// KeyMinAmount is the param store key of the minimum locked amount.
+	KeyMinAmount = []byte("MinAmount")
+	// KeyMaxTimeLockSeconds is the param store key of the longest time lock.
+	KeyMaxTimeLockSeconds = []byte("MaxTimeLockSeconds")
+	// KeyMaxRefundsPerBlock is the param store key of the automatic refund
+	// limit.
+	KeyMaxRefundsPerBlock = []byte("MaxRefundsPerBlock")
+)
+
+// DefaultMaxRefundsPerBlock is how many expired HTLCs are refunded at the end
+// of a block by default.
+const DefaultMaxRefundsPerBlock uint64 = 100
+
+// Params restrict what can be locked in an HTLC.
+type Params struct {
+	// AllowedDenoms lists the denoms that can be locked. An empty list allows
+	// every denom.
+	AllowedDenoms []string `json:"allowed_denoms" yaml:"allowed_denoms"`
+
+	// MinAmount is the smallest amount of each coin that can be locked. Zero
+	// disables the minimum.
+	MinAmount sdkmath.Int `json:"min_amount" yaml:"min_amount"`
+
+	// MaxTimeLockSeconds is how far past the block time a time lock may be
+	// set. Zero disables the limit.
+	MaxTimeLockSeconds int64 `json:"max_time_lock_seconds" yaml:"max_time_lock_seconds"`
+
+	// MaxRefundsPerBlock is how many expired HTLCs are refunded at the end of
+	// a block, the rest waiting for the next one. Zero disables the limit.
+	MaxRefundsPerBlock uint64 `json:"max_refunds_per_block" yaml:"max_refunds_per_block"`
+}
+
+// ParamKeyTable returns the parameter key table.
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams creates a new parameter configuration for the htlc module.
+func NewParams(allowedDenoms []string, minAmount sdkmath.Int, maxTimeLockSeconds int64, maxRefundsPerBlock uint64) Params {
+	return Params{
+		AllowedDenoms:      allowedDenoms,
+		MinAmount:          minAmount,
+		MaxTimeLockSeconds: maxTimeLockSeconds,
+		MaxRefundsPerBlock: maxRefundsPerBlock,
+	}
+}
+
+// DefaultParams places no restrictions on HTLCs and refunds at most
+// DefaultMaxRefundsPerBlock of them per block.
+func DefaultParams() Params {
+	return Params{
+		AllowedDenoms:      []string{},
+		MinAmount:          sdkmath.ZeroInt(),
+		MaxTimeLockSeconds: 0,
+		MaxRefundsPerBlock: DefaultMaxRefundsPerBlock,
+	}
+}
+
+// Validate all htlc module parameters.
+func (p Params) Validate() error {
+	if err := validateAllowedDenoms(p.AllowedDenoms); err != nil {
+		return err
+	}
+	if err := validateMinAmount(p.MinAmount); err != nil {
+		return err
+	}
+	if err := validateMaxTimeLockSeconds(p.MaxTimeLockSeconds); err != nil {
+		return err
+	}
+	return validateMaxRefundsPerBlock(p.MaxRefundsPerBlock)
+}
+
+// ParamSetPairs implements params.ParamSet.
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyAllowedDenoms, &p.AllowedDenoms, validateAllowedDenoms),
+		paramtypes.NewParamSetPair(KeyMinAmount, &p.MinAmount, validateMinAmount),
+		paramtypes.NewParamSetPair(KeyMaxTimeLockSeconds, &p.MaxTimeLockSeconds, validateMaxTimeLockSeconds),
+		paramtypes.NewParamSetPair(KeyMaxRefundsPerBlock, &p.MaxRefundsPerBlock, validateMaxRefundsPerBlock),
+	}
+}
+
+// IsDenomAllowed reports whether coins of denom can be locked.
+func (p Params) IsDenomAllowed(denom string) bool {
+	if len(p.AllowedDenoms) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedDenoms {
+		if allowed == denom {
+			return true
+		}
+	}
+	return false
+}
+
+func validateAllowedDenoms(i interface{}) error {
+	denoms, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[string]bool, len(denoms))
+	for _, denom := range denoms {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return fmt.Errorf("invalid allowed denom %q: %w", denom, err)
+		}
+		if seen[denom] {
+			return fmt.Errorf("duplicate allowed denom %q", denom)
+		}
+		seen[denom] = true
+	}
+	return nil
+}
+
+func validateMinAmount(i interface{}) error {
+	amount, ok := i.(sdkmath.Int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	// An unset amount is treated as zero
+	if !amount.IsNil() && amount.IsNegative() {
+		return fmt.Errorf("min amount cannot be negative: %s", amount)
+	}
+	return nil
+}
+
+func validateMaxTimeLockSeconds(i interface{}) error {
+	seconds, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if seconds < 0 {
+		return fmt.Errorf("max time lock seconds cannot be negative: %d", seconds)
+	}
+	return nil
+}
+
+func validateMaxRefundsPerBlock(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
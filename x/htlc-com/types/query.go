@@ -1,12 +1,38 @@
 package types
 
 import (
+	"time"
+
+	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 )
 
 const (
 	QueryGetHTLC = "htlc"
 	QueryListHTLCs = "htlcs"
+	QueryHTLCByHashLock = "htlc_by_hashlock"
+	QueryCurrentPrice = "current_price"
+	QueryTotalLocked = "total_locked"
+	QueryHTLCActions = "htlc_actions"
+	QueryPreimage = "preimage"
+	QueryExpiringHTLCs = "expiring_htlcs"
+)
+
+const (
+	// DefaultHTLCsPageLimit is the page size of HTLCs queries that don't set one.
+	DefaultHTLCsPageLimit = 100
+
+	// MaxHTLCsPageLimit caps the page size of HTLCs queries.
+	MaxHTLCsPageLimit = 1000
+
+	// MaxHTLCActionsIds caps the number of HTLCs an HTLCActions query asks
+	// about.
+	MaxHTLCActionsIds = 100
+
+	// MaxExpiringHTLCs caps the number of HTLCs an ExpiringHTLCs query
+	// returns, the earliest expiring first.
+	MaxExpiringHTLCs = 1000
 )
 
 type QueryGetHTLCRequest struct {
@@ -17,8 +43,79 @@ type QueryGetHTLCResponse struct {
 	HTLC HTLC `json:"htlc"`
 }
 
-type QueryListHTLCsRequest struct {}
+type QueryListHTLCsRequest struct {
+	// Status only lists HTLCs in the given state; unspecified lists all
+	Status     HTLCStatus         `json:"status,omitempty"`
+	// ClientId only lists HTLCs created with the given client id
+	ClientId   string             `json:"client_id,omitempty"`
+	Pagination *query.PageRequest `json:"pagination,omitempty"`
+}
 
 type QueryListHTLCsResponse struct {
+	HTLCs      []HTLC              `json:"htlcs"`
+	Pagination *query.PageResponse `json:"pagination,omitempty"`
+}
+
+type QueryHTLCByHashLockRequest struct {
+	HashLock []byte `json:"hash_lock"`
+}
+
+type QueryHTLCByHashLockResponse struct {
+	HTLC HTLC `json:"htlc"`
+}
+
+type QueryCurrentPriceRequest struct {
+	Id uint64 `json:"id"`
+}
+
+type QueryCurrentPriceResponse struct {
+	Price sdkmath.Int `json:"price"`
+}
+
+type QueryTotalLockedRequest struct{}
+
+type QueryTotalLockedResponse struct {
+	// Amount is the coins locked in active HTLCs, per denom
+	Amount sdk.Coins `json:"amount"`
+}
+
+type QueryPreimageRequest struct {
+	Id uint64 `json:"id"`
+}
+
+type QueryPreimageResponse struct {
+	Preimage []byte `json:"preimage"`
+}
+
+type QueryExpiringHTLCsRequest struct {
+	// Within is how far past the current block time to look; HTLCs whose
+	// time lock comes before then are returned
+	Within time.Duration `json:"within"`
+}
+
+type QueryExpiringHTLCsResponse struct {
+	// HTLCs are the unsettled HTLCs expiring within the window, ordered by
+	// time lock
 	HTLCs []HTLC `json:"htlcs"`
 }
+
+type QueryHTLCActionsRequest struct {
+	// Address is the bech32 account the actions are checked for
+	Address string   `json:"address"`
+	Ids     []uint64 `json:"ids"`
+}
+
+type QueryHTLCActionsResponse struct {
+	// Actions holds one entry per requested id, in request order
+	Actions []HTLCActions `json:"actions"`
+}
+
+// HTLCActions is what an address can do with an HTLC at the current block
+// time.
+type HTLCActions struct {
+	Id        uint64 `json:"id"`
+	CanClaim  bool   `json:"can_claim"`
+	CanRefund bool   `json:"can_refund"`
+	// Reason explains why the address can neither claim nor refund the HTLC
+	Reason    string `json:"reason,omitempty"`
+}
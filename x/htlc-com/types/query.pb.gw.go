@@ -0,0 +1,241 @@
+// Hand-written gRPC gateway of the htlc query service, laid out like the
+// output of protoc-gen-grpc-gateway. The query types are plain Go structs, so
+// requests are built from the path and query parameters here rather than by
+// runtime.PopulateQueryParameters.
+
+package types
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// queryRequest builds a query's request from the route's path and query
+// parameters and sends it through the client.
+type queryRequest func(ctx context.Context, client QueryClient, form url.Values, pathParams map[string]string) (interface{}, error)
+
+func request_Query_HTLC_0(ctx context.Context, client QueryClient, form url.Values, pathParams map[string]string) (interface{}, error) {
+	id, err := uintPathParam(pathParams, "id")
+	if err != nil {
+		return nil, err
+	}
+	return client.HTLC(ctx, &QueryGetHTLCRequest{Id: id})
+}
+
+func request_Query_HTLCs_0(ctx context.Context, client QueryClient, form url.Values, pathParams map[string]string) (interface{}, error) {
+	req := &QueryListHTLCsRequest{ClientId: form.Get("client_id")}
+	if name := form.Get("status"); name != "" {
+		htlcStatus, err := ParseHTLCStatus(name)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		req.Status = htlcStatus
+	}
+
+	pageReq, err := pageRequestParams(form)
+	if err != nil {
+		return nil, err
+	}
+	req.Pagination = pageReq
+
+	return client.HTLCs(ctx, req)
+}
+
+func request_Query_HTLCByHashLock_0(ctx context.Context, client QueryClient, form url.Values, pathParams map[string]string) (interface{}, error) {
+	val, ok := pathParams["hash_lock"]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "missing parameter %s", "hash_lock")
+	}
+	hashLock, err := hex.DecodeString(strings.TrimPrefix(val, "0x"))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "hash_lock", err)
+	}
+	return client.HTLCByHashLock(ctx, &QueryHTLCByHashLockRequest{HashLock: hashLock})
+}
+
+func request_Query_CurrentPrice_0(ctx context.Context, client QueryClient, form url.Values, pathParams map[string]string) (interface{}, error) {
+	id, err := uintPathParam(pathParams, "id")
+	if err != nil {
+		return nil, err
+	}
+	return client.CurrentPrice(ctx, &QueryCurrentPriceRequest{Id: id})
+}
+
+func request_Query_Preimage_0(ctx context.Context, client QueryClient, form url.Values, pathParams map[string]string) (interface{}, error) {
+	id, err := uintPathParam(pathParams, "id")
+	if err != nil {
+		return nil, err
+	}
+	return client.Preimage(ctx, &QueryPreimageRequest{Id: id})
+}
+
+func request_Query_TotalLocked_0(ctx context.Context, client QueryClient, form url.Values, pathParams map[string]string) (interface{}, error) {
+	return client.TotalLocked(ctx, &QueryTotalLockedRequest{})
+}
+
+func request_Query_ExpiringHTLCs_0(ctx context.Context, client QueryClient, form url.Values, pathParams map[string]string) (interface{}, error) {
+	req := &QueryExpiringHTLCsRequest{}
+	if val := form.Get("within"); val != "" {
+		within, err := time.ParseDuration(val)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "within", err)
+		}
+		req.Within = within
+	}
+	return client.ExpiringHTLCs(ctx, req)
+}
+
+func request_Query_HTLCActions_0(ctx context.Context, client QueryClient, form url.Values, pathParams map[string]string) (interface{}, error) {
+	address, ok := pathParams["address"]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "missing parameter %s", "address")
+	}
+
+	req := &QueryHTLCActionsRequest{Address: address}
+	for _, val := range form["ids"] {
+		id, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "ids", err)
+		}
+		req.Ids = append(req.Ids, id)
+	}
+	return client.HTLCActions(ctx, req)
+}
+
+// uintPathParam parses the named path parameter as a uint64.
+func uintPathParam(pathParams map[string]string, name string) (uint64, error) {
+	val, ok := pathParams[name]
+	if !ok {
+		return 0, status.Errorf(codes.InvalidArgument, "missing parameter %s", name)
+	}
+	n, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", name, err)
+	}
+	return n, nil
+}
+
+// pageRequestParams parses the pagination.* query parameters, returning nil
+// when none is set.
+func pageRequestParams(form url.Values) (*query.PageRequest, error) {
+	pageReq := &query.PageRequest{}
+	set := false
+
+	if val := form.Get("pagination.key"); val != "" {
+		key, err := base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "pagination.key", err)
+		}
+		pageReq.Key = key
+		set = true
+	}
+	for name, field := range map[string]*uint64{
+		"pagination.offset": &pageReq.Offset,
+		"pagination.limit":  &pageReq.Limit,
+	} {
+		if val := form.Get(name); val != "" {
+			n, err := strconv.ParseUint(val, 10, 64)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", name, err)
+			}
+			*field = n
+			set = true
+		}
+	}
+	for name, field := range map[string]*bool{
+		"pagination.count_total": &pageReq.CountTotal,
+		"pagination.reverse":     &pageReq.Reverse,
+	} {
+		if val := form.Get(name); val != "" {
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", name, err)
+			}
+			*field = b
+			set = true
+		}
+	}
+
+	if !set {
+		return nil, nil
+	}
+	return pageReq, nil
+}
+
+// RegisterQueryHandlerClient registers the http handlers for service Query
+// to "mux". The handlers forward requests to the grpc endpoint over the given
+// implementation of "QueryClient".
+func RegisterQueryHandlerClient(ctx context.Context, mux *runtime.ServeMux, client QueryClient) error {
+	handleQuery(mux, client, pattern_Query_HTLC_0, request_Query_HTLC_0)
+	handleQuery(mux, client, pattern_Query_HTLCs_0, request_Query_HTLCs_0)
+	handleQuery(mux, client, pattern_Query_HTLCByHashLock_0, request_Query_HTLCByHashLock_0)
+	handleQuery(mux, client, pattern_Query_CurrentPrice_0, request_Query_CurrentPrice_0)
+	handleQuery(mux, client, pattern_Query_Preimage_0, request_Query_Preimage_0)
+	handleQuery(mux, client, pattern_Query_TotalLocked_0, request_Query_TotalLocked_0)
+	handleQuery(mux, client, pattern_Query_ExpiringHTLCs_0, request_Query_ExpiringHTLCs_0)
+	handleQuery(mux, client, pattern_Query_HTLCActions_0, request_Query_HTLCActions_0)
+	return nil
+}
+
+// handleQuery serves GET requests matching pattern with the response of the
+// query request sends.
+func handleQuery(mux *runtime.ServeMux, client QueryClient, pattern runtime.Pattern, request queryRequest) {
+	mux.Handle("GET", pattern, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		rctx, err := runtime.AnnotateContext(ctx, mux, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		if err := req.ParseForm(); err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, status.Errorf(codes.InvalidArgument, "%v", err))
+			return
+		}
+
+		resp, err := request(rctx, client, req.Form, pathParams)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		buf, err := outboundMarshaler.Marshal(resp)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		w.Header().Set("Content-Type", outboundMarshaler.ContentType())
+		_, _ = w.Write(buf)
+	})
+}
+
+var (
+	pattern_Query_HTLC_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3, 1, 0, 4, 1, 5, 4}, []string{"cronos", "htlc", "v1", "htlcs", "id"}, "", runtime.AssumeColonVerbOpt(false)))
+
+	pattern_Query_HTLCs_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3}, []string{"cronos", "htlc", "v1", "htlcs"}, "", runtime.AssumeColonVerbOpt(false)))
+
+	pattern_Query_HTLCByHashLock_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3, 1, 0, 4, 1, 5, 4}, []string{"cronos", "htlc", "v1", "hashlocks", "hash_lock"}, "", runtime.AssumeColonVerbOpt(false)))
+
+	pattern_Query_CurrentPrice_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3, 1, 0, 4, 1, 5, 4, 2, 5}, []string{"cronos", "htlc", "v1", "htlcs", "id", "price"}, "", runtime.AssumeColonVerbOpt(false)))
+
+	pattern_Query_Preimage_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3, 1, 0, 4, 1, 5, 4, 2, 5}, []string{"cronos", "htlc", "v1", "htlcs", "id", "preimage"}, "", runtime.AssumeColonVerbOpt(false)))
+
+	pattern_Query_TotalLocked_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3}, []string{"cronos", "htlc", "v1", "total_locked"}, "", runtime.AssumeColonVerbOpt(false)))
+
+	pattern_Query_ExpiringHTLCs_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3}, []string{"cronos", "htlc", "v1", "expiring_htlcs"}, "", runtime.AssumeColonVerbOpt(false)))
+
+	pattern_Query_HTLCActions_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 2, 2, 2, 3, 1, 0, 4, 1, 5, 4}, []string{"cronos", "htlc", "v1", "htlc_actions", "address"}, "", runtime.AssumeColonVerbOpt(false)))
+)
@@ -0,0 +1,313 @@
+package types
+
+import (
+	"context"
+
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc"
+)
+
+// QueryServiceName is the full name of the htlc query service.
+const QueryServiceName = "cronos.htlc.v1.Query"
+
+// QueryClient is the client API for the htlc query service.
+type QueryClient interface {
+	// HTLC returns an HTLC by id.
+	HTLC(ctx context.Context, in *QueryGetHTLCRequest, opts ...grpc.CallOption) (*QueryGetHTLCResponse, error)
+	// HTLCs lists HTLCs, optionally only those with a status or client id.
+	HTLCs(ctx context.Context, in *QueryListHTLCsRequest, opts ...grpc.CallOption) (*QueryListHTLCsResponse, error)
+	// HTLCByHashLock returns the first active HTLC locked with a hash lock.
+	HTLCByHashLock(ctx context.Context, in *QueryHTLCByHashLockRequest, opts ...grpc.CallOption) (*QueryHTLCByHashLockResponse, error)
+	// CurrentPrice returns the current Dutch auction price of an HTLC.
+	CurrentPrice(ctx context.Context, in *QueryCurrentPriceRequest, opts ...grpc.CallOption) (*QueryCurrentPriceResponse, error)
+	// TotalLocked returns the coins locked in active HTLCs, per denom.
+	TotalLocked(ctx context.Context, in *QueryTotalLockedRequest, opts ...grpc.CallOption) (*QueryTotalLockedResponse, error)
+	// HTLCActions reports whether an address can claim or refund HTLCs.
+	HTLCActions(ctx context.Context, in *QueryHTLCActionsRequest, opts ...grpc.CallOption) (*QueryHTLCActionsResponse, error)
+	// Preimage returns the secret revealed by claiming an HTLC.
+	Preimage(ctx context.Context, in *QueryPreimageRequest, opts ...grpc.CallOption) (*QueryPreimageResponse, error)
+	// ExpiringHTLCs returns the unsettled HTLCs expiring within a window.
+	ExpiringHTLCs(ctx context.Context, in *QueryExpiringHTLCsRequest, opts ...grpc.CallOption) (*QueryExpiringHTLCsResponse, error)
+}
+
+type queryClient struct {
+	cc grpc1.ClientConn
+}
+
+// NewQueryClient returns a QueryClient that sends queries over cc.
+func NewQueryClient(cc grpc1.ClientConn) QueryClient {
+	return &queryClient{cc}
+}
+
+func (c *queryClient) HTLC(ctx context.Context, in *QueryGetHTLCRequest, opts ...grpc.CallOption) (*QueryGetHTLCResponse, error) {
+	out := new(QueryGetHTLCResponse)
+	if err := c.cc.Invoke(ctx, "/"+QueryServiceName+"/HTLC", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) HTLCs(ctx context.Context, in *QueryListHTLCsRequest, opts ...grpc.CallOption) (*QueryListHTLCsResponse, error) {
+	out := new(QueryListHTLCsResponse)
+	if err := c.cc.Invoke(ctx, "/"+QueryServiceName+"/HTLCs", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) HTLCByHashLock(ctx context.Context, in *QueryHTLCByHashLockRequest, opts ...grpc.CallOption) (*QueryHTLCByHashLockResponse, error) {
+	out := new(QueryHTLCByHashLockResponse)
+	if err := c.cc.Invoke(ctx, "/"+QueryServiceName+"/HTLCByHashLock", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) CurrentPrice(ctx context.Context, in *QueryCurrentPriceRequest, opts ...grpc.CallOption) (*QueryCurrentPriceResponse, error) {
+	out := new(QueryCurrentPriceResponse)
+	if err := c.cc.Invoke(ctx, "/"+QueryServiceName+"/CurrentPrice", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) TotalLocked(ctx context.Context, in *QueryTotalLockedRequest, opts ...grpc.CallOption) (*QueryTotalLockedResponse, error) {
+	out := new(QueryTotalLockedResponse)
+	if err := c.cc.Invoke(ctx, "/"+QueryServiceName+"/TotalLocked", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) HTLCActions(ctx context.Context, in *QueryHTLCActionsRequest, opts ...grpc.CallOption) (*QueryHTLCActionsResponse, error) {
+	out := new(QueryHTLCActionsResponse)
+	if err := c.cc.Invoke(ctx, "/"+QueryServiceName+"/HTLCActions", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Preimage(ctx context.Context, in *QueryPreimageRequest, opts ...grpc.CallOption) (*QueryPreimageResponse, error) {
+	out := new(QueryPreimageResponse)
+	if err := c.cc.Invoke(ctx, "/"+QueryServiceName+"/Preimage", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) ExpiringHTLCs(ctx context.Context, in *QueryExpiringHTLCsRequest, opts ...grpc.CallOption) (*QueryExpiringHTLCsResponse, error) {
+	out := new(QueryExpiringHTLCsResponse)
+	if err := c.cc.Invoke(ctx, "/"+QueryServiceName+"/ExpiringHTLCs", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QueryServer is the server API for the htlc query service.
+type QueryServer interface {
+	// HTLC returns an HTLC by id.
+	HTLC(context.Context, *QueryGetHTLCRequest) (*QueryGetHTLCResponse, error)
+	// HTLCs lists HTLCs, optionally only those with a status or client id.
+	HTLCs(context.Context, *QueryListHTLCsRequest) (*QueryListHTLCsResponse, error)
+	// HTLCByHashLock returns the first active HTLC locked with a hash lock.
+	HTLCByHashLock(context.Context, *QueryHTLCByHashLockRequest) (*QueryHTLCByHashLockResponse, error)
+	// CurrentPrice returns the current Dutch auction price of an HTLC.
+	CurrentPrice(context.Context, *QueryCurrentPriceRequest) (*QueryCurrentPriceResponse, error)
+	// TotalLocked returns the coins locked in active HTLCs, per denom.
+	TotalLocked(context.Context, *QueryTotalLockedRequest) (*QueryTotalLockedResponse, error)
+	// HTLCActions reports whether an address can claim or refund HTLCs.
+	HTLCActions(context.Context, *QueryHTLCActionsRequest) (*QueryHTLCActionsResponse, error)
+	// Preimage returns the secret revealed by claiming an HTLC.
+	Preimage(context.Context, *QueryPreimageRequest) (*QueryPreimageResponse, error)
+	// ExpiringHTLCs returns the unsettled HTLCs expiring within a window.
+	ExpiringHTLCs(context.Context, *QueryExpiringHTLCsRequest) (*QueryExpiringHTLCsResponse, error)
+}
+
+// RegisterQueryServer registers srv as the htlc query service of s.
+func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
+	s.RegisterService(&_Query_serviceDesc, srv)
+}
+
+func _Query_HTLC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryGetHTLCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).HTLC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + QueryServiceName + "/HTLC",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).HTLC(ctx, req.(*QueryGetHTLCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_HTLCs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryListHTLCsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).HTLCs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + QueryServiceName + "/HTLCs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).HTLCs(ctx, req.(*QueryListHTLCsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_HTLCByHashLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryHTLCByHashLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).HTLCByHashLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + QueryServiceName + "/HTLCByHashLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).HTLCByHashLock(ctx, req.(*QueryHTLCByHashLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_CurrentPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryCurrentPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).CurrentPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + QueryServiceName + "/CurrentPrice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).CurrentPrice(ctx, req.(*QueryCurrentPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_TotalLocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTotalLockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).TotalLocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + QueryServiceName + "/TotalLocked",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).TotalLocked(ctx, req.(*QueryTotalLockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_HTLCActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryHTLCActionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).HTLCActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + QueryServiceName + "/HTLCActions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).HTLCActions(ctx, req.(*QueryHTLCActionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Preimage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPreimageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Preimage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + QueryServiceName + "/Preimage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Preimage(ctx, req.(*QueryPreimageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_ExpiringHTLCs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryExpiringHTLCsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).ExpiringHTLCs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + QueryServiceName + "/ExpiringHTLCs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).ExpiringHTLCs(ctx, req.(*QueryExpiringHTLCsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Query_serviceDesc = grpc.ServiceDesc{
+	ServiceName: QueryServiceName,
+	HandlerType: (*QueryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "HTLC",
+			Handler:    _Query_HTLC_Handler,
+		},
+		{
+			MethodName: "HTLCs",
+			Handler:    _Query_HTLCs_Handler,
+		},
+		{
+			MethodName: "HTLCByHashLock",
+			Handler:    _Query_HTLCByHashLock_Handler,
+		},
+		{
+			MethodName: "CurrentPrice",
+			Handler:    _Query_CurrentPrice_Handler,
+		},
+		{
+			MethodName: "TotalLocked",
+			Handler:    _Query_TotalLocked_Handler,
+		},
+		{
+			MethodName: "HTLCActions",
+			Handler:    _Query_HTLCActions_Handler,
+		},
+		{
+			MethodName: "Preimage",
+			Handler:    _Query_Preimage_Handler,
+		},
+		{
+			MethodName: "ExpiringHTLCs",
+			Handler:    _Query_ExpiringHTLCs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
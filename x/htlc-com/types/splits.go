@@ -0,0 +1,55 @@
+package types
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxHTLCSplits is the most receivers a split HTLC may pay out to.
+const MaxHTLCSplits = 32
+
+// Split is the share of a split HTLC's amount paid to one receiver when the
+// HTLC is claimed.
+type Split struct {
+	Receiver sdk.AccAddress `json:"receiver" yaml:"receiver"`
+	Share    sdk.Coins      `json:"share" yaml:"share"`
+}
+
+// ValidateSplits checks that splits pay distinct receivers positive shares
+// that add up to exactly amount. No splits is valid and leaves the HTLC
+// paying out to a single address.
+func ValidateSplits(amount sdk.Coins, splits []Split) error {
+	if len(splits) == 0 {
+		return nil
+	}
+	if len(splits) > MaxHTLCSplits {
+		return errorsmod.Wrapf(ErrInvalidSplits, "%d splits, more than the maximum of %d", len(splits), MaxHTLCSplits)
+	}
+
+	total := sdk.NewCoins()
+	seen := make(map[string]bool, len(splits))
+	for i, split := range splits {
+		if err := sdk.VerifyAddressFormat(split.Receiver); err != nil {
+			return errorsmod.Wrapf(ErrInvalidSplits, "split %d: invalid receiver: %s", i, err)
+		}
+		if seen[split.Receiver.String()] {
+			return errorsmod.Wrapf(ErrInvalidSplits, "split %d: duplicate receiver %s", i, split.Receiver)
+		}
+		seen[split.Receiver.String()] = true
+
+		if len(split.Share) == 0 || !split.Share.IsValid() {
+			return errorsmod.Wrapf(ErrInvalidSplits, "split %d: share must be positive, got %q", i, split.Share)
+		}
+		total = total.Add(split.Share...)
+	}
+
+	if !total.Equal(amount) {
+		return errorsmod.Wrapf(ErrInvalidSplits, "shares add up to %s, not the htlc amount %s", total, amount)
+	}
+	return nil
+}
+
+// IsSplit reports whether the HTLC pays out to several receivers on claim.
+func (h HTLC) IsSplit() bool {
+	return len(h.Splits) > 0
+}
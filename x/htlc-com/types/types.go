@@ -2,6 +2,11 @@
 package types
 
 import (
+	"fmt"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"time"
 )
@@ -31,24 +36,226 @@ type HTLC struct {
 	
 	// Refunded indicates whether the HTLC has been refunded
 	Refunded bool `json:"refunded" yaml:"refunded"`
+
+	// InitialPrice is the Dutch auction price at StartTime. HTLCs without a
+	// positive initial price are not auctioned
+	InitialPrice sdkmath.Int `json:"initial_price,omitempty" yaml:"initial_price,omitempty"`
+
+	// MinPrice is the floor the auction price never decays below
+	MinPrice sdkmath.Int `json:"min_price,omitempty" yaml:"min_price,omitempty"`
+
+	// DecayRate is how much the auction price drops each second after StartTime
+	DecayRate sdkmath.Int `json:"decay_rate,omitempty" yaml:"decay_rate,omitempty"`
+
+	// StartTime is when the auction price starts decaying
+	StartTime time.Time `json:"start_time,omitempty" yaml:"start_time,omitempty"`
+
+	// Parts is how many parts a Merkle HTLC is claimed in, each with its own
+	// secret; HashLock is then the Merkle root over the secrets. Zero means
+	// the HTLC is claimed in full with the preimage of HashLock
+	Parts uint32 `json:"parts,omitempty" yaml:"parts,omitempty"`
+
+	// ClaimedParts is how many parts of a Merkle HTLC have been claimed
+	ClaimedParts uint32 `json:"claimed_parts,omitempty" yaml:"claimed_parts,omitempty"`
+
+	// Preimage is the secret revealed by the claim, or by the latest claimed
+	// part of a Merkle HTLC. It is empty until then
+	Preimage []byte `json:"preimage,omitempty" yaml:"preimage,omitempty"`
+
+	// ClientId is an optional identifier the sender attaches to correlate
+	// the HTLC with its own order, at most MaxClientIdLength bytes
+	ClientId string `json:"client_id,omitempty" yaml:"client_id,omitempty"`
+
+	// Splits optionally divides Amount among several receivers when the HTLC
+	// is claimed, the shares adding up to Amount. The HTLC is still claimed
+	// once, by Receiver, with the preimage of HashLock
+	Splits []Split `json:"splits,omitempty" yaml:"splits,omitempty"`
+}
+
+// MaxClientIdLength is the longest client id an HTLC may carry, in bytes.
+const MaxClientIdLength = 128
+
+// ValidateClientId checks the length of an HTLC client id. An empty client
+// id is valid.
+func ValidateClientId(clientId string) error {
+	if len(clientId) > MaxClientIdLength {
+		return errorsmod.Wrapf(ErrInvalidClientId, "client id is %d bytes, more than the maximum of %d", len(clientId), MaxClientIdLength)
+	}
+	return nil
+}
+
+// HTLCStatus is the lifecycle state of an HTLC, used to filter queries.
+type HTLCStatus int32
+
+const (
+	// HTLCStatusUnspecified matches every HTLC.
+	HTLCStatusUnspecified HTLCStatus = iota
+	// HTLCStatusActive is an unsettled HTLC that can still be claimed.
+	HTLCStatusActive
+	// HTLCStatusClaimed is an HTLC the receiver claimed.
+	HTLCStatusClaimed
+	// HTLCStatusRefunded is an HTLC whose coins went back to the sender.
+	HTLCStatusRefunded
+	// HTLCStatusExpired is an unsettled HTLC past its time lock, waiting to
+	// be refunded.
+	HTLCStatusExpired
+)
+
+var htlcStatusNames = map[HTLCStatus]string{
+	HTLCStatusUnspecified: "UNSPECIFIED",
+	HTLCStatusActive:      "ACTIVE",
+	HTLCStatusClaimed:     "CLAIMED",
+	HTLCStatusRefunded:    "REFUNDED",
+	HTLCStatusExpired:     "EXPIRED",
+}
+
+func (s HTLCStatus) String() string {
+	if name, ok := htlcStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("HTLCStatus(%d)", int32(s))
+}
+
+// IsValid reports whether s is one of the defined statuses.
+func (s HTLCStatus) IsValid() bool {
+	_, ok := htlcStatusNames[s]
+	return ok
+}
+
+// ParseHTLCStatus parses a case-insensitive status name such as "active".
+func ParseHTLCStatus(name string) (HTLCStatus, error) {
+	for status, statusName := range htlcStatusNames {
+		if strings.EqualFold(name, statusName) {
+			return status, nil
+		}
+	}
+	return HTLCStatusUnspecified, fmt.Errorf("invalid htlc status %q: must be one of active, claimed, refunded or expired", name)
+}
+
+// StatusAt returns the status of the HTLC at the given block time. An HTLC
+// can be claimed up to and including its time lock, so it only counts as
+// expired after it.
+func (h HTLC) StatusAt(t time.Time) HTLCStatus {
+	switch {
+	case h.Claimed:
+		return HTLCStatusClaimed
+	case h.Refunded:
+		return HTLCStatusRefunded
+	case t.After(h.TimeLock):
+		return HTLCStatusExpired
+	default:
+		return HTLCStatusActive
+	}
+}
+
+// IsDutchAuction reports whether the HTLC is priced by a Dutch auction
+func (h HTLC) IsDutchAuction() bool {
+	return !h.InitialPrice.IsNil() && h.InitialPrice.IsPositive()
+}
+
+// PriceAt returns the auction price at the given time. The price decays
+// linearly from InitialPrice at StartTime and is clamped at MinPrice.
+func (h HTLC) PriceAt(t time.Time) sdkmath.Int {
+	minPrice := sdkmath.ZeroInt()
+	if !h.MinPrice.IsNil() {
+		minPrice = h.MinPrice
+	}
+
+	price := h.InitialPrice
+	if t.After(h.StartTime) && !h.DecayRate.IsNil() && h.DecayRate.IsPositive() {
+		// The auction lasts until the price reaches MinPrice. The elapsed
+		// time is clamped to that duration, so the decay never exceeds the
+		// price range and cannot overflow
+		elapsed := sdkmath.NewInt(int64(t.Sub(h.StartTime) / time.Second))
+		if duration := price.Sub(minPrice).Quo(h.DecayRate); elapsed.GT(duration) {
+			return minPrice
+		}
+		price = price.Sub(h.DecayRate.Mul(elapsed))
+	}
+
+	if price.LT(minPrice) {
+		return minPrice
+	}
+	return price
+}
+
+// ValidateAuction checks the Dutch auction prices of an HTLC: the decay rate
+// cannot be negative and the price cannot start below its minimum.
+func (h HTLC) ValidateAuction() error {
+	if !h.DecayRate.IsNil() && h.DecayRate.IsNegative() {
+		return fmt.Errorf("decay rate cannot be negative: %s", h.DecayRate)
+	}
+	if !h.IsDutchAuction() || h.MinPrice.IsNil() {
+		return nil
+	}
+	if h.MinPrice.IsNegative() {
+		return fmt.Errorf("min price cannot be negative: %s", h.MinPrice)
+	}
+	if h.InitialPrice.LT(h.MinPrice) {
+		return fmt.Errorf("initial price %s is below the min price %s", h.InitialPrice, h.MinPrice)
+	}
+	return nil
 }
 
 // GenesisState represents the genesis state for the HTLC module
 type GenesisState struct {
 	// HTLCs is the list of HTLCs at genesis
 	HTLCs []HTLC `json:"htlcs" yaml:"htlcs"`
+
+	// NextId is the id the next created HTLC will get
+	NextId uint64 `json:"next_id" yaml:"next_id"`
+
+	// Params are the module parameters
+	Params Params `json:"params" yaml:"params"`
 }
 
 // DefaultGenesis returns the default genesis state
 func DefaultGenesis() *GenesisState {
 	return &GenesisState{
-		HTLCs: []HTLC{},
+		HTLCs:  []HTLC{},
+		NextId: 1,
+		Params: DefaultParams(),
 	}
 }
 
 // Validate performs basic genesis state validation returning an error upon any
 // failure.
 func (gs GenesisState) Validate() error {
-	// Add validation logic if needed
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[uint64]bool, len(gs.HTLCs))
+	for _, htlc := range gs.HTLCs {
+		if htlc.Id == 0 {
+			return fmt.Errorf("htlc id cannot be zero")
+		}
+		if seen[htlc.Id] {
+			return fmt.Errorf("duplicate htlc id %d", htlc.Id)
+		}
+		seen[htlc.Id] = true
+
+		if gs.NextId <= htlc.Id {
+			return fmt.Errorf("next id %d must be greater than htlc id %d", gs.NextId, htlc.Id)
+		}
+		if htlc.Parts > MaxHTLCParts {
+			return fmt.Errorf("htlc %d has %d parts, more than the maximum of %d", htlc.Id, htlc.Parts, MaxHTLCParts)
+		}
+		if htlc.ClaimedParts > htlc.Parts {
+			return fmt.Errorf("htlc %d has %d of %d parts claimed", htlc.Id, htlc.ClaimedParts, htlc.Parts)
+		}
+		if err := ValidateClientId(htlc.ClientId); err != nil {
+			return fmt.Errorf("htlc %d: %w", htlc.Id, err)
+		}
+		if err := htlc.ValidateAuction(); err != nil {
+			return fmt.Errorf("htlc %d: %w", htlc.Id, err)
+		}
+		if htlc.IsSplit() && htlc.IsMerkle() {
+			return fmt.Errorf("htlc %d is both split and claimed in parts", htlc.Id)
+		}
+		if err := ValidateSplits(htlc.Amount, htlc.Splits); err != nil {
+			return fmt.Errorf("htlc %d: %w", htlc.Id, err)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,97 @@
+package types_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/crypto-org-chain/cronos/v2/x/htlc/types"
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+)
+
+func TestHTLCPriceAt(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	htlc := types.HTLC{
+		InitialPrice: sdkmath.NewInt(1000),
+		MinPrice:     sdkmath.NewInt(400),
+		DecayRate:    sdkmath.NewInt(7),
+		StartTime:    start,
+	}
+
+	for _, tc := range []struct {
+		name    string
+		elapsed time.Duration
+		want    int64
+	}{
+		{"before start", -time.Minute, 1000},
+		{"at start", 0, 1000},
+		{"mid decay", 30 * time.Second, 790},
+		{"last step above minimum", 85 * time.Second, 405},
+		{"past duration", 86 * time.Second, 400},
+		{"long past duration", 100 * 365 * 24 * time.Hour, 400},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, sdkmath.NewInt(tc.want), htlc.PriceAt(start.Add(tc.elapsed)))
+		})
+	}
+
+	// a decay that would overflow is clamped at the minimum
+	large := sdkmath.NewIntFromBigInt(new(big.Int).Lsh(big.NewInt(1), 250))
+	htlc = types.HTLC{
+		InitialPrice: large,
+		MinPrice:     sdkmath.NewInt(1),
+		DecayRate:    large.QuoRaw(2),
+		StartTime:    start,
+	}
+	require.Equal(t, large.QuoRaw(2), htlc.PriceAt(start.Add(time.Second)))
+	require.NotPanics(t, func() {
+		require.Equal(t, sdkmath.NewInt(1), htlc.PriceAt(start.Add(100*365*24*time.Hour)))
+	})
+}
+
+func TestHTLCValidateAuction(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		htlc  types.HTLC
+		valid bool
+	}{
+		{
+			desc:  "fixed price",
+			htlc:  types.HTLC{},
+			valid: true,
+		},
+		{
+			desc:  "decaying auction",
+			htlc:  types.HTLC{InitialPrice: sdkmath.NewInt(1000), MinPrice: sdkmath.NewInt(400), DecayRate: sdkmath.NewInt(10)},
+			valid: true,
+		},
+		{
+			desc:  "constant price auction",
+			htlc:  types.HTLC{InitialPrice: sdkmath.NewInt(1000), MinPrice: sdkmath.NewInt(1000), DecayRate: sdkmath.ZeroInt()},
+			valid: true,
+		},
+		{
+			desc: "negative decay rate",
+			htlc: types.HTLC{InitialPrice: sdkmath.NewInt(1000), MinPrice: sdkmath.NewInt(400), DecayRate: sdkmath.NewInt(-10)},
+		},
+		{
+			desc: "initial price below min price",
+			htlc: types.HTLC{InitialPrice: sdkmath.NewInt(400), MinPrice: sdkmath.NewInt(1000), DecayRate: sdkmath.NewInt(10)},
+		},
+		{
+			desc: "negative min price",
+			htlc: types.HTLC{InitialPrice: sdkmath.NewInt(1000), MinPrice: sdkmath.NewInt(-1), DecayRate: sdkmath.NewInt(10)},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.htlc.ValidateAuction()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}